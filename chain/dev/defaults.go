@@ -82,7 +82,7 @@ var (
 	// DefaultRPCHTTPPort rpc port
 	DefaultRPCHTTPPort = uint32(8545)
 	// DefaultRPCModules rpc modules
-	DefaultRPCModules = []string{"system", "author", "chain", "state", "rpc", "grandpa"}
+	DefaultRPCModules = []string{"system", "author", "chain", "state", "rpc", "grandpa", "payment", "childstate"}
 	// DefaultRPCWSPort rpc websocket port
 	DefaultRPCWSPort = uint32(8546)
 	// DefaultRPCEnabled enables the RPC server
@@ -0,0 +1,61 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chain embeds the configuration and genesis files of the built-in chains,
+// so they can be loaded when gossamer is not run from the root of the repository.
+package chain
+
+import (
+	"embed"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed gssmr/config.toml gssmr/genesis.json gssmr/genesis-spec.json
+//go:embed dev/config.toml dev/genesis-spec.json
+//go:embed kusama/config.toml kusama/genesis.json
+//go:embed polkadot/config.toml polkadot/genesis.json
+var files embed.FS
+
+// ReadFile reads the file at the given path. If the file does not exist and the path
+// is the default path of a built-in chain file (eg. ./chain/gssmr/genesis.json), the
+// embedded copy of the file is returned instead.
+func ReadFile(fp string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	if err == nil || !os.IsNotExist(err) {
+		return data, err
+	}
+
+	embedded, embeddedErr := readEmbeddedFile(fp)
+	if embeddedErr != nil {
+		return nil, err
+	}
+
+	return embedded, nil
+}
+
+// readEmbeddedFile returns the embedded built-in chain file for the given path
+func readEmbeddedFile(fp string) ([]byte, error) {
+	name := path.Clean(filepath.ToSlash(fp))
+	if !strings.HasPrefix(name, "chain/") {
+		return nil, os.ErrNotExist
+	}
+
+	return files.ReadFile(strings.TrimPrefix(name, "chain/"))
+}
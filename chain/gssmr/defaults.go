@@ -44,6 +44,9 @@ var (
 	// DefaultGenesis is the default genesis configuration path
 	DefaultGenesis = string("./chain/gssmr/genesis.json")
 
+	// DefaultGenesisSpec is the default human-readable genesis configuration path
+	DefaultGenesisSpec = string("./chain/gssmr/genesis-spec.json")
+
 	// AccountConfig
 
 	// DefaultKey Default account key
@@ -82,7 +85,7 @@ var (
 	// DefaultRPCHTTPPort rpc port
 	DefaultRPCHTTPPort = uint32(8545)
 	// DefaultRPCModules rpc modules
-	DefaultRPCModules = []string{"system", "author", "chain", "state", "rpc", "grandpa"}
+	DefaultRPCModules = []string{"system", "author", "chain", "state", "rpc", "grandpa", "payment", "childstate"}
 	// DefaultRPCWSPort rpc websocket port
 	DefaultRPCWSPort = uint32(8546)
 )
@@ -18,11 +18,16 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/address"
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/keystore"
-	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/urfave/cli"
 )
@@ -52,11 +57,22 @@ func accountAction(ctx *cli.Context) error {
 		keytype = crypto.Secp256k1Type
 	}
 
+	params := getScryptParams(ctx)
+
 	// check --generate flag and generate new keypair
 	if keygen := ctx.Bool(GenerateFlag.Name); keygen {
 		logger.Info("generating keypair...")
 
-		file, err = keystore.GenerateKeypair(keytype, nil, basepath, getKeystorePassword(ctx))
+		var kp crypto.Keypair
+		if derivation := ctx.String(DerivationFlag.Name); derivation != "" {
+			kp, err = keystore.DeriveKeypair(keytype, derivation)
+			if err != nil {
+				logger.Error("failed to derive keypair", "error", err)
+				return err
+			}
+		}
+
+		file, err = keystore.GenerateKeypairWithParams(keytype, kp, basepath, getKeystorePassword(ctx), params)
 		if err != nil {
 			logger.Error("failed to generate keypair", "error", err)
 			return err
@@ -79,7 +95,7 @@ func accountAction(ctx *cli.Context) error {
 
 	// check if --list is set
 	if keylist := ctx.Bool(ListFlag.Name); keylist {
-		_, err = utils.KeystoreFilepaths(basepath)
+		err = listKeys(os.Stdout, basepath, ctx.String(KeyTypeFlag.Name), getSS58Prefix(cfg))
 		if err != nil {
 			logger.Error("failed to list keys", "error", err)
 			return err
@@ -88,7 +104,7 @@ func accountAction(ctx *cli.Context) error {
 
 	// check if --import-raw is set
 	if importraw := ctx.String(ImportRawFlag.Name); importraw != "" {
-		file, err = keystore.ImportRawPrivateKey(importraw, keytype, basepath, getKeystorePassword(ctx))
+		file, err = keystore.ImportRawPrivateKeyWithParams(importraw, keytype, basepath, getKeystorePassword(ctx), params)
 		if err != nil {
 			logger.Error("failed to import private key", "error", err)
 			return err
@@ -100,6 +116,71 @@ func accountAction(ctx *cli.Context) error {
 	return nil
 }
 
+// listKeys writes the index, type, public key and ss58 address of the keys in the basepath's keystore. If keytype is
+// set, only keys of that type are written. The key files are not decrypted, and the indices are the ones used by
+// --unlock, so they don't change when a type is set.
+func listKeys(w io.Writer, basepath string, keytype crypto.KeyType, prefix uint16) error {
+	switch keytype {
+	case "", crypto.Sr25519Type, crypto.Ed25519Type, crypto.Secp256k1Type:
+	default:
+		return fmt.Errorf("invalid key type %s", keytype)
+	}
+
+	keys, err := keystore.ListKeyFiles(basepath, "")
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		if keytype != "" && key.Type != keytype {
+			continue
+		}
+
+		addr, err := address.SS58Encode(key.PublicKey.Encode(), prefix)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "[%d] %s %s %s\n", i, key.Type, key.PublicKey.Hex(), addr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSS58Prefix returns the ss58Format property of the configured genesis, or the default substrate prefix if
+// the genesis cannot be loaded or does not set it
+func getSS58Prefix(cfg *dot.Config) uint16 {
+	gen, err := genesis.NewGenesisFromJSONRaw(cfg.Init.Genesis)
+	if err != nil {
+		logger.Debug("failed to load genesis, using default ss58 prefix", "error", err)
+		return address.DefaultPrefix
+	}
+
+	if _, ok := gen.Properties["ss58Format"]; !ok {
+		return address.DefaultPrefix
+	}
+
+	props, err := system.ParseProperties(gen.Properties)
+	if err != nil {
+		logger.Debug("failed to parse genesis properties, using default ss58 prefix", "error", err)
+		return address.DefaultPrefix
+	}
+
+	return uint16(props.SS58Format)
+}
+
+// getScryptParams returns the scrypt parameters set by the --scrypt-n, --scrypt-r and --scrypt-p flags
+func getScryptParams(ctx *cli.Context) *keystore.ScryptParams {
+	return &keystore.ScryptParams{
+		N: ctx.Int(ScryptNFlag.Name),
+		R: ctx.Int(ScryptRFlag.Name),
+		P: ctx.Int(ScryptPFlag.Name),
+	}
+}
+
 // getKeystorePassword checks if the --password flag is set, if not,
 func getKeystorePassword(ctx *cli.Context) []byte {
 	// check if --password is set
@@ -136,11 +217,16 @@ func unlockKeystore(ks keystore.Keystore, basepath, unlock, password string) err
 			bytes := getPassword("Enter password to unlock keystore:")
 			password = string(bytes)
 		}
+	}
 
-		err := keystore.UnlockKeys(ks, basepath, unlock, password)
-		if err != nil {
-			return fmt.Errorf("failed to unlock keys: %s", err)
-		}
+	var err error
+	if lks, ok := ks.(*keystore.LockingKeystore); ok {
+		err = lks.Unlock(basepath, unlock, password)
+	} else {
+		err = keystore.UnlockKeys(ks, basepath, unlock, password)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unlock keys: %s", err)
 	}
 
 	return nil
@@ -17,10 +17,21 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/address"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
+
+	"github.com/stretchr/testify/require"
 )
 
 // TestAccountGenerate test "gossamer account --generate"
@@ -240,3 +251,130 @@ func TestAccountList(t *testing.T) {
 
 	// TODO: check contents of data directory - improve cmd account tests
 }
+
+// TestAccountGenerateScryptParams test "gossamer account --generate --scrypt-n --scrypt-r --scrypt-p"
+func TestAccountGenerateScryptParams(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	directory := fmt.Sprintf("--basepath=%s", testDir)
+	err := app.Run([]string{"irrelevant", "account", directory, "--generate=true", "--password=false",
+		"--scrypt-n=16384", "--scrypt-r=8", "--scrypt-p=2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--generate=true", "--password=false",
+		"--scrypt-n=1024"})
+	if err == nil {
+		t.Fatal("expected error for scrypt N below minimum")
+	}
+}
+
+// TestAccountGenerateDerivation test "gossamer account --generate --derivation"
+func TestAccountGenerateDerivation(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	directory := fmt.Sprintf("--basepath=%s", testDir)
+	err := app.Run([]string{"irrelevant", "account", directory, "--generate=true", "--password=false",
+		"--derivation=//Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyfile := filepath.Join(testDir, "keystore", "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d.key")
+	if _, err = os.Stat(keyfile); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetSS58Prefix(t *testing.T) {
+	cfg := dot.NewTestConfig(t)
+
+	cfg.Init.Genesis = "../../chain/kusama/genesis.json"
+	require.Equal(t, uint16(2), getSS58Prefix(cfg))
+
+	cfg.Init.Genesis = "../../chain/polkadot/genesis.json"
+	require.Equal(t, uint16(0), getSS58Prefix(cfg))
+
+	cfg.Init.Genesis = "nonexistent.json"
+	require.Equal(t, address.DefaultPrefix, getSS58Prefix(cfg))
+}
+
+// TestAccountListType test "gossamer account --list --type"
+func TestAccountListType(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	directory := fmt.Sprintf("--basepath=%s", testDir)
+	err := app.Run([]string{"irrelevant", "account", directory, "--generate=true", "--password=false", "--ed25519"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--list", "--type=ed25519"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--list", "--type=invalid"})
+	if err == nil {
+		t.Fatal("expected error for invalid key type")
+	}
+}
+
+func TestListKeys_ByType(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	password := []byte("noot")
+
+	_, err := keystore.GenerateKeypair(string(crypto.Sr25519Type), nil, testDir, password)
+	require.NoError(t, err)
+	_, err = keystore.GenerateKeypair(string(crypto.Ed25519Type), nil, testDir, password)
+	require.NoError(t, err)
+	_, err = keystore.GenerateKeypair(string(crypto.Ed25519Type), nil, testDir, password)
+	require.NoError(t, err)
+
+	files, err := keystore.ListKeyFiles(testDir, "")
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	buf := new(bytes.Buffer)
+	err = listKeys(buf, testDir, "", address.DefaultPrefix)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 3)
+
+	// the listed keys keep the indices used by --unlock
+	expected := ""
+	for i, file := range files {
+		if file.Type != crypto.Ed25519Type {
+			continue
+		}
+
+		addr, err := address.SS58Encode(file.PublicKey.Encode(), address.DefaultPrefix)
+		require.NoError(t, err)
+		expected += fmt.Sprintf("[%d] %s %s %s\n", i, file.Type, file.PublicKey.Hex(), addr)
+	}
+
+	buf.Reset()
+	err = listKeys(buf, testDir, crypto.Ed25519Type, address.DefaultPrefix)
+	require.NoError(t, err)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestUnlockKeystore_LockingKeystore(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	password := []byte("1234")
+	_, err := keystore.GenerateKeypair(string(crypto.Sr25519Type), nil, testDir, password)
+	require.NoError(t, err)
+
+	lks := keystore.NewLockingKeystore(keystore.NewBasicKeystore(keystore.AccoName, crypto.Sr25519Type), time.Hour)
+	defer lks.Stop()
+	lks.Lock()
+
+	// the node unlocks a locking keystore through its Unlock, so it's usable again
+	err = unlockKeystore(lks, testDir, "0", string(password))
+	require.NoError(t, err)
+	require.False(t, lks.IsLocked())
+	require.Equal(t, 1, lks.Size())
+}
@@ -20,18 +20,20 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/chain/dev"
 	"github.com/ChainSafe/gossamer/chain/gssmr"
 	"github.com/ChainSafe/gossamer/dot"
 	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/genesis"
-	"github.com/ChainSafe/gossamer/lib/runtime/life"
-	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
-	"github.com/ChainSafe/gossamer/lib/runtime/wasmtime"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
 	log "github.com/ChainSafe/log15"
@@ -50,6 +52,9 @@ var (
 	kusamaName   = "kusama"
 	polkadotName = "polkadot"
 	devName      = "dev"
+
+	// chainNames are the built-in chains that can be selected with --chain
+	chainNames = []string{gossamerName, devName, kusamaName, polkadotName}
 )
 
 // loadConfigFile loads a default config file if --chain is specified, a specific
@@ -108,7 +113,7 @@ func setupConfigFromChain(ctx *cli.Context) (*ctoml.Config, *dot.Config, error)
 			cfg = dot.DevConfig()
 			err = loadConfig(tomlCfg, defaultDevConfigPath)
 		default:
-			return nil, nil, fmt.Errorf("unknown chain id provided: %s", id)
+			return nil, nil, fmt.Errorf("unknown chain id provided: %s, available chains are: %s", id, strings.Join(chainNames, ", "))
 		}
 	}
 
@@ -150,10 +155,36 @@ func createDotConfig(ctx *cli.Context) (*dot.Config, error) {
 	setDotNetworkConfig(ctx, tomlCfg.Network, &cfg.Network)
 	setDotRPCConfig(ctx, tomlCfg.RPC, &cfg.RPC)
 
+	if err := setDotBABEDevConfig(ctx, cfg.Global.ID, &cfg.Core); err != nil {
+		logger.Error("failed to set BABE dev configuration", "error", err)
+		return nil, err
+	}
+
+	if err := setDotValidatorConfig(ctx, cfg); err != nil {
+		logger.Error("failed to set validator configuration", "error", err)
+		return nil, err
+	}
+
 	if rewind := ctx.GlobalInt(RewindFlag.Name); rewind != 0 {
 		cfg.State.Rewind = rewind
 	}
 
+	if age := ctx.GlobalDuration(FutureTxMaxAgeFlag.Name); age != 0 {
+		cfg.State.FutureTxMaxAge = age
+	}
+
+	if period := ctx.GlobalUint64(FinalizationGracePeriodFlag.Name); period != 0 {
+		cfg.State.FinalizationGracePeriod = period
+	}
+
+	if ctx.GlobalBool(VerifyJustificationsFlag.Name) {
+		cfg.State.VerifyJustifications = true
+	}
+
+	if size := ctx.GlobalInt(TrieCacheSizeFlag.Name); size != 0 {
+		cfg.State.TrieCacheSize = size
+	}
+
 	// set system info
 	setSystemInfoConfig(ctx, cfg)
 
@@ -474,6 +505,11 @@ func setDotGlobalConfigFromFlags(ctx *cli.Context, cfg *dot.GlobalConfig) {
 	}
 
 	cfg.NoTelemetry = ctx.Bool("no-telemetry")
+
+	// check --telemetry-url flag and update node configuration
+	if urls := ctx.String(TelemetryURLFlag.Name); urls != "" {
+		cfg.TelemetryURLs = strings.Split(urls, ",")
+	}
 }
 
 func setDotGlobalConfigName(ctx *cli.Context, tomlCfg *ctoml.Config, cfg *dot.GlobalConfig) error {
@@ -536,6 +572,39 @@ func setDotAccountConfig(ctx *cli.Context, tomlCfg ctoml.AccountConfig, cfg *dot
 	)
 }
 
+// setDotValidatorConfig sets the authority roles if the --validator flag is set
+// and checks that session keys are available to author and finalise blocks with
+func setDotValidatorConfig(ctx *cli.Context, cfg *dot.Config) error {
+	if !ctx.GlobalBool(ValidatorFlag.Name) {
+		return nil
+	}
+
+	if roles := ctx.GlobalString(RolesFlag.Name); roles != "" && roles != strconv.Itoa(int(types.AuthorityRole)) {
+		return fmt.Errorf("--validator cannot be used with --roles=%s", roles)
+	}
+
+	// the test keyring set by --key contains both session keys
+	if cfg.Account.Key == "" {
+		// BABE uses an sr25519 key and GRANDPA uses an ed25519 key
+		for _, keytype := range []crypto.KeyType{crypto.Sr25519Type, crypto.Ed25519Type} {
+			keys, err := keystore.ListKeyFiles(cfg.Global.BasePath, keytype)
+			if err != nil {
+				return err
+			}
+
+			if len(keys) == 0 {
+				return fmt.Errorf("--validator requires a BABE (sr25519) and a GRANDPA (ed25519) session key, "+
+					"use --key or import the missing %s key into %s", keytype, cfg.Global.BasePath)
+			}
+		}
+	}
+
+	cfg.Core.Roles = types.AuthorityRole
+	cfg.Core.BabeAuthority = true
+	cfg.Core.GrandpaAuthority = true
+	return nil
+}
+
 // setDotCoreConfig sets dot.CoreConfig using flag values from the cli context
 func setDotCoreConfig(ctx *cli.Context, tomlCfg ctoml.CoreConfig, cfg *dot.CoreConfig) {
 	cfg.Roles = tomlCfg.Roles
@@ -543,6 +612,8 @@ func setDotCoreConfig(ctx *cli.Context, tomlCfg ctoml.CoreConfig, cfg *dot.CoreC
 	cfg.GrandpaAuthority = tomlCfg.Roles == types.AuthorityRole
 	cfg.SlotDuration = tomlCfg.SlotDuration
 	cfg.EpochLength = tomlCfg.EpochLength
+	cfg.WasmFuelLimit = tomlCfg.WasmFuelLimit
+	cfg.WasmMaxMemoryPages = tomlCfg.WasmMaxMemoryPages
 
 	// check --roles flag and update node configuration
 	if roles := ctx.GlobalString(RolesFlag.Name); roles != "" {
@@ -579,32 +650,76 @@ func setDotCoreConfig(ctx *cli.Context, tomlCfg ctoml.CoreConfig, cfg *dot.CoreC
 		cfg.GrandpaAuthority = false
 	}
 
-	switch tomlCfg.WasmInterpreter {
-	case wasmer.Name:
-		cfg.WasmInterpreter = wasmer.Name
-	case wasmtime.Name:
-		cfg.WasmInterpreter = wasmtime.Name
-	case life.Name:
-		cfg.WasmInterpreter = life.Name
-	case "":
+	switch {
+	case tomlCfg.WasmInterpreter == "":
 		cfg.WasmInterpreter = gssmr.DefaultWasmInterpreter
+	case runtime.IsRegisteredInterpreter(tomlCfg.WasmInterpreter):
+		cfg.WasmInterpreter = tomlCfg.WasmInterpreter
 	default:
 		cfg.WasmInterpreter = gssmr.DefaultWasmInterpreter
 		logger.Warn("invalid wasm interpreter set in config", "defaulting to", gssmr.DefaultWasmInterpreter)
 	}
 
+	// check --wasm-interpreter flag and update node configuration, unknown interpreters fail when the runtime is
+	// created
+	if interpreter := ctx.GlobalString(WasmInterpreterFlag.Name); interpreter != "" {
+		cfg.WasmInterpreter = interpreter
+	}
+
 	logger.Debug(
 		"core configuration",
 		"babe-authority", cfg.BabeAuthority,
 		"grandpa-authority", cfg.GrandpaAuthority,
 		"epoch-length", cfg.EpochLength,
 		"wasm-interpreter", cfg.WasmInterpreter,
+		"wasm-fuel-limit", cfg.WasmFuelLimit,
+		"wasm-max-memory-pages", cfg.WasmMaxMemoryPages,
 	)
 }
 
+// setDotBABEDevConfig sets the BABE slot duration and epoch length overrides in dot.CoreConfig using flag
+// values from the cli context. The overrides are only accepted for the dev chain.
+func setDotBABEDevConfig(ctx *cli.Context, id string, cfg *dot.CoreConfig) error {
+	slotDuration := ctx.GlobalInt(BABESlotDurationFlag.Name)
+	epochLength := ctx.GlobalInt(BABEEpochLengthFlag.Name)
+
+	if slotDuration == 0 && epochLength == 0 {
+		return nil
+	}
+
+	if id != dev.DefaultID {
+		return fmt.Errorf("--%s and --%s can only be used with the %s chain", BABESlotDurationFlag.Name, BABEEpochLengthFlag.Name, dev.DefaultID)
+	}
+
+	if slotDuration < 0 {
+		return fmt.Errorf("--%s must be positive, got %d", BABESlotDurationFlag.Name, slotDuration)
+	}
+
+	if epochLength < 0 {
+		return fmt.Errorf("--%s must be positive, got %d", BABEEpochLengthFlag.Name, epochLength)
+	}
+
+	if slotDuration > 0 {
+		cfg.SlotDuration = uint64(slotDuration)
+	}
+
+	if epochLength > 0 {
+		cfg.EpochLength = uint64(epochLength)
+	}
+
+	logger.Debug(
+		"BABE dev configuration",
+		"slot-duration", cfg.SlotDuration,
+		"epoch-length", cfg.EpochLength,
+	)
+	return nil
+}
+
 // setDotNetworkConfig sets dot.NetworkConfig using flag values from the cli context
 func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot.NetworkConfig) {
 	cfg.Port = tomlCfg.Port
+	cfg.ListenAddresses = tomlCfg.ListenAddresses
+	cfg.PublicAddresses = tomlCfg.PublicAddresses
 	cfg.Bootnodes = tomlCfg.Bootnodes
 	cfg.ProtocolID = tomlCfg.ProtocolID
 	cfg.NoBootstrap = tomlCfg.NoBootstrap
@@ -612,6 +727,10 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	cfg.MinPeers = tomlCfg.MinPeers
 	cfg.MaxPeers = tomlCfg.MaxPeers
 	cfg.PersistentPeers = tomlCfg.PersistentPeers
+	cfg.BlockRequestSize = tomlCfg.BlockRequestSize
+	cfg.CompressSync = tomlCfg.CompressSync
+	cfg.PingInterval = time.Duration(tomlCfg.PingInterval) * time.Second
+	cfg.PingFailureThreshold = tomlCfg.PingFailureThreshold
 
 	// check --port flag and update node configuration
 	if port := ctx.GlobalUint(PortFlag.Name); port != 0 {
@@ -643,13 +762,38 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 		cfg.NoMDNS = true
 	}
 
+	// check --compress-sync flag and update node configuration
+	if compress := ctx.GlobalBool(CompressSyncFlag.Name); compress {
+		cfg.CompressSync = true
+	}
+
+	// check --ping-interval flag and update node configuration
+	if interval := ctx.GlobalDuration(PingIntervalFlag.Name); interval != 0 {
+		cfg.PingInterval = interval
+	}
+
+	// check --ping-failure-threshold flag and update node configuration
+	if threshold := ctx.GlobalInt(PingFailureThresholdFlag.Name); threshold != 0 {
+		cfg.PingFailureThreshold = threshold
+	}
+
 	if len(cfg.PersistentPeers) == 0 {
 		cfg.PersistentPeers = []string(nil)
 	}
 
+	if len(cfg.ListenAddresses) == 0 {
+		cfg.ListenAddresses = []string(nil)
+	}
+
+	if len(cfg.PublicAddresses) == 0 {
+		cfg.PublicAddresses = []string(nil)
+	}
+
 	logger.Debug(
 		"network configuration",
 		"port", cfg.Port,
+		"listen-addresses", cfg.ListenAddresses,
+		"public-addresses", cfg.PublicAddresses,
 		"bootnodes", cfg.Bootnodes,
 		"protocol", cfg.ProtocolID,
 		"nobootstrap", cfg.NoBootstrap,
@@ -657,6 +801,10 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 		"minpeers", cfg.MinPeers,
 		"maxpeers", cfg.MaxPeers,
 		"persistent-peers", cfg.PersistentPeers,
+		"block-request-size", cfg.BlockRequestSize,
+		"compress-sync", cfg.CompressSync,
+		"ping-interval", cfg.PingInterval,
+		"ping-failure-threshold", cfg.PingFailureThreshold,
 	)
 }
 
@@ -670,6 +818,13 @@ func setDotRPCConfig(ctx *cli.Context, tomlCfg ctoml.RPCConfig, cfg *dot.RPCConf
 	cfg.WSPort = tomlCfg.WSPort
 	cfg.WS = tomlCfg.WS
 	cfg.WSExternal = tomlCfg.WSExternal
+	cfg.MaxRequestSize = tomlCfg.MaxRequestSize
+	cfg.RateLimit = tomlCfg.RateLimit
+	cfg.RateBurst = tomlCfg.RateBurst
+	cfg.WSMessageRate = tomlCfg.WSMessageRate
+	cfg.WSMessageBurst = tomlCfg.WSMessageBurst
+	cfg.TrustedProxies = tomlCfg.TrustedProxies
+	cfg.Unsafe = tomlCfg.Unsafe
 
 	// check --rpc flag and update node configuration
 	if enabled := ctx.GlobalBool(RPCEnabledFlag.Name); enabled || cfg.Enabled {
@@ -720,6 +875,34 @@ func setDotRPCConfig(ctx *cli.Context, tomlCfg ctoml.RPCConfig, cfg *dot.RPCConf
 		cfg.WSExternal = false
 	}
 
+	if size := ctx.GlobalInt64(RPCMaxRequestSizeFlag.Name); size != 0 {
+		cfg.MaxRequestSize = size
+	}
+
+	if rate := ctx.GlobalFloat64(RPCRateLimitFlag.Name); rate != 0 {
+		cfg.RateLimit = rate
+	}
+
+	if burst := ctx.GlobalInt(RPCRateBurstFlag.Name); burst != 0 {
+		cfg.RateBurst = burst
+	}
+
+	if rate := ctx.GlobalFloat64(WSMessageRateFlag.Name); rate != 0 {
+		cfg.WSMessageRate = rate
+	}
+
+	if burst := ctx.GlobalInt(WSMessageBurstFlag.Name); burst != 0 {
+		cfg.WSMessageBurst = burst
+	}
+
+	if proxies := ctx.GlobalString(RPCTrustedProxiesFlag.Name); proxies != "" {
+		cfg.TrustedProxies = strings.Split(proxies, ",")
+	}
+
+	if unsafe := ctx.GlobalBool(RPCUnsafeFlag.Name); unsafe {
+		cfg.Unsafe = true
+	}
+
 	// format rpc modules
 	if len(cfg.Modules) == 0 {
 		cfg.Modules = []string(nil)
@@ -735,6 +918,13 @@ func setDotRPCConfig(ctx *cli.Context, tomlCfg ctoml.RPCConfig, cfg *dot.RPCConf
 		"ws", cfg.WS,
 		"ws external", cfg.WSExternal,
 		"wsport", cfg.WSPort,
+		"max request size", cfg.MaxRequestSize,
+		"rate limit", cfg.RateLimit,
+		"rate burst", cfg.RateBurst,
+		"ws message rate", cfg.WSMessageRate,
+		"ws message burst", cfg.WSMessageBurst,
+		"trusted proxies", cfg.TrustedProxies,
+		"unsafe", cfg.Unsafe,
 	)
 }
 
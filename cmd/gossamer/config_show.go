@@ -0,0 +1,200 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/urfave/cli"
+)
+
+// ConfigSource is the source that set the value of a configuration field
+type ConfigSource string
+
+const (
+	// DefaultSource is used for values from the default configuration of the chain
+	DefaultSource ConfigSource = "default"
+	// TOMLSource is used for values from the toml configuration file
+	TOMLSource ConfigSource = "toml"
+	// FlagSource is used for values from command-line flags
+	FlagSource ConfigSource = "flag"
+	// GenesisSource is used for values from the genesis data stored in the node database
+	GenesisSource ConfigSource = "genesis"
+)
+
+// ConfigValue is the effective value of a configuration field and the source that set it
+type ConfigValue struct {
+	Field  string
+	Value  string
+	Source ConfigSource
+}
+
+// ResolveConfig creates the dot configuration the node would start with and
+// reports, for each field, the source that set its final value. Configuration
+// sources are applied in the order default, toml, flag, genesis; a field is
+// attributed to the last source that changed its value.
+func ResolveConfig(ctx *cli.Context) (*dot.Config, []ConfigValue, error) {
+	_, defaultCfg, err := setupConfigFromChain(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tomlCtx, err := newTOMLOnlyContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tomlCfg, err := createDotConfig(tomlCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flagCfg, err := createDotConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a random node name is generated when no name is configured, so it can only
+	// differ between the configurations because of the --name flag
+	if !ctx.GlobalIsSet(NameFlag.Name) {
+		tomlCfg.Global.Name = flagCfg.Global.Name
+	}
+
+	// system information is set from the gossamer binary rather than a configuration source
+	defaultCfg.System = flagCfg.System
+
+	cfg := *flagCfg
+	if dot.NodeInitialized(cfg.Global.BasePath, false) {
+		if err = updateDotConfigFromGenesisData(ctx, &cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	defaults := flattenConfig(defaultCfg)
+	tomlValues := flattenConfig(tomlCfg)
+	flagValues := flattenConfig(flagCfg)
+	values := flattenConfig(&cfg)
+
+	for i := range values {
+		switch {
+		case values[i].Value != flagValues[i].Value:
+			values[i].Source = GenesisSource
+		case flagValues[i].Value != tomlValues[i].Value:
+			values[i].Source = FlagSource
+		case tomlValues[i].Value != defaults[i].Value:
+			values[i].Source = TOMLSource
+		default:
+			values[i].Source = DefaultSource
+		}
+	}
+
+	return &cfg, values, nil
+}
+
+// newTOMLOnlyContext returns a context with the default value for every flag of
+// the given context, except for the flags that select the toml configuration
+func newTOMLOnlyContext(ctx *cli.Context) (*cli.Context, error) {
+	set := flag.NewFlagSet("toml", flag.ContinueOnError)
+	for _, f := range ConfigShowFlags {
+		withoutEnvVar(f).Apply(set)
+	}
+
+	for _, name := range []string{ConfigFlag.Name, ChainFlag.Name} {
+		if !ctx.GlobalIsSet(name) {
+			continue
+		}
+
+		if err := set.Set(name, ctx.GlobalString(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return cli.NewContext(ctx.App, set, nil), nil
+}
+
+// withoutEnvVar returns a copy of the flag that does not read its value from an
+// environment variable, values set from the environment are reported as flag values
+func withoutEnvVar(f cli.Flag) cli.Flag {
+	switch f := f.(type) {
+	case cli.StringFlag:
+		f.EnvVar = ""
+		return f
+	case cli.UintFlag:
+		f.EnvVar = ""
+		return f
+	case cli.IntFlag:
+		f.EnvVar = ""
+		return f
+	default:
+		return f
+	}
+}
+
+// flattenConfig returns the value of every field of the configuration in declaration order
+func flattenConfig(cfg *dot.Config) []ConfigValue {
+	var values []ConfigValue
+
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			name := v.Type().Field(i).Name
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+
+			field := v.Field(i)
+			if field.Kind() == reflect.Struct {
+				walk(name, field)
+				continue
+			}
+
+			values = append(values, ConfigValue{
+				Field: name,
+				Value: fmt.Sprintf("%v", field.Interface()),
+			})
+		}
+	}
+
+	walk("", reflect.ValueOf(cfg).Elem())
+	return values
+}
+
+// configShowAction prints the effective node configuration and the source of each value
+func configShowAction(ctx *cli.Context) error {
+	_, values, err := ResolveConfig(ctx)
+	if err != nil {
+		logger.Error("failed to resolve node configuration", "error", err)
+		return err
+	}
+
+	return printConfigValues(os.Stdout, values)
+}
+
+func printConfigValues(w io.Writer, values []ConfigValue) error {
+	for _, v := range values {
+		if _, err := fmt.Fprintf(w, "%-36s %-8s %s\n", v.Field, v.Source, v.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func configValue(t *testing.T, values []ConfigValue, field string) ConfigValue {
+	for _, v := range values {
+		if v.Field == field {
+			return v
+		}
+	}
+
+	t.Fatalf("configuration field %s not found", field)
+	return ConfigValue{}
+}
+
+// TestResolveConfig tests that ResolveConfig reports the source of each configuration value
+func TestResolveConfig(t *testing.T) {
+	testCfg, testCfgFile := newTestConfigWithFile(t)
+	require.NotNil(t, testCfg)
+	require.NotNil(t, testCfgFile)
+
+	defer utils.RemoveTestDir(t)
+
+	ctx, err := newTestContext(
+		t.Name(),
+		[]string{"config", "port"},
+		[]interface{}{testCfgFile.Name(), uint(7077)},
+	)
+	require.NoError(t, err)
+
+	cfg, values, err := ResolveConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7077), cfg.Network.Port)
+
+	// set in the toml configuration file and overridden by --port
+	require.Equal(t, ConfigValue{"Network.Port", "7077", FlagSource}, configValue(t, values, "Network.Port"))
+
+	// set in the toml configuration file only
+	require.Equal(t, ConfigValue{"Global.BasePath", testCfg.Global.BasePath, TOMLSource}, configValue(t, values, "Global.BasePath"))
+
+	// not set by any source
+	require.Equal(t, ConfigValue{"State.Rewind", "0", DefaultSource}, configValue(t, values, "State.Rewind"))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, printConfigValues(buf, values))
+	require.Equal(t, len(values), strings.Count(buf.String(), "\n"))
+	require.Contains(t, buf.String(), "Network.Port")
+}
@@ -17,14 +17,23 @@
 package main
 
 import (
+	"flag"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/chain/dev"
 	"github.com/ChainSafe/gossamer/chain/gssmr"
 	"github.com/ChainSafe/gossamer/dot"
+	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/ChainSafe/chaindb"
@@ -346,6 +355,17 @@ func TestCoreConfigFromFlags(t *testing.T) {
 				WasmInterpreter:  gssmr.DefaultWasmInterpreter,
 			},
 		},
+		{
+			"Test gossamer --wasm-interpreter",
+			[]string{"config", "wasm-interpreter"},
+			[]interface{}{testCfgFile.Name(), "stub"},
+			dot.CoreConfig{
+				Roles:            4,
+				BabeAuthority:    true,
+				GrandpaAuthority: true,
+				WasmInterpreter:  "stub",
+			},
+		},
 	}
 
 	for _, c := range testcases {
@@ -360,6 +380,137 @@ func TestCoreConfigFromFlags(t *testing.T) {
 	}
 }
 
+// TestValidatorFlag tests that --validator requires BABE and GRANDPA session keys and enables the authority roles
+func TestValidatorFlag(t *testing.T) {
+	testCfg, testCfgFile := newTestConfigWithFile(t)
+	require.NotNil(t, testCfg)
+	require.NotNil(t, testCfgFile)
+
+	defer utils.RemoveTestDir(t)
+
+	ctx, err := newTestContext(
+		t.Name(),
+		[]string{"config", "basepath", "roles", "validator"},
+		[]interface{}{testCfgFile.Name(), testCfg.Global.BasePath, "0", true},
+	)
+	require.NoError(t, err)
+	_, err = createDotConfig(ctx)
+	require.EqualError(t, err, "--validator cannot be used with --roles=0")
+
+	ctx, err = newTestContext(
+		t.Name(),
+		[]string{"config", "basepath", "validator"},
+		[]interface{}{testCfgFile.Name(), testCfg.Global.BasePath, true},
+	)
+	require.NoError(t, err)
+	_, err = createDotConfig(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--validator requires a BABE (sr25519) and a GRANDPA (ed25519) session key")
+
+	// a BABE key alone isn't enough
+	_, err = keystore.GenerateKeypair(string(crypto.Sr25519Type), nil, testCfg.Global.BasePath, []byte("noot"))
+	require.NoError(t, err)
+	_, err = createDotConfig(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "import the missing ed25519 key")
+
+	_, err = keystore.GenerateKeypair(string(crypto.Ed25519Type), nil, testCfg.Global.BasePath, []byte("noot"))
+	require.NoError(t, err)
+	cfg, err := createDotConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.AuthorityRole, cfg.Core.Roles)
+
+	ctx, err = newTestContext(
+		t.Name(),
+		[]string{"config", "basepath", "key", "roles", "validator"},
+		[]interface{}{testCfgFile.Name(), testCfg.Global.BasePath, "alice", "4", true},
+	)
+	require.NoError(t, err)
+	cfg, err = createDotConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.AuthorityRole, cfg.Core.Roles)
+	require.True(t, cfg.Core.BabeAuthority)
+	require.True(t, cfg.Core.GrandpaAuthority)
+}
+
+// TestWasmInterpreterFromConfig tests that registered wasm interpreters can be selected in the config file
+func TestWasmInterpreterFromConfig(t *testing.T) {
+	runtime.RegisterInterpreter("cmd-stub", func(_ []byte, _ *runtime.InstanceConfig) (runtime.Instance, error) {
+		return nil, nil
+	})
+
+	ctx, err := newTestContext("Test gossamer wasm-interpreter", []string{}, []interface{}{})
+	require.Nil(t, err)
+
+	cfg := &dot.CoreConfig{}
+	setDotCoreConfig(ctx, ctoml.CoreConfig{WasmInterpreter: "cmd-stub"}, cfg)
+	require.Equal(t, "cmd-stub", cfg.WasmInterpreter)
+
+	// unknown interpreters in the config file fall back to the default
+	setDotCoreConfig(ctx, ctoml.CoreConfig{WasmInterpreter: "unknown"}, cfg)
+	require.Equal(t, gssmr.DefaultWasmInterpreter, cfg.WasmInterpreter)
+}
+
+// TestBABEDevConfigFromFlags tests the BABE slot duration and epoch length overrides for the dev chain
+func TestBABEDevConfigFromFlags(t *testing.T) {
+	testCfg, testCfgFile := newTestConfigWithFile(t)
+	require.NotNil(t, testCfg)
+	require.NotNil(t, testCfgFile)
+
+	defer utils.RemoveTestDir(t)
+
+	testApp := cli.NewApp()
+	testApp.Writer = ioutil.Discard
+
+	ctx, err := newTestContext(
+		"Test gossamer --chain dev --babe-slot-duration --babe-epoch-length",
+		[]string{"chain", "babe-slot-duration", "babe-epoch-length"},
+		[]interface{}{"dev", "500", "20"},
+	)
+	require.Nil(t, err)
+	cfg, err := createDotConfig(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(500), cfg.Core.SlotDuration)
+	require.Equal(t, uint64(20), cfg.Core.EpochLength)
+
+	testcases := []struct {
+		description string
+		flags       []string
+		values      []interface{}
+	}{
+		{
+			"Test gossamer --babe-slot-duration on non-dev chain",
+			[]string{"config", "babe-slot-duration"},
+			[]interface{}{testCfgFile.Name(), "500"},
+		},
+		{
+			"Test gossamer --babe-epoch-length on non-dev chain",
+			[]string{"config", "babe-epoch-length"},
+			[]interface{}{testCfgFile.Name(), "20"},
+		},
+		{
+			"Test gossamer --chain dev --babe-slot-duration negative",
+			[]string{"chain", "babe-slot-duration"},
+			[]interface{}{"dev", "-500"},
+		},
+		{
+			"Test gossamer --chain dev --babe-epoch-length negative",
+			[]string{"chain", "babe-epoch-length"},
+			[]interface{}{"dev", "-1"},
+		},
+	}
+
+	for _, c := range testcases {
+		c := c // bypass scopelint false positive
+		t.Run(c.description, func(t *testing.T) {
+			ctx, err := newTestContext(c.description, c.flags, c.values)
+			require.Nil(t, err)
+			_, err = createDotConfig(ctx)
+			require.Error(t, err)
+		})
+	}
+}
+
 // TestNetworkConfigFromFlags tests createDotNetworkConfig using relevant network flags
 func TestNetworkConfigFromFlags(t *testing.T) {
 	testCfg, testCfgFile := newTestConfigWithFile(t)
@@ -437,6 +588,33 @@ func TestNetworkConfigFromFlags(t *testing.T) {
 				NoMDNS:      true,
 			},
 		},
+		{
+			"Test gossamer --compress-sync",
+			[]string{"config", "compress-sync"},
+			[]interface{}{testCfgFile.Name(), "true"},
+			dot.NetworkConfig{
+				Port:         testCfg.Network.Port,
+				Bootnodes:    testCfg.Network.Bootnodes,
+				ProtocolID:   testCfg.Network.ProtocolID,
+				NoBootstrap:  testCfg.Network.NoBootstrap,
+				NoMDNS:       testCfg.Network.NoMDNS,
+				CompressSync: true,
+			},
+		},
+		{
+			"Test gossamer --ping-interval --ping-failure-threshold",
+			[]string{"config", "ping-interval", "ping-failure-threshold"},
+			[]interface{}{testCfgFile.Name(), 10 * time.Second, "5"},
+			dot.NetworkConfig{
+				Port:                 testCfg.Network.Port,
+				Bootnodes:            testCfg.Network.Bootnodes,
+				ProtocolID:           testCfg.Network.ProtocolID,
+				NoBootstrap:          testCfg.Network.NoBootstrap,
+				NoMDNS:               testCfg.Network.NoMDNS,
+				PingInterval:         10 * time.Second,
+				PingFailureThreshold: 5,
+			},
+		},
 	}
 
 	for _, c := range testcases {
@@ -757,6 +935,10 @@ func TestUpdateConfigFromGenesisJSON_Default(t *testing.T) {
 		System:  testCfg.System,
 	}
 
+	// the default genesis file is embedded, so it loads from the test working directory
+	expected.Network.Bootnodes = []string{}
+	expected.Network.ProtocolID = "/gossamer/gssmr/0"
+
 	cfg, err := createDotConfig(ctx)
 	require.Nil(t, err)
 	updateDotConfigFromGenesisJSONRaw(*dotConfigToToml(testCfg), cfg)
@@ -972,3 +1154,144 @@ func TestGlobalNodeNamePriorityOrder(t *testing.T) {
 		require.NotEqual(t, cfg.Global.Name, createdCfg.Global.Name)
 	})
 }
+
+// TestConfigFromEnvVars tests that GSSMR_ environment variables set configuration values
+// when the corresponding flags are not set, and that flags take precedence over them
+func TestConfigFromEnvVars(t *testing.T) {
+	testCfg, testCfgFile := newTestConfigWithFile(t)
+	require.NotNil(t, testCfg)
+	require.NotNil(t, testCfgFile)
+
+	defer utils.RemoveTestDir(t)
+
+	envs := map[string]string{
+		"GSSMR_BASEPATH": testCfg.Global.BasePath,
+		"GSSMR_PORT":     "7077",
+		"GSSMR_RPCPORT":  "8577",
+		"GSSMR_KEY":      "bob",
+	}
+
+	for key, value := range envs {
+		require.NoError(t, os.Setenv(key, value))
+	}
+
+	defer func() {
+		for key := range envs {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	newContext := func(flags map[string]string) *cli.Context {
+		set := flag.NewFlagSet(t.Name(), 0)
+		for _, f := range RootFlags {
+			f.Apply(set)
+		}
+
+		require.NoError(t, set.Set(ConfigFlag.Name, testCfgFile.Name()))
+		for name, value := range flags {
+			require.NoError(t, set.Set(name, value))
+		}
+
+		return cli.NewContext(nil, set, nil)
+	}
+
+	cfg, err := createDotConfig(newContext(nil))
+	require.NoError(t, err)
+	require.Equal(t, testCfg.Global.BasePath, cfg.Global.BasePath)
+	require.Equal(t, uint32(7077), cfg.Network.Port)
+	require.Equal(t, uint32(8577), cfg.RPC.Port)
+	require.Equal(t, "bob", cfg.Account.Key)
+
+	cfg, err = createDotConfig(newContext(map[string]string{
+		PortFlag.Name: "7078",
+		KeyFlag.Name:  "alice",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint32(7078), cfg.Network.Port)
+	require.Equal(t, uint32(8577), cfg.RPC.Port)
+	require.Equal(t, "alice", cfg.Account.Key)
+}
+
+// TestConfigFromChainFlag_EquivalentPaths tests that --chain dev selects the same
+// configuration and genesis file as the explicit dev configuration path
+func TestConfigFromChainFlag_EquivalentPaths(t *testing.T) {
+	ctx, err := newTestContext(t.Name(), []string{"chain"}, []interface{}{devName})
+	require.NoError(t, err)
+	chainCfg, err := createDotConfig(ctx)
+	require.NoError(t, err)
+
+	ctx, err = newTestContext(t.Name(), []string{"config"}, []interface{}{defaultDevConfigPath})
+	require.NoError(t, err)
+	pathCfg, err := createDotConfig(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, "./chain/dev/genesis-spec.json", chainCfg.Init.Genesis)
+	require.Equal(t, pathCfg.Init, chainCfg.Init)
+	require.Equal(t, pathCfg.Global.BasePath, chainCfg.Global.BasePath)
+	require.Equal(t, pathCfg.Log, chainCfg.Log)
+	require.Equal(t, pathCfg.Account, chainCfg.Account)
+	require.Equal(t, pathCfg.Core, chainCfg.Core)
+	require.Equal(t, pathCfg.Network, chainCfg.Network)
+	require.Equal(t, pathCfg.RPC, chainCfg.RPC)
+}
+
+// TestConfigFromChainFlag_Unknown tests that an unknown --chain lists the built-in chains
+func TestConfigFromChainFlag_Unknown(t *testing.T) {
+	ctx, err := newTestContext(t.Name(), []string{"chain"}, []interface{}{"potato"})
+	require.NoError(t, err)
+
+	_, err = createDotConfig(ctx)
+	require.EqualError(t, err, "unknown chain id provided: potato, available chains are: gssmr, dev, kusama, polkadot")
+}
+
+// TestConfigFromEmbeddedChainFiles tests that the built-in chain configuration and genesis
+// files load when they are not present in the working directory
+func TestConfigFromEmbeddedChainFiles(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	testDir := t.TempDir()
+	require.NoError(t, os.Chdir(testDir))
+
+	gssmrConfigPath, devConfigPath := defaultGssmrConfigPath, defaultDevConfigPath
+	defaultGssmrConfigPath, defaultDevConfigPath = gssmr.DefaultConfig, dev.DefaultConfig
+
+	defer func() {
+		defaultGssmrConfigPath, defaultDevConfigPath = gssmrConfigPath, devConfigPath
+		require.NoError(t, os.Chdir(wd))
+	}()
+
+	require.False(t, utils.PathExists(gssmr.DefaultConfig))
+
+	ctx, err := newTestContext(t.Name(), []string{"name"}, []interface{}{"gssmr-node"})
+	require.NoError(t, err)
+	cfg, err := createDotConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, gssmr.DefaultGenesis, cfg.Init.Genesis)
+
+	gen, err := genesis.NewGenesisFromJSONRaw(cfg.Init.Genesis)
+	require.NoError(t, err)
+	require.Equal(t, gssmr.DefaultID, gen.ID)
+
+	ctx, err = newTestContext(t.Name(), []string{"chain", "name"}, []interface{}{devName, "dev-node"})
+	require.NoError(t, err)
+	cfg, err = createDotConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, dev.DefaultGenesis, cfg.Init.Genesis)
+	require.Equal(t, dev.DefaultKey, cfg.Account.Key)
+
+	_, err = genesis.NewGenesisFromJSON(cfg.Init.Genesis, 0)
+	require.NoError(t, err)
+
+	// a configuration file at a user supplied path overrides the embedded files
+	tomlCfg := &ctoml.Config{}
+	require.NoError(t, loadConfig(tomlCfg, gssmr.DefaultConfig))
+	tomlCfg.Network.Port = 7077
+	exportConfig(tomlCfg, filepath.Join(testDir, "config.toml"))
+
+	ctx, err = newTestContext(t.Name(), []string{"config", "name"}, []interface{}{"config.toml", "gssmr-node"})
+	require.NoError(t, err)
+	cfg, err = createDotConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7077), cfg.Network.Port)
+}
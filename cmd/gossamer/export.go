@@ -18,6 +18,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot"
 	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
@@ -107,19 +108,32 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 	}
 
 	cfg.Core = ctoml.CoreConfig{
-		Roles:            dcfg.Core.Roles,
-		BabeAuthority:    dcfg.Core.BabeAuthority,
-		GrandpaAuthority: dcfg.Core.GrandpaAuthority,
-		EpochLength:      dcfg.Core.EpochLength,
-		SlotDuration:     dcfg.Core.SlotDuration,
+		Roles:              dcfg.Core.Roles,
+		BabeAuthority:      dcfg.Core.BabeAuthority,
+		GrandpaAuthority:   dcfg.Core.GrandpaAuthority,
+		EpochLength:        dcfg.Core.EpochLength,
+		SlotDuration:       dcfg.Core.SlotDuration,
+		WasmInterpreter:    dcfg.Core.WasmInterpreter,
+		WasmFuelLimit:      dcfg.Core.WasmFuelLimit,
+		WasmMaxMemoryPages: dcfg.Core.WasmMaxMemoryPages,
 	}
 
 	cfg.Network = ctoml.NetworkConfig{
-		Port:        dcfg.Network.Port,
-		Bootnodes:   dcfg.Network.Bootnodes,
-		ProtocolID:  dcfg.Network.ProtocolID,
-		NoBootstrap: dcfg.Network.NoBootstrap,
-		NoMDNS:      dcfg.Network.NoMDNS,
+		Port:             dcfg.Network.Port,
+		ListenAddresses:  dcfg.Network.ListenAddresses,
+		PublicAddresses:  dcfg.Network.PublicAddresses,
+		Bootnodes:        dcfg.Network.Bootnodes,
+		ProtocolID:       dcfg.Network.ProtocolID,
+		NoBootstrap:      dcfg.Network.NoBootstrap,
+		NoMDNS:           dcfg.Network.NoMDNS,
+		MinPeers:         dcfg.Network.MinPeers,
+		MaxPeers:         dcfg.Network.MaxPeers,
+		PersistentPeers:  dcfg.Network.PersistentPeers,
+		BlockRequestSize: dcfg.Network.BlockRequestSize,
+		CompressSync:     dcfg.Network.CompressSync,
+
+		PingInterval:         uint32(dcfg.Network.PingInterval / time.Second),
+		PingFailureThreshold: dcfg.Network.PingFailureThreshold,
 	}
 
 	cfg.RPC = ctoml.RPCConfig{
@@ -131,6 +145,14 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		WSPort:     dcfg.RPC.WSPort,
 		WS:         dcfg.RPC.WS,
 		WSExternal: dcfg.RPC.WSExternal,
+
+		MaxRequestSize: dcfg.RPC.MaxRequestSize,
+		RateLimit:      dcfg.RPC.RateLimit,
+		RateBurst:      dcfg.RPC.RateBurst,
+		WSMessageRate:  dcfg.RPC.WSMessageRate,
+		WSMessageBurst: dcfg.RPC.WSMessageBurst,
+		TrustedProxies: dcfg.RPC.TrustedProxies,
+		Unsafe:         dcfg.RPC.Unsafe,
 	}
 
 	return cfg
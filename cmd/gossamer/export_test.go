@@ -18,7 +18,10 @@ package main
 
 import (
 	"io/ioutil"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot"
 	"github.com/ChainSafe/gossamer/lib/utils"
@@ -172,3 +175,63 @@ func TestExportCommand(t *testing.T) {
 		})
 	}
 }
+
+// TestExportConfig_RoundTrip tests that every toml configuration field of a fully populated
+// dot configuration is exported and read back with the same value
+func TestExportConfig_RoundTrip(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	dcfg := &dot.Config{
+		Global: dot.GlobalConfig{Name: "gossamer", ID: "gssmr", BasePath: testDir, LogLvl: log.LvlDebug, MetricsPort: 9877},
+		Log: dot.LogConfig{
+			CoreLvl: log.LvlDebug, SyncLvl: log.LvlTrace, NetworkLvl: log.LvlWarn, RPCLvl: log.LvlError,
+			StateLvl: log.LvlCrit, RuntimeLvl: log.LvlInfo, BlockProducerLvl: log.LvlDebug, FinalityGadgetLvl: log.LvlTrace,
+		},
+		Init:    dot.InitConfig{Genesis: "genesis.json"},
+		Account: dot.AccountConfig{Key: "alice", Unlock: "0,1"},
+		Core: dot.CoreConfig{
+			Roles: 4, BabeAuthority: true, GrandpaAuthority: true, SlotDuration: 3000, EpochLength: 200,
+			WasmInterpreter: "wasmer", WasmFuelLimit: 1000000, WasmMaxMemoryPages: 64,
+		},
+		Network: dot.NetworkConfig{
+			Port: 7001, ListenAddresses: []string{"/ip4/0.0.0.0/tcp/7001"}, PublicAddresses: []string{"/ip4/1.2.3.4/tcp/7001"},
+			Bootnodes: []string{"/ip4/127.0.0.1/tcp/7002/p2p/12D3KooW"}, ProtocolID: "/gossamer/test/0", NoBootstrap: true, NoMDNS: true,
+			MinPeers: 1, MaxPeers: 50, PersistentPeers: []string{"/ip4/127.0.0.1/tcp/7003/p2p/12D3KooX"}, BlockRequestSize: 64, CompressSync: true,
+			PingInterval: 10 * time.Second, PingFailureThreshold: 5,
+		},
+		RPC: dot.RPCConfig{
+			Enabled: true, External: true, Port: 8545, Host: "localhost", Modules: []string{"system", "chain"}, WSPort: 8546, WS: true, WSExternal: true,
+			MaxRequestSize: 1024, RateLimit: 2.5, RateBurst: 10, WSMessageRate: 1.5, WSMessageBurst: 5, TrustedProxies: []string{"127.0.0.1"},
+			Unsafe: true,
+		},
+	}
+
+	tomlCfg := dotConfigToToml(dcfg)
+
+	// every toml field must be set from the dot configuration
+	var checkNonZero func(prefix string, v reflect.Value)
+	checkNonZero = func(prefix string, v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			name := prefix + "." + v.Type().Field(i).Name
+			if v.Field(i).Kind() == reflect.Struct {
+				checkNonZero(name, v.Field(i))
+				continue
+			}
+			require.False(t, v.Field(i).IsZero(), "%s was not exported", name)
+		}
+	}
+	checkNonZero("Config", reflect.ValueOf(*tomlCfg))
+
+	fp := filepath.Join(testDir, "config.toml")
+	exportConfig(tomlCfg, fp)
+
+	fields, err := dot.TomlConfigDiff(tomlCfg, fp)
+	require.NoError(t, err)
+	require.Empty(t, fields)
+
+	cfg := new(ctoml.Config)
+	err = loadConfig(cfg, fp)
+	require.NoError(t, err)
+	require.Equal(t, tomlCfg, cfg)
+}
@@ -17,6 +17,8 @@
 package main
 
 import (
+	"github.com/ChainSafe/gossamer/lib/keystore"
+
 	log "github.com/ChainSafe/log15"
 	"github.com/urfave/cli"
 )
@@ -35,19 +37,72 @@ var (
 	}
 	// KeyFlag specifies a test keyring account to use
 	KeyFlag = cli.StringFlag{
-		Name:  "key",
-		Usage: "Specify a test keyring account to use: eg --key=alice",
+		Name:   "key",
+		Usage:  "Specify a test keyring account to use: eg --key=alice",
+		EnvVar: "GSSMR_KEY",
+	}
+	// KeystoreAutolockFlag locks the account keystore after it has been idle for the given duration
+	KeystoreAutolockFlag = cli.DurationFlag{
+		Name:  "keystore-autolock",
+		Usage: "Lock unlocked account keys after they have been unused for the given duration, eg. --keystore-autolock=30m. Consensus keys are not locked",
 	}
 	// RolesFlag role of the node (see Table D.2)
 	RolesFlag = cli.StringFlag{
 		Name:  "roles",
 		Usage: "Roles of the gossamer node",
 	}
+	// ValidatorFlag runs the node as an authority, requiring BABE and GRANDPA session keys to be available
+	ValidatorFlag = cli.BoolFlag{
+		Name: "validator",
+		Usage: "Run the node as a validator (the same as --roles=4), requires sr25519 (BABE) and ed25519 (GRANDPA) " +
+			"session keys provided with --key or present in the keystore",
+	}
 	// RewindFlag rewinds the head of the chain to the given block number. Useful for development
 	RewindFlag = cli.IntFlag{
 		Name:  "rewind",
 		Usage: "Rewind head of chain to the given block number",
 	}
+	// FutureTxMaxAgeFlag sets how long a transaction whose requirements are not met may wait in the transaction pool
+	// before it is dropped
+	FutureTxMaxAgeFlag = cli.DurationFlag{
+		Name: "future-tx-max-age",
+		Usage: "Maximum time a transaction whose requirements are not met may wait in the transaction pool before it is " +
+			"dropped (eg. 30m)",
+	}
+	// FinalizationGracePeriodFlag sets how many blocks are finalised before pruned fork blocks are deleted
+	FinalizationGracePeriodFlag = cli.Uint64Flag{
+		Name:  "finalization-grace-period",
+		Usage: "Number of blocks to finalise before blocks on pruned forks are deleted",
+	}
+	// VerifyJustificationsFlag enables verification of justifications before they're stored
+	VerifyJustificationsFlag = cli.BoolFlag{
+		Name:  "verify-justifications",
+		Usage: "Verify block justifications before storing them (ignored on GRANDPA authority nodes)",
+	}
+	// TrieCacheSizeFlag sets the number of decoded trie nodes cached in memory
+	TrieCacheSizeFlag = cli.IntFlag{
+		Name:  "trie-cache-size",
+		Usage: "Number of decoded trie nodes to cache in memory for storage reads",
+	}
+	// WasmInterpreterFlag selects the wasm interpreter used to execute the runtime
+	WasmInterpreterFlag = cli.StringFlag{
+		Name:  "wasm-interpreter",
+		Usage: "Name of the wasm interpreter used to execute the runtime (eg. wasmer, wasmtime, life)",
+	}
+)
+
+// BABE development flags
+var (
+	// BABESlotDurationFlag overrides the BABE slot duration. Only valid for the dev chain
+	BABESlotDurationFlag = cli.IntFlag{
+		Name:  "babe-slot-duration",
+		Usage: "Override the BABE slot duration in milliseconds (dev chain only)",
+	}
+	// BABEEpochLengthFlag overrides the BABE epoch length. Only valid for the dev chain
+	BABEEpochLengthFlag = cli.IntFlag{
+		Name:  "babe-epoch-length",
+		Usage: "Override the BABE epoch length in slots (dev chain only)",
+	}
 )
 
 // Global node configuration flags
@@ -58,6 +113,23 @@ var (
 		Usage: "Supports levels crit (silent) to trce (trace)",
 		Value: log.LvlInfo.String(),
 	}
+	// LogFileFlag cli service settings
+	LogFileFlag = cli.StringFlag{
+		Name:  "log-file",
+		Usage: "Write logs to the given file in addition to the console",
+	}
+	// LogFileMaxSizeFlag cli service settings
+	LogFileMaxSizeFlag = cli.Int64Flag{
+		Name:  "log-file-max-size",
+		Usage: "Maximum size of the log file in megabytes before it is rotated",
+		Value: 100,
+	}
+	// LogFileMaxFilesFlag cli service settings
+	LogFileMaxFilesFlag = cli.IntFlag{
+		Name:  "log-file-max-files",
+		Usage: "Maximum number of rotated log files to retain",
+		Value: 5,
+	}
 	// NameFlag node implementation name
 	NameFlag = cli.StringFlag{
 		Name:  "name",
@@ -65,8 +137,9 @@ var (
 	}
 	// ChainFlag is chain id used to load default configuration for specified chain
 	ChainFlag = cli.StringFlag{
-		Name:  "chain",
-		Usage: "Chain id used to load default configuration for specified chain",
+		Name:   "chain",
+		Usage:  "Built-in chain to use, selects its default configuration and genesis file (gssmr, dev, kusama, polkadot)",
+		EnvVar: "GSSMR_CHAIN",
 	}
 	// ConfigFlag TOML configuration file
 	ConfigFlag = cli.StringFlag{
@@ -75,16 +148,32 @@ var (
 	}
 	// BasePathFlag data directory for node
 	BasePathFlag = cli.StringFlag{
-		Name:  "basepath",
-		Usage: "Data directory for the node",
+		Name:   "basepath",
+		Usage:  "Data directory for the node",
+		EnvVar: "GSSMR_BASEPATH",
 	}
 	CPUProfFlag = cli.StringFlag{
 		Name:  "cpuprof",
 		Usage: "File to write CPU profile to",
 	}
+	// CPUProfDurationFlag stops the CPU profile after the given duration
+	CPUProfDurationFlag = cli.DurationFlag{
+		Name:  "cpuprofile-duration",
+		Usage: "Stop the CPU profile and write it to the --cpuprof file after the given duration, eg. --cpuprofile-duration=30s",
+	}
 	MemProfFlag = cli.StringFlag{
-		Name:  "memprof",
-		Usage: "File to write memory profile to",
+		Name:  "memprof, memprofile",
+		Usage: "File to write memory (heap) profile to at shutdown",
+	}
+	// ReadyFileFlag writes a file once the node has started
+	ReadyFileFlag = cli.StringFlag{
+		Name:  "ready-file",
+		Usage: "Write a file at the given path once all node services have started and the RPC server, if enabled, is accepting connections",
+	}
+	// PprofAddressFlag starts a net/http/pprof server on the given address
+	PprofAddressFlag = cli.StringFlag{
+		Name:  "pprof-address",
+		Usage: "Serve the pprof profiling endpoints on the given address, eg. --pprof-address=localhost:6060",
 	}
 
 	// PublishMetricsFlag publishes node metrics to prometheus.
@@ -104,6 +193,12 @@ var (
 		Name:  "no-telemetry",
 		Usage: "Disable connecting to the Substrate telemetry server",
 	}
+
+	// TelemetryURLFlag adds telemetry endpoints in addition to the ones defined in genesis.json
+	TelemetryURLFlag = cli.StringFlag{
+		Name:  "telemetry-url",
+		Usage: "Comma separated websocket URLs of telemetry servers to connect to",
+	}
 )
 
 // Initialization-only flags
@@ -131,6 +226,18 @@ var (
 	}
 )
 
+// ExportState-only flags
+var (
+	AtFlag = cli.StringFlag{
+		Name:  "at",
+		Usage: "Hash of the block whose state should be exported (default: best block)",
+	}
+	OutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Path to the JSON file the state key-value pairs are written to",
+	}
+)
+
 // BuildSpec-only flags
 var (
 	RawFlag = cli.BoolFlag{
@@ -145,14 +252,19 @@ var (
 		Name:  "output",
 		Usage: "Path to output the recently created genesis JSON file",
 	}
+	AuthoritiesFlag = cli.UintFlag{
+		Name:  "authorities",
+		Usage: "Number of well-known dev authorities to include in the genesis; uses the gssmr genesis if --genesis-spec isn't set",
+	}
 )
 
 // Network service configuration flags
 var (
 	// PortFlag Set network listening port
 	PortFlag = cli.UintFlag{
-		Name:  "port",
-		Usage: "Set network listening port",
+		Name:   "port",
+		Usage:  "Set network listening port",
+		EnvVar: "GSSMR_PORT",
 	}
 	// BootnodesFlag Network service settings
 	BootnodesFlag = cli.StringFlag{
@@ -174,6 +286,21 @@ var (
 		Name:  "nomdns",
 		Usage: "Disables network mDNS discovery",
 	}
+	// CompressSyncFlag Enables snappy compression of block requests and responses
+	CompressSyncFlag = cli.BoolFlag{
+		Name:  "compress-sync",
+		Usage: "Enables snappy compression of block requests and responses with peers that support it",
+	}
+	// PingIntervalFlag sets how often connected peers are pinged
+	PingIntervalFlag = cli.DurationFlag{
+		Name:  "ping-interval",
+		Usage: "How often connected peers are pinged (eg. 30s)",
+	}
+	// PingFailureThresholdFlag sets the number of consecutive failed pings after which a peer is disconnected
+	PingFailureThresholdFlag = cli.IntFlag{
+		Name:  "ping-failure-threshold",
+		Usage: "Number of consecutive failed pings after which a peer is disconnected",
+	}
 )
 
 // RPC service configuration flags
@@ -195,8 +322,9 @@ var (
 	}
 	// RPCPortFlag HTTP-RPC server listening port
 	RPCPortFlag = cli.IntFlag{
-		Name:  "rpcport",
-		Usage: "HTTP-RPC server listening port",
+		Name:   "rpcport",
+		Usage:  "HTTP-RPC server listening port",
+		EnvVar: "GSSMR_RPCPORT",
 	}
 	// RPCModulesFlag API modules to enable via HTTP-RPC
 	RPCModulesFlag = cli.StringFlag{
@@ -218,6 +346,41 @@ var (
 		Name:  "ws-external",
 		Usage: "Enable external websocket connections",
 	}
+	// RPCMaxRequestSizeFlag Maximum size of a RPC request
+	RPCMaxRequestSizeFlag = cli.Int64Flag{
+		Name:  "rpc-max-request-size",
+		Usage: "Maximum size in bytes of a HTTP-RPC request body or websocket message",
+	}
+	// RPCRateLimitFlag Requests per second allowed per IP
+	RPCRateLimitFlag = cli.Float64Flag{
+		Name:  "rpc-rate-limit",
+		Usage: "HTTP-RPC requests per second allowed per IP, 0 disables rate limiting",
+	}
+	// RPCRateBurstFlag Maximum burst of requests per IP
+	RPCRateBurstFlag = cli.IntFlag{
+		Name:  "rpc-rate-burst",
+		Usage: "Maximum burst of HTTP-RPC requests per IP, defaults to the rate limit",
+	}
+	// WSMessageRateFlag Messages per second allowed per websocket connection
+	WSMessageRateFlag = cli.Float64Flag{
+		Name:  "ws-message-rate",
+		Usage: "Websocket messages per second allowed per connection, 0 disables the limit",
+	}
+	// WSMessageBurstFlag Maximum burst of messages per websocket connection
+	WSMessageBurstFlag = cli.IntFlag{
+		Name:  "ws-message-burst",
+		Usage: "Maximum burst of websocket messages per connection, defaults to the message rate",
+	}
+	// RPCTrustedProxiesFlag IPs of proxies trusted to forward HTTP-RPC requests
+	RPCTrustedProxiesFlag = cli.StringFlag{
+		Name:  "rpc-trusted-proxies",
+		Usage: "IPs of proxies whose X-Forwarded-For header is used to rate limit HTTP-RPC clients, comma separated list",
+	}
+	// RPCUnsafeFlag Serve unsafe RPC methods to external requests
+	RPCUnsafeFlag = cli.BoolFlag{
+		Name:  "rpc-unsafe",
+		Usage: "Serve unsafe RPC methods, eg. state_call, to external HTTP-RPC requests and websocket connections",
+	}
 )
 
 // Account management flags
@@ -262,6 +425,34 @@ var (
 		Name:  "secp256k1",
 		Usage: "Specify account type as secp256k1",
 	}
+	// KeyTypeFlag filters listed keys by type
+	KeyTypeFlag = cli.StringFlag{
+		Name:  "type",
+		Usage: "Only list keys of the given type, one of sr25519, ed25519 or secp256k1. Used with --list",
+	}
+	// DerivationFlag derivation path used when generating a keypair
+	DerivationFlag = cli.StringFlag{
+		Name:  "derivation",
+		Usage: "Derive the generated keypair from the development phrase, or from a mnemonic given as prefix, along a path. eg. --derivation=//Alice",
+	}
+	// ScryptNFlag scrypt CPU/memory cost parameter used to encrypt the keystore
+	ScryptNFlag = cli.IntFlag{
+		Name:  "scrypt-n",
+		Usage: "Scrypt CPU/memory cost parameter used to encrypt the keystore; must be a power of two",
+		Value: keystore.DefaultScryptN,
+	}
+	// ScryptRFlag scrypt block size parameter used to encrypt the keystore
+	ScryptRFlag = cli.IntFlag{
+		Name:  "scrypt-r",
+		Usage: "Scrypt block size parameter used to encrypt the keystore",
+		Value: keystore.DefaultScryptR,
+	}
+	// ScryptPFlag scrypt parallelization parameter used to encrypt the keystore
+	ScryptPFlag = cli.IntFlag{
+		Name:  "scrypt-p",
+		Usage: "Scrypt parallelization parameter used to encrypt the keystore",
+		Value: keystore.DefaultScryptP,
+	}
 )
 
 // flag sets that are shared by multiple commands
@@ -269,13 +460,23 @@ var (
 	// GlobalFlags are flags that are valid for use with the root command and all subcommands
 	GlobalFlags = []cli.Flag{
 		LogFlag,
+		LogFileFlag,
+		LogFileMaxSizeFlag,
+		LogFileMaxFilesFlag,
 		NameFlag,
 		ChainFlag,
 		ConfigFlag,
 		BasePathFlag,
 		CPUProfFlag,
+		CPUProfDurationFlag,
 		MemProfFlag,
+		PprofAddressFlag,
 		RewindFlag,
+		FutureTxMaxAgeFlag,
+		FinalizationGracePeriodFlag,
+		VerifyJustificationsFlag,
+		TrieCacheSizeFlag,
+		WasmInterpreterFlag,
 	}
 
 	// StartupFlags are flags that are valid for use with the root command and the export subcommand
@@ -283,14 +484,19 @@ var (
 		// keystore flags
 		KeyFlag,
 		UnlockFlag,
+		KeystoreAutolockFlag,
 
 		// network flags
 		PortFlag,
 		BootnodesFlag,
 		ProtocolFlag,
 		RolesFlag,
+		ValidatorFlag,
 		NoBootstrapFlag,
 		NoMDNSFlag,
+		CompressSyncFlag,
+		PingIntervalFlag,
+		PingFailureThresholdFlag,
 
 		// rpc flags
 		RPCEnabledFlag,
@@ -301,6 +507,13 @@ var (
 		WSFlag,
 		WSExternalFlag,
 		WSPortFlag,
+		RPCMaxRequestSizeFlag,
+		RPCRateLimitFlag,
+		RPCRateBurstFlag,
+		WSMessageRateFlag,
+		WSMessageBurstFlag,
+		RPCTrustedProxiesFlag,
+		RPCUnsafeFlag,
 
 		// metrics flag
 		PublishMetricsFlag,
@@ -308,6 +521,14 @@ var (
 
 		// telemetry flags
 		NoTelemetryFlag,
+		TelemetryURLFlag,
+
+		// BABE dev flags
+		BABESlotDurationFlag,
+		BABEEpochLengthFlag,
+
+		// readiness flags
+		ReadyFileFlag,
 	}
 )
 
@@ -326,6 +547,7 @@ var (
 		RawFlag,
 		GenesisSpecFlag,
 		OutputSpecFlag,
+		AuthoritiesFlag,
 	}, GlobalFlags...)
 
 	// ExportFlags are the flags that are valid for use with the export subcommand
@@ -341,9 +563,14 @@ var (
 		ImportFlag,
 		ImportRawFlag,
 		ListFlag,
+		KeyTypeFlag,
 		Ed25519Flag,
 		Sr25519Flag,
 		Secp256k1Flag,
+		DerivationFlag,
+		ScryptNFlag,
+		ScryptRFlag,
+		ScryptPFlag,
 	}, GlobalFlags...)
 
 	ImportStateFlags = []cli.Flag{
@@ -354,6 +581,23 @@ var (
 		HeaderFlag,
 		FirstSlotFlag,
 	}
+
+	ExportStateFlags = []cli.Flag{
+		BasePathFlag,
+		ChainFlag,
+		ConfigFlag,
+		AtFlag,
+		OutFlag,
+	}
+
+	DBCheckFlags = []cli.Flag{
+		BasePathFlag,
+		ChainFlag,
+		ConfigFlag,
+	}
+
+	// ConfigShowFlags are flags that are valid for use with the config show subcommand
+	ConfigShowFlags = RootFlags
 )
 
 // FixFlagOrder allow us to use various flag order formats (ie, `gossamer init
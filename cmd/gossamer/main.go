@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ChainSafe/gossamer/chain/gssmr"
 	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
@@ -35,6 +37,11 @@ const (
 	buildSpecCommandName     = "build-spec"
 	importRuntimeCommandName = "import-runtime"
 	importStateCommandName   = "import-state"
+	exportStateCommandName   = "export-state"
+	dbCommandName            = "db"
+	dbCheckCommandName       = "check"
+	configCommandName        = "config"
+	configShowCommandName    = "show"
 )
 
 // app is the cli application
@@ -89,7 +96,8 @@ var (
 		Description: "The build-spec command outputs current genesis JSON data.\n" +
 			"\tUsage: gossamer build-spec\n" +
 			"\tTo generate raw genesis file from default: gossamer build-spec --raw > genesis.json" +
-			"\tTo generate raw genesis file from specific genesis file: gossamer build-spec --raw --genesis genesis-spec.json > genesis.json",
+			"\tTo generate raw genesis file from specific genesis file: gossamer build-spec --raw --genesis genesis-spec.json > genesis.json\n" +
+			"\tTo generate raw genesis file with N dev authorities: gossamer build-spec --raw --authorities N > genesis.json",
 	}
 
 	// importRuntime generates a genesis file given a .wasm runtime binary.
@@ -115,6 +123,61 @@ var (
 			"Input can be generated by using the RPC function state_getPairs.\n" +
 			"\tUsage: gossamer import-state --state state.json --header header.json --first-slot <first slot of network>\n",
 	}
+
+	exportStateCommand = cli.Command{
+		Action:    FixFlagOrder(exportStateAction),
+		Name:      exportStateCommandName,
+		Usage:     "Export the state at a given block to a JSON file",
+		ArgsUsage: "",
+		Flags:     ExportStateFlags,
+		Category:  "EXPORT-STATE",
+		Description: "The export-state command writes the state at a given block as a JSON file of key-value pairs.\n" +
+			"Output is in the same format as the RPC function state_getPairs and can be consumed by import-state.\n" +
+			"\tUsage: gossamer export-state --at <block hash> --out state.json\n",
+	}
+
+	// dbCommand groups the subcommands that operate on the node database
+	dbCommand = cli.Command{
+		Name:     dbCommandName,
+		Usage:    "Inspect the node database",
+		Category: "DB",
+		Description: "The db command groups the subcommands that operate on the node database.\n" +
+			"\tTo check the integrity of the database: gossamer db check --basepath <dir>\n",
+		Subcommands: []cli.Command{
+			{
+				Action:   FixFlagOrder(dbCheckAction),
+				Name:     dbCheckCommandName,
+				Usage:    "Check the integrity of the node database without starting the node",
+				Flags:    DBCheckFlags,
+				Category: "DB",
+				Description: "The db check command walks from the best block back to genesis, verifying the parent link\n" +
+					"of each header and that finalised blocks have a body, and verifies the state of the best block.\n" +
+					"The first inconsistency found is reported.\n" +
+					"\tUsage: gossamer db check --basepath <dir>\n",
+			},
+		},
+	}
+
+	// configCommand groups the subcommands that inspect the node configuration
+	configCommand = cli.Command{
+		Name:     configCommandName,
+		Usage:    "Inspect the node configuration",
+		Category: "CONFIG",
+		Description: "The config command groups the subcommands that inspect the node configuration.\n" +
+			"\tTo print the effective configuration: gossamer config show --config chain/gssmr/config.toml\n",
+		Subcommands: []cli.Command{
+			{
+				Action:   FixFlagOrder(configShowAction),
+				Name:     configShowCommandName,
+				Usage:    "Print the effective node configuration and the source of each value",
+				Flags:    ConfigShowFlags,
+				Category: "CONFIG",
+				Description: "The config show command prints the configuration the node would start with given the\n" +
+					"same flags, and which source set each value: default, toml, flag or genesis.\n" +
+					"\tUsage: gossamer config show --config chain/gssmr/config.toml --port 7002\n",
+			},
+		},
+	}
 )
 
 // init initialises the cli application
@@ -132,6 +195,9 @@ func init() {
 		buildSpecCommand,
 		importRuntimeCommand,
 		importStateCommand,
+		exportStateCommand,
+		dbCommand,
+		configCommand,
 	}
 	app.Flags = RootFlags
 }
@@ -172,6 +238,48 @@ func importStateAction(ctx *cli.Context) error {
 	return dot.ImportState(cfg.Global.BasePath, stateFP, headerFP, uint64(firstSlot))
 }
 
+// exportStateAction writes the state at the given block (or the best block) to a JSON file.
+func exportStateAction(ctx *cli.Context) error {
+	out := ctx.String(OutFlag.Name)
+	if out == "" {
+		return errors.New("must provide argument to --out")
+	}
+
+	var at *common.Hash
+	if atStr := ctx.String(AtFlag.Name); atStr != "" {
+		hash, err := common.HexToHash(atStr)
+		if err != nil {
+			return fmt.Errorf("invalid argument to --at: %w", err)
+		}
+		at = &hash
+	}
+
+	cfg, err := createImportStateConfig(ctx)
+	if err != nil {
+		logger.Error("failed to create node configuration", "error", err)
+		return err
+	}
+	cfg.Global.BasePath = utils.ExpandDir(cfg.Global.BasePath)
+
+	return dot.ExportState(cfg.Global.BasePath, at, out)
+}
+
+// dbCheckAction verifies the integrity of the node database
+func dbCheckAction(ctx *cli.Context) error {
+	cfg, err := createImportStateConfig(ctx)
+	if err != nil {
+		logger.Error("failed to create node configuration", "error", err)
+		return err
+	}
+	cfg.Global.BasePath = utils.ExpandDir(cfg.Global.BasePath)
+
+	if !dot.NodeInitialized(cfg.Global.BasePath, true) {
+		return fmt.Errorf("node database at %s has not been initialised", cfg.Global.BasePath)
+	}
+
+	return dot.CheckDatabase(cfg.Global.BasePath)
+}
+
 // importRuntimeAction generates a genesis file given a .wasm runtime binary.
 func importRuntimeAction(ctx *cli.Context) error {
 	arguments := ctx.Args()
@@ -236,6 +344,20 @@ func gossamerAction(ctx *cli.Context) error {
 		}
 	}
 
+	// upgrade the node database to the schema version used by this release
+	err = dot.MigrateDatabase(cfg.Global.BasePath)
+	if err != nil {
+		logger.Error("failed to migrate node database", "error", err)
+		return err
+	}
+
+	// ensure the node database was created with a schema version this release can read
+	err = dot.CheckDatabaseCompatible(cfg.Global.BasePath)
+	if err != nil {
+		logger.Error("incompatible node database, re-initialise the node with the init subcommand", "error", err)
+		return err
+	}
+
 	// ensure configuration matches genesis data stored during node initialization
 	// but do not overwrite configuration if the corresponding flag value is set
 	err = updateDotConfigFromGenesisData(ctx, cfg)
@@ -263,6 +385,18 @@ func gossamerAction(ctx *cli.Context) error {
 		return err
 	}
 
+	// consensus keys are needed for as long as the node runs, so only the account keys are locked.
+	// the account keystore is wrapped before it is unlocked, so the keys are decrypted by its Unlock
+	if timeout := ctx.Duration(KeystoreAutolockFlag.Name); timeout > 0 {
+		acco, ok := ks.Acco.(keystore.ClearableKeystore)
+		if !ok {
+			return errors.New("account keystore cannot be locked")
+		}
+
+		ks.Acco = keystore.NewLockingKeystore(acco, timeout)
+		logger.Info("account keystore will lock when idle", "timeout", timeout)
+	}
+
 	err = unlockKeystore(ks.Acco, cfg.Global.BasePath, cfg.Account.Unlock, ctx.String(PasswordFlag.Name))
 	if err != nil {
 		logger.Error("failed to unlock keystore", "error", err)
@@ -289,7 +423,21 @@ func gossamerAction(ctx *cli.Context) error {
 
 	logger.Info("starting node...", "name", node.Name)
 
-	// start node
+	// stop the node services in order when a shutdown signal is received
+	stopSignals := handleSignals(node, shutdownTimeout)
+	defer stopSignals()
+
+	// report when the node has started, eg. to test harnesses and process supervisors
+	if fp := ctx.String(ReadyFileFlag.Name); fp != "" {
+		removeReadyFile, err := writeReadyFile(node, utils.ExpandDir(fp))
+		if err != nil {
+			logger.Error("failed to remove previous ready file", "error", err)
+			return err
+		}
+		defer removeReadyFile()
+	}
+
+	// start node, which blocks until the node is stopped
 	err = node.Start()
 	if err != nil {
 		return err
@@ -363,8 +511,23 @@ func buildSpecAction(ctx *cli.Context) error {
 
 	var bs *dot.BuildSpec
 
-	if genesis := ctx.String(GenesisSpecFlag.Name); genesis != "" {
-		bspec, e := dot.BuildFromGenesis(genesis, 0)
+	authorities := ctx.Uint(AuthoritiesFlag.Name)
+	genesis := ctx.String(GenesisSpecFlag.Name)
+	if authorities != 0 && genesis == "" {
+		genesis = gssmr.DefaultGenesisSpec
+	}
+
+	if genesis != "" {
+		kr, e := keystore.NewSr25519Keyring()
+		if e != nil {
+			return e
+		}
+
+		if authorities > uint(len(kr.Keys)) {
+			return fmt.Errorf("number of authorities must be at most %d, the number of well-known keys", len(kr.Keys))
+		}
+
+		bspec, e := dot.BuildFromGenesis(genesis, int(authorities))
 		if e != nil {
 			return e
 		}
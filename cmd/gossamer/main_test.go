@@ -25,6 +25,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"syscall"
@@ -33,6 +34,8 @@ import (
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/docker/docker/pkg/reexec"
 	"github.com/stretchr/testify/require"
@@ -345,6 +348,48 @@ func TestBuildSpecCommandWithOutput(t *testing.T) {
 	require.Empty(t, errb)
 }
 
+func TestBuildSpecCommand_Authorities(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "genesis.json")
+	ctx, err := newTestContext(
+		"Test gossamer build-spec --authorities",
+		[]string{"log", "raw", "genesis-spec", "output", "authorities"},
+		[]interface{}{"info", true, "../../chain/gssmr/genesis-spec.json", output, uint(3)},
+	)
+	require.NoError(t, err)
+
+	err = buildSpecAction(ctx)
+	require.NoError(t, err)
+
+	gen, err := genesis.NewGenesisFromJSONRaw(output)
+	require.NoError(t, err)
+
+	// decode the authorities from the raw genesis storage
+	raw := make(map[string][]byte)
+	for k, v := range gen.Genesis.Raw["top"] {
+		raw[string(common.MustHexToBytes(k))] = common.MustHexToBytes(v)
+	}
+
+	res := &genesis.Genesis{}
+	res.Genesis.Raw = make(map[string]map[string]string)
+	res.Genesis.Runtime = make(map[string]map[string]interface{})
+	err = genesis.BuildFromMap(raw, res)
+	require.NoError(t, err)
+	require.Len(t, res.Genesis.Runtime["babe"]["authorities"], 3)
+	require.Len(t, res.Genesis.Runtime["grandpa"]["authorities"], 3)
+}
+
+func TestBuildSpecCommand_TooManyAuthorities(t *testing.T) {
+	ctx, err := newTestContext(
+		"Test gossamer build-spec --authorities with too many authorities",
+		[]string{"log", "authorities"},
+		[]interface{}{"info", uint(10)},
+	)
+	require.NoError(t, err)
+
+	err = buildSpecAction(ctx)
+	require.EqualError(t, err, "number of authorities must be at most 9, the number of well-known keys")
+}
+
 // TODO: TestExportCommand test "gossamer export" does not error
 
 // TODO: TestInitCommand test "gossamer init" does not error
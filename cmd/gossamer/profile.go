@@ -17,9 +17,15 @@
 package main
 
 import (
+	"context"
+	"net"
+	"net/http"
+	httppprof "net/http/pprof"
 	"os"
 	"runtime"
 	"runtime/pprof"
+	"sync"
+	"time"
 
 	"github.com/urfave/cli"
 )
@@ -35,6 +41,11 @@ func beginProfile(ctx *cli.Context) (func(), error) {
 		return nil, err
 	}
 
+	pprofStopFunc, err := pprofServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return func() {
 		if cpuStopFunc != nil {
 			cpuStopFunc()
@@ -43,6 +54,10 @@ func beginProfile(ctx *cli.Context) (func(), error) {
 		if memStopFunc != nil {
 			memStopFunc()
 		}
+
+		if pprofStopFunc != nil {
+			pprofStopFunc()
+		}
 	}, nil
 }
 
@@ -61,16 +76,34 @@ func cpuProfile(ctx *cli.Context) (func(), error) {
 		return nil, err
 	}
 
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				logger.Error("failed to close file", "file", cpuFile.Name())
+			}
+		})
+	}
+
+	// stop the CPU profile once the duration has elapsed, if set
+	duration := ctx.GlobalDuration(CPUProfDurationFlag.Name)
+	if duration <= 0 {
+		return stop, nil
+	}
+
+	logger.Info("CPU profile will stop after duration", "file", cpuFile.Name(), "duration", duration)
+	timer := time.AfterFunc(duration, stop)
+
 	return func() {
-		pprof.StopCPUProfile()
-		if err := cpuFile.Close(); err != nil {
-			logger.Error("failed to close file", "file", cpuFile.Name())
-		}
+		timer.Stop()
+		stop()
 	}, nil
 }
 
 func memProfile(ctx *cli.Context) (func(), error) {
-	memProfFile := ctx.GlobalString(MemProfFlag.Name)
+	// MemProfFlag.Name includes the memprofile alias, so look the flag up by its first name
+	memProfFile := ctx.GlobalString("memprof")
 	if memProfFile == "" {
 		return nil, nil
 	}
@@ -91,3 +124,48 @@ func memProfile(ctx *cli.Context) (func(), error) {
 		}
 	}, nil
 }
+
+func pprofServer(ctx *cli.Context) (func(), error) {
+	address := ctx.GlobalString(PprofAddressFlag.Name)
+	if address == "" {
+		return nil, nil
+	}
+
+	_, stop, err := startPprofServer(address)
+	return stop, err
+}
+
+// startPprofServer serves the net/http/pprof handlers on the given address and
+// returns the address the server is bound to and a function to stop the server
+func startPprofServer(address string) (net.Addr, func(), error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Error("pprof server failed", "error", err)
+		}
+	}()
+
+	logger.Info("pprof server started", "address", listener.Addr())
+
+	return listener.Addr(), func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("failed to stop pprof server", "error", err)
+		}
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPprofServer_Disabled(t *testing.T) {
+	ctx, err := newTestContext(t.Name(), []string{"pprof-address"}, []interface{}{""})
+	require.NoError(t, err)
+
+	stop, err := pprofServer(ctx)
+	require.NoError(t, err)
+	require.Nil(t, stop)
+}
+
+func TestStartPprofServer(t *testing.T) {
+	addr, stop, err := startPprofServer("127.0.0.1:0")
+	require.NoError(t, err)
+
+	for _, endpoint := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/symbol"} {
+		resp, err := http.Get("http://" + addr.String() + endpoint)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, endpoint)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	stop()
+
+	_, err = http.Get("http://" + addr.String() + "/debug/pprof/")
+	require.Error(t, err)
+}
+
+func TestCPUProfile_Duration(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	cpuProfFile := filepath.Join(testDir, "cpu.prof")
+
+	ctx, err := newTestContext(
+		t.Name(),
+		[]string{"cpuprof", "cpuprofile-duration"},
+		[]interface{}{cpuProfFile, 100 * time.Millisecond},
+	)
+	require.NoError(t, err)
+
+	stop, err := cpuProfile(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, stop)
+
+	// the profile is written once the duration has elapsed, without calling stop
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(cpuProfFile)
+		return err == nil && info.Size() > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// stopping the profile after it has already been written is a no-op
+	stop()
+
+	// a new profile can be started once the previous one has stopped
+	stop, err = cpuProfile(ctx)
+	require.NoError(t, err)
+	stop()
+}
+
+func TestMemProfile(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	memProfFile := filepath.Join(testDir, "mem.prof")
+
+	ctx, err := newTestContext(t.Name(), []string{"memprof"}, []interface{}{memProfFile})
+	require.NoError(t, err)
+
+	stop, err := memProfile(ctx)
+	require.NoError(t, err)
+	stop()
+
+	info, err := os.Stat(memProfFile)
+	require.NoError(t, err)
+	require.NotZero(t, info.Size())
+}
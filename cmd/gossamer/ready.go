@@ -0,0 +1,57 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/ChainSafe/gossamer/dot"
+)
+
+// writeReadyFile removes any ready file left at the given path by a previous run, then writes the
+// process id to it once the node is ready. The returned function removes the ready file.
+func writeReadyFile(node *dot.Node, fp string) (func(), error) {
+	if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	go func() {
+		<-node.Ready()
+
+		// write to a temporary file first, so the ready file never appears partially written
+		tmp := fp + ".tmp"
+		if err := ioutil.WriteFile(tmp, []byte(strconv.Itoa(os.Getpid())), 0600); err != nil {
+			logger.Error("failed to write ready file", "path", fp, "error", err)
+			return
+		}
+
+		if err := os.Rename(tmp, fp); err != nil {
+			logger.Error("failed to write ready file", "path", fp, "error", err)
+			return
+		}
+
+		logger.Info("wrote ready file", "path", fp)
+	}()
+
+	return func() {
+		if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+			logger.Error("failed to remove ready file", "path", fp, "error", err)
+		}
+	}, nil
+}
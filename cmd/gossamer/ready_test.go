@@ -0,0 +1,86 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/rpc"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadyFile(t *testing.T) {
+	// find a free port for the RPC server
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	node := &dot.Node{
+		Services: services.NewServiceRegistry(),
+	}
+	node.Services.RegisterService(rpc.NewHTTPServer(&rpc.HTTPServerConfig{
+		RPCPort: uint32(port),
+		RPCAPI:  rpc.NewService(),
+	}))
+
+	// a ready file left by a previous run is removed
+	fp := filepath.Join(t.TempDir(), "ready")
+	err = ioutil.WriteFile(fp, []byte("stale"), 0600)
+	require.NoError(t, err)
+
+	removeReadyFile, err := writeReadyFile(node, fp)
+	require.NoError(t, err)
+
+	_, err = os.Stat(fp)
+	require.True(t, os.IsNotExist(err))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- node.Start()
+	}()
+
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(fp)
+		return statErr == nil
+	}, time.Second*5, time.Millisecond*10)
+
+	// the RPC server accepts connections once the ready file is written
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	data, err := ioutil.ReadFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(os.Getpid()), string(data))
+
+	node.Stop()
+	require.NoError(t, <-errCh)
+
+	removeReadyFile()
+	_, err = os.Stat(fp)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot"
+)
+
+// shutdownTimeout is how long the node services are given to stop after a shutdown signal
+var shutdownTimeout = 30 * time.Second
+
+// exit is called to exit the process when the node cannot shut down cleanly
+var exit = os.Exit
+
+// handleSignals stops the node services when a SIGINT or SIGTERM is received, which returns the node
+// from Start. If the services have not stopped within the timeout, or a second signal is received,
+// the process exits immediately. The returned function stops handling signals.
+func handleSignals(node *dot.Node, timeout time.Duration) func() {
+	sigc := make(chan os.Signal, 2)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig, ok := <-sigc
+		if !ok {
+			return
+		}
+
+		logger.Info("signal received, shutting down...", "signal", sig, "timeout", timeout)
+
+		go func() {
+			if sig, ok := <-sigc; ok {
+				logger.Warn("second signal received, exiting immediately", "signal", sig)
+				exit(130)
+			}
+		}()
+
+		if err := node.StopWithTimeout(timeout); err != nil {
+			logger.Error("failed to shut down node", "error", err)
+			exit(1)
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigc)
+		close(sigc)
+	}
+}
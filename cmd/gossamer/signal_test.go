@@ -0,0 +1,158 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/stretchr/testify/require"
+)
+
+type mockService struct {
+	started chan struct{}
+	stop    chan struct{} // Stop blocks until stop is closed, if set
+	stopped bool
+}
+
+func newMockService() *mockService {
+	return &mockService{
+		started: make(chan struct{}),
+	}
+}
+
+func (s *mockService) Start() error {
+	close(s.started)
+	return nil
+}
+
+func (s *mockService) Stop() error {
+	if s.stop != nil {
+		<-s.stop
+	}
+	s.stopped = true
+	return nil
+}
+
+func startTestNode(t *testing.T, srvc *mockService) (*dot.Node, <-chan error) {
+	node := &dot.Node{
+		Services: services.NewServiceRegistry(),
+	}
+	node.Services.RegisterService(srvc)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- node.Start()
+	}()
+
+	select {
+	case <-srvc.started:
+	case <-time.After(time.Second):
+		t.Fatal("node did not start")
+	}
+
+	return node, errCh
+}
+
+func TestHandleSignals(t *testing.T) {
+	srvc := newMockService()
+	node, errCh := startTestNode(t, srvc)
+
+	timeout := time.Second * 5
+	stopSignals := handleSignals(node, timeout)
+	defer stopSignals()
+
+	err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	require.NoError(t, err)
+
+	select {
+	case err = <-errCh:
+		require.NoError(t, err)
+	case <-time.After(timeout):
+		t.Fatal("node did not shut down within the timeout")
+	}
+
+	require.True(t, srvc.stopped)
+}
+
+func TestHandleSignals_ForceExit(t *testing.T) {
+	exitCodes := make(chan int, 2)
+	exit = func(code int) {
+		exitCodes <- code
+	}
+	defer func() {
+		exit = os.Exit
+	}()
+
+	srvc := newMockService()
+	srvc.stop = make(chan struct{})
+	defer close(srvc.stop)
+
+	node, _ := startTestNode(t, srvc)
+
+	stopSignals := handleSignals(node, time.Minute)
+	defer stopSignals()
+
+	err := syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	require.NoError(t, err)
+
+	// wait for the first signal to be handled before sending the second
+	time.Sleep(time.Millisecond * 100)
+
+	err = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	require.NoError(t, err)
+
+	select {
+	case code := <-exitCodes:
+		require.Equal(t, 130, code)
+	case <-time.After(time.Second * 5):
+		t.Fatal("process did not exit after the second signal")
+	}
+}
+
+func TestHandleSignals_Timeout(t *testing.T) {
+	exitCodes := make(chan int, 1)
+	exit = func(code int) {
+		exitCodes <- code
+	}
+	defer func() {
+		exit = os.Exit
+	}()
+
+	srvc := newMockService()
+	srvc.stop = make(chan struct{})
+	defer close(srvc.stop)
+
+	node, _ := startTestNode(t, srvc)
+
+	stopSignals := handleSignals(node, time.Millisecond*100)
+	defer stopSignals()
+
+	err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	require.NoError(t, err)
+
+	select {
+	case code := <-exitCodes:
+		require.Equal(t, 1, code)
+	case <-time.After(time.Second * 5):
+		t.Fatal("process did not exit after the shutdown timeout")
+	}
+}
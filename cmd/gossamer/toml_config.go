@@ -17,26 +17,23 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
-	"path/filepath"
 	"reflect"
 	"unicode"
 
 	"github.com/naoina/toml"
 
+	"github.com/ChainSafe/gossamer/chain"
+	"github.com/ChainSafe/gossamer/dot"
 	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 )
 
 // loadConfig loads the values from the toml configuration file into the provided configuration
 func loadConfig(cfg *ctoml.Config, fp string) error {
-	fp, err := filepath.Abs(fp)
-	if err != nil {
-		logger.Error("failed to create absolute path for toml configuration file", "error", err)
-		return err
-	}
-
-	file, err := os.Open(filepath.Clean(fp))
+	// built-in chain configuration files are embedded, so they load from any working directory
+	data, err := chain.ReadFile(fp)
 	if err != nil {
 		logger.Error("failed to open toml configuration file", "error", err)
 		return err
@@ -58,7 +55,7 @@ func loadConfig(cfg *ctoml.Config, fp string) error {
 		},
 	}
 
-	if err = tomlSettings.NewDecoder(file).Decode(&cfg); err != nil {
+	if err = tomlSettings.NewDecoder(bytes.NewReader(data)).Decode(&cfg); err != nil {
 		logger.Error("failed to decode configuration", "error", err)
 		return err
 	}
@@ -68,33 +65,5 @@ func loadConfig(cfg *ctoml.Config, fp string) error {
 
 // exportConfig exports a dot configuration to a toml configuration file
 func exportConfig(cfg *ctoml.Config, fp string) *os.File {
-	var (
-		newFile *os.File
-		err     error
-		raw     []byte
-	)
-
-	if raw, err = toml.Marshal(*cfg); err != nil {
-		logger.Error("failed to marshal configuration", "error", err)
-		os.Exit(1)
-	}
-
-	newFile, err = os.Create(filepath.Clean(fp))
-	if err != nil {
-		logger.Error("failed to create configuration file", "error", err)
-		os.Exit(1)
-	}
-
-	_, err = newFile.Write(raw)
-	if err != nil {
-		logger.Error("failed to write to configuration file", "error", err)
-		os.Exit(1)
-	}
-
-	if err := newFile.Close(); err != nil {
-		logger.Error("failed to close configuration file", "error", err)
-		os.Exit(1)
-	}
-
-	return newFile
+	return dot.ExportTomlConfig(cfg, fp)
 }
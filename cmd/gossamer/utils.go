@@ -39,9 +39,6 @@ const confirmCharacter = "Y"
 
 // setupLogger sets up the gossamer logger
 func setupLogger(ctx *cli.Context) (log.Lvl, error) {
-	handler := log.StreamHandler(os.Stdout, log.TerminalFormat())
-	handler = log.CallerFileHandler(handler)
-
 	var lvl log.Lvl
 
 	if lvlToInt, err := strconv.Atoi(ctx.String(LogFlag.Name)); err == nil {
@@ -50,6 +47,19 @@ func setupLogger(ctx *cli.Context) (log.Lvl, error) {
 		return 0, err
 	}
 
+	if path := ctx.String(LogFileFlag.Name); path != "" {
+		maxSize := ctx.Int64(LogFileMaxSizeFlag.Name) * 1024 * 1024
+		f, err := utils.NewRotatingFile(path, maxSize, ctx.Int(LogFileMaxFilesFlag.Name))
+		if err != nil {
+			return 0, fmt.Errorf("failed to open log file: %w", err)
+		}
+
+		utils.SetLogFile(f)
+	}
+
+	handler := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
+	handler = log.CallerFileHandler(handler)
+
 	log.Root().SetHandler(log.LvlFilterHandler(lvl, handler))
 
 	return lvl, nil
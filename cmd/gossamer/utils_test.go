@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
@@ -38,6 +39,8 @@ func newTestContext(description string, flags []string, values []interface{}) (*
 			set.String(flags[i], v, "")
 		case uint:
 			set.Uint(flags[i], v, "")
+		case time.Duration:
+			set.Duration(flags[i], v, "")
 		default:
 			return nil, fmt.Errorf("unexpected cli value type: %T", values[i])
 		}
@@ -69,6 +72,11 @@ func newTestContext(description string, flags []string, values []interface{}) (*
 			if err != nil {
 				return nil, fmt.Errorf("failed to set cli flag: %T", flags[i])
 			}
+		case time.Duration:
+			err := ctx.Set(flags[i], v.String())
+			if err != nil {
+				return nil, fmt.Errorf("failed to set cli flag: %T", flags[i])
+			}
 		default:
 			return nil, fmt.Errorf("unexpected cli value type: %T", values[i])
 		}
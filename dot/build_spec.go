@@ -60,7 +60,8 @@ func (b *BuildSpec) ToJSONRaw() ([]byte, error) {
 		ProtocolID: b.genesis.ProtocolID,
 		Properties: b.genesis.Properties,
 		Genesis: genesis.Fields{
-			Raw: b.genesis.GenesisFields().Raw,
+			Raw:             b.genesis.GenesisFields().Raw,
+			ChildrenDefault: b.genesis.GenesisFields().ChildrenDefault,
 		},
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
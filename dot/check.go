@@ -0,0 +1,48 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package dot
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+
+	log "github.com/ChainSafe/log15"
+)
+
+// CheckDatabase verifies the integrity of the node database with the given path without starting the node. It
+// returns the first inconsistency found.
+func CheckDatabase(basepath string) (err error) {
+	srv := state.NewService(basepath, log.LvlInfo)
+	if err = srv.Start(); err != nil {
+		return fmt.Errorf("failed to start state service: %w", err)
+	}
+	defer func() {
+		if serr := srv.Stop(); serr != nil && err == nil {
+			err = serr
+		}
+	}()
+
+	log.Info("checking database...", "basepath", basepath)
+
+	if err = srv.CheckIntegrity(); err != nil {
+		return fmt.Errorf("database check failed: %w", err)
+	}
+
+	log.Info("finished database check, no inconsistencies found")
+	return nil
+}
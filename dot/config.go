@@ -18,6 +18,7 @@ package dot
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ChainSafe/gossamer/chain/dev"
 	"github.com/ChainSafe/gossamer/chain/gssmr"
@@ -52,6 +53,7 @@ type GlobalConfig struct {
 	PublishMetrics bool
 	MetricsPort    uint32
 	NoTelemetry    bool
+	TelemetryURLs  []string
 }
 
 // LogConfig represents the log levels for individual packages
@@ -79,24 +81,32 @@ type AccountConfig struct {
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
 type NetworkConfig struct {
-	Port            uint32
-	Bootnodes       []string
-	ProtocolID      string
-	NoBootstrap     bool
-	NoMDNS          bool
-	MinPeers        int
-	MaxPeers        int
-	PersistentPeers []string
+	Port                 uint32
+	ListenAddresses      []string
+	PublicAddresses      []string
+	Bootnodes            []string
+	ProtocolID           string
+	NoBootstrap          bool
+	NoMDNS               bool
+	MinPeers             int
+	MaxPeers             int
+	PersistentPeers      []string
+	BlockRequestSize     uint32
+	CompressSync         bool
+	PingInterval         time.Duration
+	PingFailureThreshold int
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
-	Roles            byte
-	BabeAuthority    bool
-	GrandpaAuthority bool
-	SlotDuration     uint64
-	EpochLength      uint64
-	WasmInterpreter  string
+	Roles              byte
+	BabeAuthority      bool
+	GrandpaAuthority   bool
+	SlotDuration       uint64
+	EpochLength        uint64
+	WasmInterpreter    string
+	WasmFuelLimit      uint64
+	WasmMaxMemoryPages uint32
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -109,11 +119,23 @@ type RPCConfig struct {
 	WSPort     uint32
 	WS         bool
 	WSExternal bool
+
+	MaxRequestSize int64
+	RateLimit      float64
+	RateBurst      int
+	WSMessageRate  float64
+	WSMessageBurst int
+	TrustedProxies []string
+	Unsafe         bool
 }
 
 // StateConfig is the config for the State service
 type StateConfig struct {
-	Rewind int
+	Rewind                  int
+	FutureTxMaxAge          time.Duration
+	FinalizationGracePeriod uint64
+	VerifyJustifications    bool
+	TrieCacheSize           int
 }
 
 // String will return the json representation for a Config
@@ -61,24 +61,32 @@ type AccountConfig struct {
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
 type NetworkConfig struct {
-	Port            uint32   `toml:"port,omitempty"`
-	Bootnodes       []string `toml:"bootnodes,omitempty"`
-	ProtocolID      string   `toml:"protocol,omitempty"`
-	NoBootstrap     bool     `toml:"nobootstrap,omitempty"`
-	NoMDNS          bool     `toml:"nomdns,omitempty"`
-	MinPeers        int      `toml:"min-peers,omitempty"`
-	MaxPeers        int      `toml:"max-peers,omitempty"`
-	PersistentPeers []string `toml:"persistent-peers,omitempty"`
+	Port                 uint32   `toml:"port,omitempty"`
+	ListenAddresses      []string `toml:"listen-addresses,omitempty"`
+	PublicAddresses      []string `toml:"public-addresses,omitempty"`
+	Bootnodes            []string `toml:"bootnodes,omitempty"`
+	ProtocolID           string   `toml:"protocol,omitempty"`
+	NoBootstrap          bool     `toml:"nobootstrap,omitempty"`
+	NoMDNS               bool     `toml:"nomdns,omitempty"`
+	MinPeers             int      `toml:"min-peers,omitempty"`
+	MaxPeers             int      `toml:"max-peers,omitempty"`
+	PersistentPeers      []string `toml:"persistent-peers,omitempty"`
+	BlockRequestSize     uint32   `toml:"block-request-size,omitempty"`
+	CompressSync         bool     `toml:"compress-sync,omitempty"`
+	PingInterval         uint32   `toml:"ping-interval,omitempty"` // in seconds
+	PingFailureThreshold int      `toml:"ping-failure-threshold,omitempty"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
-	Roles            byte   `toml:"roles,omitempty"`
-	BabeAuthority    bool   `toml:"babe-authority"`
-	GrandpaAuthority bool   `toml:"grandpa-authority"`
-	SlotDuration     uint64 `toml:"slot-duration,omitempty"`
-	EpochLength      uint64 `toml:"epoch-length,omitempty"`
-	WasmInterpreter  string `toml:"wasm-interpreter,omitempty"`
+	Roles              byte   `toml:"roles,omitempty"`
+	BabeAuthority      bool   `toml:"babe-authority"`
+	GrandpaAuthority   bool   `toml:"grandpa-authority"`
+	SlotDuration       uint64 `toml:"slot-duration,omitempty"`
+	EpochLength        uint64 `toml:"epoch-length,omitempty"`
+	WasmInterpreter    string `toml:"wasm-interpreter,omitempty"`
+	WasmFuelLimit      uint64 `toml:"wasm-fuel-limit,omitempty"`
+	WasmMaxMemoryPages uint32 `toml:"wasm-max-memory-pages,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -91,4 +99,12 @@ type RPCConfig struct {
 	WSPort     uint32   `toml:"ws-port,omitempty"`
 	WS         bool     `toml:"ws,omitempty"`
 	WSExternal bool     `toml:"ws-external,omitempty"`
+
+	MaxRequestSize int64    `toml:"max-request-size,omitempty"`
+	RateLimit      float64  `toml:"rate-limit,omitempty"`
+	RateBurst      int      `toml:"rate-burst,omitempty"`
+	WSMessageRate  float64  `toml:"ws-message-rate,omitempty"`
+	WSMessageBurst int      `toml:"ws-message-burst,omitempty"`
+	TrustedProxies []string `toml:"trusted-proxies,omitempty"`
+	Unsafe         bool     `toml:"unsafe,omitempty"`
 }
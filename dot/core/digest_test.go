@@ -418,3 +418,72 @@ func TestDigestHandler_HandleNextConfigData(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, digest.ToConfigData(), stored)
 }
+
+func TestDigestHandler_HandleConsensusDigest_NextEpochAndConfigData(t *testing.T) {
+	handler := newTestDigestHandler(t, true, false)
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	epochDigest := &types.NextEpochData{
+		Authorities: []*types.AuthorityRaw{
+			{
+				Key:    keyring.Alice().Public().(*sr25519.PublicKey).AsBytes(),
+				Weight: 1,
+			},
+			{
+				Key:    keyring.Charlie().Public().(*sr25519.PublicKey).AsBytes(),
+				Weight: 1,
+			},
+		},
+		Randomness: [32]byte{1, 2, 3},
+	}
+
+	epochData, err := epochDigest.Encode()
+	require.NoError(t, err)
+
+	configDigest := &types.NextConfigData{
+		C1:             1,
+		C2:             4,
+		SecondarySlots: 1,
+	}
+
+	configData, err := configDigest.Encode()
+	require.NoError(t, err)
+
+	header := createHeaderWithPreDigest(10)
+	header.ParentHash = handler.blockState.BestBlockHash()
+	header.Number = big.NewInt(1)
+	header.Digest = append(header.Digest,
+		&types.ConsensusDigest{
+			ConsensusEngineID: types.BabeEngineID,
+			Data:              epochData,
+		},
+		&types.ConsensusDigest{
+			ConsensusEngineID: types.BabeEngineID,
+			Data:              configData,
+		},
+	)
+
+	// a header may carry both digests, which the syncer passes on one by one
+	for _, d := range header.Digest {
+		cd, ok := d.(*types.ConsensusDigest)
+		if !ok {
+			continue
+		}
+
+		err = handler.HandleConsensusDigest(cd, header)
+		require.NoError(t, err)
+	}
+
+	stored, err := handler.epochState.(*state.EpochState).GetEpochData(1)
+	require.NoError(t, err)
+	expected, err := epochDigest.ToEpochData()
+	require.NoError(t, err)
+	require.Equal(t, expected, stored)
+	require.Equal(t, 2, len(stored.Authorities))
+
+	storedCfg, err := handler.epochState.(*state.EpochState).GetConfigData(1)
+	require.NoError(t, err)
+	require.Equal(t, configDigest.ToConfigData(), storedCfg)
+}
@@ -18,6 +18,7 @@ package core
 
 import (
 	"math/big"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -66,12 +67,17 @@ type TransactionState interface {
 	RemoveExtrinsic(ext types.Extrinsic)
 	RemoveExtrinsicFromPool(ext types.Extrinsic)
 	PendingInPool() []*transaction.ValidTransaction
+	Ban(hash common.Hash)
+	IsBanned(hash common.Hash) bool
 }
 
 // BlockProducer is the interface that a block production service must implement
 type BlockProducer interface {
 	GetBlockChannel() <-chan types.Block
 	SetOnDisabled(authorityIndex uint32)
+	Pause() error
+	Resume() error
+	RuntimeLock() sync.Locker
 }
 
 // Verifier is the interface for the block verifier
@@ -82,6 +88,8 @@ type Verifier interface {
 // Network is the interface for the network service
 type Network interface {
 	SendMessage(network.NotificationsMessage)
+	Health() common.Health
+	SyncTarget() (*big.Int, common.Hash)
 }
 
 // EpochState is the interface for state.EpochState
@@ -17,7 +17,10 @@
 package core
 
 import (
+	"errors"
+
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
@@ -35,10 +38,22 @@ func (s *Service) HandleTransactionMessage(msg *network.TransactionMessage) erro
 	for _, tx := range txs {
 		tx := tx // pin
 
+		// skip transactions that recently failed validation
+		if s.transactionState.IsBanned(tx.Hash()) {
+			logger.Trace("ignoring banned transaction", "hash", tx.Hash())
+			continue
+		}
+
 		// validate each transaction
 		val, err := s.rt.ValidateTransaction(tx)
 		if err != nil {
 			logger.Error("failed to validate transaction", "err", err)
+
+			// only ban transactions the runtime found to be invalid. future transactions may become valid, and other
+			// errors don't say anything about the transaction
+			if errors.Is(err, runtime.ErrInvalidTransaction) {
+				s.transactionState.Ban(tx.Hash())
+			}
 			return err
 		}
 
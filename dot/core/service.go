@@ -17,7 +17,6 @@ package core
 
 import (
 	"context"
-	"os"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/network"
@@ -28,6 +27,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/lib/utils"
 
 	log "github.com/ChainSafe/log15"
 )
@@ -73,6 +73,13 @@ type Service struct {
 
 	// State variables
 	lock *sync.Mutex // channel lock
+
+	// held while calling the runtime with storage set by this service, shared with the block producer
+	rtLock sync.Locker
+
+	// Sync state variables, see handleSyncState
+	producerPaused bool // true if block production has been paused because the node is syncing
+	syncChecks     int  // number of consecutive sync state checks that disagreed with producerPaused
 }
 
 // Config holds the configuration for the core Service.
@@ -115,7 +122,7 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, ErrNilBlockProducer
 	}
 
-	h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	h = log.CallerFileHandler(h)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 
@@ -153,10 +160,15 @@ func NewService(cfg *Config) (*Service, error) {
 		blockProducer:    cfg.BlockProducer,
 		verifier:         cfg.Verifier,
 		lock:             &sync.Mutex{},
+		rtLock:           &sync.Mutex{},
 		blockAddCh:       blockAddCh,
 		blockAddChID:     id,
 	}
 
+	if cfg.BlockProducer != nil {
+		srv.rtLock = cfg.BlockProducer.RuntimeLock()
+	}
+
 	if cfg.NewBlocks != nil {
 		srv.blkRec = cfg.NewBlocks
 	} else if cfg.IsBlockProducer {
@@ -179,6 +191,11 @@ func (s *Service) Start() error {
 	// start handling imported blocks
 	go s.handleBlocks(s.ctx)
 
+	// pause block production while the node is syncing
+	if s.isBlockProducer && s.net != nil {
+		go s.handleSyncState(s.ctx)
+	}
+
 	return nil
 }
 
@@ -403,8 +420,8 @@ func (s *Service) maintainTransactionPool(block *types.Block) error {
 
 // InsertKey inserts keypair into the account keystore
 // TODO: define which keystores need to be updated and create separate insert funcs for each
-func (s *Service) InsertKey(kp crypto.Keypair) {
-	s.keys.Acco.Insert(kp)
+func (s *Service) InsertKey(kp crypto.Keypair) error {
+	return s.keys.Acco.Insert(kp)
 }
 
 // HasKey returns true if given hex encoded public key string is found in keystore, false otherwise, error if there
@@ -413,6 +430,11 @@ func (s *Service) HasKey(pubKeyStr, keyType string) (bool, error) {
 	return keystore.HasKey(pubKeyStr, keyType, s.keys.Acco)
 }
 
+// KeystoreSummary returns the name, key type and number of keys of each of the node's keystores
+func (s *Service) KeystoreSummary() []keystore.Summary {
+	return s.keys.Summary()
+}
+
 // GetRuntimeVersion gets the current RuntimeVersion
 func (s *Service) GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error) {
 	var stateRootHash *common.Hash
@@ -486,3 +508,57 @@ func (s *Service) GetMetadata(bhash *common.Hash) ([]byte, error) {
 	s.rt.SetContextStorage(ts)
 	return s.rt.Metadata()
 }
+
+// CallRuntime runs the given runtime method with the given SCALE encoded arguments using the state at the given block,
+// and returns the SCALE encoded result. If the block hash is nil, the latest state is used.
+func (s *Service) CallRuntime(method string, data []byte, bhash *common.Hash) ([]byte, error) {
+	var (
+		stateRootHash *common.Hash
+		err           error
+	)
+
+	if bhash != nil {
+		stateRootHash, err = s.storageState.GetStateRootFromBlock(bhash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ts, err := s.storageState.TrieState(stateRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rtLock.Lock()
+	defer s.rtLock.Unlock()
+
+	s.rt.SetContextStorage(ts)
+	return s.rt.Exec(method, data)
+}
+
+// QueryInfo returns the fee information for the given extrinsic using the runtime at the given block.
+// If the block hash is nil, the latest state is used.
+func (s *Service) QueryInfo(ext types.Extrinsic, bhash *common.Hash) (*types.RuntimeDispatchInfo, error) {
+	var (
+		stateRootHash *common.Hash
+		err           error
+	)
+
+	if bhash != nil {
+		stateRootHash, err = s.storageState.GetStateRootFromBlock(bhash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ts, err := s.storageState.TrieState(stateRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rtLock.Lock()
+	defer s.rtLock.Unlock()
+
+	s.rt.SetContextStorage(ts)
+	return s.rt.PaymentQueryInfo(ext)
+}
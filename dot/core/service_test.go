@@ -362,6 +362,81 @@ func TestService_GetRuntimeVersion(t *testing.T) {
 	require.Equal(t, rtExpected, rtv)
 }
 
+func TestService_CallRuntime(t *testing.T) {
+	s := NewTestService(t, nil)
+	rtExpected, err := s.rt.Version()
+	require.NoError(t, err)
+
+	res, err := s.CallRuntime(runtime.CoreVersion, []byte{}, nil)
+	require.NoError(t, err)
+
+	version := new(runtime.VersionData)
+	err = version.Decode(res)
+	require.NoError(t, err)
+	require.Equal(t, rtExpected.SpecName(), version.SpecName())
+	require.Equal(t, rtExpected.SpecVersion(), version.SpecVersion())
+}
+
+func TestService_QueryInfo_WaitsForBlockProduction(t *testing.T) {
+	bp := &mockBlockProducer{}
+	s := NewTestService(t, &Config{
+		BlockProducer: bp,
+	})
+
+	// the block producer is building a block
+	bp.rtLock.Lock()
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = s.QueryInfo(types.Extrinsic{}, nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("runtime was called while a block was being built")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	bp.rtLock.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("runtime was not called once the block was built")
+	}
+}
+
+func TestService_CallRuntime_WaitsForBlockProduction(t *testing.T) {
+	bp := &mockBlockProducer{}
+	s := NewTestService(t, &Config{
+		BlockProducer: bp,
+	})
+
+	// the block producer is building a block
+	bp.rtLock.Lock()
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = s.CallRuntime(runtime.CoreVersion, []byte{}, nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("runtime was called while a block was being built")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	bp.rtLock.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("runtime was not called once the block was built")
+	}
+}
+
 func TestService_IsBlockProducer(t *testing.T) {
 	cfg := &Config{
 		IsBlockProducer: false,
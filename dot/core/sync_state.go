@@ -0,0 +1,101 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+package core
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+var (
+	// syncStateInterval is how often the network sync state is checked
+	syncStateInterval = time.Second * 6
+	// syncStateChecks is the number of consecutive checks that must agree before block production
+	// is paused or resumed, so that it doesn't flap when the node is near the head of the chain
+	syncStateChecks = 3
+	// syncTargetTolerance is how many blocks behind the sync target the node can be and still be
+	// considered caught up
+	syncTargetTolerance = big.NewInt(2)
+)
+
+func (s *Service) handleSyncState(ctx context.Context) {
+	ticker := time.NewTicker(syncStateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.checkSyncState()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkSyncState pauses the block producer if the node is syncing and resumes it once the node has
+// caught up. the node is syncing if the network reports that it's syncing and the best block is behind the
+// sync target.
+func (s *Service) checkSyncState() {
+	syncing := s.isSyncing()
+	if syncing == s.producerPaused {
+		s.syncChecks = 0
+		return
+	}
+
+	s.syncChecks++
+	if s.syncChecks < syncStateChecks {
+		return
+	}
+
+	s.syncChecks = 0
+
+	// only update producerPaused if the block producer was paused or resumed, so that it's retried otherwise
+	if syncing {
+		if err := s.blockProducer.Pause(); err != nil {
+			logger.Warn("failed to pause block production while syncing", "error", err)
+			return
+		}
+		logger.Info("paused block production while syncing")
+	} else {
+		if err := s.blockProducer.Resume(); err != nil {
+			logger.Warn("failed to resume block production after syncing", "error", err)
+			return
+		}
+		logger.Info("resumed block production after syncing")
+	}
+
+	s.producerPaused = syncing
+}
+
+func (s *Service) isSyncing() bool {
+	if !s.net.Health().IsSyncing {
+		return false
+	}
+
+	target, _ := s.net.SyncTarget()
+	if target == nil {
+		return false
+	}
+
+	best, err := s.blockState.BestBlockNumber()
+	if err != nil {
+		logger.Debug("failed to get best block number", "error", err)
+		return s.producerPaused
+	}
+
+	return new(big.Int).Sub(target, best).Cmp(syncTargetTolerance) > 0
+}
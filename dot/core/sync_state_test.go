@@ -0,0 +1,106 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CheckSyncState(t *testing.T) {
+	syncer := newMockSyncer()
+	net := &mockNetwork{
+		syncer:     syncer,
+		syncTarget: big.NewInt(100),
+	}
+	bp := new(mockBlockProducer)
+
+	cfg := &Config{
+		Network:         net,
+		BlockProducer:   bp,
+		IsBlockProducer: true,
+	}
+
+	s := NewTestService(t, cfg)
+
+	// the block producer is only paused after syncStateChecks consecutive checks
+	syncer.SetSyncing(true)
+	for i := 0; i < syncStateChecks-1; i++ {
+		s.checkSyncState()
+	}
+	require.Equal(t, 0, bp.paused)
+
+	s.checkSyncState()
+	require.Equal(t, 1, bp.paused)
+	require.True(t, s.producerPaused)
+
+	// flapping near the head doesn't resume the block producer
+	for i := 0; i < syncStateChecks*2; i++ {
+		syncer.SetSyncing(i%2 == 0)
+		s.checkSyncState()
+	}
+	require.Equal(t, 0, bp.resumed)
+
+	syncer.SetSyncing(false)
+	for i := 0; i < syncStateChecks; i++ {
+		s.checkSyncState()
+	}
+	require.Equal(t, 1, bp.resumed)
+	require.False(t, s.producerPaused)
+
+	// the node isn't considered syncing if it's within the tolerance of the sync target
+	net.syncTarget = big.NewInt(1)
+	syncer.SetSyncing(true)
+	for i := 0; i < syncStateChecks; i++ {
+		s.checkSyncState()
+	}
+	require.Equal(t, 1, bp.paused)
+	require.False(t, s.producerPaused)
+}
+
+func TestService_CheckSyncState_PauseFails(t *testing.T) {
+	syncer := newMockSyncer()
+	net := &mockNetwork{
+		syncer:     syncer,
+		syncTarget: big.NewInt(100),
+	}
+	bp := &mockBlockProducer{err: errors.New("cannot pause")}
+
+	cfg := &Config{
+		Network:         net,
+		BlockProducer:   bp,
+		IsBlockProducer: true,
+	}
+
+	s := NewTestService(t, cfg)
+
+	syncer.SetSyncing(true)
+	for i := 0; i < syncStateChecks; i++ {
+		s.checkSyncState()
+	}
+	require.False(t, s.producerPaused)
+
+	// pausing is retried after the next syncStateChecks checks
+	bp.err = nil
+	for i := 0; i < syncStateChecks; i++ {
+		s.checkSyncState()
+	}
+	require.Equal(t, 1, bp.paused)
+	require.True(t, s.producerPaused)
+}
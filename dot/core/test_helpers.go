@@ -19,6 +19,7 @@ package core
 import (
 	"io/ioutil"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -64,6 +65,10 @@ func (v *mockVerifier) SetOnDisabled(_ uint32, _ *types.Header) error {
 // mockBlockProducer implements the BlockProducer interface
 type mockBlockProducer struct {
 	disabled uint32
+	paused   int
+	resumed  int
+	err      error // returned by Pause and Resume
+	rtLock   sync.Mutex
 }
 
 // Start mocks starting
@@ -80,22 +85,61 @@ func (bp *mockBlockProducer) SetOnDisabled(idx uint32) {
 	bp.disabled = idx
 }
 
+// Pause mocks pausing
+func (bp *mockBlockProducer) Pause() error {
+	if bp.err != nil {
+		return bp.err
+	}
+	bp.paused++
+	return nil
+}
+
+// Resume mocks resuming
+func (bp *mockBlockProducer) Resume() error {
+	if bp.err != nil {
+		return bp.err
+	}
+	bp.resumed++
+	return nil
+}
+
 // GetBlockChannel returns a new channel
 func (bp *mockBlockProducer) GetBlockChannel() <-chan types.Block {
 	return make(chan types.Block)
 }
 
+// RuntimeLock mocks getting the runtime lock
+func (bp *mockBlockProducer) RuntimeLock() sync.Locker {
+	return &bp.rtLock
+}
+
 // SetRuntime mocks setting runtime
 func (bp *mockBlockProducer) SetRuntime(rt runtime.Instance) {}
 
 type mockNetwork struct {
-	Message network.Message
+	Message    network.Message
+	syncer     *mockSyncer
+	syncTarget *big.Int
 }
 
 func (n *mockNetwork) SendMessage(m network.NotificationsMessage) {
 	n.Message = m
 }
 
+func (n *mockNetwork) Health() common.Health {
+	if n.syncer == nil {
+		return common.Health{}
+	}
+
+	return common.Health{
+		IsSyncing: !n.syncer.IsSynced(),
+	}
+}
+
+func (n *mockNetwork) SyncTarget() (*big.Int, common.Hash) {
+	return n.syncTarget, common.Hash{}
+}
+
 // NewTestService creates a new test core service
 func NewTestService(t *testing.T, cfg *Config) *Service {
 	if cfg == nil {
@@ -213,6 +257,7 @@ func createTestNetworkService(t *testing.T, cfg *network.Config) (srvc *network.
 
 type mockSyncer struct {
 	highestSeen *big.Int
+	synced      bool
 }
 
 func newMockSyncer() *mockSyncer {
@@ -238,10 +283,12 @@ func (s *mockSyncer) ProcessJustification(data []*types.BlockData) (int, error)
 }
 
 func (s *mockSyncer) IsSynced() bool {
-	return false
+	return s.synced
 }
 
-func (s *mockSyncer) SetSyncing(bool) {}
+func (s *mockSyncer) SetSyncing(syncing bool) {
+	s.synced = !syncing
+}
 
 type mockTransactionHandler struct{}
 
@@ -25,3 +25,6 @@ var ErrNoKeysProvided = errors.New("no keys provided for authority node")
 
 // ErrInvalidKeystoreType when trying to create a service with the wrong keystore type
 var ErrInvalidKeystoreType = errors.New("invalid keystore type")
+
+// ErrDatabaseNeedsMigration is returned when the node database was created with an incompatible schema version
+var ErrDatabaseNeedsMigration = errors.New("database needs migration")
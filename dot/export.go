@@ -0,0 +1,113 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package dot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	log "github.com/ChainSafe/log15"
+)
+
+// ExportState writes the state at the given block, or at the best block if at is nil, of the database with the
+// given path to the file out. The state is written as a JSON array of [key, value] pairs, in the same format as
+// state_getPairs, so that it can be imported with ImportState.
+func ExportState(basepath string, at *common.Hash, out string) (err error) {
+	srv := state.NewService(basepath, log.LvlInfo)
+	if err = srv.Start(); err != nil {
+		return fmt.Errorf("failed to start state service: %w", err)
+	}
+	defer func() {
+		if serr := srv.Stop(); serr != nil && err == nil {
+			err = serr
+		}
+	}()
+
+	var header *types.Header
+	if at == nil {
+		header, err = srv.Block.BestBlockHeader()
+	} else {
+		header, err = srv.Block.GetHeader(*at)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	tr, err := srv.Storage.LoadFromDB(header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("state of block %s is not available, it may have been pruned: %w", header.Hash(), err)
+	}
+
+	file, err := os.OpenFile(filepath.Clean(out), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	log.Info("exporting state...", "block", header.Hash(), "number", header.Number, "root", header.StateRoot)
+
+	w := bufio.NewWriter(file)
+	if _, err = w.WriteString("["); err != nil {
+		return err
+	}
+
+	count := 0
+	err = tr.Iterate(func(key, value []byte) error {
+		if count > 0 {
+			if _, err := w.WriteString(","); err != nil {
+				return err
+			}
+		}
+
+		pair, err := json.Marshal([]string{common.BytesToHex(key), common.BytesToHex(value)})
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.Write(pair); err != nil {
+			return err
+		}
+
+		count++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.WriteString("]\n"); err != nil {
+		return err
+	}
+
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	log.Info("finished state export", "keys", count, "file", out)
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package dot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	log "github.com/ChainSafe/log15"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportedTestNode(t *testing.T, stateFP, headerFP string) string {
+	basepath, err := ioutil.TempDir("", "gossamer-test-*")
+	require.NoError(t, err)
+
+	cfg := NewTestConfig(t)
+	genFile := NewTestGenesisRawFile(t, cfg)
+	cfg.Init.Genesis = genFile.Name()
+	cfg.Global.BasePath = basepath
+	err = InitNode(cfg)
+	require.NoError(t, err)
+
+	err = ImportState(basepath, stateFP, headerFP, 262493679)
+	require.NoError(t, err)
+	return basepath
+}
+
+func TestExportState_RoundTrip(t *testing.T) {
+	stateFP := setupStateFile(t)
+	headerFP := setupHeaderFile(t)
+
+	basepath := newImportedTestNode(t, stateFP, headerFP)
+	defer os.RemoveAll(basepath)
+
+	out := filepath.Join(basepath, "exported.json")
+	err := ExportState(basepath, nil, out)
+	require.NoError(t, err)
+
+	expectedRoot := common.MustHexToHash("0x09f9ca28df0560c2291aa16b56e15e07d1e1927088f51356d522722aa90ca7cb")
+	tr, err := newTrieFromPairs(out)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, tr.MustHash())
+
+	// import the exported state into a fresh database
+	imported := newImportedTestNode(t, out, headerFP)
+	defer os.RemoveAll(imported)
+
+	srv := state.NewService(imported, log.LvlInfo)
+	err = srv.Start()
+	require.NoError(t, err)
+
+	root, err := srv.Storage.StorageRoot()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+
+	entries, err := srv.Storage.Entries(&root)
+	require.NoError(t, err)
+	require.Equal(t, tr.Entries(), entries)
+
+	err = srv.Stop()
+	require.NoError(t, err)
+}
+
+func TestExportState_UnknownBlock(t *testing.T) {
+	basepath, err := ioutil.TempDir("", "gossamer-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(basepath)
+
+	cfg := NewTestConfig(t)
+	genFile := NewTestGenesisRawFile(t, cfg)
+	cfg.Init.Genesis = genFile.Name()
+	cfg.Global.BasePath = basepath
+	err = InitNode(cfg)
+	require.NoError(t, err)
+
+	err = ExportState(basepath, &common.Hash{0x1}, filepath.Join(basepath, "exported.json"))
+	require.Error(t, err)
+}
@@ -17,10 +17,13 @@
 package dot
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/ChainSafe/gossamer/dot/state"
@@ -28,55 +31,175 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/trie"
 
+	"github.com/ChainSafe/chaindb"
 	log "github.com/ChainSafe/log15"
 )
 
+// importStagingDir is the directory within the basepath used to record the pairs of an in-progress state import
+const importStagingDir = "import"
+
+// importProgressInterval is the number of pairs processed between import progress log messages
+var importProgressInterval = 10000
+
+// importProgress holds the number of pairs processed during a state import
+type importProgress struct {
+	total    int
+	imported int
+	skipped  int
+}
+
 // ImportState imports the state in the given files to the database with the given path.
+// The pairs are recorded in a staging database as they are read, so that an interrupted import can be resumed by
+// running it again, in which case pairs that were already recorded with matching values are skipped.
 func ImportState(basepath, stateFP, headerFP string, firstSlot uint64) error {
-	tr, err := newTrieFromPairs(stateFP)
+	header, err := newHeaderFromFile(headerFP)
 	if err != nil {
 		return err
 	}
 
-	header, err := newHeaderFromFile(headerFP)
+	stagingPath := filepath.Join(basepath, importStagingDir)
+	staging, err := chaindb.NewBadgerDB(&chaindb.Config{
+		DataDir: stagingPath,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open import staging database: %w", err)
+	}
+
+	tr, progress, err := importPairs(stateFP, staging)
+	if cerr := staging.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("read state pairs", "total", progress.total, "imported", progress.imported, "skipped", progress.skipped)
+
+	root, err := tr.Hash()
 	if err != nil {
 		return err
 	}
 
+	if root != header.StateRoot {
+		return fmt.Errorf("state root of imported state %s does not match header state root %s", root, header.StateRoot)
+	}
+
 	log.Info("ImportState", "header", header)
 
 	srv := state.NewService(basepath, log.LvlInfo)
-	return srv.Import(header, tr, firstSlot)
+	if err = srv.Import(header, tr, firstSlot); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(stagingPath)
 }
 
 func newTrieFromPairs(filename string) (*trie.Trie, error) {
-	data, err := ioutil.ReadFile(filepath.Clean(filename))
+	tr, _, err := importPairs(filename, nil)
+	return tr, err
+}
+
+// importPairs streams the key-value pairs in the given state file into a new trie. If staging is set, each pair
+// is also recorded in it, unless it was already recorded with the same value.
+func importPairs(filename string, staging chaindb.Database) (*trie.Trie, *importProgress, error) {
+	total, err := countPairs(filename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	pairs := make([]interface{}, 0)
-	err = json.Unmarshal(data, &pairs)
-	if err != nil {
-		return nil, err
+	progress := &importProgress{
+		total: total,
 	}
 
-	entries := make(map[string]string)
-	for _, pair := range pairs {
-		pairArr := pair.([]interface{})
-		if len(pairArr) != 2 {
-			return nil, errors.New("state file contains invalid pair")
+	tr := trie.NewEmptyTrie()
+	err = readPairs(filename, func(key, value []byte) error {
+		tr.Put(key, value)
+
+		if staging != nil {
+			prev, err := staging.Get(key)
+			if err == nil && bytes.Equal(prev, value) {
+				progress.skipped++
+			} else {
+				if err = staging.Put(key, value); err != nil {
+					return err
+				}
+				progress.imported++
+			}
+		} else {
+			progress.imported++
+		}
+
+		if done := progress.imported + progress.skipped; done%importProgressInterval == 0 {
+			log.Info("importing state...", "keys", done, "total", progress.total, "skipped", progress.skipped)
 		}
-		entries[pairArr[0].(string)] = pairArr[1].(string)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	tr := trie.NewEmptyTrie()
-	err = tr.LoadFromMap(entries)
+	return tr, progress, nil
+}
+
+// countPairs returns the number of key-value pairs in the given state file
+func countPairs(filename string) (int, error) {
+	count := 0
+	err := readPairs(filename, func(_, _ []byte) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// readPairs streams the given state file, which contains a JSON array of [key, value] hex string pairs, calling fn
+// for each pair without buffering the whole file
+func readPairs(filename string, fn func(key, value []byte) error) error {
+	file, err := os.Open(filepath.Clean(filename))
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	dec := json.NewDecoder(bufio.NewReader(file))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return errors.New("state file must contain an array of pairs")
+	}
+
+	for dec.More() {
+		var pair []string
+		if err = dec.Decode(&pair); err != nil {
+			return fmt.Errorf("state file contains invalid pair: %w", err)
+		}
+
+		if len(pair) != 2 {
+			return errors.New("state file contains invalid pair")
+		}
+
+		key, err := common.HexToBytes(pair[0])
+		if err != nil {
+			return err
+		}
+
+		value, err := common.HexToBytes(pair[1])
+		if err != nil {
+			return err
+		}
+
+		if err = fn(key, value); err != nil {
+			return err
+		}
 	}
 
-	return tr, nil
+	_, err = dec.Token()
+	return err
 }
 
 func newHeaderFromFile(filename string) (*types.Header, error) {
@@ -18,14 +18,18 @@ package dot
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 
+	"github.com/ChainSafe/chaindb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,6 +47,9 @@ func setupStateFile(t *testing.T) string {
 	bz, err := json.Marshal(pairs)
 	require.NoError(t, err)
 
+	err = os.MkdirAll("./test_data", os.ModePerm)
+	require.NoError(t, err)
+
 	fp := "./test_data/state.json"
 	err = ioutil.WriteFile(fp, bz, 0777)
 	require.NoError(t, err)
@@ -51,9 +58,16 @@ func setupStateFile(t *testing.T) string {
 }
 
 func setupHeaderFile(t *testing.T) string {
-	headerStr := "{\"digest\":{\"logs\":[\"0x0642414245b501013c0000009659bd0f0000000070edad1c9064fff78cb18435223d8adaf5ea04c24b1a8766e3dc01eb03cc6a0c11b79793d4e31cc0990838229c44fed1669a7c7c79e1e6d0a96374d6496728069d1ef739e290497a0e3b728fa88fcbdd3a5504e0efde0242e7a806dd4fa9260c\",\"0x054241424501019e7f28dddcf27c1e6b328d5694c368d5b2ec5dbe0e412ae1c98f88d53be4d8502fac571f3f19c9caaf281a673319241e0c5095a683ad34316204088a36a4bd86\"]},\"extrinsicsRoot\":\"0xda26dc8c1455f8f81cae12e4fc59e23ce961b2c837f6d3f664283af906d344e0\",\"number\":\"0x169d12\",\"parentHash\":\"0x3b45c9c22dcece75a30acc9c2968cb311e6b0557350f83b430f47559db786975\",\"stateRoot\":\"0x09f9ca28df0560c2291aa16b56e15e07d1e1927088f51356d522722aa90ca7cb\"}"
+	return setupHeaderFileWithStateRoot(t, "0x09f9ca28df0560c2291aa16b56e15e07d1e1927088f51356d522722aa90ca7cb")
+}
+
+func setupHeaderFileWithStateRoot(t *testing.T, stateRoot string) string {
+	headerStr := "{\"digest\":{\"logs\":[\"0x0642414245b501013c0000009659bd0f0000000070edad1c9064fff78cb18435223d8adaf5ea04c24b1a8766e3dc01eb03cc6a0c11b79793d4e31cc0990838229c44fed1669a7c7c79e1e6d0a96374d6496728069d1ef739e290497a0e3b728fa88fcbdd3a5504e0efde0242e7a806dd4fa9260c\",\"0x054241424501019e7f28dddcf27c1e6b328d5694c368d5b2ec5dbe0e412ae1c98f88d53be4d8502fac571f3f19c9caaf281a673319241e0c5095a683ad34316204088a36a4bd86\"]},\"extrinsicsRoot\":\"0xda26dc8c1455f8f81cae12e4fc59e23ce961b2c837f6d3f664283af906d344e0\",\"number\":\"0x169d12\",\"parentHash\":\"0x3b45c9c22dcece75a30acc9c2968cb311e6b0557350f83b430f47559db786975\",\"stateRoot\":\"" + stateRoot + "\"}"
+	err := os.MkdirAll("./test_data", os.ModePerm)
+	require.NoError(t, err)
+
 	fp := "./test_data/header.json"
-	err := ioutil.WriteFile(fp, []byte(headerStr), 0777)
+	err = ioutil.WriteFile(fp, []byte(headerStr), 0777)
 	require.NoError(t, err)
 	return fp
 }
@@ -112,3 +126,95 @@ func TestImportState(t *testing.T) {
 	err = ImportState(basepath, stateFP, headerFP, firstSlot)
 	require.NoError(t, err)
 }
+
+func TestImportState_StateRootMismatch(t *testing.T) {
+	basepath, err := ioutil.TempDir("", "gossamer-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(basepath)
+
+	stateFP := setupStateFile(t)
+	headerFP := setupHeaderFileWithStateRoot(t, common.Hash{0x1}.String())
+
+	err = ImportState(basepath, stateFP, headerFP, 262493679)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match header state root")
+}
+
+func writeStateFile(t *testing.T, dir, name string, pairs [][]string) string {
+	bz, err := json.Marshal(pairs)
+	require.NoError(t, err)
+
+	fp := filepath.Join(dir, name)
+	err = ioutil.WriteFile(fp, bz, 0600)
+	require.NoError(t, err)
+	return fp
+}
+
+func TestImportPairs_Resume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gossamer-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	numPairs := 5000
+	pairs := make([][]string, numPairs)
+	for i := range pairs {
+		key := make([]byte, 32)
+		_, err = rand.Read(key)
+		require.NoError(t, err)
+		value := make([]byte, 64)
+		_, err = rand.Read(value)
+		require.NoError(t, err)
+		pairs[i] = []string{common.BytesToHex(key), common.BytesToHex(value)}
+	}
+
+	fullFP := writeStateFile(t, dir, "full.json", pairs)
+	partialFP := writeStateFile(t, dir, "partial.json", pairs[:numPairs/2])
+
+	staging, err := chaindb.NewBadgerDB(&chaindb.Config{
+		DataDir: filepath.Join(dir, importStagingDir),
+	})
+	require.NoError(t, err)
+	defer staging.Close()
+
+	defer func(interval int) {
+		importProgressInterval = interval
+	}(importProgressInterval)
+	importProgressInterval = 1000
+
+	// simulate an import that was interrupted half way through
+	_, progress, err := importPairs(partialFP, staging)
+	require.NoError(t, err)
+	require.Equal(t, &importProgress{total: numPairs / 2, imported: numPairs / 2}, progress)
+
+	// a pair recorded with a different value must be imported again
+	err = staging.Put(common.MustHexToBytes(pairs[0][0]), []byte{1})
+	require.NoError(t, err)
+
+	tr, progress, err := importPairs(fullFP, staging)
+	require.NoError(t, err)
+	require.Equal(t, &importProgress{
+		total:    numPairs,
+		imported: numPairs/2 + 1,
+		skipped:  numPairs/2 - 1,
+	}, progress)
+
+	expected, err := newTrieFromPairs(fullFP)
+	require.NoError(t, err)
+	require.Equal(t, expected.MustHash(), tr.MustHash())
+}
+
+func TestReadPairs_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gossamer-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fp := writeStateFile(t, dir, "invalid.json", [][]string{{"0x01", "0x02", "0x03"}})
+	_, err = newTrieFromPairs(fp)
+	require.Error(t, err)
+
+	fp = filepath.Join(dir, "object.json")
+	err = ioutil.WriteFile(fp, []byte(`{"result": []}`), 0600)
+	require.NoError(t, err)
+	_, err = newTrieFromPairs(fp)
+	require.Error(t, err)
+}
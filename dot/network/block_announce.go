@@ -34,6 +34,9 @@ var (
 	_ NotificationsMessage = &BlockAnnounceHandshake{}
 )
 
+// errGenesisHashMismatch is returned when a peer's BlockAnnounceHandshake has a different genesis hash to ours
+var errGenesisHashMismatch = errors.New("genesis hash mismatch")
+
 // BlockAnnounceMessage is a state block header
 type BlockAnnounceMessage struct {
 	ParentHash     common.Hash
@@ -208,8 +211,13 @@ func (s *Service) validateBlockAnnounceHandshake(peer peer.ID, hs Handshake) err
 		return errors.New("invalid handshake type")
 	}
 
+	// the peer is on a different chain, disconnect from it
 	if bhs.GenesisHash != s.blockState.GenesisHash() {
-		return errors.New("genesis hash mismatch")
+		err := s.host.closePeer(peer)
+		if err != nil {
+			logger.Debug("failed to disconnect from peer with different genesis hash", "peer", peer, "error", err)
+		}
+		return errGenesisHashMismatch
 	}
 
 	np, ok := s.notificationsProtocols[BlockAnnounceMsgType]
@@ -235,6 +243,7 @@ func (s *Service) validateBlockAnnounceHandshake(peer peer.ID, hs Handshake) err
 	}
 
 	bestBlockNum := big.NewInt(int64(bhs.BestBlockNumber))
+	s.syncQueue.updatePeerHead(peer, bestBlockNum, bhs.BestBlockHash)
 
 	// check if peer block number is greater than host block number
 	if latestHeader.Number.Cmp(bestBlockNum) >= 0 {
@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -129,3 +130,58 @@ func TestValidateBlockAnnounceHandshake(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestBlockAnnounceHandshake_DifferentGenesisHash(t *testing.T) {
+	configA := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		RandSeed:    1,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		RandSeed:    2,
+		NoBootstrap: true,
+		NoMDNS:      true,
+		BlockState:  &MockBlockState{genesisHash: common.Hash{0x99}},
+	}
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	require.NotEqual(t, nodeA.blockState.GenesisHash(), nodeB.blockState.GenesisHash())
+
+	// each node refuses the other's handshake
+	hsA, err := nodeA.getBlockAnnounceHandshake()
+	require.NoError(t, err)
+	hsB, err := nodeB.getBlockAnnounceHandshake()
+	require.NoError(t, err)
+	require.Equal(t, errGenesisHashMismatch, nodeA.validateBlockAnnounceHandshake(nodeB.host.id(), hsB))
+	require.Equal(t, errGenesisHashMismatch, nodeB.validateBlockAnnounceHandshake(nodeA.host.id(), hsA))
+
+	addrInfosB, err := nodeB.host.addrInfos()
+	require.NoError(t, err)
+
+	err = nodeA.host.connect(*addrInfosB[0])
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(*addrInfosB[0])
+	}
+	require.NoError(t, err)
+	require.Equal(t, 1, nodeA.host.peerCount())
+
+	// sending a block announce begins the handshake, which node B rejects by disconnecting
+	nodeA.SendMessage(&BlockAnnounceMessage{
+		ParentHash: common.Hash{1},
+		Number:     big.NewInt(2),
+		Digest:     types.Digest{},
+	})
+
+	require.Eventually(t, func() bool {
+		return nodeA.host.peerCount() == 0 && nodeB.host.peerCount() == 0
+	}, time.Second*5, time.Millisecond*100)
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/snappy"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+)
+
+// snappySuffix is appended to the sync protocol ID to negotiate snappy compressed block requests and responses.
+// peers that don't support it negotiate the uncompressed protocol instead.
+const snappySuffix = "/snappy"
+
+// snappyMessage wraps a Message so that it's snappy compressed when encoded
+type snappyMessage struct {
+	Message
+}
+
+// Encode encodes the wrapped message and compresses it
+func (m *snappyMessage) Encode() ([]byte, error) {
+	enc, err := m.Message.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return snappy.Encode(nil, enc), nil
+}
+
+// snappyDecode decompresses the input, it returns an error without decompressing if the decompressed
+// size would be greater than max
+func snappyDecode(in []byte, max uint64) ([]byte, error) {
+	n, err := snappy.DecodedLen(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(n) > max {
+		return nil, fmt.Errorf("decompressed message size greater than maximum: got %d", n)
+	}
+
+	return snappy.Decode(nil, in)
+}
+
+// isSnappyStream returns true if the stream's protocol uses snappy compression
+func isSnappyStream(stream libp2pnetwork.Stream) bool {
+	return strings.HasSuffix(string(stream.Protocol()), snappySuffix)
+}
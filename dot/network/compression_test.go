@@ -0,0 +1,153 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common/optional"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/lib/utils"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnappyMessage_BlockResponseRoundTrip(t *testing.T) {
+	msg := &BlockResponseMessage{}
+	for i := 0; i < int(MaxBlockRequestSize); i++ {
+		header := types.Header{
+			Number: big.NewInt(int64(i)),
+			Digest: types.Digest{},
+		}
+
+		// repetitive extrinsics, as in real blocks, compress well
+		body := make([]byte, 8192)
+		for j := range body {
+			body[j] = byte(j % 16)
+		}
+
+		msg.BlockData = append(msg.BlockData, &types.BlockData{
+			Hash:          header.Hash(),
+			Header:        header.AsOptional(),
+			Body:          optional.NewBody(true, body),
+			Receipt:       optional.NewBytes(false, nil),
+			MessageQueue:  optional.NewBytes(false, nil),
+			Justification: optional.NewBytes(false, nil),
+		})
+	}
+
+	enc, err := msg.Encode()
+	require.NoError(t, err)
+
+	compressed, err := (&snappyMessage{msg}).Encode()
+	require.NoError(t, err)
+	require.Less(t, len(compressed), len(enc))
+
+	dec, err := snappyDecode(compressed, maxBlockResponseSize)
+	require.NoError(t, err)
+	require.Equal(t, enc, dec)
+
+	expected := new(BlockResponseMessage)
+	err = expected.Decode(enc)
+	require.NoError(t, err)
+
+	res := new(BlockResponseMessage)
+	err = res.Decode(dec)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
+func TestSnappyDecode_MaxSize(t *testing.T) {
+	// a small payload that decompresses to more than the maximum message size
+	bomb := snappy.Encode(nil, make([]byte, maxBlockResponseSize+1))
+	require.Less(t, uint64(len(bomb)), maxBlockResponseSize/10)
+
+	_, err := snappyDecode(bomb, maxBlockResponseSize)
+	require.Error(t, err)
+
+	_, err = snappyDecode([]byte{0xff, 0xff, 0xff}, maxBlockResponseSize)
+	require.Error(t, err)
+}
+
+func TestSyncWithPeer_Compression(t *testing.T) {
+	configA := &Config{
+		BasePath:     utils.NewTestBasePath(t, "nodeA"),
+		Port:         7001,
+		RandSeed:     1,
+		NoBootstrap:  true,
+		NoMDNS:       true,
+		CompressSync: true,
+	}
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	// the sync queue is stopped by createTestService, restart its context so that requests can be sent
+	nodeA.syncQueue.ctx, nodeA.syncQueue.cancel = context.WithCancel(nodeA.ctx)
+
+	testCases := []struct {
+		compress bool
+		port     uint32
+	}{
+		{compress: true, port: 7002},
+		{compress: false, port: 7003},
+	}
+
+	for _, tc := range testCases {
+		configB := &Config{
+			BasePath:     utils.NewTestBasePath(t, fmt.Sprintf("node%d", tc.port)),
+			Port:         tc.port,
+			RandSeed:     int64(tc.port),
+			NoBootstrap:  true,
+			NoMDNS:       true,
+			CompressSync: tc.compress,
+		}
+		nodeB := createTestService(t, configB)
+		nodeB.noGossip = true
+
+		addrInfosB, err := nodeB.host.addrInfos()
+		require.NoError(t, err)
+
+		err = nodeA.host.connect(*addrInfosB[0])
+		if failedToDial(err) {
+			time.Sleep(TestBackoffTimeout)
+			err = nodeA.host.connect(*addrInfosB[0])
+		}
+		require.NoError(t, err)
+
+		start, err := variadic.NewUint64OrHash(uint64(1))
+		require.NoError(t, err)
+
+		req := &BlockRequestMessage{
+			RequestedData: 3,
+			StartingBlock: start,
+			EndBlockHash:  optional.NewHash(false, [32]byte{}),
+			Direction:     0,
+			Max:           optional.NewUint32(false, 0),
+		}
+
+		// the response is the same whether or not the peer supports compression
+		resp, err := nodeA.syncQueue.syncWithPeer(nodeB.host.id(), req)
+		require.NoError(t, err)
+		require.Equal(t, testBlockResponseMessage(), resp)
+	}
+}
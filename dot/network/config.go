@@ -18,11 +18,13 @@ package network
 
 import (
 	"errors"
+	"fmt"
 	"path"
 	"time"
 
 	log "github.com/ChainSafe/log15"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 const (
@@ -49,6 +51,27 @@ const (
 
 	// DefaultMaxPeerCount is the default maximum peer count
 	DefaultMaxPeerCount = 50
+
+	// DefaultBlockRequestSize the default value for Config.BlockRequestSize
+	DefaultBlockRequestSize = uint32(128)
+
+	// MaxBlockRequestSize is the maximum number of blocks that can be requested in a single BlockRequestMessage
+	MaxBlockRequestSize = uint32(128)
+
+	// DefaultDHTRefreshInterval the default value for Config.DHTRefreshInterval
+	DefaultDHTRefreshInterval = 10 * time.Minute
+
+	// DefaultDHTBootstrapTimeout the default value for Config.DHTBootstrapTimeout
+	DefaultDHTBootstrapTimeout = 10 * time.Second
+
+	// DefaultPingInterval the default value for Config.PingInterval
+	DefaultPingInterval = 30 * time.Second
+
+	// DefaultPingFailureThreshold the default value for Config.PingFailureThreshold
+	DefaultPingFailureThreshold = 3
+
+	// DefaultMaxMessageSize the default value for Config.MaxMessageSize (4mb)
+	DefaultMaxMessageSize = uint64(1024 * 1024 * 4)
 )
 
 // DefaultBootnodes the default value for Config.Bootnodes
@@ -72,6 +95,12 @@ type Config struct {
 
 	// Port the network port used for listening
 	Port uint32
+	// ListenAddresses the multiaddrs used for listening, eg. /ip6/::/tcp/7001 or /dns4/example.com/tcp/7001
+	// (defaults to /ip4/0.0.0.0/tcp/<Port>)
+	ListenAddresses []string
+	// PublicAddresses the multiaddrs advertised to peers instead of the detected local addresses, eg. the
+	// port-forwarded address of a node behind NAT
+	PublicAddresses []string
 	// RandSeed the seed used to generate the network p2p identity (0 = non-deterministic random seed)
 	RandSeed int64
 	// Bootnodes the peer addresses used for bootstrapping
@@ -89,9 +118,33 @@ type Config struct {
 	// PersistentPeers is a list of multiaddrs which the node should remain connected to
 	PersistentPeers []string
 
+	// BlockRequestSize the number of blocks requested in each BlockRequestMessage sent while syncing
+	BlockRequestSize uint32
+	// CompressSync enables snappy compression of block requests and responses with peers that support it
+	CompressSync bool
+
+	// DHTRefreshInterval how often the Kademlia DHT routing table is refreshed
+	DHTRefreshInterval time.Duration
+	// DHTBootstrapTimeout how long to wait for the initial DHT routing table refresh before searching for peers
+	DHTBootstrapTimeout time.Duration
+
+	// PingInterval how often connected peers are pinged
+	PingInterval time.Duration
+	// PingFailureThreshold the number of consecutive failed pings after which a peer is evicted
+	PingFailureThreshold int
+
+	// MaxMessageSize the maximum size in bytes of a message read from a stream; peers that declare a larger
+	// message length are disconnected
+	MaxMessageSize uint64
+
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
 
+	// listenAddrs the parsed and validated ListenAddresses
+	listenAddrs []ma.Multiaddr
+	// publicAddrs the parsed PublicAddresses
+	publicAddrs []ma.Multiaddr
+
 	// PublishMetrics enables collection of network metrics
 	PublishMetrics bool
 
@@ -121,6 +174,39 @@ func (c *Config) build() error {
 		c.Port = DefaultPort
 	}
 
+	if c.BlockRequestSize == 0 {
+		c.BlockRequestSize = DefaultBlockRequestSize
+	}
+
+	if c.DHTRefreshInterval == 0 {
+		c.DHTRefreshInterval = DefaultDHTRefreshInterval
+	}
+
+	if c.DHTBootstrapTimeout == 0 {
+		c.DHTBootstrapTimeout = DefaultDHTBootstrapTimeout
+	}
+
+	if c.PingInterval < 0 {
+		return fmt.Errorf("invalid ping interval %s: must not be negative", c.PingInterval)
+	}
+
+	if c.PingInterval == 0 {
+		c.PingInterval = DefaultPingInterval
+	}
+
+	if c.PingFailureThreshold == 0 {
+		c.PingFailureThreshold = DefaultPingFailureThreshold
+	}
+
+	if c.MaxMessageSize == 0 {
+		c.MaxMessageSize = DefaultMaxMessageSize
+	}
+
+	if c.BlockRequestSize > MaxBlockRequestSize {
+		c.logger.Warn("block request size higher than protocol maximum; setting to maximum", "size", c.BlockRequestSize)
+		c.BlockRequestSize = MaxBlockRequestSize
+	}
+
 	// build identity configuration
 	err = c.buildIdentity()
 	if err != nil {
@@ -133,6 +219,18 @@ func (c *Config) build() error {
 		return err
 	}
 
+	// build listen address configuration
+	err = c.buildListenAddresses()
+	if err != nil {
+		return err
+	}
+
+	// build public address configuration
+	err = c.buildPublicAddresses()
+	if err != nil {
+		return err
+	}
+
 	// check bootnoode configuration
 	if !c.NoBootstrap && len(c.Bootnodes) == 0 {
 		c.logger.Warn("Bootstrap is enabled but no bootstrap nodes are defined")
@@ -221,3 +319,56 @@ func (c *Config) buildProtocol() error {
 
 	return nil
 }
+
+// buildListenAddresses parses and validates the configured listen addresses, each address must start with an
+// ip4, ip6, dns4 or dns6 component followed by a tcp component. if no listen addresses are configured, the node
+// listens on all IPv4 interfaces on the configured port.
+func (c *Config) buildListenAddresses() error {
+	if len(c.ListenAddresses) == 0 {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", c.Port))
+		if err != nil {
+			return err
+		}
+
+		c.listenAddrs = []ma.Multiaddr{addr}
+		return nil
+	}
+
+	c.listenAddrs = make([]ma.Multiaddr, len(c.ListenAddresses))
+	for i, a := range c.ListenAddresses {
+		addr, err := ma.NewMultiaddr(a)
+		if err != nil {
+			return fmt.Errorf("invalid listen address %s: %w", a, err)
+		}
+
+		first, _ := ma.SplitFirst(addr)
+		switch first.Protocol().Code {
+		case ma.P_IP4, ma.P_IP6, ma.P_DNS4, ma.P_DNS6:
+		default:
+			return fmt.Errorf("invalid listen address %s: must start with ip4, ip6, dns4 or dns6", a)
+		}
+
+		if _, err = addr.ValueForProtocol(ma.P_TCP); err != nil {
+			return fmt.Errorf("invalid listen address %s: must contain a tcp port", a)
+		}
+
+		c.listenAddrs[i] = addr
+	}
+
+	return nil
+}
+
+// buildPublicAddresses parses the configured public addresses
+func (c *Config) buildPublicAddresses() error {
+	c.publicAddrs = make([]ma.Multiaddr, len(c.PublicAddresses))
+	for i, a := range c.PublicAddresses {
+		addr, err := ma.NewMultiaddr(a)
+		if err != nil {
+			return fmt.Errorf("invalid public address %s: %w", a, err)
+		}
+
+		c.publicAddrs[i] = addr
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ package network
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/lib/utils"
@@ -110,4 +111,110 @@ func TestBuild(t *testing.T) {
 	require.Equal(t, DefaultProtocolID, cfg.ProtocolID)
 	require.Equal(t, false, cfg.NoBootstrap)
 	require.Equal(t, false, cfg.NoMDNS)
+	require.Equal(t, DefaultBlockRequestSize, cfg.BlockRequestSize)
+}
+
+func TestBuild_BlockRequestSize(t *testing.T) {
+	testBasePath := utils.NewTestBasePath(t, "node")
+	defer utils.RemoveTestDir(t)
+
+	cfg := &Config{
+		logger:           log.New("srvc", "NET"),
+		BlockState:       &state.BlockState{},
+		BasePath:         testBasePath,
+		RandSeed:         1,
+		BlockRequestSize: MaxBlockRequestSize + 1,
+	}
+
+	err := cfg.build()
+	require.NoError(t, err)
+	require.Equal(t, MaxBlockRequestSize, cfg.BlockRequestSize)
+}
+
+func TestBuild_PingInterval(t *testing.T) {
+	testBasePath := utils.NewTestBasePath(t, "node")
+	defer utils.RemoveTestDir(t)
+
+	cfg := &Config{
+		logger:       log.New("srvc", "NET"),
+		BlockState:   &state.BlockState{},
+		BasePath:     testBasePath,
+		RandSeed:     1,
+		PingInterval: -time.Second,
+	}
+
+	err := cfg.build()
+	require.EqualError(t, err, "invalid ping interval -1s: must not be negative")
+
+	cfg.PingInterval = 0
+	err = cfg.build()
+	require.NoError(t, err)
+	require.Equal(t, DefaultPingInterval, cfg.PingInterval)
+}
+
+func TestBuild_ListenAddresses(t *testing.T) {
+	testBasePath := utils.NewTestBasePath(t, "node")
+	defer utils.RemoveTestDir(t)
+
+	cfg := &Config{
+		logger:     log.New("srvc", "NET"),
+		BlockState: &state.BlockState{},
+		BasePath:   testBasePath,
+		RandSeed:   1,
+		Port:       7001,
+	}
+
+	err := cfg.build()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cfg.listenAddrs))
+	require.Equal(t, "/ip4/0.0.0.0/tcp/7001", cfg.listenAddrs[0].String())
+
+	testCases := []struct {
+		addrs []string
+		valid bool
+	}{
+		{addrs: []string{"/ip4/127.0.0.1/tcp/7001", "/ip6/::/tcp/7001"}, valid: true},
+		{addrs: []string{"/dns4/example.com/tcp/7001", "/dns6/example.com/tcp/7001"}, valid: true},
+		{addrs: []string{"/ip6/::1"}, valid: false},
+		{addrs: []string{"/tcp/7001"}, valid: false},
+		{addrs: []string{"/dnsaddr/example.com/tcp/7001"}, valid: false},
+		{addrs: []string{"not-a-multiaddr"}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		cfg.ListenAddresses = tc.addrs
+		err = cfg.buildListenAddresses()
+		if !tc.valid {
+			require.Error(t, err, tc.addrs)
+			continue
+		}
+
+		require.NoError(t, err, tc.addrs)
+		require.Equal(t, len(tc.addrs), len(cfg.listenAddrs))
+		for i, addr := range cfg.listenAddrs {
+			require.Equal(t, tc.addrs[i], addr.String())
+		}
+	}
+}
+
+func TestBuild_PublicAddresses(t *testing.T) {
+	testBasePath := utils.NewTestBasePath(t, "node")
+	defer utils.RemoveTestDir(t)
+
+	cfg := &Config{
+		logger:          log.New("srvc", "NET"),
+		BlockState:      &state.BlockState{},
+		BasePath:        testBasePath,
+		RandSeed:        1,
+		PublicAddresses: []string{"/ip4/203.0.113.5/tcp/30333", "/dns4/example.com/tcp/30333"},
+	}
+
+	err := cfg.build()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cfg.publicAddrs))
+	require.Equal(t, "/ip4/203.0.113.5/tcp/30333", cfg.publicAddrs[0].String())
+
+	cfg.PublicAddresses = []string{"203.0.113.5:30333"}
+	err = cfg.build()
+	require.Error(t, err)
 }
@@ -65,8 +65,8 @@ type host struct {
 
 // newHost creates a host wrapper with a new libp2p host instance
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
-	// create multiaddress (without p2p identity)
-	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.Port))
+	// resolve listen addresses, dns addresses are advertised as configured
+	listenAddrs, dnsAddrs, err := resolveListenAddrs(ctx, cfg.listenAddrs)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +103,9 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		dual.DHTOption(kaddht.BootstrapPeers(bns...)),
 		dual.DHTOption(kaddht.V1ProtocolOverride(pid + "/kad")),
 		dual.DHTOption(kaddht.Mode(kaddht.ModeAutoServer)),
+		// the routing table is refreshed by the network service every DHTRefreshInterval
+		dual.DHTOption(kaddht.RoutingTableRefreshPeriod(cfg.DHTRefreshInterval)),
+		dual.DHTOption(kaddht.DisableAutoRefresh()),
 	}
 
 	privateIPs := ma.NewFilters()
@@ -122,7 +125,7 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 
 	// set libp2p host options
 	opts := []libp2p.Option{
-		libp2p.ListenAddrs(addr),
+		libp2p.ListenAddrs(listenAddrs...),
 		libp2p.DisableRelay(),
 		libp2p.Identity(cfg.privateKey),
 		libp2p.NATPortMap(),
@@ -130,13 +133,17 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		libp2p.ConnectionManager(cm),
 		libp2p.ChainOptions(libp2p.DefaultSecurity, libp2p.Security(secio.ID, secio.New)), // TODO: deprecate secio?
 		libp2p.AddrsFactory(func(as []ma.Multiaddr) []ma.Multiaddr {
+			if len(cfg.publicAddrs) > 0 {
+				return cfg.publicAddrs
+			}
+
 			ok := []ma.Multiaddr{}
 			for _, addr := range as {
 				if !privateIPs.AddrBlocked(addr) {
 					ok = append(ok, addr)
 				}
 			}
-			return ok
+			return append(ok, dnsAddrs...)
 		}),
 	}
 
@@ -188,6 +195,75 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 	return host, nil
 }
 
+// resolveListenAddrs returns the addresses to listen on, with any dns4 or dns6 addresses resolved to the IP
+// addresses they refer to. the unresolved dns addresses are also returned so they can be advertised.
+func resolveListenAddrs(ctx context.Context, addrs []ma.Multiaddr) ([]ma.Multiaddr, []ma.Multiaddr, error) {
+	var listen, dns []ma.Multiaddr
+	for _, addr := range addrs {
+		first, rest := ma.SplitFirst(addr)
+
+		var network, proto string
+		switch first.Protocol().Code {
+		case ma.P_DNS4:
+			network, proto = "ip4", "ip4"
+		case ma.P_DNS6:
+			network, proto = "ip6", "ip6"
+		default:
+			listen = append(listen, addr)
+			continue
+		}
+
+		ips, err := net.DefaultResolver.LookupIP(ctx, network, first.Value())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve listen address %s: %w", addr, err)
+		}
+
+		for _, ip := range ips {
+			var c *ma.Component
+			c, err = ma.NewComponent(proto, ip.String())
+			if err != nil {
+				return nil, nil, err
+			}
+			listen = append(listen, c.Encapsulate(rest))
+		}
+
+		dns = append(dns, addr)
+	}
+
+	return listen, dns, nil
+}
+
+// refreshRoutingTable refreshes the LAN and WAN DHT routing tables, it returns once both refreshes
+// have completed or the context is done
+func (h *host) refreshRoutingTable(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	lan := h.dht.LAN.RefreshRoutingTable()
+	wan := h.dht.WAN.RefreshRoutingTable()
+
+	var lanErr, wanErr error
+	for lan != nil || wan != nil {
+		select {
+		case lanErr = <-lan:
+			lan = nil
+		case wanErr = <-wan:
+			wan = nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// a node is usually only able to refresh one of its routing tables, eg. a node on a local network has no
+	// WAN peers
+	if lanErr != nil && wanErr != nil {
+		return fmt.Errorf("failed to refresh routing tables: LAN: %s, WAN: %w", lanErr, wanErr)
+	}
+
+	return nil
+}
+
 // close closes host services and the libp2p host (host services first)
 func (h *host) close() error {
 	// close DHT service
@@ -351,10 +427,20 @@ func (h *host) closeStream(p peer.ID, pid protocol.ID) {
 }
 
 // closePeer closes the peer connection
-func (h *host) closePeer(peer peer.ID) error { //nolint
+func (h *host) closePeer(peer peer.ID) error {
 	return h.h.Network().ClosePeer(peer)
 }
 
+// evictPeer closes the peer connection and removes the peer's addresses from the peerstore
+func (h *host) evictPeer(p peer.ID) {
+	err := h.closePeer(p)
+	if err != nil {
+		logger.Debug("failed to close connection to evicted peer", "peer", p, "error", err)
+	}
+
+	h.h.Peerstore().ClearAddrs(p)
+}
+
 // id returns the host id
 func (h *host) id() peer.ID {
 	return h.h.ID()
@@ -61,6 +61,71 @@ func TestExternalAddrs(t *testing.T) {
 	}
 }
 
+func TestListenAddresses_IPv6(t *testing.T) {
+	config := &Config{
+		BasePath:        utils.NewTestBasePath(t, "node"),
+		ListenAddresses: []string{"/ip6/::1/tcp/7001"},
+		RandSeed:        1,
+		NoBootstrap:     true,
+		NoMDNS:          true,
+	}
+
+	node := createTestService(t, config)
+
+	expected, err := ma.NewMultiaddr("/ip6/::1/tcp/7001")
+	require.NoError(t, err)
+	require.Contains(t, node.host.h.Network().ListenAddresses(), expected)
+	require.Contains(t, node.host.h.Addrs(), expected)
+}
+
+func TestListenAddresses_DNS(t *testing.T) {
+	config := &Config{
+		BasePath:        utils.NewTestBasePath(t, "node"),
+		ListenAddresses: []string{"/dns4/localhost/tcp/7001"},
+		RandSeed:        1,
+		NoBootstrap:     true,
+		NoMDNS:          true,
+	}
+
+	node := createTestService(t, config)
+
+	listening, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/7001")
+	require.NoError(t, err)
+	require.Contains(t, node.host.h.Network().ListenAddresses(), listening)
+
+	// the dns address is advertised as configured
+	advertised, err := ma.NewMultiaddr("/dns4/localhost/tcp/7001")
+	require.NoError(t, err)
+	require.Contains(t, node.host.h.Addrs(), advertised)
+}
+
+func TestPublicAddresses(t *testing.T) {
+	config := &Config{
+		BasePath:        utils.NewTestBasePath(t, "node"),
+		Port:            7001,
+		PublicAddresses: []string{"/ip4/203.0.113.5/tcp/30333"},
+		RandSeed:        1,
+		NoBootstrap:     true,
+		NoMDNS:          true,
+	}
+
+	node := createTestService(t, config)
+
+	public, err := ma.NewMultiaddr("/ip4/203.0.113.5/tcp/30333")
+	require.NoError(t, err)
+
+	addrInfos, err := node.host.addrInfos()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(addrInfos))
+	require.Equal(t, []ma.Multiaddr{public}, addrInfos[0].Addrs)
+
+	// the node still listens on its local address
+	require.NotContains(t, node.host.h.Network().ListenAddresses(), public)
+	listen, err := ma.NewMultiaddr("/ip4/0.0.0.0/tcp/7001")
+	require.NoError(t, err)
+	require.Contains(t, node.host.h.Network().ListenAddresses(), listen)
+}
+
 // test host connect method
 func TestConnect(t *testing.T) {
 	basePathA := utils.NewTestBasePath(t, "nodeA")
@@ -455,3 +520,42 @@ func TestKadDHT(t *testing.T) {
 	_, err = nodes[2].host.dht.FindPeer(ctx, nodes[1].host.id())
 	require.NoError(t, err)
 }
+
+func TestRefreshRoutingTable(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+
+	nodes := createServiceHelper(t, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	connectNoSync(t, ctx, nodes[1], nodes[0])
+	wait(t, ctx, nodes[1].host.dht, nodes[0].host.dht)
+	require.Equal(t, 1, nodes[1].host.dht.LAN.RoutingTable().Size())
+
+	// node 2 joins after node 1 has populated its routing table
+	connectNoSync(t, ctx, nodes[2], nodes[0])
+	wait(t, ctx, nodes[2].host.dht, nodes[0].host.dht)
+	wait(t, ctx, nodes[0].host.dht, nodes[2].host.dht)
+	require.Equal(t, 1, nodes[1].host.dht.LAN.RoutingTable().Size())
+
+	// a refresh may not reach node 2 on the first attempt when the host is busy
+	require.Eventually(t, func() bool {
+		err := nodes[1].RefreshRoutingTable(ctx)
+		require.NoError(t, err)
+		return nodes[1].host.dht.LAN.RoutingTable().Find(nodes[2].host.id()) != ""
+	}, 10*time.Second, 500*time.Millisecond)
+	require.Equal(t, 2, nodes[1].host.dht.LAN.RoutingTable().Size())
+}
+
+func TestRefreshRoutingTable_Cancelled(t *testing.T) {
+	nodes := createServiceHelper(t, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := nodes[0].RefreshRoutingTable(ctx)
+	require.Equal(t, context.Canceled, err)
+}
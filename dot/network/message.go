@@ -63,6 +63,12 @@ const (
 	RequestedDataJustification = byte(16)
 )
 
+// BlockRequestMessage directions
+const (
+	Ascending  = byte(0)
+	Descending = byte(1)
+)
+
 var _ Message = &BlockRequestMessage{}
 
 // BlockRequestMessage is sent to request some blocks from a peer
@@ -223,6 +223,14 @@ func TestCreateNotificationsMessageHandler_BlockAnnounceHandshake(t *testing.T)
 	require.True(t, data.received)
 	require.False(t, data.validated)
 
+	// the peer is disconnected as it's on a different chain, reconnect
+	require.Equal(t, 0, s.host.peerCount())
+	err = s.host.connect(*addrInfosB[0])
+	require.NoError(t, err)
+
+	stream, err = s.host.h.NewStream(s.ctx, b.host.id(), s.host.protocolID+blockAnnounceID)
+	require.NoError(t, err)
+
 	// try valid handshake
 	testHandshake = &BlockAnnounceHandshake{
 		Roles:           4,
@@ -0,0 +1,105 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+)
+
+// pingTimeout is the maximum duration to wait for a ping response
+var pingTimeout = 10 * time.Second
+
+// ping sends a ping to the peer and waits for the response
+func (h *host) ping(ctx context.Context, p peer.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	res, ok := <-ping.Ping(ctx, h.h, p)
+	if !ok {
+		return ctx.Err()
+	}
+
+	return res.Error
+}
+
+// pingPeers pings the connected peers every PingInterval until the service is stopped
+func (s *Service) pingPeers() {
+	ticker := time.NewTicker(s.cfg.PingInterval)
+	defer ticker.Stop()
+
+	failures := make(map[peer.ID]int) // peer -> number of consecutive failed pings
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.pingConnectedPeers(failures)
+		}
+	}
+}
+
+// pingConnectedPeers pings each connected peer and evicts peers that have failed PingFailureThreshold
+// consecutive pings
+func (s *Service) pingConnectedPeers(failures map[peer.ID]int) {
+	peers := s.host.peers()
+	errs := make([]error, len(peers))
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(i int, p peer.ID) {
+			defer wg.Done()
+			errs[i] = s.host.ping(s.ctx, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	connected := make(map[peer.ID]struct{}, len(peers))
+	for i, p := range peers {
+		connected[p] = struct{}{}
+
+		if errs[i] == nil {
+			delete(failures, p)
+			continue
+		}
+
+		failures[p]++
+		logger.Debug("failed to ping peer", "peer", p, "failures", failures[p], "error", errs[i])
+
+		if failures[p] >= s.cfg.PingFailureThreshold {
+			logger.Debug("evicting unresponsive peer", "peer", p)
+			s.host.evictPeer(p)
+			delete(failures, p)
+		}
+	}
+
+	// forget failures of peers that have since disconnected
+	for p := range failures {
+		if _, has := connected[p]; !has {
+			delete(failures, p)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/utils"
+
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingPeers_EvictsUnresponsivePeer(t *testing.T) {
+	configA := &Config{
+		BasePath:             utils.NewTestBasePath(t, "nodeA"),
+		Port:                 7001,
+		RandSeed:             1,
+		NoBootstrap:          true,
+		NoMDNS:               true,
+		PingInterval:         time.Millisecond * 100,
+		PingFailureThreshold: 2,
+	}
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		RandSeed:    2,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	addrInfosB, err := nodeB.host.addrInfos()
+	require.NoError(t, err)
+
+	err = nodeA.host.connect(*addrInfosB[0])
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(*addrInfosB[0])
+	}
+	require.NoError(t, err)
+
+	// node B responds to pings, so it is kept
+	time.Sleep(configA.PingInterval * time.Duration(configA.PingFailureThreshold+1))
+	require.Equal(t, 1, nodeA.host.peerCount())
+	require.NotEmpty(t, nodeA.host.h.Peerstore().Addrs(nodeB.host.id()))
+
+	// node B stops responding while its connection stays open
+	nodeB.host.h.RemoveStreamHandler(ping.ID)
+
+	require.Eventually(t, func() bool {
+		return nodeA.host.peerCount() == 0 && len(nodeA.host.h.Peerstore().Addrs(nodeB.host.id())) == 0
+	}, time.Second*5, configA.PingInterval)
+}
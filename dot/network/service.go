@@ -21,14 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"sync"
 	"time"
 
 	gssmrmetrics "github.com/ChainSafe/gossamer/dot/metrics"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 	"github.com/ethereum/go-ethereum/metrics"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
@@ -99,7 +100,7 @@ type Service struct {
 func NewService(cfg *Config) (*Service, error) {
 	ctx, cancel := context.WithCancel(context.Background()) //nolint
 
-	h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	h = log.CallerFileHandler(h)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 	cfg.logger = logger
@@ -181,9 +182,13 @@ func (s *Service) Start() error {
 	connMgr := s.host.h.ConnManager().(*ConnManager)
 	connMgr.registerDisconnectHandler(func(p peer.ID) {
 		s.syncQueue.peerScore.Delete(p)
+		s.syncQueue.peerHeads.Delete(p)
 	})
 
 	s.host.registerStreamHandler(syncID, s.handleSyncStream)
+	if s.cfg.CompressSync {
+		s.host.registerStreamHandler(syncID+snappySuffix, s.handleSnappySyncStream)
+	}
 	s.host.registerStreamHandler(lightID, s.handleLightStream)
 
 	// register block announce protocol
@@ -251,8 +256,10 @@ func (s *Service) Start() error {
 	}
 
 	go s.logPeerCount()
+	go s.pingPeers()
 	go s.publishNetworkTelemetry(s.closeCh)
 	go s.sentBlockIntervalTelemetry()
+	go s.publishFinalizedTelemetry(s.closeCh)
 
 	return nil
 }
@@ -305,6 +312,36 @@ main:
 	}
 }
 
+// publishFinalizedTelemetry sends a telemetry message for each block that is finalised
+func (s *Service) publishFinalizedTelemetry(done chan interface{}) {
+	finalised := make(chan *types.FinalisationInfo, 16)
+	id, err := s.blockState.RegisterFinalizedChannel(finalised)
+	if err != nil {
+		logger.Error("failed to register finalised channel for telemetry", "error", err)
+		return
+	}
+	defer s.blockState.UnregisterFinalizedChannel(id)
+
+	for {
+		select {
+		case <-done:
+			return
+		case info, ok := <-finalised:
+			if !ok {
+				return
+			}
+
+			// a block finalised by GRANDPA is notified for both its round and set ID and 0/0,
+			// only send the message once
+			if info.Round != 0 || info.SetID != 0 {
+				continue
+			}
+
+			telemetry.GetInstance().SendBlockFinalized(info.Header.Hash(), info.Header.Number)
+		}
+	}
+}
+
 func (s *Service) sentBlockIntervalTelemetry() {
 	for {
 		best, err := s.blockState.BestBlockHeader()
@@ -342,8 +379,15 @@ func (s *Service) beginDiscovery() error {
 		return fmt.Errorf("failed to bootstrap DHT: %w", err)
 	}
 
-	// wait to connect to bootstrap peers
-	time.Sleep(time.Second)
+	// wait for the initial routing table refresh to complete, or for the bootstrap timeout
+	ctx, cancel := context.WithTimeout(s.ctx, s.cfg.DHTBootstrapTimeout)
+	err = s.RefreshRoutingTable(ctx)
+	cancel()
+	if err != nil {
+		logger.Debug("initial DHT routing table refresh did not complete", "error", err)
+	}
+
+	go s.refreshRoutingTablePeriodically()
 
 	go func() {
 		peerCh, err := rd.FindPeers(s.ctx, s.cfg.ProtocolID)
@@ -374,6 +418,31 @@ func (s *Service) beginDiscovery() error {
 	return nil
 }
 
+// RefreshRoutingTable refreshes the Kademlia DHT routing tables, it returns once the refresh has completed
+// or the context is done
+func (s *Service) RefreshRoutingTable(ctx context.Context) error {
+	return s.host.refreshRoutingTable(ctx)
+}
+
+// refreshRoutingTablePeriodically refreshes the DHT routing tables every DHTRefreshInterval until the
+// service is stopped
+func (s *Service) refreshRoutingTablePeriodically() {
+	ticker := time.NewTicker(s.cfg.DHTRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.RefreshRoutingTable(s.ctx)
+			if err != nil {
+				logger.Debug("failed to refresh DHT routing table", "error", err)
+			}
+		}
+	}
+}
+
 // Stop closes running instances of the host and network services as well as
 // the message channel from the network service to the core service (services that
 // are dependent on the host instance should be closed first)
@@ -552,15 +621,20 @@ func isInbound(stream libp2pnetwork.Stream) bool {
 
 func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder, handler messageHandler) {
 	var (
-		maxMessageSize uint64 = maxBlockResponseSize // TODO: determine actual max message size
-		msgBytes              = make([]byte, maxMessageSize)
-		peer                  = stream.Conn().RemotePeer()
+		msgBytes = make([]byte, s.cfg.MaxMessageSize)
+		peer     = stream.Conn().RemotePeer()
 	)
 
 	for {
 		tot, err := readStream(stream, msgBytes)
 		if err == io.EOF {
 			continue
+		} else if err == errMessageTooLarge || err == errInvalidLEB128 {
+			logger.Debug("disconnecting peer that sent invalid message length",
+				"peer", peer, "protocol", stream.Protocol(), "error", err)
+			_ = stream.Close()
+			_ = s.host.closePeer(peer)
+			return
 		} else if err != nil {
 			logger.Trace("failed to read from stream", "peer", stream.Conn().RemotePeer(), "protocol", stream.Protocol(), "error", err)
 			_ = stream.Close()
@@ -637,10 +711,40 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) error
 
 // Health returns information about host needed for the rpc server
 func (s *Service) Health() common.Health {
+	peers := s.host.peerCount()
+	synced := s.syncer.IsSynced()
+
+	var hasTarget bool
+	if s.syncQueue != nil {
+		target, _ := s.syncQueue.syncTarget()
+		if target != nil {
+			best, err := s.blockState.BestBlockNumber()
+			if err != nil {
+				logger.Debug("failed to get best block number", "error", err)
+			} else {
+				hasTarget = target.Cmp(best) > 0
+			}
+		}
+	}
+
 	return common.Health{
-		Peers:           s.host.peerCount(),
-		IsSyncing:       !s.syncer.IsSynced(),
+		Peers:           peers,
+		IsSyncing:       !synced,
 		ShouldHavePeers: !s.noBootstrap,
+		HasSyncTarget:   hasTarget,
+		SyncStatus:      syncStatus(peers, synced),
+	}
+}
+
+// syncStatus returns no-peers if the node has no peers to sync with, otherwise synced or syncing
+func syncStatus(peers int, synced bool) common.SyncStatus {
+	switch {
+	case peers == 0:
+		return common.SyncStatusNoPeers
+	case synced:
+		return common.SyncStatusSynced
+	default:
+		return common.SyncStatusSyncing
 	}
 }
 
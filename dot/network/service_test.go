@@ -19,11 +19,13 @@ package network
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -265,6 +267,116 @@ func TestService_Health(t *testing.T) {
 	require.Equal(t, s.Health().IsSyncing, false)
 }
 
+func TestService_Health_NoPeers(t *testing.T) {
+	config := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		RandSeed:    1,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	s := createTestService(t, config)
+	s.syncer.(*mockSyncer).SetSyncing(false)
+
+	// an isolated dev node is idle rather than stuck
+	health := s.Health()
+	require.Equal(t, 0, health.Peers)
+	require.False(t, health.ShouldHavePeers)
+	require.False(t, health.HasSyncTarget)
+	require.Equal(t, common.SyncStatusNoPeers, health.SyncStatus)
+}
+
+func TestService_Health_Syncing(t *testing.T) {
+	configA := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		RandSeed:    1,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		RandSeed:    2,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	addrInfosB, err := nodeB.host.addrInfos()
+	require.NoError(t, err)
+
+	err = nodeA.host.connect(*addrInfosB[0])
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(*addrInfosB[0])
+	}
+	require.NoError(t, err)
+
+	// a peer that isn't ahead of us isn't a sync target
+	best, err := nodeA.blockState.BestBlockNumber()
+	require.NoError(t, err)
+	nodeA.syncQueue.updatePeerHead(nodeB.host.id(), best, common.Hash{0x1})
+	require.False(t, nodeA.Health().HasSyncTarget)
+
+	nodeA.syncQueue.updatePeerHead(nodeB.host.id(), big.NewInt(100), common.Hash{0x1})
+
+	health := nodeA.Health()
+	require.Equal(t, 1, health.Peers)
+	require.True(t, health.IsSyncing)
+	require.True(t, health.HasSyncTarget)
+	require.Equal(t, common.SyncStatusSyncing, health.SyncStatus)
+
+	nodeA.syncer.(*mockSyncer).SetSyncing(false)
+	require.Equal(t, common.SyncStatusSynced, nodeA.Health().SyncStatus)
+}
+
+func TestReadStream_DisconnectsPeerOnOversizedMessage(t *testing.T) {
+	configA := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		RandSeed:    1,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		RandSeed:    2,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	addrInfosB, err := nodeB.host.addrInfos()
+	require.NoError(t, err)
+
+	err = nodeA.host.connect(*addrInfosB[0])
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(*addrInfosB[0])
+	}
+	require.NoError(t, err)
+
+	stream, err := nodeA.host.h.NewStream(nodeA.ctx, nodeB.host.id(), nodeB.host.protocolID+syncID)
+	require.NoError(t, err)
+
+	_, err = stream.Write(uint64ToLEB128(nodeB.cfg.MaxMessageSize + 1))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(nodeB.host.h.Network().ConnsToPeer(nodeA.host.id())) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func TestBeginDiscovery(t *testing.T) {
 	configA := &Config{
 		BasePath:    utils.NewTestBasePath(t, "nodeA"),
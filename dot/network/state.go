@@ -31,6 +31,8 @@ type BlockState interface {
 	HasBlockBody(common.Hash) (bool, error)
 	GetFinalizedHeader(round, setID uint64) (*types.Header, error)
 	GetHashByNumber(num *big.Int) (common.Hash, error)
+	RegisterFinalizedChannel(ch chan<- *types.FinalisationInfo) (byte, error)
+	UnregisterFinalizedChannel(id byte)
 }
 
 // Syncer is implemented by the syncing service
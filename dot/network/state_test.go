@@ -25,7 +25,8 @@ import (
 
 // MockBlockState ...
 type MockBlockState struct {
-	number *big.Int
+	number      *big.Int
+	genesisHash common.Hash
 }
 
 func newMockBlockState(number *big.Int) *MockBlockState {
@@ -61,6 +62,9 @@ func (mbs *MockBlockState) BestBlockHeader() (*types.Header, error) {
 }
 
 func (mbs *MockBlockState) GenesisHash() common.Hash {
+	if mbs.genesisHash != (common.Hash{}) {
+		return mbs.genesisHash
+	}
 	return common.NewHash([]byte{})
 }
 
@@ -79,3 +83,9 @@ func (mbs *MockBlockState) GetFinalizedHeader(_, _ uint64) (*types.Header, error
 func (mbs *MockBlockState) GetHashByNumber(_ *big.Int) (common.Hash, error) {
 	return common.Hash{}, nil
 }
+
+func (mbs *MockBlockState) RegisterFinalizedChannel(_ chan<- *types.FinalisationInfo) (byte, error) {
+	return 0, nil
+}
+
+func (mbs *MockBlockState) UnregisterFinalizedChannel(_ byte) {}
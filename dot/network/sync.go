@@ -34,6 +34,7 @@ import (
 	"github.com/ChainSafe/chaindb"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
 )
 
 // handleSyncStream handles streams with the <protocol-id>/sync/2 protocol ID
@@ -45,12 +46,30 @@ func (s *Service) handleSyncStream(stream libp2pnetwork.Stream) {
 	s.readStream(stream, s.decodeSyncMessage, s.handleSyncMessage)
 }
 
+// handleSnappySyncStream handles streams of the snappy compressed sync protocol
+func (s *Service) handleSnappySyncStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, s.decodeSnappySyncMessage, s.handleSyncMessage)
+}
+
 func (s *Service) decodeSyncMessage(in []byte, peer peer.ID, inbound bool) (Message, error) {
 	msg := new(BlockRequestMessage)
 	err := msg.Decode(in)
 	return msg, err
 }
 
+func (s *Service) decodeSnappySyncMessage(in []byte, peer peer.ID, inbound bool) (Message, error) {
+	dec, err := snappyDecode(in, maxBlockResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeSyncMessage(dec, peer, inbound)
+}
+
 // handleSyncMessage handles synchronisation message types (BlockRequest and BlockResponse)
 func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg Message) error {
 	if msg == nil {
@@ -70,7 +89,12 @@ func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg Message) er
 			return nil
 		}
 
-		err = s.host.writeToStream(stream, resp)
+		var out Message = resp
+		if isSnappyStream(stream) {
+			out = &snappyMessage{resp}
+		}
+
+		err = s.host.writeToStream(stream, out)
 		if err != nil {
 			logger.Error("failed to send BlockResponse message", "peer", stream.Conn().RemotePeer(), "error", err)
 		}
@@ -80,9 +104,8 @@ func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg Message) er
 }
 
 var (
-	blockRequestSize        uint32 = 128
-	blockRequestBufferSize  int    = 6
-	blockResponseBufferSize int    = 6
+	blockRequestBufferSize  int = 6
+	blockResponseBufferSize int = 6
 
 	maxBlockResponseSize   uint64 = 1024 * 1024 * 4 // 4mb
 	badPeerThreshold       int    = -2
@@ -118,6 +141,7 @@ type syncQueue struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	peerScore    *sync.Map // map[peer.ID]int; peers we have successfully synced from before -> their score; score increases on successful response
+	peerHeads    *sync.Map // map[peer.ID]*peerHead; peers -> the best block they have announced
 
 	requestData              *sync.Map // map[uint64]requestData; map of start # of request -> requestData
 	justificationRequestData *sync.Map // map[common.Hash]requestData; map of requests of justifications -> requestData
@@ -128,8 +152,9 @@ type syncQueue struct {
 	responseLock sync.RWMutex
 
 	buf                []byte
-	goal               int64 // goal block number we are trying to sync to
-	currStart, currEnd int64 // the start and end of the BlockResponse we are currently handling; 0 and 0 if we are not currently handling any
+	requestSize        uint32 // number of blocks to request in each BlockRequestMessage
+	goal               int64  // goal block number we are trying to sync to
+	currStart, currEnd int64  // the start and end of the BlockResponse we are currently handling; 0 and 0 if we are not currently handling any
 
 	benchmarker *syncBenchmarker
 }
@@ -143,6 +168,7 @@ func newSyncQueue(s *Service) *syncQueue {
 		ctx:                      ctx,
 		cancel:                   cancel,
 		peerScore:                new(sync.Map),
+		peerHeads:                new(sync.Map),
 		requestData:              new(sync.Map),
 		justificationRequestData: new(sync.Map),
 		requestCh:                make(chan *syncRequest, blockRequestBufferSize),
@@ -150,6 +176,7 @@ func newSyncQueue(s *Service) *syncQueue {
 		responseCh:               make(chan []*types.BlockData, blockResponseBufferSize),
 		benchmarker:              newSyncBenchmarker(),
 		buf:                      make([]byte, maxBlockResponseSize),
+		requestSize:              s.cfg.BlockRequestSize,
 	}
 }
 
@@ -339,7 +366,7 @@ func (q *syncQueue) benchmark() {
 			"hashes", fmt.Sprintf("[%s ... %s]", before.Hash(), after.Hash()),
 		)
 
-		if q.goal-before.Number.Int64() < int64(blockRequestSize) {
+		if q.goal-before.Number.Int64() < int64(q.requestSize) {
 			continue
 		}
 
@@ -401,9 +428,9 @@ func (q *syncQueue) pushRequest(start uint64, numRequests int, to peer.ID) {
 		q.goal = best.Int64()
 	}
 
-	if q.goal-int64(start) < int64(blockRequestSize) {
+	if q.goal-int64(start) < int64(q.requestSize) {
 		start := best.Int64() + 1
-		req := createBlockRequest(start, 0)
+		req := createBlockRequest(start, q.requestSize)
 
 		logger.Debug("pushing request to queue", "start", start)
 		q.requestData.Store(start, requestData{
@@ -417,8 +444,8 @@ func (q *syncQueue) pushRequest(start uint64, numRequests int, to peer.ID) {
 		return
 	}
 
-	// all requests must start at a multiple of 128 + 1
-	m := start % uint64(blockRequestSize)
+	// all requests must start at a multiple of the request size + 1
+	m := start % uint64(q.requestSize)
 	start = start - m + 1
 
 	for i := 0; i < numRequests; i++ {
@@ -426,7 +453,7 @@ func (q *syncQueue) pushRequest(start uint64, numRequests int, to peer.ID) {
 			return
 		}
 
-		req := createBlockRequest(int64(start), blockRequestSize)
+		req := createBlockRequest(int64(start), q.requestSize)
 
 		if d, has := q.requestData.Load(start); has {
 			data := d.(requestData)
@@ -447,7 +474,7 @@ func (q *syncQueue) pushRequest(start uint64, numRequests int, to peer.ID) {
 			to:  to,
 		}
 
-		start += uint64(blockRequestSize)
+		start += uint64(q.requestSize)
 	}
 }
 
@@ -610,21 +637,30 @@ func (q *syncQueue) trySync(req *syncRequest) {
 }
 
 func (q *syncQueue) syncWithPeer(peer peer.ID, req *BlockRequestMessage) (*BlockResponseMessage, error) {
-	fullSyncID := q.s.host.protocolID + syncID
+	// if compression is enabled, prefer the snappy sync protocol if the peer supports it
+	pids := []protocol.ID{q.s.host.protocolID + syncID}
+	if q.s.cfg.CompressSync {
+		pids = append([]protocol.ID{q.s.host.protocolID + syncID + snappySuffix}, pids...)
+	}
 
 	q.s.host.h.ConnManager().Protect(peer, "")
 	defer q.s.host.h.ConnManager().Unprotect(peer, "")
-	defer q.s.host.closeStream(peer, fullSyncID)
 
 	ctx, cancel := context.WithTimeout(q.ctx, time.Second*2)
 	defer cancel()
 
-	s, err := q.s.host.h.NewStream(ctx, peer, fullSyncID)
+	s, err := q.s.host.h.NewStream(ctx, peer, pids...)
 	if err != nil {
 		return nil, err
 	}
+	defer q.s.host.closeStream(peer, s.Protocol())
+
+	var out Message = req
+	if isSnappyStream(s) {
+		out = &snappyMessage{req}
+	}
 
-	err = q.s.host.writeToStream(s, req)
+	err = q.s.host.writeToStream(s, out)
 	if err != nil {
 		return nil, err
 	}
@@ -634,12 +670,23 @@ func (q *syncQueue) syncWithPeer(peer peer.ID, req *BlockRequestMessage) (*Block
 
 func (q *syncQueue) receiveBlockResponse(stream libp2pnetwork.Stream) (*BlockResponseMessage, error) {
 	n, err := readStream(stream, q.buf)
-	if err != nil {
+	if err == errMessageTooLarge || err == errInvalidLEB128 {
+		_ = q.s.host.closePeer(stream.Conn().RemotePeer())
+		return nil, err
+	} else if err != nil {
 		return nil, err
 	}
 
+	enc := q.buf[:n]
+	if isSnappyStream(stream) {
+		enc, err = snappyDecode(enc, maxBlockResponseSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	msg := new(BlockResponseMessage)
-	err = msg.Decode(q.buf[:n])
+	err = msg.Decode(enc)
 	return msg, err
 }
 
@@ -667,6 +714,12 @@ func (q *syncQueue) handleBlockJustification(data []*types.BlockData) {
 	_, err := q.s.syncer.ProcessJustification(data)
 	if err != nil {
 		logger.Warn("failed to handle block justifications", "error", err)
+
+		// penalise the peer that sent us invalid justifications, so it can be requested again from someone else
+		if d, ok := q.justificationRequestData.Load(startHash); ok {
+			q.updatePeerScore(d.(requestData).from, -1)
+			q.justificationRequestData.Delete(startHash)
+		}
 		return
 	}
 
@@ -796,6 +849,10 @@ func (q *syncQueue) handleBlockAnnounce(msg *BlockAnnounceMessage, from peer.ID)
 		return
 	}
 
+	if msg.BestBlock {
+		q.updatePeerHead(from, header.Number, header.Hash())
+	}
+
 	has, _ := q.s.blockState.HasBlockBody(header.Hash())
 	if has {
 		return
@@ -832,7 +889,7 @@ func createBlockRequest(startInt int64, size uint32) *BlockRequestMessage {
 		RequestedData: RequestedDataHeader + RequestedDataBody + RequestedDataJustification,
 		StartingBlock: start,
 		EndBlockHash:  optional.NewHash(false, common.Hash{}),
-		Direction:     0, // ascending
+		Direction:     Ascending,
 		Max:           max,
 	}
 
@@ -853,7 +910,7 @@ func createBlockRequestWithHash(startHash common.Hash, size uint32) *BlockReques
 		RequestedData: RequestedDataHeader + RequestedDataBody + RequestedDataJustification,
 		StartingBlock: start,
 		EndBlockHash:  optional.NewHash(false, common.Hash{}),
-		Direction:     0, // ascending
+		Direction:     Ascending,
 		Max:           max,
 	}
 
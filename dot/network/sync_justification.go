@@ -34,7 +34,7 @@ func (q *syncQueue) pushJustificationRequest(to peer.ID, start uint64) {
 		return
 	}
 
-	req := createBlockRequestWithHash(startHash, blockRequestSize)
+	req := createBlockRequestWithHash(startHash, q.requestSize)
 	req.RequestedData = RequestedDataJustification
 
 	logger.Debug("pushing justification request to queue", "start", start, "hash", startHash)
@@ -49,7 +49,7 @@ func TestSyncQueue_PushResponse_Justification(t *testing.T) {
 		BlockData: []*types.BlockData{},
 	}
 
-	for i := 0; i < int(blockRequestSize); i++ {
+	for i := 0; i < int(DefaultBlockRequestSize); i++ {
 		msg.BlockData = append(msg.BlockData, &types.BlockData{
 			Hash:          common.Hash{byte(i)},
 			Justification: optional.NewBytes(true, []byte{1}),
@@ -88,7 +88,7 @@ func TestSyncQueue_PushResponse_EmptyJustification(t *testing.T) {
 		BlockData: []*types.BlockData{},
 	}
 
-	for i := 0; i < int(blockRequestSize); i++ {
+	for i := 0; i < int(DefaultBlockRequestSize); i++ {
 		msg.BlockData = append(msg.BlockData, &types.BlockData{
 			Hash:          common.Hash{byte(i)},
 			Justification: optional.NewBytes(false, nil),
@@ -0,0 +1,97 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// stalledPeerSlots is the number of slots after which a peer whose announced head hasn't advanced is
+// considered stalled. stalled peers are only chosen as the sync target if every peer is stalled.
+var stalledPeerSlots = 10
+
+// peerHead is the best block a peer has announced to us
+type peerHead struct {
+	number   *big.Int
+	hash     common.Hash
+	advanced time.Time // the last time the announced number increased
+}
+
+// SyncTarget returns the number and hash of the highest best block announced by our peers.
+// It returns a nil number if no peer has announced its best block.
+func (s *Service) SyncTarget() (*big.Int, common.Hash) {
+	return s.syncQueue.syncTarget()
+}
+
+// updatePeerHead records the best block announced by a peer
+func (q *syncQueue) updatePeerHead(pid peer.ID, number *big.Int, hash common.Hash) {
+	head := &peerHead{
+		number:   new(big.Int).Set(number),
+		hash:     hash,
+		advanced: time.Now(),
+	}
+
+	if prev, has := q.peerHeads.Load(pid); has {
+		prev := prev.(*peerHead)
+		if number.Cmp(prev.number) <= 0 {
+			head.advanced = prev.advanced
+		}
+	}
+
+	q.peerHeads.Store(pid, head)
+}
+
+// isStalled returns true if the peer's announced head hasn't advanced in stalledPeerSlots slots
+func (q *syncQueue) isStalled(head *peerHead) bool {
+	return time.Since(head.advanced) > q.slotDuration*time.Duration(stalledPeerSlots)
+}
+
+// syncTarget selects the highest head announced by a peer that isn't stalled. if every peer is stalled,
+// the highest head announced by any peer is selected.
+func (q *syncQueue) syncTarget() (*big.Int, common.Hash) {
+	var target, stalledTarget *peerHead
+
+	q.peerHeads.Range(func(_, h interface{}) bool {
+		head := h.(*peerHead)
+		if q.isStalled(head) {
+			if stalledTarget == nil || head.number.Cmp(stalledTarget.number) > 0 {
+				stalledTarget = head
+			}
+			return true
+		}
+
+		if target == nil || head.number.Cmp(target.number) > 0 {
+			target = head
+		}
+		return true
+	})
+
+	if target == nil {
+		target = stalledTarget
+	}
+
+	if target == nil {
+		return nil, common.Hash{}
+	}
+
+	return new(big.Int).Set(target.number), target.hash
+}
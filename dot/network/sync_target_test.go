@@ -0,0 +1,93 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncQueue_SyncTarget(t *testing.T) {
+	q := newTestSyncQueue(t)
+	q.stop()
+	time.Sleep(time.Second)
+
+	num, hash := q.syncTarget()
+	require.Nil(t, num)
+	require.Equal(t, common.Hash{}, hash)
+
+	peers := []peer.ID{"alice", "bob", "charlie"}
+	for i, pid := range peers {
+		q.updatePeerHead(pid, big.NewInt(int64(100+i)), common.Hash{byte(i)})
+	}
+
+	num, hash = q.syncTarget()
+	require.Equal(t, big.NewInt(102), num)
+	require.Equal(t, common.Hash{2}, hash)
+
+	// a new announcement changes the target
+	q.updatePeerHead("bob", big.NewInt(110), common.Hash{0xb})
+	num, hash = q.syncTarget()
+	require.Equal(t, big.NewInt(110), num)
+	require.Equal(t, common.Hash{0xb}, hash)
+
+	// re-announcing the same head doesn't count as advancing, so bob is deprioritised once stalled
+	q.updatePeerHead("bob", big.NewInt(110), common.Hash{0xb})
+	h, _ := q.peerHeads.Load(peer.ID("bob"))
+	h.(*peerHead).advanced = time.Now().Add(-q.slotDuration * time.Duration(stalledPeerSlots+1))
+
+	num, hash = q.syncTarget()
+	require.Equal(t, big.NewInt(102), num)
+	require.Equal(t, common.Hash{2}, hash)
+
+	// if every peer is stalled, the highest head is chosen
+	q.peerHeads.Range(func(_, h interface{}) bool {
+		h.(*peerHead).advanced = time.Time{}
+		return true
+	})
+
+	num, hash = q.syncTarget()
+	require.Equal(t, big.NewInt(110), num)
+	require.Equal(t, common.Hash{0xb}, hash)
+}
+
+func TestSyncQueue_HandleBlockAnnounce_UpdatesPeerHead(t *testing.T) {
+	q := newTestSyncQueue(t)
+	q.stop()
+	time.Sleep(time.Second)
+
+	msg := &BlockAnnounceMessage{
+		Number:    big.NewInt(77),
+		BestBlock: true,
+	}
+
+	q.handleBlockAnnounce(msg, peer.ID("noot"))
+
+	num, hash := q.syncTarget()
+	require.Equal(t, big.NewInt(77), num)
+
+	header, err := types.NewHeader(msg.ParentHash, msg.StateRoot, msg.ExtrinsicsRoot, msg.Number, msg.Digest)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), hash)
+}
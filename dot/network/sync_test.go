@@ -38,12 +38,12 @@ func createBlockRequests(start, end int64) []*BlockRequestMessage {
 		return nil
 	}
 
-	numReqs := (end - start) / int64(blockRequestSize)
+	numReqs := (end - start) / int64(DefaultBlockRequestSize)
 	if numReqs > int64(blockRequestBufferSize) {
 		numReqs = int64(blockRequestBufferSize)
 	}
 
-	if end-start < int64(blockRequestSize) {
+	if end-start < int64(DefaultBlockRequestSize) {
 		// +1 because we want to include the block w/ the ending number
 		req := createBlockRequest(start, uint32(end-start)+1)
 		return []*BlockRequestMessage{req}
@@ -51,8 +51,8 @@ func createBlockRequests(start, end int64) []*BlockRequestMessage {
 
 	reqs := make([]*BlockRequestMessage, numReqs)
 	for i := 0; i < int(numReqs); i++ {
-		offset := i * int(blockRequestSize)
-		reqs[i] = createBlockRequest(start+int64(offset), blockRequestSize)
+		offset := i * int(DefaultBlockRequestSize)
+		reqs[i] = createBlockRequest(start+int64(offset), DefaultBlockRequestSize)
 	}
 	return reqs
 }
@@ -60,6 +60,9 @@ func createBlockRequests(start, end int64) []*BlockRequestMessage {
 func TestDecodeSyncMessage(t *testing.T) {
 	s := &Service{
 		ctx: context.Background(),
+		cfg: &Config{
+			BlockRequestSize: DefaultBlockRequestSize,
+		},
 	}
 
 	s.syncQueue = newSyncQueue(s)
@@ -93,7 +96,7 @@ func TestSyncQueue_PushResponse(t *testing.T) {
 		BlockData: []*types.BlockData{},
 	}
 
-	for i := 0; i < int(blockRequestSize); i++ {
+	for i := 0; i < int(DefaultBlockRequestSize); i++ {
 		testHeader := types.Header{
 			Number: big.NewInt(int64(77 + i)),
 		}
@@ -110,7 +113,7 @@ func TestSyncQueue_PushResponse(t *testing.T) {
 }
 
 func TestSortRequests(t *testing.T) {
-	reqs := createBlockRequests(1, int64(blockRequestSize*5)+1)
+	reqs := createBlockRequests(1, int64(DefaultBlockRequestSize*5)+1)
 	sreqs := []*syncRequest{}
 	for _, req := range reqs {
 		sreqs = append(sreqs, &syncRequest{
@@ -127,7 +130,7 @@ func TestSortRequests(t *testing.T) {
 }
 
 func TestSortRequests_RemoveDuplicates(t *testing.T) {
-	reqs := createBlockRequests(1, int64(blockRequestSize*5)+1)
+	reqs := createBlockRequests(1, int64(DefaultBlockRequestSize*5)+1)
 	sreqs := []*syncRequest{}
 	for _, req := range reqs {
 		sreqs = append(sreqs, &syncRequest{
@@ -138,7 +141,7 @@ func TestSortRequests_RemoveDuplicates(t *testing.T) {
 	expected := make([]*syncRequest, len(sreqs))
 	copy(expected, sreqs)
 
-	dup := createBlockRequest(1, blockRequestSize)
+	dup := createBlockRequest(1, DefaultBlockRequestSize)
 	sreqs = append(sreqs, &syncRequest{req: dup})
 
 	rand.Shuffle(len(sreqs), func(i, j int) { sreqs[i], sreqs[j] = sreqs[j], sreqs[i] })
@@ -255,7 +258,7 @@ func TestSyncQueue_HandleBlockAnnounceHandshake(t *testing.T) {
 
 	head, err := q.s.blockState.BestBlockNumber()
 	require.NoError(t, err)
-	expected := createBlockRequest(head.Int64(), blockRequestSize)
+	expected := createBlockRequest(head.Int64(), DefaultBlockRequestSize)
 	req := <-q.requestCh
 	require.Equal(t, &syncRequest{req: expected, to: testPeerID}, req)
 }
@@ -275,11 +278,44 @@ func TestSyncQueue_HandleBlockAnnounce(t *testing.T) {
 
 	head, err := q.s.blockState.BestBlockNumber()
 	require.NoError(t, err)
-	expected := createBlockRequest(head.Int64(), blockRequestSize)
+	expected := createBlockRequest(head.Int64(), DefaultBlockRequestSize)
 	req := <-q.requestCh
 	require.Equal(t, &syncRequest{req: expected, to: testPeerID}, req)
 }
 
+func TestSyncQueue_PushRequest_BlockRequestSize(t *testing.T) {
+	basePath := utils.NewTestBasePath(t, "node")
+	cfg := &Config{
+		BasePath:         basePath,
+		Port:             7001,
+		RandSeed:         1,
+		NoBootstrap:      true,
+		NoMDNS:           true,
+		BlockRequestSize: 32,
+	}
+
+	q := createTestService(t, cfg).syncQueue
+	q.stop()
+	time.Sleep(time.Second)
+
+	q.goal = 32 * 10
+	q.pushRequest(1, 2, "")
+	require.Equal(t, 2, len(q.requestCh))
+
+	for _, start := range []int64{1, 33} {
+		req := <-q.requestCh
+		require.Equal(t, createBlockRequest(start, 32), req.req)
+		require.True(t, req.req.Max.Exists())
+		require.Equal(t, uint32(32), req.req.Max.Value())
+	}
+
+	// requests near the goal must also respect the configured size
+	q.goal = 16
+	q.pushRequest(1, 1, "")
+	req := <-q.requestCh
+	require.Equal(t, uint32(32), req.req.Max.Value())
+}
+
 func TestSyncQueue_ProcessBlockRequests(t *testing.T) {
 	configA := &Config{
 		BasePath:    utils.NewTestBasePath(t, "nodeA"),
@@ -359,7 +395,7 @@ func TestSyncQueue_handleResponseQueue_noRequestsOrResponses(t *testing.T) {
 	q := newTestSyncQueue(t)
 	q.stop()
 	time.Sleep(time.Second)
-	q.goal = int64(blockRequestSize) * 10
+	q.goal = int64(DefaultBlockRequestSize) * 10
 	q.ctx = context.Background()
 	go q.handleResponseQueue()
 	time.Sleep(time.Second * 2)
@@ -370,7 +406,7 @@ func TestSyncQueue_handleResponseQueue_responseQueueAhead(t *testing.T) {
 	q := newTestSyncQueue(t)
 	q.stop()
 	time.Sleep(time.Second)
-	q.goal = int64(blockRequestSize) * 10
+	q.goal = int64(DefaultBlockRequestSize) * 10
 	q.ctx = context.Background()
 
 	testHeader0 := types.Header{
@@ -396,7 +432,7 @@ func TestSyncQueue_processBlockResponses(t *testing.T) {
 	q := newTestSyncQueue(t)
 	q.stop()
 	time.Sleep(time.Second)
-	q.goal = int64(blockRequestSize) * 10
+	q.goal = int64(DefaultBlockRequestSize) * 10
 	q.ctx = context.Background()
 
 	testHeader0 := types.Header{
@@ -18,7 +18,7 @@ func testBlockResponseMessage() *BlockResponseMessage {
 		BlockData: []*types.BlockData{},
 	}
 
-	for i := 0; i < int(blockRequestSize); i++ {
+	for i := 0; i < int(DefaultBlockRequestSize); i++ {
 		testHeader := types.Header{
 			Number: big.NewInt(int64(77 + i)),
 			Digest: types.Digest{},
@@ -151,10 +151,20 @@ func uint64ToLEB128(in uint64) []byte {
 	return out
 }
 
+var (
+	errMessageTooLarge = errors.New("message size greater than maximum")
+	errInvalidLEB128   = errors.New("invalid LEB128 length prefix")
+)
+
 func readLEB128ToUint64(r *bufio.Reader) (uint64, error) {
 	var out uint64
 	var shift uint
 	for {
+		// a uint64 is encoded in at most 10 bytes
+		if shift >= 64 {
+			return 0, errInvalidLEB128
+		}
+
 		b, err := r.ReadByte()
 		if err != nil {
 			return 0, err
@@ -169,7 +179,8 @@ func readLEB128ToUint64(r *bufio.Reader) (uint64, error) {
 	return out, nil
 }
 
-// readStream reads from the stream into the given buffer, returning the number of bytes read
+// readStream reads from the stream into the given buffer, returning the number of bytes read. messages with a
+// length prefix greater than the size of the buffer are rejected with errMessageTooLarge without being read.
 func readStream(stream libp2pnetwork.Stream, buf []byte) (int, error) {
 	if stream == nil {
 		return 0, errors.New("stream is nil")
@@ -192,21 +203,14 @@ func readStream(stream libp2pnetwork.Stream, buf []byte) (int, error) {
 		return 0, nil // msg length of 0 is allowed, for example transactions handshake
 	}
 
-	// TODO: check if length > len(buf), if so probably log.Crit
-	if length > maxBlockResponseSize {
-		logger.Warn("received message with size greater than maxBlockResponseSize, discarding", "length", length)
-		for {
-			_, err = r.Discard(int(maxBlockResponseSize))
-			if err != nil {
-				break
-			}
-		}
-		return 0, fmt.Errorf("message size greater than maximum: got %d", length)
+	if length > uint64(len(buf)) {
+		logger.Debug("received message with size greater than maximum", "length", length, "maximum", len(buf))
+		return 0, errMessageTooLarge
 	}
 
 	tot = 0
 	for i := 0; i < maxReads; i++ {
-		n, err := r.Read(buf[tot:])
+		n, err := r.Read(buf[tot:length])
 		if err != nil {
 			return n + tot, err
 		}
@@ -17,10 +17,15 @@
 package network
 
 import (
+	"bytes"
+	"io"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/utils"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
 )
 
 // list of IPFS peers, for testing only
@@ -93,3 +98,63 @@ func TestGenerateKey(t *testing.T) {
 		t.Error("Generated keys should match")
 	}
 }
+
+// mockReadStream is a stream that only supports reading from the given reader
+type mockReadStream struct {
+	libp2pnetwork.Stream
+	r io.Reader
+}
+
+func (s *mockReadStream) Read(p []byte) (int, error) {
+	return s.r.Read(p)
+}
+
+func TestReadStream(t *testing.T) {
+	msg := []byte("noot")
+	data := append(uint64ToLEB128(uint64(len(msg))), msg...)
+
+	buf := make([]byte, 8)
+	n, err := readStream(&mockReadStream{r: bytes.NewReader(data)}, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, buf[:n]) {
+		t.Fatalf("Fail: got %x expected %x", buf[:n], msg)
+	}
+}
+
+func TestReadStream_MessageTooLarge(t *testing.T) {
+	// a 1tb length prefix, followed by a little data
+	data := append(uint64ToLEB128(1<<40), make([]byte, 1024)...)
+	buf := make([]byte, DefaultMaxMessageSize)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	_, err := readStream(&mockReadStream{r: bytes.NewReader(data)}, buf)
+	if err != errMessageTooLarge {
+		t.Fatalf("Fail: got %v expected %v", err, errMessageTooLarge)
+	}
+
+	runtime.ReadMemStats(&after)
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1024*1024 {
+		t.Fatalf("Fail: allocated %d bytes while reading oversized message", allocated)
+	}
+
+	// a length prefix one byte larger than the buffer is also rejected
+	data = append(uint64ToLEB128(uint64(len(buf)+1)), make([]byte, len(buf)+1)...)
+	_, err = readStream(&mockReadStream{r: bytes.NewReader(data)}, buf)
+	if err != errMessageTooLarge {
+		t.Fatalf("Fail: got %v expected %v", err, errMessageTooLarge)
+	}
+}
+
+func TestReadStream_InvalidLEB128(t *testing.T) {
+	// continuation bits set on more bytes than a uint64 can hold
+	data := bytes.Repeat([]byte{0xff}, 11)
+	_, err := readStream(&mockReadStream{r: bytes.NewReader(data)}, make([]byte, 8))
+	if err != errInvalidLEB128 {
+		t.Fatalf("Fail: got %v expected %v", err, errInvalidLEB128)
+	}
+}
@@ -17,26 +17,31 @@
 package dot
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"path"
 	"runtime/debug"
 	"strconv"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/core"
 	gssmrmetrics "github.com/ChainSafe/gossamer/dot/metrics"
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/rpc"
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/prometheus"
@@ -50,6 +55,10 @@ type Node struct {
 	Services *services.ServiceRegistry // registry of all node services
 	StopFunc func()                    // func to call when node stops, currently used for profiling
 	wg       sync.WaitGroup
+	doneOnce sync.Once // releases wg once, whether the node was stopped or failed to start
+
+	readyOnce sync.Once
+	ready     chan struct{} // closed once all node services have started
 }
 
 // InitNode initialises a new dot node from the provided dot node configuration
@@ -170,6 +179,52 @@ func NodeInitialized(basepath string, expected bool) bool {
 	return true
 }
 
+// MigrateDatabase runs the migrations needed to upgrade the node database to the schema version used by this release
+func MigrateDatabase(basepath string) (err error) {
+	db, err := state.SetupDatabase(basepath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if cerr := db.Close(); cerr != nil {
+			logger.Error("failed to close database", "error", cerr)
+		}
+	}()
+
+	return state.Migrate(db)
+}
+
+// CheckDatabaseCompatible returns an error wrapping ErrDatabaseNeedsMigration if the node database was created
+// with a different schema version than the one used by this release. Databases created before the schema version
+// was recorded are treated as version 0.
+func CheckDatabaseCompatible(basepath string) (err error) {
+	db, err := state.SetupDatabase(basepath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if cerr := db.Close(); cerr != nil {
+			logger.Error("failed to close database", "error", cerr)
+		}
+	}()
+
+	version, err := state.NewBaseState(db).LoadSchemaVersion()
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		version = 0
+	} else if err != nil {
+		return fmt.Errorf("failed to load database schema version: %w", err)
+	}
+
+	if version != state.SchemaVersion {
+		return fmt.Errorf("%w: database schema version is %d, expected %d",
+			ErrDatabaseNeedsMigration, version, state.SchemaVersion)
+	}
+
+	return nil
+}
+
 // LoadGlobalNodeName returns the stored global node name from database
 func LoadGlobalNodeName(basepath string) (nodename string, err error) {
 	// initialise database using data directory
@@ -211,6 +266,11 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore, stopFunc func()) (*Node,
 
 	setupLogger(cfg)
 
+	// metrics must be enabled before any services register them
+	if cfg.Global.PublishMetrics {
+		metrics.Enabled = true
+	}
+
 	// if authority node, should have at least 1 key in keystore
 	if cfg.Core.Roles == types.AuthorityRole && (ks.Babe.Size() == 0 || ks.Gran.Size() == 0) {
 		return nil, ErrNoKeysProvided
@@ -284,6 +344,12 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore, stopFunc func()) (*Node,
 	}
 	nodeSrvcs = append(nodeSrvcs, fg)
 
+	// the justifications stored by a voting node also contain its prevotes, which don't verify as a block
+	// justification, so they're only verified on non-authority nodes
+	if cfg.State.VerifyJustifications && !cfg.Core.GrandpaAuthority {
+		stateSrvc.Block.SetJustificationVerifier(fg)
+	}
+
 	// Syncer
 	syncer, err := createSyncService(cfg, stateSrvc, bp, fg, dh, ver, rt)
 	if err != nil {
@@ -318,7 +384,7 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore, stopFunc func()) (*Node,
 	// check if rpc service is enabled
 	if enabled := cfg.RPC.Enabled; enabled {
 		// create rpc service and append rpc service to node services
-		rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, bp, rt, sysSrvc)
+		rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, bp, fg, rt, sysSrvc)
 		nodeSrvcs = append(nodeSrvcs, rpcSrvc)
 	} else {
 		// do not create or append rpc service if rpc service is not enabled
@@ -338,6 +404,19 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore, stopFunc func()) (*Node,
 		node.Services.RegisterService(srvc)
 	}
 
+	// stop the services in dependency order: the RPC service first so no new requests are handled,
+	// and the state service last so the database is flushed once no other service writes to it
+	node.Services.SetStopOrder(
+		(*rpc.HTTPServer)(nil),
+		(*network.Service)(nil),
+		(*babe.Service)(nil),
+		(*grandpa.Service)(nil),
+		(*core.DigestHandler)(nil),
+		(*core.Service)(nil),
+		(*system.Service)(nil),
+		(*state.Service)(nil),
+	)
+
 	if cfg.Global.PublishMetrics {
 		publishMetrics(cfg)
 	}
@@ -351,7 +430,15 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore, stopFunc func()) (*Node,
 		return node, nil
 	}
 
-	telemetry.GetInstance().AddConnections(gd.TelemetryEndpoints)
+	endpoints := gd.TelemetryEndpoints
+	for _, url := range cfg.Global.TelemetryURLs {
+		endpoints = append(endpoints, &genesis.TelemetryEndpoint{
+			Endpoint: url,
+		})
+	}
+
+	telemetry.GetInstance().AddConnections(endpoints)
+	node.Services.RegisterService(telemetry.GetInstance())
 	data := &telemetry.ConnectionData{
 		Authority:     cfg.Core.GrandpaAuthority,
 		Chain:         sysSrvc.ChainName(),
@@ -417,29 +504,43 @@ func storeGlobalNodeName(name, basepath string) (err error) {
 	return nil
 }
 
-// Start starts all dot node services
+// Start starts all dot node services and blocks until the node is stopped. It returns an error if any of the
+// services fails to start, in which case the node is never ready.
 func (n *Node) Start() error {
 	logger.Info("🕸️ starting node services...")
 
-	// start all dot node services
-	n.Services.StartAll()
+	// the node may be stopped while its services are starting
+	n.wg.Add(1)
 
-	go func() {
-		sigc := make(chan os.Signal, 1)
-		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
-		defer signal.Stop(sigc)
-		<-sigc
-		logger.Info("signal interrupt, shutting down...")
-		n.Stop()
-		os.Exit(130)
-	}()
+	// start all dot node services. if any of them fails to start, the services that did start are stopped again
+	// and the node is never ready
+	if err := n.Services.StartAll(); err != nil {
+		logger.Error("failed to start node services", "error", err)
+		n.done()
+		return err
+	}
+
+	logger.Info("node services started", "name", n.Name)
+	close(n.readyChan())
 
-	n.wg.Add(1)
 	n.wg.Wait()
 
 	return nil
 }
 
+// Ready returns a channel that is closed once all node services have started, at which point the
+// RPC server is accepting connections, if enabled
+func (n *Node) Ready() <-chan struct{} {
+	return n.readyChan()
+}
+
+func (n *Node) readyChan() chan struct{} {
+	n.readyOnce.Do(func() {
+		n.ready = make(chan struct{})
+	})
+	return n.ready
+}
+
 // Stop stops all dot node services
 func (n *Node) Stop() {
 	if n.StopFunc != nil {
@@ -448,5 +549,32 @@ func (n *Node) Stop() {
 
 	// stop all node services
 	n.Services.StopAll()
-	n.wg.Done()
+
+	if err := utils.CloseLogFile(); err != nil {
+		logger.Warn("failed to close log file", "error", err)
+	}
+
+	n.done()
+}
+
+// done releases the wait group that Start blocks on
+func (n *Node) done() {
+	n.doneOnce.Do(n.wg.Done)
+}
+
+// StopWithTimeout stops all dot node services, returning an error if they have not stopped within
+// the timeout
+func (n *Node) StopWithTimeout(timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		n.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("node services did not stop within %s", timeout)
+	}
 }
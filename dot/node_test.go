@@ -18,6 +18,7 @@ package dot
 
 import (
 	"encoding/binary"
+	"errors"
 	"math/big"
 	"reflect"
 	"sync"
@@ -93,6 +94,52 @@ func TestNodeInitialized(t *testing.T) {
 	require.Equal(t, expected, true)
 }
 
+func TestCheckDatabaseCompatible(t *testing.T) {
+	cfg := NewTestConfig(t)
+	require.NotNil(t, cfg)
+
+	genFile := NewTestGenesisRawFile(t, cfg)
+	require.NotNil(t, genFile)
+
+	defer utils.RemoveTestDir(t)
+
+	cfg.Init.Genesis = genFile.Name()
+
+	err := InitNode(cfg)
+	require.NoError(t, err)
+
+	err = CheckDatabaseCompatible(cfg.Global.BasePath)
+	require.NoError(t, err)
+
+	setSchemaVersion := func(version []byte) {
+		db, err := state.SetupDatabase(cfg.Global.BasePath)
+		require.NoError(t, err)
+		if version == nil {
+			err = db.Del(common.SchemaVersionKey)
+		} else {
+			err = db.Put(common.SchemaVersionKey, version)
+		}
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+	}
+
+	// stale schema version
+	setSchemaVersion([]byte{0, 0, 0, 0})
+	err = CheckDatabaseCompatible(cfg.Global.BasePath)
+	require.True(t, errors.Is(err, ErrDatabaseNeedsMigration))
+
+	// database created before the schema version was recorded
+	setSchemaVersion(nil)
+	err = CheckDatabaseCompatible(cfg.Global.BasePath)
+	require.True(t, errors.Is(err, ErrDatabaseNeedsMigration))
+
+	err = MigrateDatabase(cfg.Global.BasePath)
+	require.NoError(t, err)
+
+	err = CheckDatabaseCompatible(cfg.Global.BasePath)
+	require.NoError(t, err)
+}
+
 // TestNewNode
 func TestNewNode(t *testing.T) {
 	cfg := NewTestConfig(t)
@@ -379,6 +426,44 @@ func TestNode_StopFunc(t *testing.T) {
 	require.Equal(t, testvar, "after")
 }
 
+type mockFailingService struct{}
+
+func (*mockFailingService) Start() error { return errors.New("failed to start") }
+func (*mockFailingService) Stop() error  { return nil }
+
+type mockStartedService struct {
+	running bool
+}
+
+func (s *mockStartedService) Start() error { s.running = true; return nil }
+func (s *mockStartedService) Stop() error  { s.running = false; return nil }
+
+func TestNode_Start_ServiceFails(t *testing.T) {
+	node := &Node{
+		Services: services.NewServiceRegistry(),
+		wg:       sync.WaitGroup{},
+	}
+	started := &mockStartedService{}
+	node.Services.RegisterService(started)
+	node.Services.RegisterService(&mockFailingService{})
+
+	err := node.Start()
+	require.EqualError(t, err, "failed to start service *dot.mockFailingService: failed to start")
+
+	// the service that did start is stopped again, and the node's wait group is released
+	require.False(t, started.running)
+	node.wg.Wait()
+
+	// a later Stop must not release the wait group a second time
+	node.Stop()
+
+	select {
+	case <-node.Ready():
+		t.Fatal("node should not be ready")
+	default:
+	}
+}
+
 func TestNode_PersistGlobalName_WhenInitialize(t *testing.T) {
 	globalName := RandomNodeName()
 
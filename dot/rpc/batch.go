@@ -0,0 +1,168 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+const (
+	// maxBatchSize is the maximum number of requests in a batch
+	maxBatchSize = 100
+	// maxBatchConcurrency is the maximum number of requests of a batch that are handled at the same time
+	maxBatchConcurrency = 8
+)
+
+// batchTooLargeResponse is returned when a batch contains more than maxBatchSize requests
+var batchTooLargeResponse = []byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Batch too large"},"id":null}`)
+
+// invalidBatchResponse is returned when a batch request isn't valid JSON or is an empty array
+var invalidBatchResponse = []byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`)
+
+// batchHandler is a http.Handler that dispatches each request of a JSON-RPC 2.0 batch to the wrapped handler.
+// Requests that aren't batches are passed to the wrapped handler unchanged.
+type batchHandler struct {
+	next http.Handler
+}
+
+func newBatchHandler(next http.Handler) *batchHandler {
+	return &batchHandler{
+		next: next,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !isBatch(body) {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	var reqs []json.RawMessage
+	if err = json.Unmarshal(body, &reqs); err != nil || len(reqs) == 0 {
+		_, _ = w.Write(invalidBatchResponse)
+		return
+	}
+
+	if len(reqs) > maxBatchSize {
+		_, _ = w.Write(batchTooLargeResponse)
+		return
+	}
+
+	// dispatch up to maxBatchConcurrency requests concurrently, keeping the responses in request order
+	responses := make([]json.RawMessage, len(reqs))
+	sem := make(chan struct{}, maxBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, req json.RawMessage) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			responses[i] = h.dispatch(r, req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	// notifications don't have a response
+	res := make([]json.RawMessage, 0, len(responses))
+	for _, resp := range responses {
+		if len(resp) != 0 {
+			res = append(res, resp)
+		}
+	}
+
+	if len(res) == 0 {
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(res); err != nil {
+		logger.Error("failed to write batch response", "error", err)
+	}
+}
+
+// dispatch calls the wrapped handler with a single request of a batch and returns its response
+func (h *batchHandler) dispatch(r *http.Request, req json.RawMessage) json.RawMessage {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(req, &obj); err != nil {
+		return invalidBatchResponse
+	}
+
+	sub := r.Clone(r.Context())
+	sub.Body = ioutil.NopCloser(bytes.NewReader(req))
+	sub.ContentLength = int64(len(req))
+
+	rw := newBufferedResponseWriter()
+	h.next.ServeHTTP(rw, sub)
+
+	res := bytes.TrimSpace(rw.buf.Bytes())
+	if len(res) != 0 && !json.Valid(res) {
+		// the wrapped handler failed before producing a JSON-RPC response
+		return invalidBatchResponse
+	}
+
+	return res
+}
+
+// isBatch returns true if the request body is a JSON array
+func isBatch(body []byte) bool {
+	body = bytes.TrimLeft(body, " \t\r\n")
+	return len(body) != 0 && body[0] == '['
+}
+
+// bufferedResponseWriter is a http.ResponseWriter that stores the response body in memory
+type bufferedResponseWriter struct {
+	header http.Header
+	buf    *bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+		buf:    new(bytes.Buffer),
+	}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(int) {}
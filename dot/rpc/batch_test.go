@@ -0,0 +1,121 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/system"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestHandler(t *testing.T) http.Handler {
+	genesisHeader, err := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash, trie.EmptyHash, big.NewInt(0), types.Digest{})
+	require.NoError(t, err)
+
+	bs, err := state.NewBlockStateFromGenesis(state.NewInMemoryDB(t), genesisHeader)
+	require.NoError(t, err)
+
+	cfg := &HTTPServerConfig{
+		Modules:   []string{"system", "chain"},
+		External:  true,
+		RPCAPI:    NewService(),
+		BlockAPI:  bs,
+		SystemAPI: system.NewService(&types.SystemInfo{}, &genesis.Data{Name: "gssmr"}),
+	}
+
+	s := NewHTTPServer(cfg)
+	s.rpcServer.RegisterCodec(NewDotUpCodec(), "application/json")
+	return newBatchHandler(s.rpcServer)
+}
+
+func TestBatchHandler(t *testing.T) {
+	h := newBatchTestHandler(t)
+
+	data := []byte(`[
+		{"jsonrpc":"2.0","method":"chain_getHeader","params":[],"id":1},
+		{"jsonrpc":"2.0","method":"system_chain","params":[]},
+		{"jsonrpc":"2.0","method":"system_unknown","params":[],"id":2},
+		{"jsonrpc":"2.0","method":"system_chain","params":[],"id":3}
+	]`)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var res []map[string]interface{}
+	err := json.Unmarshal(rec.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// the notification without an id is omitted
+	require.Len(t, res, 3)
+
+	require.Equal(t, float64(1), res[0]["id"])
+	header, ok := res[0]["result"].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "0x00", header["number"])
+
+	// an error in one request doesn't fail the batch
+	require.Equal(t, float64(2), res[1]["id"])
+	require.NotNil(t, res[1]["error"])
+
+	require.Equal(t, float64(3), res[2]["id"])
+	require.Equal(t, "gssmr", res[2]["result"])
+}
+
+func TestBatchHandler_Invalid(t *testing.T) {
+	h := newBatchTestHandler(t)
+
+	for _, data := range []string{`[]`, `[1]`, `[{"jsonrpc":"2.0"`} {
+		req := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(data)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		var res interface{}
+		err := json.Unmarshal(rec.Body.Bytes(), &res)
+		require.NoError(t, err, data)
+		require.Contains(t, rec.Body.String(), "Invalid Request", data)
+	}
+}
+
+func TestBatchHandler_TooLarge(t *testing.T) {
+	h := newBatchTestHandler(t)
+
+	reqs := make([]string, maxBatchSize+1)
+	for i := range reqs {
+		reqs[i] = `{"jsonrpc":"2.0","method":"system_chain","params":[],"id":1}`
+	}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader("["+strings.Join(reqs, ",")+"]"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Contains(t, rec.Body.String(), "Batch too large")
+}
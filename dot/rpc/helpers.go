@@ -24,6 +24,8 @@ import (
 	"github.com/jpillora/ipfilter"
 )
 
+var errUnsafeMethod = errors.New("RPC call is unsafe to be called externally")
+
 // LocalhostFilter creates a ipfilter object for localhost
 func LocalhostFilter() *ipfilter.IPFilter {
 	return ipfilter.New(ipfilter.Options{
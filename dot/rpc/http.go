@@ -17,14 +17,18 @@
 package rpc
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"os"
+	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/rpc/subscription"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -37,9 +41,15 @@ type HTTPServer struct {
 	logger       log.Logger
 	rpcServer    *rpc.Server // Actual RPC call handler
 	serverConfig *HTTPServerConfig
+	wsConnsLock  sync.Mutex
 	wsConns      []*subscription.WSConn
+	rpcHTTP      *http.Server
+	wsHTTP       *http.Server
 }
 
+// shutdownTimeout is how long Stop waits for in-flight requests to complete
+var shutdownTimeout = 10 * time.Second
+
 // HTTPServerConfig configures the HTTPServer
 type HTTPServerConfig struct {
 	LogLvl              log.Lvl
@@ -48,6 +58,7 @@ type HTTPServerConfig struct {
 	NetworkAPI          modules.NetworkAPI
 	CoreAPI             modules.CoreAPI
 	BlockProducerAPI    modules.BlockProducerAPI
+	GrandpaAPI          modules.GrandpaAPI
 	RuntimeAPI          modules.RuntimeAPI
 	TransactionQueueAPI modules.TransactionStateAPI
 	RPCAPI              modules.RPCAPI
@@ -59,6 +70,13 @@ type HTTPServerConfig struct {
 	WSExternal          bool
 	WSPort              uint32
 	Modules             []string
+	MaxRequestSize      int64    // maximum size in bytes of a request body, defaults to DefaultMaxRequestSize
+	RateLimit           float64  // requests per second allowed per IP, 0 disables rate limiting
+	RateBurst           int      // maximum burst of requests per IP, defaults to the rate limit
+	WSMessageRate       float64  // messages per second allowed per websocket connection, 0 disables the limit
+	WSMessageBurst      int      // maximum burst of messages per websocket connection, defaults to the message rate
+	TrustedProxies      []string // IPs of proxies whose X-Forwarded-For header is used to rate limit the client
+	Unsafe              bool     // serve unsafe methods, eg. state_call, to external requests
 }
 
 var logger log.Logger
@@ -66,10 +84,22 @@ var logger log.Logger
 // NewHTTPServer creates a new http server and registers an associated rpc server
 func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 	logger = log.New("pkg", "rpc")
-	h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	h = log.CallerFileHandler(h)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 
+	if cfg.MaxRequestSize <= 0 {
+		cfg.MaxRequestSize = DefaultMaxRequestSize
+	}
+
+	if cfg.RateBurst <= 0 {
+		cfg.RateBurst = burstFromRate(cfg.RateLimit)
+	}
+
+	if cfg.WSMessageBurst <= 0 {
+		cfg.WSMessageBurst = burstFromRate(cfg.WSMessageRate)
+	}
+
 	server := &HTTPServer{
 		logger:       logger,
 		rpcServer:    rpc.NewServer(),
@@ -99,13 +129,18 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "chain":
 			srvc = modules.NewChainModule(h.serverConfig.BlockAPI)
 		case "grandpa":
-			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI)
+			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI, h.serverConfig.GrandpaAPI)
 		case "state":
 			srvc = modules.NewStateModule(h.serverConfig.NetworkAPI, h.serverConfig.StorageAPI, h.serverConfig.CoreAPI)
 		case "rpc":
 			srvc = modules.NewRPCModule(h.serverConfig.RPCAPI)
 		case "dev":
-			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI)
+			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI, h.serverConfig.CoreAPI,
+				h.serverConfig.StorageAPI, h.serverConfig.SystemAPI, h.serverConfig.BlockAPI)
+		case "payment":
+			srvc = modules.NewPaymentModule(h.serverConfig.CoreAPI)
+		case "childstate":
+			srvc = modules.NewChildStateModule(h.serverConfig.StorageAPI)
 		default:
 			h.logger.Warn("Unrecognised module", "module", mod)
 			continue
@@ -131,13 +166,20 @@ func (h *HTTPServer) Start() error {
 
 	h.logger.Info("Starting HTTP Server...", "host", h.serverConfig.Host, "port", h.serverConfig.RPCPort)
 	r := mux.NewRouter()
-	r.Handle("/", h.rpcServer)
+	r.Handle("/", newLimitHandler(newBatchHandler(h.rpcServer), h.serverConfig.MaxRequestSize,
+		h.serverConfig.RateLimit, h.serverConfig.RateBurst, h.serverConfig.TrustedProxies))
 
 	validate := validator.New()
 	// Add custom validator for `common.Hash`
 	validate.RegisterCustomTypeFunc(common.HashValidator, common.Hash{})
 
 	validateHandler := func(r *rpc.RequestInfo, v interface{}) error {
+		if !h.serverConfig.Unsafe && modules.IsUnsafeMethod(r.Method) {
+			if err := LocalRequestOnly(r, v); err != nil {
+				return errUnsafeMethod
+			}
+		}
+
 		err := validate.Struct(v)
 		if err != nil {
 			return err
@@ -146,12 +188,15 @@ func (h *HTTPServer) Start() error {
 	}
 
 	h.rpcServer.RegisterValidateRequestFunc(validateHandler)
-	go func() {
-		err := http.ListenAndServe(fmt.Sprintf(":%d", h.serverConfig.RPCPort), r)
-		if err != nil {
-			h.logger.Error("http error", "err", err)
-		}
-	}()
+	h.rpcHTTP = &http.Server{
+		Addr:    fmt.Sprintf(":%d", h.serverConfig.RPCPort),
+		Handler: r,
+	}
+
+	// listen before returning, so the server accepts connections once the service has started
+	if err := h.listenAndServe(h.rpcHTTP); err != nil {
+		return err
+	}
 
 	if !h.serverConfig.WS {
 		return nil
@@ -160,9 +205,24 @@ func (h *HTTPServer) Start() error {
 	h.logger.Info("Starting WebSocket Server...", "host", h.serverConfig.Host, "port", h.serverConfig.WSPort)
 	ws := mux.NewRouter()
 	ws.Handle("/", h)
+	h.wsHTTP = &http.Server{
+		Addr:    fmt.Sprintf(":%d", h.serverConfig.WSPort),
+		Handler: ws,
+	}
+
+	return h.listenAndServe(h.wsHTTP)
+}
+
+// listenAndServe listens on the server's address and serves connections in a new goroutine
+func (h *HTTPServer) listenAndServe(srv *http.Server) error {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+
 	go func() {
-		err := http.ListenAndServe(fmt.Sprintf(":%d", h.serverConfig.WSPort), ws)
-		if err != nil {
+		err := srv.Serve(ln)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			h.logger.Error("http error", "err", err)
 		}
 	}()
@@ -170,28 +230,33 @@ func (h *HTTPServer) Start() error {
 	return nil
 }
 
-// Stop stops the server
+// Stop stops accepting new connections, closes websocket connections and waits up to shutdownTimeout
+// for in-flight requests to complete
 func (h *HTTPServer) Stop() error {
-	if h.serverConfig.WS {
-		// close all channels and websocket connections
-		for _, conn := range h.wsConns {
-			for _, sub := range conn.Subscriptions {
-				switch v := sub.(type) {
-				case *subscription.StorageObserver:
-					h.serverConfig.StorageAPI.UnregisterStorageObserver(v)
-				case *subscription.BlockListener:
-					h.serverConfig.BlockAPI.UnregisterImportedChannel(v.ChanID)
-					close(v.Channel)
-				}
-			}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-			err := conn.Wsconn.Close()
-			if err != nil {
-				h.logger.Error("error closing websocket connection", "error", err)
-			}
+	// websocket connections are hijacked, so they are not closed by Shutdown
+	if h.wsHTTP != nil {
+		if err := h.wsHTTP.Shutdown(ctx); err != nil {
+			h.logger.Error("error shutting down websocket server", "error", err)
 		}
 	}
-	return nil
+
+	h.wsConnsLock.Lock()
+	for _, conn := range h.wsConns {
+		if err := conn.Close(); err != nil {
+			h.logger.Error("error closing websocket connection", "error", err)
+		}
+	}
+	h.wsConns = nil
+	h.wsConnsLock.Unlock()
+
+	if h.rpcHTTP == nil {
+		return nil
+	}
+
+	return h.rpcHTTP.Shutdown(ctx)
 }
 
 // ServeHTTP implemented to handle WebSocket connections
@@ -222,9 +287,13 @@ func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("websocket upgrade failed", "error", err)
 		return
 	}
+	ws.SetReadLimit(h.serverConfig.MaxRequestSize)
+
 	// create wsConn
 	wsc := NewWSConn(ws, h.serverConfig)
+	h.wsConnsLock.Lock()
 	h.wsConns = append(h.wsConns, wsc)
+	h.wsConnsLock.Unlock()
 
 	go wsc.HandleComm()
 }
@@ -243,5 +312,16 @@ func NewWSConn(conn *websocket.Conn, cfg *HTTPServerConfig) *subscription.WSConn
 		TxStateAPI:         cfg.TransactionQueueAPI,
 		RPCHost:            fmt.Sprintf("http://%s:%d/", cfg.Host, cfg.RPCPort),
 	}
+
+	if cfg.WSMessageRate > 0 {
+		c.MessageLimiter = utils.NewTokenBucket(cfg.WSMessageRate, cfg.WSMessageBurst)
+	}
+
+	// websocket requests are forwarded to the HTTP-RPC server from localhost, so unsafe methods called by external
+	// connections are refused here
+	if !cfg.Unsafe {
+		ip, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+		c.RejectUnsafe = err != nil || !isLoopback(ip)
+	}
 	return c
 }
@@ -18,11 +18,14 @@ package rpc
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/stretchr/testify/require"
@@ -46,6 +49,8 @@ func TestNewHTTPServer(t *testing.T) {
 	err := s.Start()
 	require.Nil(t, err)
 
+	defer s.Stop()
+
 	time.Sleep(time.Second) // give server a second to start
 
 	// Valid request
@@ -90,3 +95,80 @@ func TestNewHTTPServer(t *testing.T) {
 
 	require.Equal(t, "405 Method Not Allowed", res.Status)
 }
+
+type slowModule struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *slowModule) Wait(r *http.Request, req *modules.EmptyRequest, res *string) error {
+	close(m.started)
+	<-m.release
+	*res = "done"
+	return nil
+}
+
+func TestHTTPServer_GracefulShutdown(t *testing.T) {
+	cfg := &HTTPServerConfig{
+		RPCPort: 8547,
+		RPCAPI:  NewService(),
+	}
+
+	s := NewHTTPServer(cfg)
+	slow := &slowModule{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	err := s.rpcServer.RegisterService(slow, "slow")
+	require.NoError(t, err)
+
+	err = s.Start()
+	require.NoError(t, err)
+	time.Sleep(time.Second) // give server a second to start
+
+	post := func(method string) (*http.Response, error) {
+		client := &http.Client{
+			Transport: &http.Transport{DisableKeepAlives: true},
+		}
+		data := fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":[],"id":1}`, method)
+		return client.Post("http://localhost:8547/", "application/json", bytes.NewBufferString(data))
+	}
+
+	type result struct {
+		res *http.Response
+		err error
+	}
+	inFlight := make(chan result)
+	go func() {
+		res, err := post("slow_wait")
+		inFlight <- result{res, err}
+	}()
+	<-slow.started
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- s.Stop()
+	}()
+	time.Sleep(100 * time.Millisecond) // give shutdown time to close the listener
+
+	// new requests are refused while shutting down
+	_, err = post("rpc_methods")
+	require.Error(t, err)
+
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before in-flight request completed")
+	default:
+	}
+
+	close(slow.release)
+
+	r := <-inFlight
+	require.NoError(t, r.err)
+	defer r.res.Body.Close()
+	body, err := ioutil.ReadAll(r.res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"result":"done"`)
+
+	require.NoError(t, <-stopped)
+}
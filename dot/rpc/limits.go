@@ -0,0 +1,216 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/utils"
+)
+
+// DefaultMaxRequestSize is the default maximum size in bytes of a RPC request body
+const DefaultMaxRequestSize = int64(15 * 1024 * 1024)
+
+// rateLimiterSweepInterval is how often unused rate limiter buckets are removed
+var rateLimiterSweepInterval = time.Minute
+
+// ipRateLimiter is a per-IP token bucket rate limiter
+type ipRateLimiter struct {
+	sync.Mutex
+	rate      float64
+	burst     int
+	buckets   map[string]*utils.TokenBucket
+	lastSweep time.Time
+}
+
+func newIPRateLimiter(rate float64, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		rate:      rate,
+		burst:     burst,
+		buckets:   make(map[string]*utils.TokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow reports whether n requests from the given IP may be handled now
+func (l *ipRateLimiter) allow(ip string, n int) bool {
+	l.Lock()
+	if time.Since(l.lastSweep) > rateLimiterSweepInterval {
+		// buckets that have refilled completely are equivalent to new ones
+		for k, b := range l.buckets {
+			if b.Full() {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = time.Now()
+	}
+
+	b, has := l.buckets[ip]
+	if !has {
+		b = utils.NewTokenBucket(l.rate, l.burst)
+		l.buckets[ip] = b
+	}
+	l.Unlock()
+
+	return b.AllowN(n)
+}
+
+// limitHandler is a http.Handler that rejects request bodies larger than maxSize and rate limits requests per IP.
+// Each request of a batch counts as a request. Requests forwarded by a trusted proxy are rate limited by the client
+// IP in their X-Forwarded-For header. Other requests from localhost, which includes requests forwarded from websocket
+// connections, are not rate limited.
+type limitHandler struct {
+	next           http.Handler
+	maxSize        int64
+	limiter        *ipRateLimiter
+	trustedProxies map[string]struct{}
+}
+
+func newLimitHandler(next http.Handler, maxSize int64, rate float64, burst int, trustedProxies []string) *limitHandler {
+	h := &limitHandler{
+		next:           next,
+		maxSize:        maxSize,
+		trustedProxies: make(map[string]struct{}, len(trustedProxies)),
+	}
+
+	for _, ip := range trustedProxies {
+		parsed := net.ParseIP(strings.TrimSpace(ip))
+		if parsed == nil {
+			logger.Warn("ignoring invalid trusted proxy IP", "ip", ip)
+			continue
+		}
+		h.trustedProxies[parsed.String()] = struct{}{}
+	}
+
+	if rate > 0 {
+		h.limiter = newIPRateLimiter(rate, burst)
+	}
+
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *limitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Body != nil {
+		if r.ContentLength > h.maxSize {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// the content length may be unknown, so read at most one byte more than allowed to detect oversized bodies
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(r.Body, h.maxSize+1))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if int64(len(body)) > h.maxSize {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if h.limiter != nil {
+		ip, forwarded := h.clientIP(r)
+		if (forwarded || !isLoopback(ip)) && !h.limiter.allow(ip, requestCount(body)) {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	h.next.ServeHTTP(w, r)
+}
+
+// clientIP returns the IP of the client that sent the request, and whether the request was forwarded by a trusted
+// proxy. The client of a forwarded request is the last IP of the X-Forwarded-For header that isn't a trusted proxy.
+func (h *limitHandler) clientIP(r *http.Request) (string, bool) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !h.isTrustedProxy(ip) {
+		return ip, false
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+
+	if len(hops) == 0 {
+		return ip, false
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = strings.TrimSpace(hops[i])
+		if !h.isTrustedProxy(ip) {
+			break
+		}
+	}
+
+	return ip, true
+}
+
+func (h *limitHandler) isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	_, has := h.trustedProxies[parsed.String()]
+	return has
+}
+
+// requestCount returns the number of requests in the request body, which is the number of elements of a batch
+func requestCount(body []byte) int {
+	if !isBatch(body) {
+		return 1
+	}
+
+	var reqs []json.RawMessage
+	if err := json.Unmarshal(body, &reqs); err != nil || len(reqs) == 0 {
+		return 1
+	}
+
+	return len(reqs)
+}
+
+func isLoopback(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsLoopback()
+}
+
+// burstFromRate returns the default burst for the given rate, which allows one second worth of events
+func burstFromRate(rate float64) int {
+	if rate < 1 {
+		return 1
+	}
+	return int(rate)
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestLimitHandler_MaxRequestSize(t *testing.T) {
+	h := newLimitHandler(okHandler, 16, 0, 0, nil)
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	// an unknown content length is checked while reading the body
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	req = httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{}`)))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLimitHandler_RateLimit(t *testing.T) {
+	h := newLimitHandler(okHandler, DefaultMaxRequestSize, 1, 2, nil)
+
+	serve := func(remoteAddr string) int {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve("192.0.2.1:1234"))
+	require.Equal(t, http.StatusOK, serve("192.0.2.1:1234"))
+	require.Equal(t, http.StatusTooManyRequests, serve("192.0.2.1:1234"))
+
+	// other IPs have their own budget
+	require.Equal(t, http.StatusOK, serve("192.0.2.2:1234"))
+
+	// localhost is not rate limited
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, serve("127.0.0.1:1234"))
+	}
+}
+
+func TestLimitHandler_RateLimitBatch(t *testing.T) {
+	h := newLimitHandler(okHandler, DefaultMaxRequestSize, 1, 3, nil)
+
+	serve := func(body string) int {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// each request of a batch uses a token
+	require.Equal(t, http.StatusOK, serve(`[{},{}]`))
+	require.Equal(t, http.StatusTooManyRequests, serve(`[{},{}]`))
+	require.Equal(t, http.StatusOK, serve(`{}`))
+	require.Equal(t, http.StatusTooManyRequests, serve(`{}`))
+}
+
+func TestLimitHandler_TrustedProxy(t *testing.T) {
+	h := newLimitHandler(okHandler, DefaultMaxRequestSize, 1, 1, []string{"127.0.0.1", "192.0.2.10"})
+
+	serve := func(remoteAddr string, forwardedFor ...string) int {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+		req.RemoteAddr = remoteAddr
+		for _, ip := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", ip)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// requests forwarded by a proxy on localhost are rate limited by the client IP
+	require.Equal(t, http.StatusOK, serve("127.0.0.1:1234", "198.51.100.1"))
+	require.Equal(t, http.StatusTooManyRequests, serve("127.0.0.1:1234", "198.51.100.1"))
+	require.Equal(t, http.StatusOK, serve("127.0.0.1:1234", "198.51.100.2"))
+
+	// the client is the last hop that isn't a trusted proxy, earlier hops can be spoofed
+	require.Equal(t, http.StatusTooManyRequests, serve("127.0.0.1:1234", "203.0.113.1, 198.51.100.1, 192.0.2.10"))
+
+	// the header is ignored for requests that don't come from a trusted proxy
+	require.Equal(t, http.StatusOK, serve("192.0.2.1:1234", "198.51.100.3"))
+	require.Equal(t, http.StatusTooManyRequests, serve("192.0.2.1:1234", "198.51.100.4"))
+
+	// requests from localhost that aren't forwarded are not rate limited
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, serve("127.0.0.1:1234"))
+	}
+}
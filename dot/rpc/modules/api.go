@@ -7,7 +7,9 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
@@ -20,6 +22,8 @@ type StorageAPI interface {
 	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	StoreTrie(ts *rtstorage.TrieState) error
 }
 
 // BlockAPI is the interface for the block state
@@ -36,6 +40,7 @@ type BlockAPI interface {
 	RegisterFinalizedChannel(ch chan<- *types.FinalisationInfo) (byte, error)
 	UnregisterFinalizedChannel(id byte)
 	SubChain(start, end common.Hash) ([]common.Hash, error)
+	BlocktreeAsDOT() (string, error)
 }
 
 // NetworkAPI interface for network state methods
@@ -55,6 +60,14 @@ type BlockProducerAPI interface {
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	SetSlot(slot uint64) error
+	ParentStateRoot(parent *types.Header) common.Hash
+	SetParentStateRoot(parent, root common.Hash) error
+}
+
+// GrandpaAPI is the interface for the grandpa finality gadget
+type GrandpaAPI interface {
+	RoundState() *types.GrandpaRoundState
 }
 
 // TransactionStateAPI ...
@@ -63,16 +76,20 @@ type TransactionStateAPI interface {
 	Pop() *transaction.ValidTransaction
 	Peek() *transaction.ValidTransaction
 	Pending() []*transaction.ValidTransaction
+	PendingInOrder() []*transaction.ValidTransaction
 }
 
 // CoreAPI is the interface for the core methods
 type CoreAPI interface {
-	InsertKey(kp crypto.Keypair)
+	InsertKey(kp crypto.Keypair) error
 	HasKey(pubKeyStr string, keyType string) (bool, error)
+	KeystoreSummary() []keystore.Summary
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	IsBlockProducer() bool
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 	GetMetadata(bhash *common.Hash) ([]byte, error)
+	QueryInfo(ext types.Extrinsic, bhash *common.Hash) (*types.RuntimeDispatchInfo, error)
+	CallRuntime(method string, data []byte, bhash *common.Hash) ([]byte, error)
 }
 
 // RPCAPI is the interface for methods related to RPC service
@@ -93,4 +110,5 @@ type SystemAPI interface {
 	Properties() map[string]interface{}
 	ChainType() string
 	ChainName() string
+	ChainID() string
 }
@@ -58,6 +58,20 @@ type KeyInsertResponse []byte
 // PendingExtrinsicsResponse is a bi-dimensional array of bytes for allocating the pending extrisics
 type PendingExtrinsicsResponse []string
 
+// PendingExtrinsicDetail holds a pending extrinsic and the validity information it was queued with
+type PendingExtrinsicDetail struct {
+	Extrinsic string   `json:"extrinsic"`
+	Hash      string   `json:"hash"`
+	Priority  uint64   `json:"priority"`
+	Longevity uint64   `json:"longevity"`
+	Requires  []string `json:"requires"`
+	Provides  []string `json:"provides"`
+	Propagate bool     `json:"propagate"`
+}
+
+// PendingExtrinsicsDetailedResponse is an array of pending extrinsics along with their validity information
+type PendingExtrinsicsDetailedResponse []*PendingExtrinsicDetail
+
 // RemoveExtrinsicsResponse is a array of hash used to Remove extrinsics
 type RemoveExtrinsicsResponse []common.Hash
 
@@ -118,7 +132,11 @@ func (cm *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, res *K
 		return fmt.Errorf("generated public key does not equal provide public key")
 	}
 
-	cm.coreAPI.InsertKey(keyPair)
+	err = cm.coreAPI.InsertKey(keyPair)
+	if err != nil {
+		return err
+	}
+
 	cm.logger.Info("inserted key into keystore", "key", keyPair.Public().Hex())
 	return nil
 }
@@ -131,9 +149,9 @@ func (cm *AuthorModule) HasKey(r *http.Request, req *[]string, res *bool) error
 	return err
 }
 
-// PendingExtrinsics Returns all pending extrinsics
+// PendingExtrinsics Returns all pending extrinsics, ordered by priority
 func (cm *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, res *PendingExtrinsicsResponse) error {
-	pending := cm.txStateAPI.Pending()
+	pending := cm.txStateAPI.PendingInOrder()
 	resp := make([]string, len(pending))
 	for idx, tx := range pending {
 		resp[idx] = common.BytesToHex(tx.Extrinsic)
@@ -143,6 +161,43 @@ func (cm *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 	return nil
 }
 
+// PendingExtrinsicsDetailed Returns all pending extrinsics ordered by priority, along with their priority, longevity
+// and requires/provides tags
+func (cm *AuthorModule) PendingExtrinsicsDetailed(r *http.Request, req *EmptyRequest,
+	res *PendingExtrinsicsDetailedResponse) error {
+	pending := cm.txStateAPI.PendingInOrder()
+	resp := make([]*PendingExtrinsicDetail, len(pending))
+	for idx, tx := range pending {
+		detail := &PendingExtrinsicDetail{
+			Extrinsic: common.BytesToHex(tx.Extrinsic),
+			Hash:      tx.Extrinsic.Hash().String(),
+			Requires:  []string{},
+			Provides:  []string{},
+		}
+
+		if tx.Validity != nil {
+			detail.Priority = tx.Validity.Priority
+			detail.Longevity = tx.Validity.Longevity
+			detail.Propagate = tx.Validity.Propagate
+			detail.Requires = tagsToHex(tx.Validity.Requires)
+			detail.Provides = tagsToHex(tx.Validity.Provides)
+		}
+
+		resp[idx] = detail
+	}
+
+	*res = PendingExtrinsicsDetailedResponse(resp)
+	return nil
+}
+
+func tagsToHex(tags [][]byte) []string {
+	hex := make([]string, len(tags))
+	for i, tag := range tags {
+		hex[i] = common.BytesToHex(tag)
+	}
+	return hex
+}
+
 // RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
 func (cm *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest, res *RemoveExtrinsicsResponse) error {
 	return nil
@@ -2,6 +2,7 @@ package modules
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"reflect"
 	"testing"
@@ -74,6 +75,67 @@ func TestAuthorModule_Pending(t *testing.T) {
 	}
 }
 
+func TestAuthorModule_PendingInOrder(t *testing.T) {
+	txQueue := state.NewTransactionState()
+	auth := NewAuthorModule(nil, nil, nil, txQueue)
+
+	low := &transaction.ValidTransaction{
+		Extrinsic: types.NewExtrinsic([]byte("low")),
+		Validity:  &transaction.Validity{Priority: 1},
+	}
+	high := &transaction.ValidTransaction{
+		Extrinsic: types.NewExtrinsic([]byte("high")),
+		Validity:  &transaction.Validity{Priority: 9},
+	}
+
+	_, err := txQueue.Push(low)
+	require.NoError(t, err)
+	txQueue.AddToPool(high)
+
+	res := new(PendingExtrinsicsResponse)
+	err = auth.PendingExtrinsics(nil, nil, res)
+	require.NoError(t, err)
+
+	expected := PendingExtrinsicsResponse{common.BytesToHex(high.Extrinsic), common.BytesToHex(low.Extrinsic)}
+	require.Equal(t, expected, *res)
+}
+
+func TestAuthorModule_PendingDetailed(t *testing.T) {
+	txQueue := state.NewTransactionState()
+	auth := NewAuthorModule(nil, nil, nil, txQueue)
+
+	vtx := &transaction.ValidTransaction{
+		Extrinsic: types.NewExtrinsic(testExt),
+		Validity: transaction.NewValidity(
+			5,
+			[][]byte{{0x01, 0x02}},
+			[][]byte{{0x03}, {0x04, 0x05}},
+			64,
+			true,
+		),
+	}
+
+	_, err := txQueue.Push(vtx)
+	require.NoError(t, err)
+
+	res := new(PendingExtrinsicsDetailedResponse)
+	err = auth.PendingExtrinsicsDetailed(nil, nil, res)
+	require.NoError(t, err)
+
+	expected := PendingExtrinsicsDetailedResponse{
+		{
+			Extrinsic: common.BytesToHex(vtx.Extrinsic),
+			Hash:      vtx.Extrinsic.Hash().String(),
+			Priority:  5,
+			Longevity: 64,
+			Requires:  []string{"0x0102"},
+			Provides:  []string{"0x03", "0x0405"},
+			Propagate: true,
+		},
+	}
+	require.Equal(t, expected, *res)
+}
+
 func TestAuthorModule_SubmitExtrinsic(t *testing.T) {
 	t.Skip()
 	// setup auth module
@@ -301,3 +363,11 @@ func setupAuthModule(t *testing.T, txq *state.TransactionState) *AuthorModule {
 type mockNetwork struct{}
 
 func (n *mockNetwork) SendMessage(_ network.NotificationsMessage) {}
+
+func (n *mockNetwork) Health() common.Health {
+	return common.Health{}
+}
+
+func (n *mockNetwork) SyncTarget() (*big.Int, common.Hash) {
+	return nil, common.Hash{}
+}
@@ -17,11 +17,13 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"regexp"
 
+	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
@@ -144,9 +146,13 @@ func (cm *ChainModule) GetFinalizedHead(r *http.Request, req *EmptyRequest, res
 	return nil
 }
 
-// GetFinalizedHeadByRound returns the hash of the block finalised at the given round and setID
+// GetFinalizedHeadByRound returns the hash of the block finalised at the given round and setID.
+// It returns an error if no block was finalised in that round.
 func (cm *ChainModule) GetFinalizedHeadByRound(r *http.Request, req *ChainFinalizedHeadRequest, res *ChainHashResponse) error {
 	h, err := cm.blockAPI.GetFinalizedHash(req.Round, req.SetID)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return fmt.Errorf("no block finalised in round %d with set ID %d", req.Round, req.SetID)
+	}
 	if err != nil {
 		return err
 	}
@@ -155,6 +161,16 @@ func (cm *ChainModule) GetFinalizedHeadByRound(r *http.Request, req *ChainFinali
 	return nil
 }
 
+// GetFinalizedHeader returns the header of the most recently finalised block
+func (cm *ChainModule) GetFinalizedHeader(r *http.Request, req *EmptyRequest, res *ChainBlockHeaderResponse) error {
+	h, err := cm.blockAPI.GetFinalizedHash(0, 0)
+	if err != nil {
+		return err
+	}
+
+	return cm.GetHeader(r, &ChainHashRequest{Bhash: &h}, res)
+}
+
 //GetHeader Get header of a relay chain block. If no block hash is provided, the latest block header will be returned.
 func (cm *ChainModule) GetHeader(r *http.Request, req *ChainHashRequest, res *ChainBlockHeaderResponse) error {
 	hash := cm.hashLookup(req)
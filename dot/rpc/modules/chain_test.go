@@ -275,6 +275,68 @@ func TestChainGetFinalizedHeadByRound(t *testing.T) {
 	require.Equal(t, common.BytesToHex(testhash[:]), res)
 }
 
+func TestChainGetFinalizedHeadByRound_History(t *testing.T) {
+	state := newTestStateService(t)
+	svc := NewChainModule(state.Block)
+
+	finalized := map[uint64]common.Hash{
+		1: {1},
+		2: {2},
+	}
+	for round, hash := range finalized {
+		err := state.Block.SetFinalizedHash(hash, round, 1)
+		require.NoError(t, err)
+	}
+
+	for round, hash := range finalized {
+		var res ChainHashResponse
+		err := svc.GetFinalizedHeadByRound(nil, &ChainFinalizedHeadRequest{round, 1}, &res)
+		require.NoError(t, err)
+		require.Equal(t, hash.String(), res)
+	}
+
+	// round 1 was never finalised with set ID 0
+	var res ChainHashResponse
+	err := svc.GetFinalizedHeadByRound(nil, &ChainFinalizedHeadRequest{1, 0}, &res)
+	require.EqualError(t, err, "no block finalised in round 1 with set ID 0")
+
+	// round 3 hasn't been reached yet
+	err = svc.GetFinalizedHeadByRound(nil, &ChainFinalizedHeadRequest{3, 1}, &res)
+	require.Error(t, err)
+}
+
+func TestChainGetFinalizedHeader(t *testing.T) {
+	state := newTestStateService(t)
+	svc := NewChainModule(state.Block)
+
+	best, err := state.Block.BestBlockHeader()
+	require.NoError(t, err)
+	expected, err := HeaderToJSON(*best)
+	require.NoError(t, err)
+
+	err = state.Block.SetFinalizedHash(best.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	var hashRes ChainHashResponse
+	err = svc.GetFinalizedHead(nil, &EmptyRequest{}, &hashRes)
+	require.NoError(t, err)
+	require.Equal(t, best.Hash().String(), hashRes)
+
+	// the finalised head can be passed to chain_getHeader
+	finalized, err := common.HexToHash(hashRes.(string))
+	require.NoError(t, err)
+
+	var res ChainBlockHeaderResponse
+	err = svc.GetHeader(nil, &ChainHashRequest{Bhash: &finalized}, &res)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	res = ChainBlockHeaderResponse{}
+	err = svc.GetFinalizedHeader(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 var gen, genTrie, genesisHeader = newTestGenesisWithTrieAndHeader()
 
 func newTestStateService(t *testing.T) *state.Service {
@@ -0,0 +1,98 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package modules
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// errPageFull is returned by the iteration callback once a page of keys is complete
+var errPageFull = errors.New("page full")
+
+// ChildStateModule is an RPC module providing access to child storage
+type ChildStateModule struct {
+	storageAPI StorageAPI
+}
+
+// NewChildStateModule creates a new ChildState rpc module.
+func NewChildStateModule(storage StorageAPI) *ChildStateModule {
+	return &ChildStateModule{
+		storageAPI: storage,
+	}
+}
+
+// ChildStateKeysPagedRequest holds the child storage key, the key prefix, the maximum number of keys to return, the key
+// after which to start and an optional block hash
+type ChildStateKeysPagedRequest struct {
+	ChildStorageKey string
+	Prefix          string
+	Qty             uint32
+	AfterKey        string
+	Block           *common.Hash
+}
+
+// GetKeysPaged returns up to Qty keys with the given prefix in the child trie at the given child storage key,
+// starting after AfterKey. If no block hash is provided, the best block's state is used.
+func (cs *ChildStateModule) GetKeysPaged(r *http.Request, req *ChildStateKeysPagedRequest, res *StateKeysResponse) error {
+	*res = StateKeysResponse{}
+
+	child, err := childTrie(cs.storageAPI, req.ChildStorageKey, req.Block)
+	if err != nil || child == nil {
+		return err
+	}
+
+	prefix := []byte{}
+	if req.Prefix != "" {
+		prefix, err = common.HexToBytes(req.Prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	var afterKey []byte
+	if req.AfterKey != "" {
+		afterKey, err = common.HexToBytes(req.AfterKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Qty == 0 {
+		return nil
+	}
+
+	// the trie is iterated in lexicographic order, so all keys after afterKey are returned in order
+	err = child.Iterate(func(key, _ []byte) error {
+		if !bytes.HasPrefix(key, prefix) || (afterKey != nil && bytes.Compare(key, afterKey) <= 0) {
+			return nil
+		}
+
+		*res = append(*res, common.BytesToHex(key))
+		if uint32(len(*res)) >= req.Qty {
+			return errPageFull
+		}
+		return nil
+	})
+	if errors.Is(err, errPageFull) {
+		return nil
+	}
+	return err
+}
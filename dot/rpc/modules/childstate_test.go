@@ -0,0 +1,128 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package modules
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChildStateModule(t *testing.T, keyToChild []byte, childKeys [][]byte) (*ChildStateModule, common.Hash) {
+	chain := newTestStateService(t)
+
+	ts, err := chain.Storage.TrieState(nil)
+	require.NoError(t, err)
+
+	err = ts.SetChild(keyToChild, trie.NewEmptyTrie())
+	require.NoError(t, err)
+	for _, k := range childKeys {
+		err = ts.SetChildStorage(keyToChild, k, []byte("value"))
+		require.NoError(t, err)
+	}
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+	err = chain.Storage.StoreTrie(ts)
+	require.NoError(t, err)
+
+	best, err := chain.Block.BestBlockHeader()
+	require.NoError(t, err)
+
+	header := &types.Header{
+		ParentHash: best.Hash(),
+		Number:     big.NewInt(0).Add(best.Number, big.NewInt(1)),
+		StateRoot:  root,
+	}
+	err = chain.Block.AddBlock(&types.Block{
+		Header: header,
+		Body:   types.NewBody([]byte{}),
+	})
+	require.NoError(t, err)
+
+	return NewChildStateModule(chain.Storage), header.Hash()
+}
+
+func TestChildStateModule_GetKeysPaged(t *testing.T) {
+	keyToChild := []byte(":child_storage_key")
+
+	var childKeys [][]byte
+	var expected []string
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf(":key_%03d", i))
+		childKeys = append(childKeys, key)
+		expected = append(expected, common.BytesToHex(key))
+	}
+	childKeys = append(childKeys, []byte(":other"))
+	sort.Strings(expected)
+
+	cs, hash := setupChildStateModule(t, keyToChild, childKeys)
+
+	var paged []string
+	afterKey := ""
+	for {
+		req := &ChildStateKeysPagedRequest{
+			ChildStorageKey: common.BytesToHex(keyToChild),
+			Prefix:          common.BytesToHex([]byte(":key_")),
+			Qty:             30,
+			AfterKey:        afterKey,
+			Block:           &hash,
+		}
+		var res StateKeysResponse
+		err := cs.GetKeysPaged(nil, req, &res)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res), 30)
+
+		if len(res) == 0 {
+			break
+		}
+		paged = append(paged, res...)
+		afterKey = res[len(res)-1]
+	}
+	require.Equal(t, expected, paged)
+
+	// without a block hash the best block's state is used, and the child storage key may be prefixed
+	req := &ChildStateKeysPagedRequest{
+		ChildStorageKey: common.BytesToHex(append(trie.ChildStorageKeyPrefix, keyToChild...)),
+		Qty:             200,
+	}
+	var res StateKeysResponse
+	err := cs.GetKeysPaged(nil, req, &res)
+	require.NoError(t, err)
+	require.Len(t, res, 101)
+	require.Equal(t, common.BytesToHex([]byte(":other")), res[100])
+}
+
+func TestChildStateModule_GetKeysPaged_Absent(t *testing.T) {
+	cs, hash := setupChildStateModule(t, []byte(":child_storage_key"), nil)
+
+	req := &ChildStateKeysPagedRequest{
+		ChildStorageKey: common.BytesToHex([]byte(":not_exist")),
+		Qty:             10,
+		Block:           &hash,
+	}
+	var res StateKeysResponse
+	err := cs.GetKeysPaged(nil, req, &res)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
@@ -17,11 +17,24 @@
 package modules
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/address"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/scale"
+	gscale "github.com/centrifuge/go-substrate-rpc-client/v2/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v2/types"
 )
 
 var blockProducerStoppedMsg = "babe service stopped"
@@ -29,17 +42,57 @@ var blockProducerStartedMsg = "babe service started"
 var networkStoppedMsg = "network service stopped"
 var networkStartedMsg = "network service started"
 
+// undecodable is reported in place of a section of an extrinsic that could not be decoded
+var undecodable = "undecodable"
+
+// devChainID is the id of the only chain on which FundAccount may modify the state
+const devChainID = "dev"
+
+// fundAccountBalance is the free balance FundAccount gives an account, 10^24
+var fundAccountBalance = new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+
+// accountInfoLength is the length of a scale encoded types.AccountInfo
+const accountInfoLength = 72
+
 // DevModule is an RPC module that provides developer endpoints
 type DevModule struct {
 	networkAPI       NetworkAPI
 	blockProducerAPI BlockProducerAPI
+	coreAPI          CoreAPI
+	storageAPI       StorageAPI
+	systemAPI        SystemAPI
+	blockAPI         BlockAPI
+}
+
+// DecodeExtrinsicResponse holds the decoded sections of an extrinsic. Sections that could not be
+// decoded are set to "undecodable".
+type DecodeExtrinsicResponse struct {
+	Version   uint8  `json:"version"`
+	Signed    bool   `json:"signed"`
+	Signer    string `json:"signer,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+	Tip       string `json:"tip,omitempty"`
+	CallIndex string `json:"callIndex"`
+	Module    string `json:"module"`
+	Call      string `json:"call"`
+	Args      string `json:"args"`
+}
+
+// SetSlotRequest holds the slot number to advance block production to
+type SetSlotRequest struct {
+	Slot uint64
 }
 
 // NewDevModule creates a new Dev module.
-func NewDevModule(bp BlockProducerAPI, net NetworkAPI) *DevModule {
+func NewDevModule(bp BlockProducerAPI, net NetworkAPI, core CoreAPI, storage StorageAPI, system SystemAPI,
+	block BlockAPI) *DevModule {
 	return &DevModule{
 		networkAPI:       net,
 		blockProducerAPI: bp,
+		coreAPI:          core,
+		storageAPI:       storage,
+		systemAPI:        system,
+		blockAPI:         block,
 	}
 }
 
@@ -89,9 +142,282 @@ func (m *DevModule) EpochLength(r *http.Request, req *EmptyRequest, res *string)
 	return err
 }
 
+// SetSlot Dev RPC to advance the BABE slot to the given slot number and build a block for it, if the
+// node can claim the slot. It returns the slot number. It is only available on the dev chain.
+func (m *DevModule) SetSlot(r *http.Request, req *SetSlotRequest, res *string) error {
+	if m.systemAPI == nil || m.systemAPI.ChainID() != devChainID {
+		return fmt.Errorf("slots can only be set on the %s chain", devChainID)
+	}
+
+	if m.blockProducerAPI == nil {
+		return errors.New("not a block producer")
+	}
+
+	if err := m.blockProducerAPI.SetSlot(req.Slot); err != nil {
+		return err
+	}
+
+	*res = uint64ToHex(req.Slot)
+	return nil
+}
+
+// BlockTree Dev RPC to return the block tree in Graphviz DOT format, with each block labelled with its number
+// and short hash, and the latest finalised and best blocks highlighted
+func (m *DevModule) BlockTree(r *http.Request, req *EmptyRequest, res *string) error {
+	if m.blockAPI == nil {
+		return errors.New("no block state")
+	}
+
+	dot, err := m.blockAPI.BlocktreeAsDOT()
+	if err != nil {
+		return err
+	}
+
+	*res = dot
+	return nil
+}
+
+// KeystoreSummary Dev RPC to return the name, key type and number of keys of each of the node's keystores
+func (m *DevModule) KeystoreSummary(r *http.Request, req *EmptyRequest, res *[]keystore.Summary) error {
+	if m.coreAPI == nil {
+		return errors.New("no core service")
+	}
+
+	*res = m.coreAPI.KeystoreSummary()
+	return nil
+}
+
+// FundAccount Dev RPC to set the free balance of the given SS58 account to a large value in the
+// state of the best block. The modified state is stored under a new state root, which is returned,
+// and the next block built on the best block is built on it. It is only available on the dev chain.
+func (m *DevModule) FundAccount(r *http.Request, req *StringRequest, res *string) error {
+	if m.systemAPI == nil || m.systemAPI.ChainID() != devChainID {
+		return fmt.Errorf("accounts can only be funded on the %s chain", devChainID)
+	}
+
+	if m.storageAPI == nil {
+		return errors.New("no storage service")
+	}
+
+	if m.blockProducerAPI == nil {
+		return errors.New("not a block producer")
+	}
+
+	if m.blockAPI == nil {
+		return errors.New("no block service")
+	}
+
+	if req == nil || req.String == "" {
+		return errors.New("account address must be provided")
+	}
+
+	pub, _, err := address.SS58Decode(req.String)
+	if err != nil {
+		return err
+	}
+
+	if len(pub) != 32 {
+		return fmt.Errorf("invalid account public key length %d", len(pub))
+	}
+
+	hash, err := common.Blake2b128(pub)
+	if err != nil {
+		return err
+	}
+
+	key := append(runtime.SystemAccountPrefix(), hash...)
+	key = append(key, pub...)
+
+	best, err := m.blockAPI.GetHeader(m.blockAPI.BestBlockHash())
+	if err != nil {
+		return err
+	}
+
+	// build on any state already set for the next block, so that earlier funding isn't lost
+	parentRoot := m.blockProducerAPI.ParentStateRoot(best)
+	ts, err := m.storageAPI.TrieState(&parentRoot)
+	if err != nil {
+		return err
+	}
+
+	// keep the nonce and other balances of an existing account
+	info := &types.AccountInfo{}
+	if enc := ts.Get(key); len(enc) > 0 {
+		if info, err = decodeAccountInfo(enc); err != nil {
+			return err
+		}
+	}
+
+	info.Data.Free = *common.Uint128FromBigInt(fundAccountBalance)
+
+	enc, err := scale.Encode(info)
+	if err != nil {
+		return err
+	}
+
+	ts.Set(key, enc)
+
+	if err = m.storageAPI.StoreTrie(ts); err != nil {
+		return err
+	}
+
+	root := ts.MustRoot()
+	if err = m.blockProducerAPI.SetParentStateRoot(best.Hash(), root); err != nil {
+		return err
+	}
+
+	*res = root.String()
+	return nil
+}
+
+// DecodeExtrinsic Dev RPC to decode a hex encoded extrinsic into its call index, signer, nonce and tip,
+// using the runtime metadata to name the call
+func (m *DevModule) DecodeExtrinsic(r *http.Request, req *StringRequest, res *DecodeExtrinsicResponse) error {
+	if req == nil || req.String == "" {
+		return errors.New("extrinsic must be provided")
+	}
+
+	enc, err := common.HexToBytes(req.String)
+	if err != nil {
+		return err
+	}
+
+	*res = DecodeExtrinsicResponse{
+		CallIndex: undecodable,
+		Module:    undecodable,
+		Call:      undecodable,
+		Args:      undecodable,
+	}
+
+	// extrinsics are encoded as a length prefixed byte array
+	ext, err := scale.Decode(enc, []byte{})
+	if err != nil {
+		return nil
+	}
+
+	buf := bytes.NewReader(ext.([]byte))
+	version, err := common.ReadByte(buf)
+	if err != nil {
+		return nil
+	}
+
+	res.Version = version & 0x7f
+	res.Signed = version&0x80 != 0
+
+	if res.Signed {
+		var sig ctypes.ExtrinsicSignatureV4
+		if err = gscale.NewDecoder(buf).Decode(&sig); err != nil {
+			res.Signer, res.Nonce, res.Tip = undecodable, undecodable, undecodable
+			return nil
+		}
+
+		res.Signer = signerToString(sig.Signer)
+		nonce, tip := big.Int(sig.Nonce), big.Int(sig.Tip)
+		res.Nonce, res.Tip = nonce.String(), tip.String()
+	}
+
+	call, err := ioutil.ReadAll(buf)
+	if err != nil || len(call) < 2 {
+		return nil
+	}
+
+	res.CallIndex = common.BytesToHex(call[:2])
+	res.Args = common.BytesToHex(call[2:])
+
+	module, fn, err := m.callName(ctypes.CallIndex{SectionIndex: call[0], MethodIndex: call[1]})
+	if err != nil {
+		return nil
+	}
+
+	res.Module, res.Call = module, fn
+	return nil
+}
+
+// callName returns the module and function names of the given call index from the latest runtime metadata
+func (m *DevModule) callName(idx ctypes.CallIndex) (string, string, error) {
+	if m.coreAPI == nil {
+		return "", "", errors.New("no core service")
+	}
+
+	rawMeta, err := m.coreAPI.GetMetadata(nil)
+	if err != nil {
+		return "", "", err
+	}
+	sdMeta, err := scale.Decode(rawMeta, []byte{})
+	if err != nil {
+		return "", "", err
+	}
+	var metadata ctypes.Metadata
+	err = ctypes.DecodeFromBytes(sdMeta.([]byte), &metadata)
+	if err != nil {
+		return "", "", err
+	}
+
+	var modules []ctypes.ModuleMetadataV10
+	switch {
+	case metadata.IsMetadataV12:
+		for _, mod := range metadata.AsMetadataV12.Modules {
+			if mod.HasCalls && mod.Index == idx.SectionIndex && int(idx.MethodIndex) < len(mod.Calls) {
+				return string(mod.Name), string(mod.Calls[idx.MethodIndex].Name), nil
+			}
+		}
+	case metadata.IsMetadataV11:
+		modules = metadata.AsMetadataV11.Modules
+	case metadata.IsMetadataV10:
+		modules = metadata.AsMetadataV10.Modules
+	}
+
+	// prior to v12 the module index is the position of the module amongst modules with calls
+	mi := uint8(0)
+	for _, mod := range modules {
+		if !mod.HasCalls {
+			continue
+		}
+
+		if mi == idx.SectionIndex && int(idx.MethodIndex) < len(mod.Calls) {
+			return string(mod.Name), string(mod.Calls[idx.MethodIndex].Name), nil
+		}
+		mi++
+	}
+
+	return "", "", fmt.Errorf("call index %v not found in metadata", idx)
+}
+
+// signerToString returns an extrinsic signer as an SS58 address, or as an account index
+func signerToString(signer ctypes.Address) string {
+	if signer.IsAccountIndex {
+		return fmt.Sprintf("%d", signer.AsAccountIndex)
+	}
+
+	pub, err := sr25519.NewPublicKey(signer.AsAccountID[:])
+	if err != nil {
+		return undecodable
+	}
+
+	return string(crypto.PublicKeyToAddress(pub))
+}
+
 // uint64ToHex converts a uint64 to a hexed string
 func uint64ToHex(input uint64) string {
 	buffer := make([]byte, 8)
 	binary.LittleEndian.PutUint64(buffer, input)
 	return common.BytesToHex(buffer)
 }
+
+// decodeAccountInfo decodes a scale encoded types.AccountInfo. The reflection based decoder cannot set
+// the unexported fields of common.Uint128, so the fixed layout is read directly.
+func decodeAccountInfo(enc []byte) (*types.AccountInfo, error) {
+	if len(enc) != accountInfoLength {
+		return nil, fmt.Errorf("failed to decode account info: invalid length %d", len(enc))
+	}
+
+	info := &types.AccountInfo{
+		Nonce:    binary.LittleEndian.Uint32(enc[0:4]),
+		RefCount: binary.LittleEndian.Uint32(enc[4:8]),
+	}
+	info.Data.Free = *common.Uint128FromLEBytes(enc[8:24])
+	info.Data.Reserved = *common.Uint128FromLEBytes(enc[24:40])
+	info.Data.MiscFrozen = *common.Uint128FromLEBytes(enc[40:56])
+	info.Data.FreeFrozen = *common.Uint128FromLEBytes(enc[56:72])
+	return info, nil
+}
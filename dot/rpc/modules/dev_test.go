@@ -2,15 +2,22 @@ package modules
 
 import (
 	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	log "github.com/ChainSafe/log15"
@@ -60,7 +67,7 @@ func newBABEService(t *testing.T) *babe.Service {
 
 func TestDevControl_Babe(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil, nil, nil, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"babe", "stop"}, &res)
@@ -76,7 +83,7 @@ func TestDevControl_Babe(t *testing.T) {
 
 func TestDevControl_Network(t *testing.T) {
 	net := newNetworkService(t)
-	m := NewDevModule(nil, net)
+	m := NewDevModule(nil, net, nil, nil, nil, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"network", "stop"}, &res)
@@ -92,7 +99,7 @@ func TestDevControl_Network(t *testing.T) {
 
 func TestDevControl_SlotDuration(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil, nil, nil, nil)
 
 	slotDurationSource := m.blockProducerAPI.SlotDuration()
 
@@ -106,7 +113,7 @@ func TestDevControl_SlotDuration(t *testing.T) {
 
 func TestDevControl_EpochLength(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil, nil, nil, nil)
 
 	epochLengthSource := m.blockProducerAPI.EpochLength()
 
@@ -117,3 +124,224 @@ func TestDevControl_EpochLength(t *testing.T) {
 	epochLengthFetched := binary.LittleEndian.Uint64(common.MustHexToBytes(res))
 	require.Equal(t, epochLengthSource, epochLengthFetched)
 }
+
+// extrinsic for transfer signed by alice, nonce 4 (created with polkadot.js/api test_transaction)
+var testSignedTransferExt = "0x2d0284ffd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d018c35943da8a04f06a36db9fadc7b2f02ccdef38dd89f88835c0af16b5fce816b117d8073aca078984d5b81bcf86e89cfa3195e5ec3c457d4282370b854f430850010000600ff90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22e5c0"
+
+func TestDevModule_DecodeExtrinsic(t *testing.T) {
+	m := NewDevModule(nil, nil, nil, nil, nil, nil)
+
+	res := new(DecodeExtrinsicResponse)
+	err := m.DecodeExtrinsic(nil, &StringRequest{String: testSignedTransferExt}, res)
+	require.NoError(t, err)
+
+	require.Equal(t, uint8(4), res.Version)
+	require.True(t, res.Signed)
+	require.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", res.Signer)
+	require.Equal(t, "4", res.Nonce)
+	require.Equal(t, "0", res.Tip)
+	require.Equal(t, "0x0600", res.CallIndex)
+	// without a core service the call can't be named
+	require.Equal(t, undecodable, res.Module)
+	require.Equal(t, undecodable, res.Call)
+}
+
+func TestDevModule_DecodeExtrinsic_Metadata(t *testing.T) {
+	chain := newTestStateService(t)
+	m := NewDevModule(nil, nil, newCoreService(t, chain), nil, nil, nil)
+
+	res := new(DecodeExtrinsicResponse)
+	err := m.DecodeExtrinsic(nil, &StringRequest{String: testSignedTransferExt}, res)
+	require.NoError(t, err)
+	require.Equal(t, "0x0600", res.CallIndex)
+	require.Equal(t, "Balances", res.Module)
+	require.Equal(t, "transfer", res.Call)
+}
+
+func TestDevModule_DecodeExtrinsic_Undecodable(t *testing.T) {
+	m := NewDevModule(nil, nil, nil, nil, nil, nil)
+
+	// signed extrinsic with a truncated signature
+	res := new(DecodeExtrinsicResponse)
+	err := m.DecodeExtrinsic(nil, &StringRequest{String: "0x1084ffd435"}, res)
+	require.NoError(t, err)
+	require.True(t, res.Signed)
+	require.Equal(t, undecodable, res.Signer)
+	require.Equal(t, undecodable, res.CallIndex)
+}
+
+type mockKeystoreCoreAPI struct {
+	CoreAPI
+	ks *keystore.GlobalKeystore
+}
+
+func (m *mockKeystoreCoreAPI) KeystoreSummary() []keystore.Summary {
+	return m.ks.Summary()
+}
+
+func TestDevModule_KeystoreSummary(t *testing.T) {
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	ks := keystore.NewGlobalKeystore()
+	ks.Babe.Insert(kr.Alice())
+	ks.Acco.Insert(kr.Alice())
+	ks.Acco.Insert(kr.Bob())
+
+	m := NewDevModule(nil, nil, &mockKeystoreCoreAPI{ks: ks}, nil, nil, nil)
+
+	var res []keystore.Summary
+	err = m.KeystoreSummary(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	require.Contains(t, res, keystore.Summary{Name: keystore.BabeName, Type: crypto.Sr25519Type, Size: 1})
+	require.Contains(t, res, keystore.Summary{Name: keystore.AccoName, Type: crypto.UnknownType, Size: 2})
+	require.Contains(t, res, keystore.Summary{Name: keystore.GranName, Type: crypto.Ed25519Type, Size: 0})
+
+	m = NewDevModule(nil, nil, nil, nil, nil, nil)
+	err = m.KeystoreSummary(nil, &EmptyRequest{}, &res)
+	require.Error(t, err)
+}
+
+func TestDevModule_FundAccount(t *testing.T) {
+	gen, genTrie, genHeader := newTestGenesisWithTrieAndHeader()
+
+	testDatadirPath, err := ioutil.TempDir("/tmp", "test-datadir-*")
+	require.NoError(t, err)
+	chain := state.NewService(testDatadirPath, log.LvlInfo)
+	chain.UseMemDB()
+	require.NoError(t, chain.Initialise(gen, genHeader, genTrie))
+	require.NoError(t, chain.Start())
+	t.Cleanup(func() {
+		chain.Stop()
+	})
+
+	rtCfg := &wasmer.Config{}
+	rtCfg.Storage, err = rtstorage.NewTrieState(genTrie)
+	require.NoError(t, err)
+	rt, err := wasmer.NewRuntimeFromGenesis(gen, rtCfg)
+	require.NoError(t, err)
+
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	alice := kr.Alice().(*sr25519.Keypair)
+
+	bs, err := babe.NewService(&babe.ServiceConfig{
+		BlockState:           chain.Block,
+		StorageState:         chain.Storage,
+		TransactionState:     chain.Transaction,
+		EpochState:           chain.Epoch,
+		Keypair:              alice,
+		Runtime:              rt,
+		AuthData:             []*types.Authority{{Key: alice.Public().(*sr25519.PublicKey), Weight: 1}},
+		IsDev:                true,
+		Authority:            true,
+		ThresholdNumerator:   1,
+		ThresholdDenominator: 1,
+	})
+	require.NoError(t, err)
+
+	blocks := bs.GetBlockChannel()
+	buildBlock := func(slot uint64) {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- bs.SetSlot(slot)
+		}()
+
+		select {
+		case block := <-blocks:
+			require.NoError(t, <-errCh)
+			require.NoError(t, chain.Block.AddBlock(&block))
+		case <-time.After(10 * time.Second):
+			t.Fatal("did not receive block")
+		}
+	}
+
+	// the first block runs the runtime's storage migrations, which would rewrite the funded account
+	buildBlock(1000)
+
+	sys := &mockSystemAPI{info: testSystemInfo, genData: &genesis.Data{ID: devChainID}}
+	m := NewDevModule(bs, nil, nil, chain.Storage, sys, chain.Block)
+
+	bob := kr.Bob().Public()
+	var res string
+	err = m.FundAccount(nil, &StringRequest{String: string(bob.Address())}, &res)
+	require.NoError(t, err)
+
+	// the funded state is used by the next block built on the best block
+	buildBlock(1001)
+
+	hash, err := common.Blake2b128(bob.Encode())
+	require.NoError(t, err)
+	key := append(runtime.SystemAccountPrefix(), hash...)
+	key = append(key, bob.Encode()...)
+
+	var enc StateStorageResponse
+	sm := NewStateModule(nil, chain.Storage, nil)
+	err = sm.GetStorage(nil, &StateStorageRequest{Key: common.BytesToHex(key)}, &enc)
+	require.NoError(t, err)
+
+	info, err := decodeAccountInfo(common.MustHexToBytes(string(enc)))
+	require.NoError(t, err)
+	require.Equal(t, 0, info.Data.Free.Cmp(common.Uint128FromBigInt(fundAccountBalance)))
+}
+
+func TestDevModule_FundAccount_NotDevChain(t *testing.T) {
+	chain := newTestStateService(t)
+	m := NewDevModule(nil, nil, nil, chain.Storage, newMockSystemAPI(), nil)
+
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	var res string
+	err = m.FundAccount(nil, &StringRequest{String: string(kr.Bob().Public().Address())}, &res)
+	require.EqualError(t, err, "accounts can only be funded on the dev chain")
+
+	m = NewDevModule(nil, nil, nil, chain.Storage, nil, nil)
+	err = m.FundAccount(nil, &StringRequest{String: string(kr.Bob().Public().Address())}, &res)
+	require.Error(t, err)
+
+	sys := &mockSystemAPI{info: testSystemInfo, genData: &genesis.Data{ID: devChainID}}
+	m = NewDevModule(nil, nil, nil, chain.Storage, sys, chain.Block)
+	err = m.FundAccount(nil, &StringRequest{String: string(kr.Bob().Public().Address())}, &res)
+	require.EqualError(t, err, "not a block producer")
+}
+
+func TestDevModule_SetSlot(t *testing.T) {
+	bs := newBABEService(t)
+	m := NewDevModule(bs, nil, nil, nil, newMockSystemAPI(), nil)
+
+	var res string
+	err := m.SetSlot(nil, &SetSlotRequest{Slot: 1}, &res)
+	require.EqualError(t, err, "slots can only be set on the dev chain")
+
+	sys := &mockSystemAPI{info: testSystemInfo, genData: &genesis.Data{ID: devChainID}}
+	m = NewDevModule(bs, nil, nil, nil, sys, nil)
+
+	// the BABE service was not created for the dev chain
+	err = m.SetSlot(nil, &SetSlotRequest{Slot: 1}, &res)
+	require.Equal(t, babe.ErrNotDevChain, err)
+
+	m = NewDevModule(nil, nil, nil, nil, sys, nil)
+	err = m.SetSlot(nil, &SetSlotRequest{Slot: 1}, &res)
+	require.EqualError(t, err, "not a block producer")
+}
+
+func TestDevModule_BlockTree(t *testing.T) {
+	chain := newTestStateService(t)
+	m := NewDevModule(nil, nil, nil, nil, nil, chain.Block)
+
+	var res string
+	err := m.BlockTree(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res, "digraph blocktree {"))
+
+	best, err := chain.Block.BestBlockHeader()
+	require.NoError(t, err)
+	require.Contains(t, res, fmt.Sprintf("[label=\"#%s %s\", style=filled, fillcolor=palegreen]",
+		best.Number, best.Hash().String()[:10]))
+	require.Contains(t, res, fmt.Sprintf("\"%s\" -> \"%s\"", best.ParentHash, best.Hash()))
+
+	m = NewDevModule(nil, nil, nil, nil, nil, nil)
+	err = m.BlockTree(nil, &EmptyRequest{}, &res)
+	require.Error(t, err)
+}
@@ -20,3 +20,6 @@ import "errors"
 
 // ErrSubscriptionTransport error sent when trying to access websocket subscriptions via http
 var ErrSubscriptionTransport = errors.New("subscriptions are not available on this transport")
+
+// ErrPaymentAPINotSupported is returned when the runtime does not implement TransactionPaymentApi_query_info
+var ErrPaymentAPINotSupported = errors.New("runtime does not support TransactionPaymentApi_query_info")
@@ -17,23 +17,44 @@
 package modules
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
 // GrandpaModule init parameters
 type GrandpaModule struct {
-	blockAPI BlockAPI
+	blockAPI   BlockAPI
+	grandpaAPI GrandpaAPI
 }
 
 // NewGrandpaModule creates a new Grandpa rpc module.
-func NewGrandpaModule(api BlockAPI) *GrandpaModule {
+func NewGrandpaModule(api BlockAPI, grandpaAPI GrandpaAPI) *GrandpaModule {
 	return &GrandpaModule{
-		blockAPI: api,
+		blockAPI:   api,
+		grandpaAPI: grandpaAPI,
 	}
 }
 
+// RoundStateVote is a vote for a block in the current round
+type RoundStateVote struct {
+	Hash   string `json:"hash"`
+	Number uint32 `json:"number"`
+}
+
+// RoundStateResponse holds the state of the current grandpa round
+type RoundStateResponse struct {
+	Round       uint64          `json:"round"`
+	SetID       uint64          `json:"setId"`
+	Prevote     *RoundStateVote `json:"prevote"`
+	Precommit   *RoundStateVote `json:"precommit"`
+	Prevotes    int             `json:"prevotes"`
+	Precommits  int             `json:"precommits"`
+	Completable bool            `json:"completable"`
+}
+
 // ProveFinalityRequest request struct
 type ProveFinalityRequest struct {
 	blockHashStart common.Hash
@@ -71,3 +92,45 @@ func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityReques
 
 	return nil
 }
+
+// SubscribeJustifications handled by websocket handler, but this func should remain
+//  here so it's added to rpc_methods list
+func (gm *GrandpaModule) SubscribeJustifications(r *http.Request, req *EmptyRequest, res *string) error {
+	return ErrSubscriptionTransport
+}
+
+// UnsubscribeJustifications handled by websocket handler, but this func should remain
+//  here so it's added to rpc_methods list
+func (gm *GrandpaModule) UnsubscribeJustifications(r *http.Request, req *EmptyRequest, res *bool) error {
+	return ErrSubscriptionTransport
+}
+
+// RoundState returns the state of the current grandpa round, including our own votes and the number of votes received
+func (gm *GrandpaModule) RoundState(r *http.Request, req *EmptyRequest, res *RoundStateResponse) error {
+	if gm.grandpaAPI == nil {
+		return errors.New("grandpa service not available")
+	}
+
+	rs := gm.grandpaAPI.RoundState()
+	*res = RoundStateResponse{
+		Round:       rs.Round,
+		SetID:       rs.SetID,
+		Prevote:     roundStateVote(rs.Prevote),
+		Precommit:   roundStateVote(rs.Precommit),
+		Prevotes:    rs.Prevotes,
+		Precommits:  rs.Precommits,
+		Completable: rs.Completable,
+	}
+	return nil
+}
+
+func roundStateVote(v *types.GrandpaVote) *RoundStateVote {
+	if v == nil {
+		return nil
+	}
+
+	return &RoundStateVote{
+		Hash:   v.Hash.String(),
+		Number: v.Number,
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGrandpaProveFinality(t *testing.T) {
@@ -33,7 +36,7 @@ func TestGrandpaProveFinality(t *testing.T) {
 		t.Errorf("Fail: bestblock failed")
 	}
 
-	gmSvc := NewGrandpaModule(testStateService.Block)
+	gmSvc := NewGrandpaModule(testStateService.Block, nil)
 
 	testStateService.Block.SetJustification(bestBlock.Header.ParentHash, make([]byte, 10))
 	testStateService.Block.SetJustification(bestBlock.Header.Hash(), make([]byte, 11))
@@ -55,3 +58,49 @@ func TestGrandpaProveFinality(t *testing.T) {
 		t.Errorf("Fail: expected: %+v got: %+v\n", res, &expectedResponse)
 	}
 }
+
+type mockGrandpaAPI struct {
+	roundState *types.GrandpaRoundState
+}
+
+func (m *mockGrandpaAPI) RoundState() *types.GrandpaRoundState {
+	return m.roundState
+}
+
+func TestGrandpaRoundState(t *testing.T) {
+	gmSvc := NewGrandpaModule(nil, nil)
+	err := gmSvc.RoundState(nil, &EmptyRequest{}, new(RoundStateResponse))
+	require.EqualError(t, err, "grandpa service not available")
+
+	vote := &types.GrandpaVote{
+		Hash:   common.Hash{1, 2, 3},
+		Number: 4,
+	}
+	gmSvc = NewGrandpaModule(nil, &mockGrandpaAPI{
+		roundState: &types.GrandpaRoundState{
+			Round:       7,
+			SetID:       1,
+			Prevote:     vote,
+			Prevotes:    9,
+			Precommits:  5,
+			Completable: true,
+		},
+	})
+
+	res := new(RoundStateResponse)
+	err = gmSvc.RoundState(nil, &EmptyRequest{}, res)
+	require.NoError(t, err)
+
+	expected := &RoundStateResponse{
+		Round: 7,
+		SetID: 1,
+		Prevote: &RoundStateVote{
+			Hash:   vote.Hash.String(),
+			Number: 4,
+		},
+		Prevotes:    9,
+		Precommits:  5,
+		Completable: true,
+	}
+	require.Equal(t, expected, res)
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package modules
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+// PaymentModule is an RPC module providing access to the runtime transaction payment API
+type PaymentModule struct {
+	coreAPI CoreAPI
+}
+
+// NewPaymentModule creates a new Payment rpc module.
+func NewPaymentModule(api CoreAPI) *PaymentModule {
+	return &PaymentModule{
+		coreAPI: api,
+	}
+}
+
+// PaymentQueryInfoRequest holds the hex encoded extrinsic and an optional block hash
+type PaymentQueryInfoRequest struct {
+	Ext  string
+	Hash *common.Hash
+}
+
+// PaymentQueryInfoResponse holds the fee information of an extrinsic
+type PaymentQueryInfoResponse struct {
+	Weight     uint64 `json:"weight"`
+	Class      string `json:"class"`
+	PartialFee string `json:"partialFee"`
+}
+
+// QueryInfo returns the weight, dispatch class and partial fee of the given extrinsic at the given block.
+// If no block hash is provided, the latest state is used.
+func (pm *PaymentModule) QueryInfo(r *http.Request, req *PaymentQueryInfoRequest, res *PaymentQueryInfoResponse) error {
+	ext, err := common.HexToBytes(req.Ext)
+	if err != nil {
+		return err
+	}
+
+	info, err := pm.coreAPI.QueryInfo(ext, req.Hash)
+	if errors.Is(err, runtime.ErrExportFunctionNotFound) {
+		return ErrPaymentAPINotSupported
+	}
+	if err != nil {
+		return err
+	}
+
+	*res = PaymentQueryInfoResponse{
+		Weight:     info.Weight,
+		Class:      info.Class.String(),
+		PartialFee: new(big.Int).SetBytes(info.PartialFee.ToBEBytes()).String(),
+	}
+	return nil
+}
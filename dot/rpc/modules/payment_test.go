@@ -0,0 +1,77 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package modules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPaymentCoreAPI struct {
+	CoreAPI
+	info *types.RuntimeDispatchInfo
+	err  error
+}
+
+func (m *mockPaymentCoreAPI) QueryInfo(ext types.Extrinsic, bhash *common.Hash) (*types.RuntimeDispatchInfo, error) {
+	return m.info, m.err
+}
+
+func TestPaymentModule_QueryInfo(t *testing.T) {
+	api := &mockPaymentCoreAPI{
+		info: &types.RuntimeDispatchInfo{
+			Weight:     195000000,
+			Class:      types.NormalDispatch,
+			PartialFee: &common.Uint128{Upper: 1, Lower: 5},
+		},
+	}
+
+	pm := NewPaymentModule(api)
+	req := &PaymentQueryInfoRequest{
+		Ext: "0x0102",
+	}
+
+	res := new(PaymentQueryInfoResponse)
+	err := pm.QueryInfo(nil, req, res)
+	require.NoError(t, err)
+
+	expected := PaymentQueryInfoResponse{
+		Weight:     195000000,
+		Class:      "normal",
+		PartialFee: "18446744073709551621",
+	}
+	require.Equal(t, expected, *res)
+}
+
+func TestPaymentModule_QueryInfo_NotSupported(t *testing.T) {
+	api := &mockPaymentCoreAPI{
+		err: fmt.Errorf("%w %s", runtime.ErrExportFunctionNotFound, runtime.TransactionPaymentAPIQueryInfo),
+	}
+
+	pm := NewPaymentModule(api)
+	req := &PaymentQueryInfoRequest{
+		Ext: "0x0102",
+	}
+
+	err := pm.QueryInfo(nil, req, new(PaymentQueryInfoResponse))
+	require.Equal(t, ErrPaymentAPINotSupported, err)
+}
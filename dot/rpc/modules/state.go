@@ -17,7 +17,9 @@
 package modules
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -25,19 +27,20 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/scale"
+	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
 // StateCallRequest holds json fields
 type StateCallRequest struct {
 	Method string       `json:"method"`
-	Data   []byte       `json:"data"`
+	Data   string       `json:"data"`
 	Block  *common.Hash `json:"block"`
 }
 
 // StateChildStorageRequest holds json fields
 type StateChildStorageRequest struct {
-	ChildStorageKey []byte       `json:"childStorageKey"`
-	Key             []byte       `json:"key"`
+	ChildStorageKey string       `json:"childStorageKey"`
+	Key             string       `json:"key"`
 	Block           *common.Hash `json:"block"`
 }
 
@@ -93,21 +96,31 @@ type StateStorageQueryRangeRequest struct {
 // StateStorageKeysQuery field to store storage keys
 type StateStorageKeysQuery [][]byte
 
-// StateCallResponse holds json fields
-type StateCallResponse []byte
+// StateCallResponse holds the hex encoded result of a runtime call
+type StateCallResponse string
 
 // StateKeysResponse field to store the state keys
-type StateKeysResponse [][]byte
+type StateKeysResponse []string
 
-// StateStorageDataResponse field to store data response
+// StateStorageDataResponse field to store data response, encoded as null if it's empty
 type StateStorageDataResponse string
 
+// MarshalJSON encodes an empty response, ie. an absent storage entry, as null
+func (r StateStorageDataResponse) MarshalJSON() ([]byte, error) {
+	return marshalNullableString(string(r))
+}
+
 // StateStorageHashResponse is a hash value
 type StateStorageHashResponse string
 
-// StateChildStorageResponse is a hash value
+// StateChildStorageResponse is a hash value, encoded as null if it's empty
 type StateChildStorageResponse string
 
+// MarshalJSON encodes an empty response, ie. the hash of an absent storage entry, as null
+func (r StateChildStorageResponse) MarshalJSON() ([]byte, error) {
+	return marshalNullableString(string(r))
+}
+
 // StateChildStorageSizeResponse is a unint value
 type StateChildStorageSizeResponse uint64
 
@@ -207,37 +220,145 @@ func (sm *StateModule) GetPairs(r *http.Request, req *StatePairRequest, res *Sta
 	return nil
 }
 
-// Call isn't implemented properly yet.
+// Call runs the given runtime method with the hex encoded SCALE arguments using the state at the given block, and
+// returns the hex encoded result. If no block hash is provided, the latest state is used.
 func (sm *StateModule) Call(r *http.Request, req *StateCallRequest, res *StateCallResponse) error {
 	_ = sm.networkAPI
-	_ = sm.storageAPI
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if req.Data != "" {
+		data, err = common.HexToBytes(req.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	ret, err := sm.coreAPI.CallRuntime(req.Method, data, req.Block)
+	if err != nil {
+		return err
+	}
+
+	*res = StateCallResponse(common.BytesToHex(ret))
 	return nil
 }
 
-// GetChildKeys isn't implemented properly yet.
+// GetChildKeys returns the keys with the given prefix in the child trie at the given child storage key, in the
+// state of the given block. If no block hash is provided, the best block's state is used.
 func (sm *StateModule) GetChildKeys(r *http.Request, req *StateChildStorageRequest, res *StateKeysResponse) error {
-	// TODO implement change storage trie so that block hash parameter works (See issue #834)
+	child, err := childTrie(sm.storageAPI, req.ChildStorageKey, req.Block)
+	if err != nil || child == nil {
+		return err
+	}
+
+	prefix, err := common.HexToBytes(req.Key)
+	if err != nil {
+		return err
+	}
+
+	*res = StateKeysResponse{}
+	for _, key := range child.GetKeysWithPrefix(prefix) {
+		*res = append(*res, common.BytesToHex(key))
+	}
 	return nil
 }
 
-// GetChildStorage isn't implemented properly yet.
+// GetChildStorage returns a storage entry of the child trie at the given child storage key, in the state of the
+// given block. If no block hash is provided, the best block's state is used.
 func (sm *StateModule) GetChildStorage(r *http.Request, req *StateChildStorageRequest, res *StateStorageDataResponse) error {
-	// TODO implement change storage trie so that block hash parameter works (See issue #834)
+	value, err := sm.childStorage(req)
+	if err != nil {
+		return err
+	}
+
+	if len(value) > 0 {
+		*res = StateStorageDataResponse(common.BytesToHex(value))
+	}
 	return nil
 }
 
-// GetChildStorageHash isn't implemented properly yet.
+// GetChildStorageHash returns the blake2b hash of a storage entry of the child trie at the given child storage key,
+// in the state of the given block. If no block hash is provided, the best block's state is used.
 func (sm *StateModule) GetChildStorageHash(r *http.Request, req *StateChildStorageRequest, res *StateChildStorageResponse) error {
-	// TODO implement change storage trie so that block hash parameter works (See issue #834)
+	value, err := sm.childStorage(req)
+	if err != nil {
+		return err
+	}
+
+	if len(value) == 0 {
+		return nil
+	}
+
+	hash, err := common.Blake2bHash(value)
+	if err != nil {
+		return err
+	}
+
+	*res = StateChildStorageResponse(hash.String())
 	return nil
 }
 
-// GetChildStorageSize isn't implemented properly yet.
+// GetChildStorageSize returns the size of a storage entry of the child trie at the given child storage key, in the
+// state of the given block. If no block hash is provided, the best block's state is used.
 func (sm *StateModule) GetChildStorageSize(r *http.Request, req *StateChildStorageRequest, res *StateChildStorageSizeResponse) error {
-	// TODO implement change storage trie so that block hash parameter works (See issue #834)
+	value, err := sm.childStorage(req)
+	if err != nil {
+		return err
+	}
+
+	*res = StateChildStorageSizeResponse(len(value))
 	return nil
 }
 
+// childStorage returns the value of the requested child storage entry, or nil if the entry or child trie is absent
+func (sm *StateModule) childStorage(req *StateChildStorageRequest) ([]byte, error) {
+	child, err := childTrie(sm.storageAPI, req.ChildStorageKey, req.Block)
+	if err != nil || child == nil {
+		return nil, err
+	}
+
+	key, err := common.HexToBytes(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return child.Get(key), nil
+}
+
+// childTrie returns the child trie at the given child storage key in the state of the given block, or the best block
+// if it's nil. The key may include the :child_storage:default: prefix. It returns nil if there is no child trie at
+// the key.
+func childTrie(storageAPI StorageAPI, childStorageKey string, block *common.Hash) (*trie.Trie, error) {
+	keyToChild, err := common.HexToBytes(childStorageKey)
+	if err != nil {
+		return nil, err
+	}
+	keyToChild = bytes.TrimPrefix(keyToChild, trie.ChildStorageKeyPrefix)
+
+	var root *common.Hash
+	if block != nil {
+		root, err = storageAPI.GetStateRootFromBlock(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ts, err := storageAPI.TrieState(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetChild only fails if there is no child trie at the key
+	child, err := ts.GetChild(keyToChild)
+	if err != nil {
+		return nil, nil
+	}
+	return child, nil
+}
+
 // GetKeysPaged Returns the keys with prefix with pagination support.
 func (sm *StateModule) GetKeysPaged(r *http.Request, req *StateStorageKeyRequest, res *StateStorageKeysResponse) error {
 	if req.Prefix == "" {
@@ -413,3 +534,11 @@ func ConvertAPIs(in []*runtime.APIItem) []interface{} {
 	}
 	return ret
 }
+
+// marshalNullableString encodes s as a JSON string, or null if it's empty
+func marshalNullableString(s string) ([]byte, error) {
+	if s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s)
+}
@@ -17,6 +17,7 @@ package modules
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/stretchr/testify/require"
 )
 
@@ -355,6 +358,53 @@ func TestStateModule_GetMetadata(t *testing.T) {
 	}
 }
 
+func TestStateModule_Call(t *testing.T) {
+	sm, hash, _ := setupStateModule(t)
+
+	testCases := []struct {
+		name  string
+		block *common.Hash
+	}{
+		{name: "latest"},
+		{name: "block", block: hash},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req := &StateCallRequest{
+				Method: "Core_version",
+				Data:   "0x",
+				Block:  test.block,
+			}
+
+			var res StateCallResponse
+			err := sm.Call(nil, req, &res)
+			require.NoError(t, err)
+
+			enc, err := common.HexToBytes(string(res))
+			require.NoError(t, err)
+
+			version := new(runtime.VersionData)
+			err = version.Decode(enc)
+			require.NoError(t, err)
+			require.Equal(t, []byte("node"), version.SpecName())
+		})
+	}
+}
+
+func TestStateModule_Call_InvalidData(t *testing.T) {
+	sm, _, _ := setupStateModule(t)
+
+	req := &StateCallRequest{
+		Method: "Core_version",
+		Data:   "0xzz",
+	}
+
+	var res StateCallResponse
+	err := sm.Call(nil, req, &res)
+	require.Error(t, err)
+}
+
 func TestStateModule_GetKeysPaged(t *testing.T) {
 	sm, _, stateRootHash := setupStateModule(t)
 
@@ -446,3 +496,115 @@ func setupStateModule(t *testing.T) (*StateModule, *common.Hash, *common.Hash) {
 	core := newCoreService(t, chain)
 	return NewStateModule(net, chain.Storage, core), hash, &sr1
 }
+
+// setupStateModuleWithChildStorage returns a StateModule whose best block's state has a child trie at keyToChild
+// with the entries :child_first and :child_second, along with the hash of the best block
+func setupStateModuleWithChildStorage(t *testing.T, keyToChild []byte) (*StateModule, common.Hash) {
+	chain := newTestStateService(t)
+
+	ts, err := chain.Storage.TrieState(nil)
+	require.NoError(t, err)
+
+	err = ts.SetChild(keyToChild, trie.NewEmptyTrie())
+	require.NoError(t, err)
+	err = ts.SetChildStorage(keyToChild, []byte(":child_first"), []byte("value1"))
+	require.NoError(t, err)
+	err = ts.SetChildStorage(keyToChild, []byte(":child_second"), []byte("value2"))
+	require.NoError(t, err)
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+	err = chain.Storage.StoreTrie(ts)
+	require.NoError(t, err)
+
+	best, err := chain.Block.BestBlockHeader()
+	require.NoError(t, err)
+
+	header := &types.Header{
+		ParentHash: best.Hash(),
+		Number:     big.NewInt(0).Add(best.Number, big.NewInt(1)),
+		StateRoot:  root,
+	}
+	err = chain.Block.AddBlock(&types.Block{
+		Header: header,
+		Body:   types.NewBody([]byte{}),
+	})
+	require.NoError(t, err)
+
+	return NewStateModule(nil, chain.Storage, nil), header.Hash()
+}
+
+func TestStateModule_ChildStorage(t *testing.T) {
+	keyToChild := []byte(":child_storage_key")
+	sm, hash := setupStateModuleWithChildStorage(t, keyToChild)
+
+	childKey := common.BytesToHex(keyToChild)
+	prefixedChildKey := common.BytesToHex(append(trie.ChildStorageKeyPrefix, keyToChild...))
+	first := common.BytesToHex([]byte(":child_first"))
+
+	firstHash, err := common.Blake2bHash([]byte("value1"))
+	require.NoError(t, err)
+
+	for _, req := range []*StateChildStorageRequest{
+		{ChildStorageKey: childKey, Key: first},
+		{ChildStorageKey: childKey, Key: first, Block: &hash},
+		{ChildStorageKey: prefixedChildKey, Key: first},
+	} {
+		var value StateStorageDataResponse
+		err = sm.GetChildStorage(nil, req, &value)
+		require.NoError(t, err)
+		require.Equal(t, StateStorageDataResponse(common.BytesToHex([]byte("value1"))), value)
+
+		var valueHash StateChildStorageResponse
+		err = sm.GetChildStorageHash(nil, req, &valueHash)
+		require.NoError(t, err)
+		require.Equal(t, StateChildStorageResponse(firstHash.String()), valueHash)
+
+		var size StateChildStorageSizeResponse
+		err = sm.GetChildStorageSize(nil, req, &size)
+		require.NoError(t, err)
+		require.Equal(t, StateChildStorageSizeResponse(len("value1")), size)
+	}
+
+	var keys StateKeysResponse
+	err = sm.GetChildKeys(nil, &StateChildStorageRequest{
+		ChildStorageKey: childKey,
+		Key:             common.BytesToHex([]byte(":child")),
+		Block:           &hash,
+	}, &keys)
+	require.NoError(t, err)
+	require.Equal(t, StateKeysResponse{first, common.BytesToHex([]byte(":child_second"))}, keys)
+}
+
+func TestStateModule_ChildStorage_Absent(t *testing.T) {
+	keyToChild := []byte(":child_storage_key")
+	sm, _ := setupStateModuleWithChildStorage(t, keyToChild)
+
+	// an absent entry of an existing child trie, and an entry of an absent child trie
+	for _, req := range []*StateChildStorageRequest{
+		{ChildStorageKey: common.BytesToHex(keyToChild), Key: common.BytesToHex([]byte(":absent"))},
+		{ChildStorageKey: common.BytesToHex([]byte(":absent")), Key: common.BytesToHex([]byte(":child_first"))},
+	} {
+		var value StateStorageDataResponse
+		err := sm.GetChildStorage(nil, req, &value)
+		require.NoError(t, err)
+		enc, err := json.Marshal(value)
+		require.NoError(t, err)
+		require.Equal(t, "null", string(enc))
+
+		var valueHash StateChildStorageResponse
+		err = sm.GetChildStorageHash(nil, req, &valueHash)
+		require.NoError(t, err)
+		enc, err = json.Marshal(valueHash)
+		require.NoError(t, err)
+		require.Equal(t, "null", string(enc))
+	}
+
+	var keys StateKeysResponse
+	err := sm.GetChildKeys(nil, &StateChildStorageRequest{
+		ChildStorageKey: common.BytesToHex([]byte(":absent")),
+		Key:             "0x",
+	}, &keys)
+	require.NoError(t, err)
+	require.Nil(t, keys)
+}
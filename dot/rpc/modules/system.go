@@ -19,8 +19,6 @@ package modules
 import (
 	"bytes"
 	"errors"
-	"fmt"
-	"math/big"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -158,73 +156,77 @@ func (sm *SystemModule) NodeRoles(r *http.Request, req *EmptyRequest, res *[]int
 	return nil
 }
 
-// AccountNextIndex Returns the next valid index (aka. nonce) for given account.
+// AccountNextIndex Returns the next valid index (aka. nonce) for given account. The index is the on-chain
+// account nonce plus the number of pending transactions signed by the account.
 func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, res *U64Response) error {
 	if req == nil || req.String == "" {
 		return errors.New("account address must be valid")
 	}
 	addressPubKey := crypto.PublicAddressToByteArray(common.Address(req.String))
 
-	// check pending transactions for extrinsics singed by addressPubKey
-	pending := sm.txStateAPI.Pending()
-	nonce := uint64(0)
-	found := false
-	for _, v := range pending {
+	nonce, err := sm.accountNonce(addressPubKey)
+	if err != nil {
+		return err
+	}
+
+	// count pending transactions for extrinsics signed by addressPubKey
+	for _, v := range sm.txStateAPI.Pending() {
 		var ext ctypes.Extrinsic
 		err := ctypes.DecodeFromBytes(v.Extrinsic[1:], &ext)
 		if err != nil {
 			return err
 		}
-		extSigner, err := common.HexToBytes(fmt.Sprintf("0x%x", ext.Signature.Signer.AsAccountID))
-		if err != nil {
-			return err
+
+		if !ext.IsSigned() {
+			continue
 		}
-		if bytes.Equal(extSigner, addressPubKey) {
-			found = true
-			sigNonce := big.Int(ext.Signature.Nonce)
-			if sigNonce.Uint64() > nonce {
-				nonce = sigNonce.Uint64()
-			}
+
+		if bytes.Equal(ext.Signature.Signer.AsAccountID[:], addressPubKey) {
+			nonce++
 		}
 	}
 
-	if found {
-		*res = U64Response(nonce)
-		return nil
-	}
+	*res = U64Response(nonce)
+	return nil
+}
 
-	// no extrinsic signed by request found in pending transactions, so look in storage
+// accountNonce returns the nonce stored in System.Account for the given public key in the latest state,
+// or 0 if the account has no on-chain entry
+func (sm *SystemModule) accountNonce(pubKey []byte) (uint64, error) {
 	// get metadata to build storage storageKey
 	rawMeta, err := sm.coreAPI.GetMetadata(nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	sdMeta, err := scale.Decode(rawMeta, []byte{})
 	if err != nil {
-		return err
+		return 0, err
 	}
 	var metadata ctypes.Metadata
 	err = ctypes.DecodeFromBytes(sdMeta.([]byte), &metadata)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	storageKey, err := ctypes.CreateStorageKey(&metadata, "System", "Account", addressPubKey, nil)
+	storageKey, err := ctypes.CreateStorageKey(&metadata, "System", "Account", pubKey, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	accountRaw, err := sm.storageAPI.GetStorage(nil, storageKey)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	if len(accountRaw) == 0 {
+		return 0, nil
 	}
 
 	var accountInfo ctypes.AccountInfo
 	err = ctypes.DecodeFromBytes(accountRaw, &accountInfo)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	*res = U64Response(accountInfo.Nonce)
-	return nil
+	return uint64(accountInfo.Nonce), nil
 }
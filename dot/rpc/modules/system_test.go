@@ -17,13 +17,16 @@
 package modules
 
 import (
+	"io/ioutil"
 	"math/big"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -37,6 +40,7 @@ var (
 		Peers:           0,
 		IsSyncing:       true,
 		ShouldHavePeers: true,
+		SyncStatus:      common.SyncStatusNoPeers,
 	}
 	testPeers = []common.PeerInfo{}
 )
@@ -95,6 +99,12 @@ func (s *mockBlockState) GetHashByNumber(_ *big.Int) (common.Hash, error) {
 	return common.Hash{}, nil
 }
 
+func (s *mockBlockState) RegisterFinalizedChannel(_ chan<- *types.FinalisationInfo) (byte, error) {
+	return 0, nil
+}
+
+func (s *mockBlockState) UnregisterFinalizedChannel(_ byte) {}
+
 type mockTransactionHandler struct{}
 
 func (h *mockTransactionHandler) HandleTransactionMessage(_ *network.TransactionMessage) error {
@@ -225,6 +235,10 @@ func (api *mockSystemAPI) ChainType() string {
 	return api.genData.ChainType
 }
 
+func (api *mockSystemAPI) ChainID() string {
+	return api.genData.ID
+}
+
 func TestSystemModule_Chain(t *testing.T) {
 	sys := NewSystemModule(nil, newMockSystemAPI(), nil, nil, nil)
 
@@ -273,6 +287,51 @@ func TestSystemModule_Properties(t *testing.T) {
 	require.Equal(t, expected, *res)
 }
 
+func TestSystemModule_GenesisProperties(t *testing.T) {
+	genesisJSON := `{
+		"name": "Custom Testnet",
+		"id": "custom",
+		"chainType": "Local",
+		"properties": {"ss58Format": 42, "tokenDecimals": 12, "tokenSymbol": "CUST", "custom": "value"},
+		"genesis": {"raw": {"top": {}}}
+	}`
+
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	err := ioutil.WriteFile(genesisPath, []byte(genesisJSON), 0600)
+	require.NoError(t, err)
+
+	gen, err := genesis.NewGenesisFromJSONRaw(genesisPath)
+	require.NoError(t, err)
+
+	si := &types.SystemInfo{
+		SystemName:    "gossamer",
+		SystemVersion: "0.3.2",
+	}
+	sys := NewSystemModule(nil, system.NewService(si, gen.GenesisData()), nil, nil, nil)
+
+	chain := new(string)
+	err = sys.Chain(nil, nil, chain)
+	require.NoError(t, err)
+	require.Equal(t, "Custom Testnet", *chain)
+
+	version := new(string)
+	err = sys.Version(nil, nil, version)
+	require.NoError(t, err)
+	require.Equal(t, "0.3.2", *version)
+
+	expected := map[string]interface{}{
+		"ss58Format":    uint8(42),
+		"tokenDecimals": uint32(12),
+		"tokenSymbol":   "CUST",
+		"custom":        "value",
+	}
+
+	props := new(interface{})
+	err = sys.Properties(nil, nil, props)
+	require.NoError(t, err)
+	require.Equal(t, expected, *props)
+}
+
 func TestSystemModule_AccountNextIndex_StoragePending(t *testing.T) {
 	sys := setupSystemModule(t)
 	expectedStored := U64Response(uint64(3))
@@ -335,6 +394,19 @@ func TestSystemModule_AccountNextIndex_Pending(t *testing.T) {
 	require.Equal(t, expectedPending, *res)
 }
 
+func TestSystemModule_AccountNextIndex_NoAccount(t *testing.T) {
+	sys := setupSystemModule(t)
+
+	res := new(U64Response)
+	req := StringRequest{
+		// bob has no System.Account entry in the test state
+		String: "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+	}
+	err := sys.AccountNextIndex(nil, &req, res)
+	require.NoError(t, err)
+	require.Equal(t, U64Response(0), *res)
+}
+
 func setupSystemModule(t *testing.T) *SystemModule {
 	// setup service
 	net := newNetworkService(t)
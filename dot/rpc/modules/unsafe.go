@@ -0,0 +1,43 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package modules
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// unsafeMethods are the RPC methods that can be expensive to serve or that should otherwise not be exposed to
+// external requests by default
+var unsafeMethods = map[string]struct{}{
+	"state_call":      {},
+	"dev_fundAccount": {},
+	"dev_setSlot":     {},
+}
+
+// IsUnsafeMethod returns true if the given RPC method is classified as unsafe. The method may be given either in its
+// JSON-RPC form, eg. state_call, or in the service form used by the RPC server, eg. state.Call
+func IsUnsafeMethod(method string) bool {
+	if parts := strings.SplitN(method, ".", 2); len(parts) == 2 {
+		r, n := utf8.DecodeRuneInString(parts[1])
+		method = parts[0] + "_" + string(unicode.ToLower(r)) + parts[1][n:]
+	}
+
+	_, unsafe := unsafeMethods[method]
+	return unsafe
+}
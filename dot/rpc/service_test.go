@@ -35,7 +35,7 @@ func TestNewService(t *testing.T) {
 func TestService_Methods(t *testing.T) {
 	qtySystemMethods := 10
 	qtyRPCMethods := 1
-	qtyAuthorMethods := 7
+	qtyAuthorMethods := 8
 
 	rpcService := NewService()
 	sysMod := modules.NewSystemModule(nil, nil, nil, nil, nil)
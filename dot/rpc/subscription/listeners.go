@@ -138,6 +138,42 @@ func (l *BlockFinalizedListener) Listen() {
 	}
 }
 
+// JustificationListener to handle listening for the justifications of finalised blocks
+type JustificationListener struct {
+	channel  chan *types.FinalisationInfo
+	wsconn   WSConnAPI
+	blockAPI modules.BlockAPI
+	chanID   byte
+	subID    uint
+}
+
+// GrandpaJustifications method name
+const GrandpaJustifications = "grandpa_justifications"
+
+// Listen implementation of Listen interface to listen for finalised blocks and send their justifications
+func (l *JustificationListener) Listen() {
+	for info := range l.channel {
+		// a block is notified once for its round and set ID, and once as the latest finalised block with round 0
+		// and set ID 0. only the latter is sent, so that each justification is sent once.
+		if info == nil || info.Header == nil || info.Round != 0 || info.SetID != 0 {
+			continue
+		}
+
+		l.sendJustification(info.Header.Hash())
+	}
+}
+
+// sendJustification sends the SCALE-encoded justification of the given block, if there is one
+func (l *JustificationListener) sendJustification(hash common.Hash) {
+	justification, err := l.blockAPI.GetJustification(hash)
+	if err != nil {
+		logger.Debug("failed to get justification", "block", hash, "error", err)
+		return
+	}
+
+	l.wsconn.safeSend(newSubscriptionResponse(GrandpaJustifications, l.subID, common.BytesToHex(justification)))
+}
+
 // ExtrinsicSubmitListener to handle listening for extrinsic events
 type ExtrinsicSubmitListener struct {
 	wsconn    WSConnAPI
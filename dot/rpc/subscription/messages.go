@@ -59,3 +59,18 @@ func newSubscriptionResponseJSON(subID uint, reqID float64) ResponseJSON {
 		ID:      reqID,
 	}
 }
+
+// BooleanResponseJSON for json responses with a boolean result, such as unsubscribe calls
+type BooleanResponseJSON struct {
+	Jsonrpc string  `json:"jsonrpc"`
+	Result  bool    `json:"result"`
+	ID      float64 `json:"id"`
+}
+
+func newBooleanResponseJSON(value bool, reqID float64) BooleanResponseJSON {
+	return BooleanResponseJSON{
+		Jsonrpc: "2.0",
+		Result:  value,
+		ID:      reqID,
+	}
+}
@@ -23,12 +23,14 @@ import (
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 	"github.com/gorilla/websocket"
 )
@@ -49,6 +51,8 @@ type WSConn struct {
 	CoreAPI            modules.CoreAPI
 	TxStateAPI         modules.TransactionStateAPI
 	RPCHost            string
+	MessageLimiter     *utils.TokenBucket // limits the rate of messages received on the connection, if set
+	RejectUnsafe       bool               // refuse calls to unsafe RPC methods
 }
 
 //HandleComm handles messages received on websocket connections
@@ -64,6 +68,13 @@ func (c *WSConn) HandleComm() {
 		// determine if request is for subscribe method type
 		var msg map[string]interface{}
 		err = json.Unmarshal(mbytes, &msg)
+
+		if c.MessageLimiter != nil && !c.MessageLimiter.Allow() {
+			reqid, _ := msg["id"].(float64)
+			c.safeSendError(reqid, big.NewInt(-32005), "Message rate limit exceeded")
+			continue
+		}
+
 		if err != nil {
 			logger.Warn("websocket failed to unmarshal request message", "error", err)
 			c.safeSendError(0, big.NewInt(-32600), "Invalid request")
@@ -103,6 +114,19 @@ func (c *WSConn) HandleComm() {
 					continue
 				}
 				c.startListener(rvl)
+			case "grandpa_subscribeJustifications":
+				jl, err5 := c.initJustificationListener(reqid)
+				if err5 != nil {
+					logger.Warn("failed to create justification listener", "error", err5)
+					continue
+				}
+				c.startListener(jl)
+			case "grandpa_unsubscribeJustifications":
+				err6 := c.unsubscribeJustifications(reqid, params)
+				if err6 != nil {
+					logger.Warn("failed to unsubscribe from justifications", "error", err6)
+					c.safeSendError(reqid, nil, err6.Error())
+				}
 			}
 			continue
 		}
@@ -119,6 +143,12 @@ func (c *WSConn) HandleComm() {
 			continue
 		}
 
+		if c.RejectUnsafe && modules.IsUnsafeMethod(fmt.Sprintf("%s", method)) {
+			reqid, _ := msg["id"].(float64)
+			c.safeSendError(reqid, big.NewInt(-32601), "RPC call is unsafe to be called externally")
+			continue
+		}
+
 		// handle non-subscribe calls
 		client := &http.Client{}
 		buf := &bytes.Buffer{}
@@ -201,7 +231,7 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (u
 
 	c.StorageAPI.RegisterStorageObserver(myObs)
 
-	c.Subscriptions[myObs.id] = myObs
+	c.setSubscription(myObs.id, myObs)
 
 	initRes := newSubscriptionResponseJSON(myObs.id, reqID)
 	c.safeSend(initRes)
@@ -226,7 +256,7 @@ func (c *WSConn) initBlockListener(reqID float64) (uint, error) {
 	bl.ChanID = chanID
 	c.qtyListeners++
 	bl.subID = c.qtyListeners
-	c.Subscriptions[bl.subID] = bl
+	c.setSubscription(bl.subID, bl)
 	c.BlockSubChannels[bl.subID] = chanID
 	initRes := newSubscriptionResponseJSON(bl.subID, reqID)
 	c.safeSend(initRes)
@@ -251,7 +281,7 @@ func (c *WSConn) initBlockFinalizedListener(reqID float64) (uint, error) {
 	bfl.chanID = chanID
 	c.qtyListeners++
 	bfl.subID = c.qtyListeners
-	c.Subscriptions[bfl.subID] = bfl
+	c.setSubscription(bfl.subID, bfl)
 	c.BlockSubChannels[bfl.subID] = chanID
 	initRes := newSubscriptionResponseJSON(bfl.subID, reqID)
 	c.safeSend(initRes)
@@ -259,6 +289,85 @@ func (c *WSConn) initBlockFinalizedListener(reqID float64) (uint, error) {
 	return bfl.subID, nil
 }
 
+func (c *WSConn) initJustificationListener(reqID float64) (uint, error) {
+	if c.BlockAPI == nil {
+		c.safeSendError(reqID, nil, "error BlockAPI not set")
+		return 0, fmt.Errorf("error BlockAPI not set")
+	}
+
+	jl := &JustificationListener{
+		channel:  make(chan *types.FinalisationInfo),
+		wsconn:   c,
+		blockAPI: c.BlockAPI,
+	}
+
+	chanID, err := c.BlockAPI.RegisterFinalizedChannel(jl.channel)
+	if err != nil {
+		return 0, err
+	}
+	jl.chanID = chanID
+	c.qtyListeners++
+	jl.subID = c.qtyListeners
+	c.setSubscription(jl.subID, jl)
+	c.BlockSubChannels[jl.subID] = chanID
+	c.safeSend(newSubscriptionResponseJSON(jl.subID, reqID))
+
+	// send the justification of the current finalised head, if it has one
+	head, err := c.BlockAPI.GetFinalizedHash(0, 0)
+	if err == nil {
+		jl.sendJustification(head)
+	}
+
+	return jl.subID, nil
+}
+
+func (c *WSConn) unsubscribeJustifications(reqID float64, params interface{}) error {
+	subID, err := subscriptionIDFromParams(params)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	jl, ok := c.Subscriptions[subID].(*JustificationListener)
+	if ok {
+		delete(c.Subscriptions, subID)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		c.safeSend(newBooleanResponseJSON(false, reqID))
+		return nil
+	}
+
+	c.BlockAPI.UnregisterFinalizedChannel(jl.chanID)
+	close(jl.channel)
+	delete(c.BlockSubChannels, subID)
+
+	c.safeSend(newBooleanResponseJSON(true, reqID))
+	return nil
+}
+
+// subscriptionIDFromParams returns the subscription ID passed as the first parameter of an unsubscribe call
+func subscriptionIDFromParams(params interface{}) (uint, error) {
+	pA, ok := params.([]interface{})
+	if !ok || len(pA) == 0 {
+		return 0, fmt.Errorf("missing subscription ID")
+	}
+
+	switch id := pA[0].(type) {
+	case float64:
+		return uint(id), nil
+	case string:
+		subID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid subscription ID: %s", id)
+		}
+		return uint(subID), nil
+	default:
+		return 0, fmt.Errorf("invalid subscription ID type")
+	}
+}
+
 func (c *WSConn) initExtrinsicWatch(reqID float64, params interface{}) (uint, error) {
 	pA := params.([]interface{})
 	extBytes, err := common.HexToBytes(pA[0].(string))
@@ -289,7 +398,7 @@ func (c *WSConn) initExtrinsicWatch(reqID float64, params interface{}) (uint, er
 
 	c.qtyListeners++
 	esl.subID = c.qtyListeners
-	c.Subscriptions[esl.subID] = esl
+	c.setSubscription(esl.subID, esl)
 	c.BlockSubChannels[esl.subID] = esl.importedChanID
 
 	err = c.CoreAPI.HandleSubmittedExtrinsic(extBytes)
@@ -318,7 +427,7 @@ func (c *WSConn) initRuntimeVersionListener(reqID float64) (uint, error) {
 	}
 	c.qtyListeners++
 	rvl.subID = c.qtyListeners
-	c.Subscriptions[rvl.subID] = rvl
+	c.setSubscription(rvl.subID, rvl)
 	initRes := newSubscriptionResponseJSON(rvl.subID, reqID)
 	c.safeSend(initRes)
 
@@ -363,6 +472,52 @@ type ErrorMessageJSON struct {
 	Message string   `json:"message"`
 }
 
+// Close closes the websocket connection, then unregisters and closes the notification channels of its subscriptions
+func (c *WSConn) Close() error {
+	c.mu.Lock()
+	err := c.Wsconn.Close()
+	subs := c.Subscriptions
+	c.Subscriptions = make(map[uint]Listener)
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		switch v := sub.(type) {
+		case *StorageObserver:
+			c.StorageAPI.UnregisterStorageObserver(v)
+		case *BlockListener:
+			c.BlockAPI.UnregisterImportedChannel(v.ChanID)
+			close(v.Channel)
+		case *BlockFinalizedListener:
+			c.BlockAPI.UnregisterFinalizedChannel(v.chanID)
+			close(v.channel)
+		case *JustificationListener:
+			c.BlockAPI.UnregisterFinalizedChannel(v.chanID)
+			close(v.channel)
+		case *ExtrinsicSubmitListener:
+			c.BlockAPI.UnregisterImportedChannel(v.importedChanID)
+			close(v.importedChan)
+			c.BlockAPI.UnregisterFinalizedChannel(v.finalisedChanID)
+			close(v.finalisedChan)
+		}
+	}
+
+	return err
+}
+
+// setSubscription stores the listener of a subscription, the subscriptions are guarded by the connection's
+// mutex as they are removed when the connection is closed
+func (c *WSConn) setSubscription(subID uint, l Listener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Subscriptions[subID] = l
+}
+
 func (c *WSConn) startListener(lid uint) {
-	go c.Subscriptions[lid].Listen()
+	c.mu.Lock()
+	l, ok := c.Subscriptions[lid]
+	c.mu.Unlock()
+
+	if ok {
+		go l.Listen()
+	}
 }
@@ -4,7 +4,9 @@ import (
 	"log"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +14,13 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/log15"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
@@ -174,7 +182,193 @@ func TestWSConn_HandleComm(t *testing.T) {
 	res, err = wsconn.initExtrinsicWatch(0, []interface{}{"0x26aa"})
 	require.NoError(t, err)
 	require.Equal(t, uint(8), res)
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","result":8,"id":0}`+"\n"), msg)
+
+	// test unsafe methods are refused
+	wsconn.RejectUnsafe = true
+	c.WriteMessage(websocket.TextMessage, []byte(`{
+		"jsonrpc": "2.0",
+		"method": "state_call",
+		"params": ["Core_version", "0x"],
+		"id": 9
+	}`))
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"RPC call is unsafe to be called externally"},"id":9}`+"\n"), msg)
+}
+
+func TestWSConn_MessageLimiter(t *testing.T) {
+	conn := &WSConn{
+		Subscriptions:    make(map[uint]Listener),
+		BlockSubChannels: make(map[uint]byte),
+		MessageLimiter:   utils.NewTokenBucket(0.001, 1),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil) //nolint
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		conn.Wsconn = c
+		conn.HandleComm()
+	}))
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil) //nolint
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"state_subscribeStorage","params":[],"id":1}`))
+	require.NoError(t, err)
+	_, msg, err := c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","error":{"code":null,"message":"error StorageAPI not set"},"id":1}`+"\n"), msg)
+
+	// the rate limit error is sent with the id of the rejected request
+	err = c.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"state_subscribeStorage","params":[],"id":7}`))
+	require.NoError(t, err)
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Message rate limit exceeded"},"id":7}`+"\n"), msg)
+}
+
+func TestWSConn_CloseWhileSubscribing(t *testing.T) {
+	conn := &WSConn{
+		Subscriptions:    make(map[uint]Listener),
+		BlockSubChannels: make(map[uint]byte),
+		BlockAPI:         new(MockBlockAPI),
+	}
+	ready := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil) //nolint
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		conn.Wsconn = c
+		close(ready)
+		conn.HandleComm()
+	}))
+	defer server.Close()
 
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil) //nolint
+	require.NoError(t, err)
+	defer c.Close()
+
+	<-ready
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_, _ = conn.initBlockListener(1)
+		}
+	}()
+
+	err = conn.Close()
+	require.NoError(t, err)
+	<-done
+}
+
+func TestWSConn_JustificationSubscription(t *testing.T) {
+	gen, err := genesis.NewGenesisFromJSONRaw("../../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+	genTrie, err := genesis.NewTrieFromGenesis(gen)
+	require.NoError(t, err)
+	genesisHeader, err := types.NewHeader(common.NewHash([]byte{0}), genTrie.MustHash(), trie.EmptyHash,
+		big.NewInt(0), types.Digest{})
+	require.NoError(t, err)
+
+	stateSrvc, err := state.NewInMemoryService(log15.LvlInfo)
+	require.NoError(t, err)
+	err = stateSrvc.Initialise(gen, genesisHeader, genTrie)
+	require.NoError(t, err)
+	err = stateSrvc.Start()
+	require.NoError(t, err)
+	defer stateSrvc.Stop()
+
+	genesisJustification := []byte{1, 2, 3}
+	err = stateSrvc.Block.SetJustification(genesisHeader.Hash(), genesisJustification)
+	require.NoError(t, err)
+
+	conn := &WSConn{
+		Subscriptions:    make(map[uint]Listener),
+		BlockSubChannels: make(map[uint]byte),
+		BlockAPI:         stateSrvc.Block,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil) //nolint
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		conn.Wsconn = c
+		conn.HandleComm()
+	}))
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil) //nolint
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"grandpa_subscribeJustifications","params":[],"id":1}`))
+	require.NoError(t, err)
+	_, msg, err := c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","result":1,"id":1}`+"\n"), msg)
+
+	// the justification of the current finalised head is sent on subscribe
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","method":"grandpa_justifications","params":{"result":"0x010203","subscription":1}}`+"\n"), msg) //nolint
+
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: genesisHeader.Hash(),
+			Number:     big.NewInt(1),
+			Digest:     types.Digest{},
+		},
+		Body: types.NewBody([]byte{}),
+	}
+	err = stateSrvc.Block.AddBlock(block)
+	require.NoError(t, err)
+	err = stateSrvc.Block.SetJustification(block.Header.Hash(), []byte{4, 5, 6})
+	require.NoError(t, err)
+
+	// like GRANDPA, finalise the block for its round and set ID, and then as the latest finalised block. the
+	// justification is only sent once.
+	err = stateSrvc.Block.SetFinalizedHash(block.Header.Hash(), 1, 0)
+	require.NoError(t, err)
+	err = stateSrvc.Block.SetFinalizedHash(block.Header.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","method":"grandpa_justifications","params":{"result":"0x040506","subscription":1}}`+"\n"), msg) //nolint
+	time.Sleep(100 * time.Millisecond)
+
+	err = c.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"grandpa_unsubscribeJustifications","params":[1],"id":2}`))
+	require.NoError(t, err)
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","result":true,"id":2}`+"\n"), msg)
+
+	// the listener has been removed, so unsubscribing again fails
+	err = c.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"grandpa_unsubscribeJustifications","params":[1],"id":3}`))
+	require.NoError(t, err)
+	_, msg, err = c.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"jsonrpc":"2.0","result":false,"id":3}`+"\n"), msg)
 }
 
 type MockStorageAPI struct{}
@@ -199,6 +393,12 @@ func (m *MockStorageAPI) GetStateRootFromBlock(bhash *common.Hash) (*common.Hash
 func (m *MockStorageAPI) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error) {
 	return nil, nil
 }
+func (m *MockStorageAPI) TrieState(root *common.Hash) (*rtstorage.TrieState, error) {
+	return nil, nil
+}
+func (m *MockStorageAPI) StoreTrie(ts *rtstorage.TrieState) error {
+	return nil
+}
 
 type MockBlockAPI struct {
 }
@@ -240,9 +440,13 @@ func (m *MockBlockAPI) SubChain(start, end common.Hash) ([]common.Hash, error) {
 	return make([]common.Hash, 0), nil
 }
 
+func (m *MockBlockAPI) BlocktreeAsDOT() (string, error) {
+	return "", nil
+}
+
 type MockCoreAPI struct{}
 
-func (m *MockCoreAPI) InsertKey(kp crypto.Keypair) {}
+func (m *MockCoreAPI) InsertKey(kp crypto.Keypair) error { return nil }
 
 func (m *MockCoreAPI) HasKey(pubKeyStr string, keyType string) (bool, error) {
 	return false, nil
@@ -263,3 +467,15 @@ func (m *MockCoreAPI) HandleSubmittedExtrinsic(types.Extrinsic) error {
 func (m *MockCoreAPI) GetMetadata(bhash *common.Hash) ([]byte, error) {
 	return nil, nil
 }
+
+func (m *MockCoreAPI) KeystoreSummary() []keystore.Summary {
+	return nil
+}
+
+func (m *MockCoreAPI) QueryInfo(ext types.Extrinsic, bhash *common.Hash) (*types.RuntimeDispatchInfo, error) {
+	return nil, nil
+}
+
+func (m *MockCoreAPI) CallRuntime(method string, data []byte, bhash *common.Hash) ([]byte, error) {
+	return nil, nil
+}
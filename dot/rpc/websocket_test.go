@@ -28,6 +28,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
@@ -137,6 +138,10 @@ func (m *MockBlockAPI) SubChain(start, end common.Hash) ([]common.Hash, error) {
 	return make([]common.Hash, 0), nil
 }
 
+func (m *MockBlockAPI) BlocktreeAsDOT() (string, error) {
+	return "", nil
+}
+
 type MockStorageAPI struct{}
 
 func (m *MockStorageAPI) GetStorage(_ *common.Hash, key []byte) ([]byte, error) {
@@ -159,3 +164,9 @@ func (m *MockStorageAPI) GetStateRootFromBlock(bhash *common.Hash) (*common.Hash
 func (m *MockStorageAPI) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error) {
 	return nil, nil
 }
+func (m *MockStorageAPI) TrieState(root *common.Hash) (*rtstorage.TrieState, error) {
+	return nil, nil
+}
+func (m *MockStorageAPI) StoreTrie(ts *rtstorage.TrieState) error {
+	return nil
+}
@@ -38,9 +38,11 @@ import (
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
-	"github.com/ChainSafe/gossamer/lib/runtime/life"
-	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
-	"github.com/ChainSafe/gossamer/lib/runtime/wasmtime"
+
+	// register the wasm interpreters
+	_ "github.com/ChainSafe/gossamer/lib/runtime/life"
+	_ "github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	_ "github.com/ChainSafe/gossamer/lib/runtime/wasmtime"
 )
 
 func newInMemoryDB(path string) (chaindb.Database, error) {
@@ -63,6 +65,21 @@ func createStateService(cfg *Config) (*state.Service, error) {
 		return nil, fmt.Errorf("failed to start state service: %s", err)
 	}
 
+	if cfg.State.FutureTxMaxAge != 0 {
+		stateSrvc.Transaction.SetFutureTransactionMaxAge(cfg.State.FutureTxMaxAge)
+	}
+
+	if cfg.State.FinalizationGracePeriod != 0 {
+		stateSrvc.Block.SetFinalizationGracePeriod(cfg.State.FinalizationGracePeriod)
+	}
+
+	if cfg.State.TrieCacheSize != 0 {
+		err = stateSrvc.Storage.SetNodeCacheSize(cfg.State.TrieCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set trie cache size: %w", err)
+		}
+	}
+
 	if cfg.State.Rewind != 0 {
 		err = stateSrvc.Rewind(int64(cfg.State.Rewind))
 		if err != nil {
@@ -91,6 +108,10 @@ func createRuntime(cfg *Config, st *state.Service, ks *keystore.GlobalKeystore,
 		"interpreter", cfg.Core.WasmInterpreter,
 	)
 
+	if !runtime.IsRegisteredInterpreter(cfg.Core.WasmInterpreter) {
+		return nil, fmt.Errorf("%w: %s", runtime.ErrUnknownInterpreter, cfg.Core.WasmInterpreter)
+	}
+
 	// load runtime code from trie
 	code, err := st.Storage.GetStorage(nil, []byte(":code"))
 	if err != nil {
@@ -112,56 +133,21 @@ func createRuntime(cfg *Config, st *state.Service, ks *keystore.GlobalKeystore,
 		PersistentStorage: chaindb.NewTable(st.DB(), "offlinestorage"),
 	}
 
-	var rt runtime.Instance
-	switch cfg.Core.WasmInterpreter {
-	case wasmer.Name:
-		rtCfg := &wasmer.Config{
-			Imports: wasmer.ImportsNodeRuntime,
-		}
-		rtCfg.Storage = ts
-		rtCfg.Keystore = ks
-		rtCfg.LogLvl = cfg.Log.RuntimeLvl
-		rtCfg.NodeStorage = ns
-		rtCfg.Network = net
-		rtCfg.Role = cfg.Core.Roles
-
-		// create runtime executor
-		rt, err = wasmer.NewInstance(code, rtCfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create runtime executor: %s", err)
-		}
-	case wasmtime.Name:
-		rtCfg := &wasmtime.Config{
-			Imports: wasmtime.ImportNodeRuntime,
-		}
-		rtCfg.Storage = ts
-		rtCfg.Keystore = ks
-		rtCfg.LogLvl = cfg.Log.RuntimeLvl
-		rtCfg.NodeStorage = ns
-		rtCfg.Network = net
-		rtCfg.Role = cfg.Core.Roles
-
-		// create runtime executor
-		rt, err = wasmtime.NewInstance(code, rtCfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create runtime executor: %s", err)
-		}
-	case life.Name:
-		rtCfg := &life.Config{
-			Resolver: new(life.Resolver),
-		}
-		rtCfg.Storage = ts
-		rtCfg.Keystore = ks
-		rtCfg.LogLvl = cfg.Log.RuntimeLvl
-		rtCfg.NodeStorage = ns
-		rtCfg.Network = net
-		rtCfg.Role = cfg.Core.Roles
-
-		// create runtime executor
-		rt, err = life.NewInstance(code, rtCfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create runtime executor: %s", err)
-		}
+	rtCfg := &runtime.InstanceConfig{
+		Storage:        ts,
+		Keystore:       ks,
+		LogLvl:         cfg.Log.RuntimeLvl,
+		NodeStorage:    ns,
+		Network:        net,
+		Role:           cfg.Core.Roles,
+		FuelLimit:      cfg.Core.WasmFuelLimit,
+		MaxMemoryPages: cfg.Core.WasmMaxMemoryPages,
+	}
+
+	// create runtime executor using the registered interpreter
+	rt, err := runtime.NewInstance(cfg.Core.WasmInterpreter, code, rtCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime executor: %w", err)
 	}
 
 	return rt, nil
@@ -231,7 +217,11 @@ func createCoreService(cfg *Config, bp core.BlockProducer, verifier *babe.Verifi
 		Runtime:          rt,
 		IsBlockProducer:  cfg.Core.BabeAuthority,
 		Verifier:         verifier,
-		Network:          net,
+	}
+
+	// avoid setting a typed nil network, the core service checks if the network is nil
+	if net != nil {
+		coreConfig.Network = net
 	}
 
 	// create new core service
@@ -260,19 +250,26 @@ func createNetworkService(cfg *Config, stateSrvc *state.Service) (*network.Servi
 
 	// network service configuation
 	networkConfig := network.Config{
-		LogLvl:          cfg.Log.NetworkLvl,
-		BlockState:      stateSrvc.Block,
-		BasePath:        cfg.Global.BasePath,
-		Roles:           cfg.Core.Roles,
-		Port:            cfg.Network.Port,
-		Bootnodes:       cfg.Network.Bootnodes,
-		ProtocolID:      cfg.Network.ProtocolID,
-		NoBootstrap:     cfg.Network.NoBootstrap,
-		NoMDNS:          cfg.Network.NoMDNS,
-		MinPeers:        cfg.Network.MinPeers,
-		MaxPeers:        cfg.Network.MaxPeers,
-		PublishMetrics:  cfg.Global.PublishMetrics,
-		PersistentPeers: cfg.Network.PersistentPeers,
+		LogLvl:           cfg.Log.NetworkLvl,
+		BlockState:       stateSrvc.Block,
+		BasePath:         cfg.Global.BasePath,
+		Roles:            cfg.Core.Roles,
+		Port:             cfg.Network.Port,
+		ListenAddresses:  cfg.Network.ListenAddresses,
+		PublicAddresses:  cfg.Network.PublicAddresses,
+		Bootnodes:        cfg.Network.Bootnodes,
+		ProtocolID:       cfg.Network.ProtocolID,
+		NoBootstrap:      cfg.Network.NoBootstrap,
+		NoMDNS:           cfg.Network.NoMDNS,
+		MinPeers:         cfg.Network.MinPeers,
+		MaxPeers:         cfg.Network.MaxPeers,
+		PublishMetrics:   cfg.Global.PublishMetrics,
+		PersistentPeers:  cfg.Network.PersistentPeers,
+		BlockRequestSize: cfg.Network.BlockRequestSize,
+		CompressSync:     cfg.Network.CompressSync,
+
+		PingInterval:         cfg.Network.PingInterval,
+		PingFailureThreshold: cfg.Network.PingFailureThreshold,
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -287,7 +284,7 @@ func createNetworkService(cfg *Config, stateSrvc *state.Service) (*network.Servi
 // RPC Service
 
 // createRPCService creates the RPC service from the provided core configuration
-func createRPCService(cfg *Config, stateSrvc *state.Service, coreSrvc *core.Service, networkSrvc *network.Service, bp modules.BlockProducerAPI, rt runtime.Instance, sysSrvc *system.Service) *rpc.HTTPServer {
+func createRPCService(cfg *Config, stateSrvc *state.Service, coreSrvc *core.Service, networkSrvc *network.Service, bp modules.BlockProducerAPI, fg modules.GrandpaAPI, rt runtime.Instance, sysSrvc *system.Service) *rpc.HTTPServer {
 	logger.Info(
 		"creating rpc service...",
 		"host", cfg.RPC.Host,
@@ -307,6 +304,7 @@ func createRPCService(cfg *Config, stateSrvc *state.Service, coreSrvc *core.Serv
 		NetworkAPI:          networkSrvc,
 		CoreAPI:             coreSrvc,
 		BlockProducerAPI:    bp,
+		GrandpaAPI:          fg,
 		RuntimeAPI:          rt,
 		TransactionQueueAPI: stateSrvc.Transaction,
 		RPCAPI:              rpcService,
@@ -318,6 +316,13 @@ func createRPCService(cfg *Config, stateSrvc *state.Service, coreSrvc *core.Serv
 		WSExternal:          cfg.RPC.WSExternal,
 		WSPort:              cfg.RPC.WSPort,
 		Modules:             cfg.RPC.Modules,
+		MaxRequestSize:      cfg.RPC.MaxRequestSize,
+		RateLimit:           cfg.RPC.RateLimit,
+		RateBurst:           cfg.RPC.RateBurst,
+		WSMessageRate:       cfg.RPC.WSMessageRate,
+		WSMessageBurst:      cfg.RPC.WSMessageBurst,
+		TrustedProxies:      cfg.RPC.TrustedProxies,
+		Unsafe:              cfg.RPC.Unsafe,
 	}
 
 	return rpc.NewHTTPServer(rpcConfig)
@@ -330,6 +335,11 @@ func createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*syst
 	if err != nil {
 		return nil, err
 	}
+
+	if _, err = system.ParseProperties(genesisData.Properties); err != nil {
+		logger.Warn("failed to parse genesis properties, returning them as defined in genesis", "error", err)
+	}
+
 	// TODO: use data from genesisData for SystemInfo once they are in database (See issue #1248)
 	return system.NewService(cfg, genesisData), nil
 }
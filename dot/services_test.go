@@ -17,6 +17,7 @@
 package dot
 
 import (
+	"errors"
 	"flag"
 	"net/url"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/gorilla/websocket"
@@ -90,6 +92,54 @@ func TestCreateCoreService(t *testing.T) {
 	require.NotNil(t, coreSrvc)
 }
 
+type stubInstance struct {
+	runtime.Instance
+	code []byte
+}
+
+func TestCreateRuntime_RegisteredInterpreter(t *testing.T) {
+	runtime.RegisterInterpreter("dot-stub", func(code []byte, _ *runtime.InstanceConfig) (runtime.Instance, error) {
+		return &stubInstance{code: code}, nil
+	})
+
+	cfg := NewTestConfig(t)
+	require.NotNil(t, cfg)
+
+	genFile := NewTestGenesisRawFile(t, cfg)
+	require.NotNil(t, genFile)
+
+	defer utils.RemoveTestDir(t)
+
+	cfg.Core.Roles = types.FullNodeRole
+	cfg.Core.BabeAuthority = false
+	cfg.Core.GrandpaAuthority = false
+	cfg.Core.WasmInterpreter = "dot-stub"
+	cfg.Init.Genesis = genFile.Name()
+
+	err := InitNode(cfg)
+	require.NoError(t, err)
+
+	stateSrvc, err := createStateService(cfg)
+	require.NoError(t, err)
+
+	ks := keystore.NewGlobalKeystore()
+	networkSrvc := &network.Service{}
+
+	rt, err := createRuntime(cfg, stateSrvc, ks, networkSrvc)
+	require.NoError(t, err)
+
+	stub, ok := rt.(*stubInstance)
+	require.True(t, ok)
+
+	code, err := stateSrvc.Storage.GetStorage(nil, []byte(":code"))
+	require.NoError(t, err)
+	require.Equal(t, code, stub.code)
+
+	cfg.Core.WasmInterpreter = "unknown"
+	_, err = createRuntime(cfg, stateSrvc, ks, networkSrvc)
+	require.True(t, errors.Is(err, runtime.ErrUnknownInterpreter))
+}
+
 func TestCreateBlockVerifier(t *testing.T) {
 	cfg := NewTestConfig(t)
 	require.NotNil(t, cfg)
@@ -202,7 +252,7 @@ func TestCreateRPCService(t *testing.T) {
 	sysSrvc, err := createSystemService(&cfg.System, stateSrvc)
 	require.NoError(t, err)
 
-	rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, nil, rt, sysSrvc)
+	rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, nil, nil, rt, sysSrvc)
 	require.NotNil(t, rpcSrvc)
 }
 
@@ -323,7 +373,7 @@ func TestNewWebSocketServer(t *testing.T) {
 	sysSrvc, err := createSystemService(&cfg.System, stateSrvc)
 	require.NoError(t, err)
 
-	rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, nil, rt, sysSrvc)
+	rpcSrvc := createRPCService(cfg, stateSrvc, coreSrvc, networkSrvc, nil, nil, rt, sysSrvc)
 	err = rpcSrvc.Start()
 	require.Nil(t, err)
 
@@ -27,6 +27,11 @@ import (
 	"github.com/ChainSafe/chaindb"
 )
 
+// SchemaVersion is the version of the database schema written by this release. It must be incremented, and a
+// migration added, when the on-disk format changes in a way that databases created by earlier releases can no
+// longer be read.
+const SchemaVersion uint32 = 2
+
 // SetupDatabase will return an instance of database based on basepath
 func SetupDatabase(basepath string) (chaindb.Database, error) {
 	return chaindb.NewBadgerDB(&chaindb.Config{
@@ -61,6 +66,31 @@ func (s *BaseState) LoadNodeGlobalName() (string, error) {
 	return string(nodeName), nil
 }
 
+// StoreSchemaVersion stores the database schema version at SchemaVersionKey
+func (s *BaseState) StoreSchemaVersion(version uint32) error {
+	return s.db.Put(common.SchemaVersionKey, encodeSchemaVersion(version))
+}
+
+// LoadSchemaVersion loads the database schema version stored at SchemaVersionKey
+func (s *BaseState) LoadSchemaVersion() (uint32, error) {
+	data, err := s.db.Get(common.SchemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) != 4 {
+		return 0, fmt.Errorf("invalid schema version length %d", len(data))
+	}
+
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+func encodeSchemaVersion(version uint32) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, version)
+	return buf
+}
+
 // StoreBestBlockHash stores the hash at the BestBlockHashKey
 func (s *BaseState) StoreBestBlockHash(hash common.Hash) error {
 	return s.db.Put(common.BestBlockHashKey, hash[:])
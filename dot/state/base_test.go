@@ -112,3 +112,18 @@ func TestStoreAndLoadBestBlockHash(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, hash, res)
 }
+
+func TestStoreAndLoadSchemaVersion(t *testing.T) {
+	db := NewInMemoryDB(t)
+	base := NewBaseState(db)
+
+	_, err := base.LoadSchemaVersion()
+	require.Error(t, err)
+
+	err = base.StoreSchemaVersion(SchemaVersion)
+	require.NoError(t, err)
+
+	res, err := base.LoadSchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, res)
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -30,6 +31,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var blockPrefix = "block"
@@ -52,6 +54,19 @@ type BlockState struct {
 	finalisedLock sync.RWMutex
 
 	pruneKeyCh chan *types.Header
+
+	// blocks pruned from the blocktree are only deleted once this many more blocks have been finalised
+	finalizationGracePeriod uint64
+	pendingDeletes          []*pendingDelete
+
+	// if set, justifications are verified before they're stored
+	justificationVerifier JustificationVerifier
+}
+
+// pendingDelete is a block pruned from the blocktree that's deleted once the given block number is finalised
+type pendingDelete struct {
+	hash     common.Hash
+	deleteAt uint64
 }
 
 // NewBlockState will create a new BlockState backed by the database located at basePath
@@ -75,6 +90,11 @@ func NewBlockState(db chaindb.Database, bt *blocktree.BlockTree) (*BlockState, e
 	}
 
 	bs.genesisHash = genesisBlock.Header.Hash()
+
+	if err = bs.loadPendingDeletes(); err != nil {
+		return nil, fmt.Errorf("failed to load pending block deletes: %w", err)
+	}
+
 	return bs, nil
 }
 
@@ -134,6 +154,7 @@ var (
 	receiptPrefix       = []byte("rcp") // receiptPrefix + hash -> receipt
 	messageQueuePrefix  = []byte("mqp") // messageQueuePrefix + hash -> message queue
 	justificationPrefix = []byte("jcp") // justificationPrefix + hash -> justification
+	slotHashesPrefix    = []byte("slt") // slotHashesPrefix + slot -> hashes of the blocks claiming the slot
 )
 
 // encodeBlockNumber encodes a block number as big endian uint64
@@ -163,6 +184,11 @@ func arrivalTimeKey(hash common.Hash) []byte {
 	return append(arrivalTimePrefix, hash.ToBytes()...)
 }
 
+// slotHashesKey = slotHashesPrefix + slot (uint64 big endian)
+func slotHashesKey(slot uint64) []byte {
+	return append(slotHashesPrefix, encodeBlockNumber(slot)...)
+}
+
 // finalizedHashKey = hashkey + round + setID (LE encoded)
 func finalizedHashKey(round, setID uint64) []byte {
 	buf := make([]byte, 8)
@@ -179,8 +205,13 @@ func (bs *BlockState) GenesisHash() common.Hash {
 
 // DeleteBlock deletes all instances of the block and its related data in the database
 func (bs *BlockState) DeleteBlock(hash common.Hash) error {
-	if has, _ := bs.HasHeader(hash); has {
-		err := bs.db.Del(headerKey(hash))
+	if header, err := bs.GetHeader(hash); err == nil {
+		err = bs.deleteSlotHash(header)
+		if err != nil {
+			return err
+		}
+
+		err = bs.db.Del(headerKey(hash))
 		if err != nil {
 			return err
 		}
@@ -432,9 +463,141 @@ func (bs *BlockState) SetFinalizedHash(hash common.Hash, round, setID uint64) er
 		}
 	}
 
+	prevHead := bs.bt.DeepestBlockHash()
 	pruned := bs.bt.Prune(hash)
-	for _, rem := range pruned {
+
+	// pruning removes every fork that doesn't descend from the finalised block, so if the best block was on one of
+	// them, the best block is now on the finalised chain
+	err := bs.handleFinalisedBlock(prevHead, bs.bt.DeepestBlockHash())
+	if err != nil {
+		return err
+	}
+
+	err = bs.deletePrunedBlocks(hash, pruned)
+	if err != nil {
+		return err
+	}
+
+	// the pruned blocks are removed from the blocktree straight away, even if their deletion from the database is
+	// deferred by the finalisation grace period
+	metrics.GetOrRegisterGauge("state/block/leaves", metrics.DefaultRegistry).Update(int64(bs.bt.LeafCount()))
+
+	err = bs.db.Put(finalizedHashKey(round, setID), hash[:])
+	if err != nil {
+		return err
+	}
+
+	// round 0 and set ID 0 is the latest finalised block, only update the metrics once per finalised block
+	if round == 0 && setID == 0 {
+		bs.updateFinalisedMetrics(hash)
+	}
+
+	return nil
+}
+
+// SetFinalizationGracePeriod sets the number of blocks that have to be finalised after blocks are pruned from the
+// blocktree before they're deleted. Until then, the pruned blocks can still be queried by hash.
+func (bs *BlockState) SetFinalizationGracePeriod(blocks uint64) {
+	bs.Lock()
+	defer bs.Unlock()
+	bs.finalizationGracePeriod = blocks
+}
+
+// deletePrunedBlocks deletes the blocks pruned from the blocktree on finalisation of the given block, along with the
+// previously pruned blocks whose grace period has passed
+func (bs *BlockState) deletePrunedBlocks(finalised common.Hash, pruned []common.Hash) error {
+	if bs.finalizationGracePeriod == 0 && len(bs.pendingDeletes) == 0 {
+		return bs.deleteBlocks(pruned)
+	}
+
+	header, err := bs.GetHeader(finalised)
+	if err != nil {
+		return err
+	}
+
+	number := header.Number.Uint64()
+	for _, hash := range pruned {
+		bs.pendingDeletes = append(bs.pendingDeletes, &pendingDelete{
+			hash:     hash,
+			deleteAt: number + bs.finalizationGracePeriod,
+		})
+	}
+
+	var due []common.Hash
+	pending := bs.pendingDeletes[:0]
+	for _, p := range bs.pendingDeletes {
+		if p.deleteAt <= number {
+			due = append(due, p.hash)
+		} else {
+			pending = append(pending, p)
+		}
+	}
+	bs.pendingDeletes = pending
+
+	// the pending deletes are stored along with the due blocks before those are deleted, so that no pruned block is
+	// left behind if the node stops in between. due blocks that were already deleted are skipped by deleteBlocks.
+	if err = bs.storePendingDeletes(due); err != nil {
+		return err
+	}
+
+	if err = bs.deleteBlocks(due); err != nil {
+		return err
+	}
+
+	return bs.storePendingDeletes(nil)
+}
+
+// storePendingDeletes stores the pending deletes, along with the given blocks which are stored as due now
+func (bs *BlockState) storePendingDeletes(due []common.Hash) error {
+	enc := make([]byte, 0, (len(bs.pendingDeletes)+len(due))*40)
+	buf := make([]byte, 8)
+	for _, hash := range due {
+		enc = append(enc, hash[:]...)
+		enc = append(enc, buf...)
+	}
+
+	for _, p := range bs.pendingDeletes {
+		binary.LittleEndian.PutUint64(buf, p.deleteAt)
+		enc = append(enc, p.hash[:]...)
+		enc = append(enc, buf...)
+	}
+
+	return bs.db.Put(common.PendingBlockDeletesKey, enc)
+}
+
+// loadPendingDeletes loads the pending deletes stored by storePendingDeletes
+func (bs *BlockState) loadPendingDeletes() error {
+	enc, err := bs.db.Get(common.PendingBlockDeletesKey)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(enc)%40 != 0 {
+		return fmt.Errorf("invalid encoding length %d", len(enc))
+	}
+
+	bs.pendingDeletes = make([]*pendingDelete, 0, len(enc)/40)
+	for i := 0; i < len(enc); i += 40 {
+		bs.pendingDeletes = append(bs.pendingDeletes, &pendingDelete{
+			hash:     common.BytesToHash(enc[i : i+32]),
+			deleteAt: binary.LittleEndian.Uint64(enc[i+32 : i+40]),
+		})
+	}
+
+	return nil
+}
+
+// deleteBlocks deletes the given blocks and notifies the storage state to prune their tries. Blocks that don't exist
+// are skipped.
+func (bs *BlockState) deleteBlocks(hashes []common.Hash) error {
+	for _, rem := range hashes {
 		header, err := bs.GetHeader(rem)
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -448,7 +611,24 @@ func (bs *BlockState) SetFinalizedHash(hash common.Hash, round, setID uint64) er
 		bs.pruneKeyCh <- header
 	}
 
-	return bs.db.Put(finalizedHashKey(round, setID), hash[:])
+	return nil
+}
+
+// updateFinalisedMetrics updates the finalised block number and the time from import to finalisation
+func (bs *BlockState) updateFinalisedMetrics(hash common.Hash) {
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return
+	}
+
+	metrics.GetOrRegisterGauge("state/block/finalized", metrics.DefaultRegistry).Update(header.Number.Int64())
+
+	arrivalTime, err := bs.GetArrivalTime(hash)
+	if err != nil {
+		return
+	}
+
+	metrics.GetOrRegisterTimer("state/block/finalization", metrics.DefaultRegistry).UpdateSince(arrivalTime)
 }
 
 // SetRound sets the latest finalised GRANDPA round in the db
@@ -500,15 +680,16 @@ func (bs *BlockState) AddBlock(block *types.Block) error {
 
 // AddBlockWithArrivalTime adds a block to the blocktree and the DB with the given arrival time
 func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime time.Time) error {
-	err := bs.setArrivalTime(block.Header.Hash(), arrivalTime)
+	prevHead := bs.bt.DeepestBlockHash()
+
+	// add block to blocktree
+	err := bs.bt.AddBlock(block.Header, uint64(arrivalTime.UnixNano()))
 	if err != nil {
 		return err
 	}
 
-	prevHead := bs.bt.DeepestBlockHash()
-
-	// add block to blocktree
-	err = bs.bt.AddBlock(block.Header, uint64(arrivalTime.UnixNano()))
+	// only store the arrival time once the block is in the blocktree, so that it's pruned along with the block
+	err = bs.setArrivalTime(block.Header.Hash(), arrivalTime)
 	if err != nil {
 		return err
 	}
@@ -520,12 +701,19 @@ func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime ti
 	}
 	hash := block.Header.Hash()
 
+	err = bs.addSlotHash(block.Header)
+	if err != nil {
+		return err
+	}
+
 	// set best block key if this is the highest block we've seen
 	if hash == bs.BestBlockHash() {
 		err = bs.setBestBlockHashKey(hash)
 		if err != nil {
 			return err
 		}
+
+		metrics.GetOrRegisterGauge("state/block/best", metrics.DefaultRegistry).Update(block.Header.Number.Int64())
 	}
 
 	// only set number->hash mapping for our current chain
@@ -548,6 +736,8 @@ func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime ti
 		return err
 	}
 
+	metrics.GetOrRegisterGauge("state/block/leaves", metrics.DefaultRegistry).Update(int64(bs.bt.LeafCount()))
+
 	go bs.notifyImported(block)
 	return bs.db.Flush()
 }
@@ -589,17 +779,93 @@ func (bs *BlockState) handleAddedBlock(prev, curr common.Hash) error {
 	return batch.Flush()
 }
 
-// AddBlockToBlockTree adds the given block to the blocktree. It does not write it to the database.
+// handleFinalisedBlock re-sets the best block and the canonical number->hash mapping if finalising a block moved
+// the best block from prev, which is no longer in the blocktree, to curr on the finalised chain.
+func (bs *BlockState) handleFinalisedBlock(prev, curr common.Hash) error {
+	if prev == curr {
+		return nil
+	}
+
+	header, err := bs.GetHeader(curr)
+	if err != nil {
+		return err
+	}
+	best := header.Number.Uint64()
+
+	batch := bs.db.NewBatch()
+
+	// walk back from the new best block until the mapping already contains the finalised chain
+	for {
+		hash := header.Hash()
+		canonical, err := bs.GetHashByNumber(header.Number)
+		if err == nil && canonical == hash {
+			break
+		}
+
+		err = batch.Put(headerHashKey(header.Number.Uint64()), hash.ToBytes())
+		if err != nil {
+			return err
+		}
+
+		if header.Number.Sign() == 0 {
+			break
+		}
+
+		header, err = bs.GetHeader(header.ParentHash)
+		if err != nil {
+			return fmt.Errorf("failed to get header on finalised chain: %w", err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return err
+	}
+
+	// the previous best chain may have been longer, remove the mapping for its blocks above the new best block
+	for num := best + 1; ; num++ {
+		has, err := bs.db.Has(headerHashKey(num))
+		if err != nil {
+			return err
+		}
+
+		if !has {
+			break
+		}
+
+		err = bs.db.Del(headerHashKey(num))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bs.setBestBlockHashKey(curr)
+	if err != nil {
+		return err
+	}
+
+	metrics.GetOrRegisterGauge("state/block/best", metrics.DefaultRegistry).Update(int64(best))
+	return nil
+}
+
+// AddBlockToBlockTree adds the given block to the blocktree. It does not write it to the database, only its
+// arrival time if it doesn't have one yet.
 func (bs *BlockState) AddBlockToBlockTree(header *types.Header) error {
 	bs.Lock()
 	defer bs.Unlock()
 
 	arrivalTime, err := bs.GetArrivalTime(header.Hash())
-	if err != nil {
+	hasArrivalTime := err == nil
+	if !hasArrivalTime {
 		arrivalTime = time.Now()
 	}
 
-	return bs.bt.AddBlock(header, uint64(arrivalTime.UnixNano()))
+	err = bs.bt.AddBlock(header, uint64(arrivalTime.UnixNano()))
+	if err != nil || hasArrivalTime {
+		return err
+	}
+
+	return bs.setArrivalTime(header.Hash(), arrivalTime)
 }
 
 // GetAllBlocksAtDepth returns all hashes with the depth of the given hash plus one
@@ -607,6 +873,12 @@ func (bs *BlockState) GetAllBlocksAtDepth(hash common.Hash) []common.Hash {
 	return bs.bt.GetAllBlocksAtDepth(hash)
 }
 
+// GetBlocksAtDepth returns up to limit hashes with the depth of the given hash plus one, skipping the first offset
+// of them. A negative limit returns all the remaining hashes.
+func (bs *BlockState) GetBlocksAtDepth(hash common.Hash, offset, limit int) []common.Hash {
+	return bs.bt.GetBlocksAtDepth(hash, offset, limit)
+}
+
 func (bs *BlockState) isBlockOnCurrentChain(header *types.Header) (bool, error) {
 	bestBlock, err := bs.BestBlockHeader()
 	if err != nil {
@@ -683,6 +955,90 @@ func (bs *BlockState) GetSlotForBlock(hash common.Hash) (uint64, error) {
 	return types.GetSlotFromHeader(header)
 }
 
+// GetBlockHashesBySlot returns the hashes of all the blocks added to the block state that claim the given slot,
+// in the order they were added. More than one block claiming a slot may indicate an equivocation.
+func (bs *BlockState) GetBlockHashesBySlot(slot uint64) ([]common.Hash, error) {
+	enc, err := bs.db.Get(slotHashesKey(slot))
+	if err == chaindb.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSlotHashes(enc), nil
+}
+
+// addSlotHash adds the block to the index of the blocks claiming its slot. Blocks without a BABE pre-runtime digest,
+// such as the genesis block, don't claim a slot and aren't indexed.
+func (bs *BlockState) addSlotHash(header *types.Header) error {
+	slot, err := types.GetSlotFromHeader(header)
+	if err != nil {
+		return nil
+	}
+
+	hashes, err := bs.GetBlockHashesBySlot(slot)
+	if err != nil {
+		return err
+	}
+
+	hash := header.Hash()
+	for _, h := range hashes {
+		if h == hash {
+			return nil
+		}
+	}
+
+	return bs.db.Put(slotHashesKey(slot), encodeSlotHashes(append(hashes, hash)))
+}
+
+// deleteSlotHash removes the block from the index of the blocks claiming its slot
+func (bs *BlockState) deleteSlotHash(header *types.Header) error {
+	slot, err := types.GetSlotFromHeader(header)
+	if err != nil {
+		return nil
+	}
+
+	hashes, err := bs.GetBlockHashesBySlot(slot)
+	if err != nil {
+		return err
+	}
+
+	hash := header.Hash()
+	kept := hashes[:0]
+	for _, h := range hashes {
+		if h != hash {
+			kept = append(kept, h)
+		}
+	}
+
+	if len(kept) == len(hashes) {
+		return nil
+	}
+
+	if len(kept) == 0 {
+		return bs.db.Del(slotHashesKey(slot))
+	}
+
+	return bs.db.Put(slotHashesKey(slot), encodeSlotHashes(kept))
+}
+
+func encodeSlotHashes(hashes []common.Hash) []byte {
+	enc := make([]byte, 0, len(hashes)*common.HashLength)
+	for _, hash := range hashes {
+		enc = append(enc, hash[:]...)
+	}
+	return enc
+}
+
+func decodeSlotHashes(enc []byte) []common.Hash {
+	hashes := make([]common.Hash, len(enc)/common.HashLength)
+	for i := range hashes {
+		copy(hashes[i][:], enc[i*common.HashLength:])
+	}
+	return hashes
+}
+
 // SubChain returns the sub-blockchain between the starting hash and the ending hash using the block tree
 func (bs *BlockState) SubChain(start, end common.Hash) ([]common.Hash, error) {
 	if bs.bt == nil {
@@ -702,6 +1058,15 @@ func (bs *BlockState) IsDescendantOf(parent, child common.Hash) (bool, error) {
 	return bs.bt.IsDescendantOf(parent, child)
 }
 
+// GetDescendants returns the hashes of all the blocks descending from the block with the given hash
+func (bs *BlockState) GetDescendants(hash common.Hash) ([]common.Hash, error) {
+	if bs.bt == nil {
+		return nil, fmt.Errorf("blocktree is nil")
+	}
+
+	return bs.bt.GetAllDescendants(hash)
+}
+
 // HighestCommonAncestor returns the block with the highest number that is an ancestor of both a and b
 func (bs *BlockState) HighestCommonAncestor(a, b common.Hash) (common.Hash, error) {
 	return bs.bt.HighestCommonAncestor(a, b)
@@ -712,11 +1077,36 @@ func (bs *BlockState) Leaves() []common.Hash {
 	return bs.bt.Leaves()
 }
 
+// LeafCount returns the number of leaves of the blocktree
+func (bs *BlockState) LeafCount() int {
+	return bs.bt.LeafCount()
+}
+
 // BlocktreeAsString returns the blocktree as a string
 func (bs *BlockState) BlocktreeAsString() string {
 	return bs.bt.String()
 }
 
+// BlocktreeAsDOT returns the blocktree in Graphviz DOT format, labelling each block with its number and short hash
+// and highlighting the latest finalised block and the best block
+func (bs *BlockState) BlocktreeAsDOT() (string, error) {
+	finalised, err := bs.GetFinalizedHash(0, 0)
+	if err != nil {
+		return "", err
+	}
+
+	label := func(hash common.Hash) string {
+		short := hash.String()[:10]
+		header, err := bs.GetHeader(hash)
+		if err != nil {
+			return short
+		}
+		return fmt.Sprintf("#%s %s", header.Number, short)
+	}
+
+	return bs.bt.DOT(label, finalised), nil
+}
+
 func (bs *BlockState) setBestBlockHashKey(hash common.Hash) error {
 	return bs.baseState.StoreBestBlockHash(hash)
 }
@@ -17,9 +17,16 @@
 package state
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
+// ErrInvalidJustification is returned when a justification is refused by the JustificationVerifier
+var ErrInvalidJustification = errors.New("invalid justification")
+
 // prefixKey = prefix + hash
 func prefixKey(hash common.Hash, prefix []byte) []byte {
 	return append(prefix, hash.ToBytes()...)
@@ -86,8 +93,38 @@ func (bs *BlockState) HasJustification(hash common.Hash) (bool, error) {
 	return bs.db.Has(prefixKey(hash, justificationPrefix))
 }
 
-// SetJustification sets a Justification in the database
+// JustificationVerifier verifies block justifications before they're stored, eg. the finality gadget
+type JustificationVerifier interface {
+	VerifyBlockJustification(hash common.Hash, number *big.Int, justification []byte) error
+}
+
+// SetJustificationVerifier sets the verifier for justifications passed to SetJustification.
+// If it's nil, which is the default, justifications are stored without being verified.
+func (bs *BlockState) SetJustificationVerifier(v JustificationVerifier) {
+	bs.Lock()
+	defer bs.Unlock()
+	bs.justificationVerifier = v
+}
+
+// SetJustification sets a Justification in the database.
+// If a JustificationVerifier is set, it returns an error and doesn't store the justification if it doesn't verify.
 func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
+	bs.RLock()
+	verifier := bs.justificationVerifier
+	bs.RUnlock()
+
+	if verifier != nil {
+		header, err := bs.GetHeader(hash)
+		if err != nil {
+			return fmt.Errorf("cannot get header to verify justification for block %s: %w", hash, err)
+		}
+
+		err = verifier.VerifyBlockJustification(hash, header.Number, data)
+		if err != nil {
+			return fmt.Errorf("%w for block %s: %s", ErrInvalidJustification, hash, err)
+		}
+	}
+
 	bs.Lock()
 	defer bs.Unlock()
 
@@ -17,6 +17,8 @@
 package state
 
 import (
+	"bytes"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -92,3 +94,56 @@ func TestGetSet_ReceiptMessageQueue_Justification(t *testing.T) {
 		}
 	}
 }
+
+// mockJustificationVerifier only accepts the valid justification for the block with the given hash
+type mockJustificationVerifier struct {
+	hash  common.Hash
+	valid []byte
+}
+
+func (v *mockJustificationVerifier) VerifyBlockJustification(hash common.Hash, _ *big.Int, justification []byte) error {
+	if hash != v.hash {
+		return errors.New("justification is for another block")
+	}
+	if !bytes.Equal(justification, v.valid) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func TestSetJustification_Verifier(t *testing.T) {
+	s := newTestBlockState(t, testGenesisHeader)
+	headers, _ := AddBlocksToState(t, s, 2)
+	hash := headers[0].Hash()
+
+	valid := []byte("valid")
+	s.SetJustificationVerifier(&mockJustificationVerifier{hash: hash, valid: valid})
+
+	err := s.SetJustification(hash, valid)
+	require.NoError(t, err)
+
+	res, err := s.GetJustification(hash)
+	require.NoError(t, err)
+	require.Equal(t, valid, res)
+
+	other := headers[1].Hash()
+	err = s.SetJustification(other, []byte("invalid"))
+	require.True(t, errors.Is(err, ErrInvalidJustification))
+
+	has, err := s.HasJustification(other)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// a valid justification of one block is refused for another block
+	err = s.SetJustification(other, valid)
+	require.True(t, errors.Is(err, ErrInvalidJustification))
+
+	has, err = s.HasJustification(other)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// without a verifier, justifications aren't verified
+	s.SetJustificationVerifier(nil)
+	err = s.SetJustification(other, []byte("invalid"))
+	require.NoError(t, err)
+}
@@ -17,11 +17,14 @@
 package state
 
 import (
+	"io/ioutil"
 	"math/big"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/trie"
 
@@ -198,6 +201,50 @@ func TestGetSlotForBlock(t *testing.T) {
 	require.Equal(t, expectedSlot, res)
 }
 
+func TestGetBlockHashesBySlot(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+	slot := uint64(77)
+
+	// two authorities claim the same slot with blocks on different forks
+	var hashes []common.Hash
+	for authority := uint32(0); authority < 2; authority++ {
+		babeHeader := types.NewBabePrimaryPreDigest(authority, slot, [32]byte{}, [64]byte{})
+		block := &types.Block{
+			Header: &types.Header{
+				ParentHash: testGenesisHeader.Hash(),
+				Number:     big.NewInt(1),
+				Digest:     types.Digest{types.NewBABEPreRuntimeDigest(babeHeader.Encode())},
+			},
+			Body: &types.Body{},
+		}
+
+		err := bs.AddBlock(block)
+		require.NoError(t, err)
+		hashes = append(hashes, block.Header.Hash())
+	}
+
+	res, err := bs.GetBlockHashesBySlot(slot)
+	require.NoError(t, err)
+	require.Equal(t, hashes, res)
+
+	res, err = bs.GetBlockHashesBySlot(slot + 1)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	// deleted blocks are removed from the index
+	err = bs.DeleteBlock(hashes[0])
+	require.NoError(t, err)
+	res, err = bs.GetBlockHashesBySlot(slot)
+	require.NoError(t, err)
+	require.Equal(t, hashes[1:], res)
+
+	err = bs.DeleteBlock(hashes[1])
+	require.NoError(t, err)
+	res, err = bs.GetBlockHashesBySlot(slot)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
 func TestIsBlockOnCurrentChain(t *testing.T) {
 	bs := newTestBlockState(t, testGenesisHeader)
 	currChain, branchChains := AddBlocksToState(t, bs, 3)
@@ -304,12 +351,11 @@ func TestFinalization_DeleteBlock(t *testing.T) {
 	before := bs.bt.GetAllBlocks()
 	leaves := bs.Leaves()
 
-	// TODO: why isn't arrival time set?
-	// for _, n := range before {
-	// 	has, err := bs.HasArrivalTime(n)
-	// 	require.NoError(t, err)
-	// 	require.True(t, has, n)
-	// }
+	for _, n := range before {
+		has, err := bs.HasArrivalTime(n)
+		require.NoError(t, err)
+		require.True(t, has, n)
+	}
 
 	// pick block to finalise
 	fin := leaves[len(leaves)-1]
@@ -357,13 +403,13 @@ func TestFinalization_DeleteBlock(t *testing.T) {
 			require.False(t, has)
 		}
 
-		// has, err = bs.HasArrivalTime(b)
-		// require.NoError(t, err)
-		// if isFinalised && b != bs.genesisHash {
-		// 	require.True(t, has, b)
-		// } else {
-		// 	require.False(t, has)
-		// }
+		has, err = bs.HasArrivalTime(b)
+		require.NoError(t, err)
+		if isFinalised {
+			require.True(t, has, b)
+		} else {
+			require.False(t, has)
+		}
 	}
 }
 
@@ -517,3 +563,277 @@ func TestAddBlockToBlockTree(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, bs.BestBlockHash(), header.Hash())
 }
+
+// addTestForkBlock adds a block with the given parent and number to the block state, distinguished from its
+// siblings by the given fork byte
+func addTestForkBlock(t *testing.T, bs *BlockState, parent common.Hash, number int64, fork byte) common.Hash {
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(number),
+			StateRoot:  trie.EmptyHash,
+			Digest: types.Digest{
+				&types.PreRuntimeDigest{
+					Data: []byte{fork},
+				},
+			},
+		},
+		Body: &types.Body{},
+	}
+
+	err := bs.AddBlock(block)
+	require.NoError(t, err)
+	return block.Header.Hash()
+}
+
+func TestGetAllBlocksAtDepth_Forks(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+	// 1 -> {2a -> 3a, 2b -> 3b, 2c}
+	one := addTestForkBlock(t, bs, testGenesisHeader.Hash(), 1, 0)
+	twoA := addTestForkBlock(t, bs, one, 2, 'a')
+	twoB := addTestForkBlock(t, bs, one, 2, 'b')
+	twoC := addTestForkBlock(t, bs, one, 2, 'c')
+	threeA := addTestForkBlock(t, bs, twoA, 3, 'a')
+	threeB := addTestForkBlock(t, bs, twoB, 3, 'b')
+
+	require.Equal(t, []common.Hash{twoA, twoB, twoC}, bs.GetAllBlocksAtDepth(one))
+	require.Equal(t, []common.Hash{threeA, threeB}, bs.GetAllBlocksAtDepth(twoC))
+
+	require.Equal(t, []common.Hash{twoA, twoB}, bs.GetBlocksAtDepth(one, 0, 2))
+	require.Equal(t, []common.Hash{twoC}, bs.GetBlocksAtDepth(one, 2, 2))
+	require.Equal(t, []common.Hash{}, bs.GetBlocksAtDepth(one, 3, 2))
+
+	err := bs.SetFinalizedHash(twoA, 0, 0)
+	require.NoError(t, err)
+
+	// 3b was on a pruned fork
+	require.Equal(t, []common.Hash{threeA}, bs.GetAllBlocksAtDepth(twoA))
+	require.Equal(t, []common.Hash{}, bs.GetAllBlocksAtDepth(twoB))
+}
+
+func TestGetDescendants(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	// 1 -> {2a -> {3a, 3c}, 2b -> 3b}
+	one := addTestForkBlock(t, bs, testGenesisHeader.Hash(), 1, 0)
+	twoA := addTestForkBlock(t, bs, one, 2, 'a')
+	twoB := addTestForkBlock(t, bs, one, 2, 'b')
+	threeA := addTestForkBlock(t, bs, twoA, 3, 'a')
+	threeB := addTestForkBlock(t, bs, twoB, 3, 'b')
+	threeC := addTestForkBlock(t, bs, twoA, 3, 'c')
+
+	descendants, err := bs.GetDescendants(one)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Hash{twoA, twoB, threeA, threeB, threeC}, descendants)
+
+	descendants, err = bs.GetDescendants(twoA)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Hash{threeA, threeC}, descendants)
+
+	descendants, err = bs.GetDescendants(threeB)
+	require.NoError(t, err)
+	require.NotNil(t, descendants)
+	require.Empty(t, descendants)
+
+	_, err = bs.GetDescendants(common.Hash{0xff})
+	require.Error(t, err)
+}
+
+func TestArrivalTime_AfterRestart(t *testing.T) {
+	testDatadirPath, err := ioutil.TempDir("/tmp", "test-datadir-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDatadirPath)
+
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{
+		DataDir: testDatadirPath,
+	})
+	require.NoError(t, err)
+
+	bs, err := NewBlockStateFromGenesis(db, testGenesisHeader)
+	require.NoError(t, err)
+	AddBlocksToState(t, bs, 4)
+
+	arrivalTimes := make(map[common.Hash]time.Time)
+	for _, hash := range bs.bt.GetAllBlocks() {
+		arrivalTimes[hash], err = bs.GetArrivalTime(hash)
+		require.NoError(t, err)
+	}
+
+	err = bs.bt.Store()
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	db, err = chaindb.NewBadgerDB(&chaindb.Config{
+		DataDir: testDatadirPath,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	bt := blocktree.NewEmptyBlockTree(db)
+	err = bt.Load()
+	require.NoError(t, err)
+
+	bs, err = NewBlockState(db, bt)
+	require.NoError(t, err)
+
+	for hash, expected := range arrivalTimes {
+		has, err := bs.HasArrivalTime(hash)
+		require.NoError(t, err)
+		require.True(t, has)
+
+		arrivalTime, err := bs.GetArrivalTime(hash)
+		require.NoError(t, err)
+		require.Equal(t, expected.UnixNano(), arrivalTime.UnixNano())
+	}
+}
+
+func TestFinalization_GracePeriod(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+	bs.SetFinalizationGracePeriod(2)
+
+	// 1 -> 2 -> 3 -> 4 -> 5 and 1 -> 2b
+	chain := []common.Hash{addTestForkBlock(t, bs, testGenesisHeader.Hash(), 1, 0)}
+	for i := int64(2); i <= 5; i++ {
+		chain = append(chain, addTestForkBlock(t, bs, chain[len(chain)-1], i, 0))
+	}
+	fork := addTestForkBlock(t, bs, chain[0], 2, 'b')
+
+	// finalising block 2 prunes the fork, its deletion is deferred until block 4 is finalised
+	for _, hash := range chain[1:3] {
+		err := bs.SetFinalizedHash(hash, 0, 0)
+		require.NoError(t, err)
+
+		has, err := bs.HasHeader(fork)
+		require.NoError(t, err)
+		require.True(t, has)
+
+		_, err = bs.GetHeader(fork)
+		require.NoError(t, err)
+	}
+
+	err := bs.SetFinalizedHash(chain[3], 0, 0)
+	require.NoError(t, err)
+
+	has, err := bs.HasHeader(fork)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	has, err = bs.HasArrivalTime(fork)
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestFinalization_GracePeriod_Restart(t *testing.T) {
+	db := NewInMemoryDB(t)
+	bs, err := NewBlockStateFromGenesis(db, testGenesisHeader)
+	require.NoError(t, err)
+	bs.SetFinalizationGracePeriod(2)
+
+	// 1 -> 2 -> 3 -> 4 and 1 -> 2b
+	chain := []common.Hash{addTestForkBlock(t, bs, testGenesisHeader.Hash(), 1, 0)}
+	for i := int64(2); i <= 4; i++ {
+		chain = append(chain, addTestForkBlock(t, bs, chain[len(chain)-1], i, 0))
+	}
+	fork := addTestForkBlock(t, bs, chain[0], 2, 'b')
+
+	err = bs.SetFinalizedHash(chain[1], 0, 0)
+	require.NoError(t, err)
+
+	// the deferred deletion of the fork survives a restart
+	bs, err = NewBlockState(db, bs.bt)
+	require.NoError(t, err)
+	require.Equal(t, []*pendingDelete{{hash: fork, deleteAt: 4}}, bs.pendingDeletes)
+
+	err = bs.SetFinalizedHash(chain[2], 0, 0)
+	require.NoError(t, err)
+	has, err := bs.HasHeader(fork)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	err = bs.SetFinalizedHash(chain[3], 0, 0)
+	require.NoError(t, err)
+	has, err = bs.HasHeader(fork)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	bs, err = NewBlockState(db, bs.bt)
+	require.NoError(t, err)
+	require.Empty(t, bs.pendingDeletes)
+}
+
+func TestFinalization_BestBlockOnFinalisedChain(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	// 1 -> 2 -> 3 and a longer fork 1 -> 2b -> 3b -> 4b -> 5b, which becomes the best chain
+	chain := []common.Hash{addTestForkBlock(t, bs, testGenesisHeader.Hash(), 1, 0)}
+	for i := int64(2); i <= 3; i++ {
+		chain = append(chain, addTestForkBlock(t, bs, chain[len(chain)-1], i, 0))
+	}
+
+	fork := []common.Hash{chain[0]}
+	for i := int64(2); i <= 5; i++ {
+		fork = append(fork, addTestForkBlock(t, bs, fork[len(fork)-1], i, 'b'))
+	}
+	require.Equal(t, fork[4], bs.BestBlockHash())
+
+	// finalising block 3 switches the best chain back, even though the fork is longer
+	err := bs.SetFinalizedHash(chain[2], 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, chain[2], bs.BestBlockHash())
+
+	for i, hash := range chain {
+		canonical, err := bs.GetHashByNumber(big.NewInt(int64(i + 1)))
+		require.NoError(t, err)
+		require.Equal(t, hash, canonical)
+	}
+
+	_, err = bs.GetHashByNumber(big.NewInt(4))
+	require.Error(t, err)
+
+	best, err := bs.baseState.LoadBestBlockHash()
+	require.NoError(t, err)
+	require.Equal(t, chain[2], best)
+
+	// blocks that don't descend from the finalised block can't become the best block
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: fork[1],
+			Number:     big.NewInt(3),
+			StateRoot:  trie.EmptyHash,
+			Digest:     types.Digest{&types.PreRuntimeDigest{Data: []byte{'c'}}},
+		},
+		Body: &types.Body{},
+	}
+	err = bs.AddBlock(block)
+	require.Equal(t, blocktree.ErrParentNotFound, err)
+	require.Equal(t, chain[2], bs.BestBlockHash())
+
+	// the best chain grows from the finalised block
+	next := addTestForkBlock(t, bs, chain[2], 4, 0)
+	require.Equal(t, next, bs.BestBlockHash())
+}
+
+func TestFinalization_LeafCountBounded(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+	bs.SetFinalizationGracePeriod(100)
+
+	// each block of the chain gets a short-lived fork, and finality lags 5 blocks behind the best block
+	const lag = 5
+	chain := []common.Hash{testGenesisHeader.Hash()}
+	for i := int64(1); i <= 200; i++ {
+		parent := chain[len(chain)-1]
+		addTestForkBlock(t, bs, parent, i, 'f')
+		chain = append(chain, addTestForkBlock(t, bs, parent, i, 0))
+
+		if i <= lag {
+			continue
+		}
+
+		err := bs.SetFinalizedHash(chain[i-lag], 0, 0)
+		require.NoError(t, err)
+
+		// only the forks off the unfinalised blocks remain, along with the best block
+		require.Equal(t, lag+1, bs.LeafCount())
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CheckIntegrity verifies the consistency of the block and storage data in the database. It walks from the best
+// block back to the genesis block, checking that each header links to a stored parent with the preceding block
+// number and that finalised blocks have a body, and checks that each node of the state trie of the best block
+// matches the hash it is stored under. The first inconsistency found is returned.
+func (s *Service) CheckIntegrity() error {
+	best, err := s.Block.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("failed to get best block header: %w", err)
+	}
+
+	finalised, err := s.Block.GetFinalizedHeader(0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get finalised block header: %w", err)
+	}
+
+	tr, err := s.Storage.LoadFromDB(best.StateRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load state of best block %s (number %d): %w", best.Hash(), best.Number, err)
+	}
+
+	if err = tr.Verify(); err != nil {
+		return fmt.Errorf("state of best block %s (number %d) does not match its root %s: %w",
+			best.Hash(), best.Number, best.StateRoot, err)
+	}
+
+	curr := best
+	for {
+		hash := curr.Hash()
+
+		if curr.Number.Cmp(finalised.Number) <= 0 {
+			has, err := s.Block.HasBlockBody(hash)
+			if err != nil {
+				return fmt.Errorf("failed to check body of block %s (number %d): %w", hash, curr.Number, err)
+			}
+
+			if !has {
+				return fmt.Errorf("body of finalised block %s (number %d) is missing", hash, curr.Number)
+			}
+		}
+
+		if curr.Number.Sign() == 0 {
+			if hash != s.Block.GenesisHash() {
+				return fmt.Errorf("block %s at number 0 is not the genesis block %s", hash, s.Block.GenesisHash())
+			}
+
+			return nil
+		}
+
+		parent, err := s.Block.GetHeader(curr.ParentHash)
+		if err != nil {
+			return fmt.Errorf("parent link of block %s (number %d) is broken: parent %s not found: %w",
+				hash, curr.Number, curr.ParentHash, err)
+		}
+
+		if parent.Hash() != curr.ParentHash {
+			return fmt.Errorf("parent link of block %s (number %d) is broken: header stored at %s hashes to %s",
+				hash, curr.Number, curr.ParentHash, parent.Hash())
+		}
+
+		if expected := new(big.Int).Sub(curr.Number, big.NewInt(1)); parent.Number.Cmp(expected) != 0 {
+			return fmt.Errorf("parent link of block %s (number %d) is broken: parent %s has number %d",
+				hash, curr.Number, curr.ParentHash, parent.Number)
+		}
+
+		curr = parent
+	}
+}
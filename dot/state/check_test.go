@@ -0,0 +1,133 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/utils"
+
+	log "github.com/ChainSafe/log15"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCheckService(t *testing.T) (*Service, []*types.Header) {
+	testDir := utils.NewTestDir(t)
+
+	serv := NewService(testDir, log.LvlTrace)
+	serv.UseMemDB()
+
+	genData, genTrie, genesisHeader := newTestGenesisWithTrieAndHeader(t)
+	err := serv.Initialise(genData, genesisHeader, genTrie)
+	require.NoError(t, err)
+
+	err = serv.Start()
+	require.NoError(t, err)
+
+	chain, _ := AddBlocksToState(t, serv.Block, 6)
+	err = serv.Block.SetFinalizedHash(chain[1].Hash(), 0, 0)
+	require.NoError(t, err)
+	return serv, chain
+}
+
+func TestService_CheckIntegrity(t *testing.T) {
+	serv, _ := newTestCheckService(t)
+	defer utils.RemoveTestDir(t)
+
+	err := serv.CheckIntegrity()
+	require.NoError(t, err)
+}
+
+func TestService_CheckIntegrity_BrokenParentLink(t *testing.T) {
+	serv, chain := newTestCheckService(t)
+	defer utils.RemoveTestDir(t)
+
+	// overwrite the header of block 1 with a header that has a different parent
+	broken := *chain[0]
+	broken.ParentHash = common.Hash{0x1}
+	enc, err := broken.Encode()
+	require.NoError(t, err)
+	err = serv.Block.db.Put(headerKey(chain[0].Hash()), enc)
+	require.NoError(t, err)
+
+	err = serv.CheckIntegrity()
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		fmt.Sprintf("(number 2) is broken: header stored at %s hashes to", chain[0].Hash()))
+}
+
+func TestService_CheckIntegrity_MissingBody(t *testing.T) {
+	serv, chain := newTestCheckService(t)
+	defer utils.RemoveTestDir(t)
+
+	err := serv.Block.db.Del(blockBodyKey(chain[0].Hash()))
+	require.NoError(t, err)
+
+	err = serv.CheckIntegrity()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("body of finalised block %s (number 1) is missing", chain[0].Hash()))
+}
+
+func TestService_CheckIntegrity_CorruptStateNode(t *testing.T) {
+	serv, chain := newTestCheckService(t)
+	defer utils.RemoveTestDir(t)
+
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("noot"), bytes.Repeat([]byte{1}, 64))
+	tr.Put([]byte("other"), bytes.Repeat([]byte{2}, 64))
+	err := tr.Store(serv.Storage.db)
+	require.NoError(t, err)
+
+	parent := chain[len(chain)-1]
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(0).Add(parent.Number, big.NewInt(1)),
+			StateRoot:  tr.MustHash(),
+			Digest:     types.Digest{},
+		},
+		Body: &types.Body{},
+	}
+	err = serv.Block.AddBlock(block)
+	require.NoError(t, err)
+	require.Equal(t, block.Header.Hash(), serv.Block.BestBlockHash())
+
+	err = serv.CheckIntegrity()
+	require.NoError(t, err)
+
+	// corrupt the leaf referenced by the last child hash of the root branch
+	root := tr.MustHash()
+	rootEnc, err := serv.Storage.db.Get(root[:])
+	require.NoError(t, err)
+	childHash := rootEnc[len(rootEnc)-32:]
+	enc, err := serv.Storage.db.Get(childHash)
+	require.NoError(t, err)
+	corrupt := append([]byte{}, enc...)
+	corrupt[len(corrupt)-1]++
+	err = serv.Storage.db.Put(childHash, corrupt)
+	require.NoError(t, err)
+
+	err = serv.CheckIntegrity()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("node key=0x%x is corrupt", childHash))
+}
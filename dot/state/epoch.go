@@ -21,12 +21,18 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/scale"
 )
 
+// maxEpochCacheSize is the maximum number of block hash -> epoch entries kept by the EpochState.
+// once exceeded, the cache is cleared.
+const maxEpochCacheSize = 4096
+
 var (
 	epochPrefix      = "epoch"
 	epochLengthKey   = []byte("epochlength")
@@ -56,6 +62,10 @@ type EpochState struct {
 	epochLength uint64 // measured in slots
 	firstSlot   uint64
 	skipToEpoch uint64
+
+	// cache of block hash -> epoch number, invalidated when the first slot changes
+	epochCacheLock sync.RWMutex
+	epochCache     map[common.Hash]uint64
 }
 
 // NewEpochStateFromGenesis returns a new EpochState given information for the first epoch, fetched from the runtime
@@ -82,6 +92,7 @@ func NewEpochStateFromGenesis(db chaindb.Database, genesisConfig *types.BabeConf
 		db:          epochDB,
 		epochLength: genesisConfig.EpochLength,
 		firstSlot:   1,
+		epochCache:  make(map[common.Hash]uint64),
 	}
 
 	auths, err := types.BABEAuthorityRawToAuthority(genesisConfig.GenesisAuthorities)
@@ -143,6 +154,7 @@ func NewEpochState(db chaindb.Database) (*EpochState, error) {
 		epochLength: epochLength,
 		firstSlot:   firstSlot,
 		skipToEpoch: skipToEpoch,
+		epochCache:  make(map[common.Hash]uint64),
 	}, nil
 }
 
@@ -179,11 +191,43 @@ func (s *EpochState) GetCurrentEpoch() (uint64, error) {
 }
 
 // GetEpochForBlock checks the pre-runtime digest to determine what epoch the block was formed in.
+// The result is cached by block hash, so repeated lookups for the same block don't need to decode the digest.
 func (s *EpochState) GetEpochForBlock(header *types.Header) (uint64, error) {
 	if header == nil {
 		return 0, errors.New("header is nil")
 	}
 
+	// a header without a block number can't be hashed, so it can't be cached
+	if header.Number == nil {
+		return s.getEpochForBlock(header)
+	}
+
+	hash := header.Hash()
+
+	s.epochCacheLock.RLock()
+	epoch, has := s.epochCache[hash]
+	s.epochCacheLock.RUnlock()
+	if has {
+		return epoch, nil
+	}
+
+	s.epochCacheLock.Lock()
+	defer s.epochCacheLock.Unlock()
+
+	epoch, err := s.getEpochForBlock(header)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(s.epochCache) >= maxEpochCacheSize {
+		s.epochCache = make(map[common.Hash]uint64)
+	}
+
+	s.epochCache[hash] = epoch
+	return epoch, nil
+}
+
+func (s *EpochState) getEpochForBlock(header *types.Header) (uint64, error) {
 	for _, d := range header.Digest {
 		if d.Type() != types.PreRuntimeDigestType {
 			continue
@@ -289,6 +333,13 @@ func (s *EpochState) GetStartSlotForEpoch(epoch uint64) (uint64, error) {
 
 // SetFirstSlot sets the first slot number of the network
 func (s *EpochState) SetFirstSlot(slot uint64) error {
+	s.epochCacheLock.Lock()
+	defer s.epochCacheLock.Unlock()
+
+	if slot != s.firstSlot {
+		s.epochCache = make(map[common.Hash]uint64)
+	}
+
 	s.firstSlot = slot
 	return s.baseState.storeFirstSlot(slot)
 }
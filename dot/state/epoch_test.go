@@ -17,9 +17,11 @@
 package state
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/stretchr/testify/require"
@@ -178,3 +180,82 @@ func TestEpochState_GetEpochForBlock(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint64(2), epoch)
 }
+
+func newTestHeaderForSlot(t testing.TB, num int64, slot uint64) *types.Header {
+	babeHeader := types.NewBabePrimaryPreDigest(0, slot, [32]byte{}, [64]byte{})
+	digest := types.NewBABEPreRuntimeDigest(babeHeader.Encode())
+
+	header, err := types.NewHeader(common.Hash{}, common.Hash{}, common.Hash{}, big.NewInt(num), types.Digest{digest})
+	require.NoError(t, err)
+	return header
+}
+
+func TestEpochState_GetEpochForBlock_Cached(t *testing.T) {
+	s := newEpochStateFromGenesis(t)
+
+	// blocks straddling the boundary between epochs 0 and 1
+	slots := []uint64{s.epochLength - 1, s.epochLength, s.epochLength + 1, s.epochLength + 2}
+	headers := make([]*types.Header, len(slots))
+	for i, slot := range slots {
+		headers[i] = newTestHeaderForSlot(t, int64(i+1), slot)
+	}
+
+	for _, header := range headers {
+		expected, err := s.getEpochForBlock(header)
+		require.NoError(t, err)
+
+		epoch, err := s.GetEpochForBlock(header)
+		require.NoError(t, err)
+		require.Equal(t, expected, epoch)
+
+		// second lookup is served from the cache
+		require.Contains(t, s.epochCache, header.Hash())
+		epoch, err = s.GetEpochForBlock(header)
+		require.NoError(t, err)
+		require.Equal(t, expected, epoch)
+	}
+
+	epoch, err := s.GetEpochForBlock(headers[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), epoch)
+	epoch, err = s.GetEpochForBlock(headers[len(headers)-1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), epoch)
+
+	// changing the first slot invalidates the cache
+	err = s.SetFirstSlot(3)
+	require.NoError(t, err)
+	require.Empty(t, s.epochCache)
+
+	for _, header := range headers {
+		expected, err := s.getEpochForBlock(header)
+		require.NoError(t, err)
+
+		epoch, err := s.GetEpochForBlock(header)
+		require.NoError(t, err)
+		require.Equal(t, expected, epoch)
+	}
+
+	epoch, err = s.GetEpochForBlock(headers[len(headers)-1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), epoch)
+}
+
+func BenchmarkEpochState_GetEpochForBlock(b *testing.B) {
+	s, err := NewEpochStateFromGenesis(NewInMemoryDB(b), genesisBABEConfig)
+	require.NoError(b, err)
+
+	header := newTestHeaderForSlot(b, 1, s.epochLength+1)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.getEpochForBlock(header)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.GetEpochForBlock(header)
+		}
+	})
+}
@@ -185,6 +185,11 @@ func (s *Service) storeInitialValues(data *genesis.Data, header *types.Header, t
 		return fmt.Errorf("failed to write genesis data to database: %s", err)
 	}
 
+	// write database schema version to state database
+	if err := s.Base.StoreSchemaVersion(SchemaVersion); err != nil {
+		return fmt.Errorf("failed to write schema version to database: %s", err)
+	}
+
 	return nil
 }
 
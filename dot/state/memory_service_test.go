@@ -0,0 +1,67 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package state_test
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/trie"
+
+	log "github.com/ChainSafe/log15"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInMemoryService(t *testing.T) {
+	gen, err := genesis.NewGenesisFromJSONRaw("../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+
+	genTrie, err := genesis.NewTrieFromGenesis(gen)
+	require.NoError(t, err)
+
+	genesisHeader, err := types.NewHeader(common.NewHash([]byte{0}), genTrie.MustHash(), trie.EmptyHash,
+		big.NewInt(0), types.Digest{})
+	require.NoError(t, err)
+
+	s, err := state.NewInMemoryService(log.LvlInfo)
+	require.NoError(t, err)
+
+	err = s.Initialise(gen, genesisHeader, genTrie)
+	require.NoError(t, err)
+
+	err = s.Start()
+	require.NoError(t, err)
+
+	require.Equal(t, genesisHeader.Hash(), s.Block.BestBlockHash())
+	require.Equal(t, genesisHeader.Hash(), s.Block.GenesisHash())
+
+	root, err := s.Storage.StorageRoot()
+	require.NoError(t, err)
+	require.Equal(t, genTrie.MustHash(), root)
+
+	dir := s.DB().Path()
+	err = s.Stop()
+	require.NoError(t, err)
+
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
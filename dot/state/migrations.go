@@ -0,0 +1,149 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/ChainSafe/chaindb"
+)
+
+// migration upgrades the database schema from version to version+1. The changes made by migrate must be written to
+// the given batch, which is only flushed, together with the new schema version, if the migration succeeds.
+type migration struct {
+	version uint32
+	name    string
+	migrate func(db chaindb.Database, batch chaindb.Batch) error
+}
+
+// migrations is the ordered list of database migrations, where migrations[i] upgrades from version i to version i+1.
+// Migrations must be idempotent, so that a migration can be re-run if the node stops before it is recorded.
+var migrations = []migration{
+	{
+		version: 0,
+		name:    "record schema version",
+		migrate: func(chaindb.Database, chaindb.Batch) error { return nil },
+	},
+	{
+		version: 1,
+		name:    "re-index block number to hash",
+		migrate: reindexBlockNumbers,
+	},
+}
+
+// Migrate runs the migrations needed to upgrade the database from its stored schema version to SchemaVersion.
+// Databases created before the schema version was recorded are treated as version 0.
+func Migrate(db chaindb.Database) error {
+	return runMigrations(db, migrations, SchemaVersion)
+}
+
+func runMigrations(db chaindb.Database, migrations []migration, target uint32) error {
+	base := NewBaseState(db)
+	version, err := base.LoadSchemaVersion()
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		version = 0
+	} else if err != nil {
+		return fmt.Errorf("failed to load database schema version: %w", err)
+	}
+
+	if version > target {
+		return fmt.Errorf("database schema version %d is newer than the latest supported version %d", version, target)
+	}
+
+	for ; version < target; version++ {
+		if int(version) >= len(migrations) || migrations[version].version != version {
+			return fmt.Errorf("no migration from database schema version %d", version)
+		}
+
+		m := migrations[version]
+		logger.Info("migrating database...", "from", version, "to", version+1, "migration", m.name)
+
+		batch := db.NewBatch()
+		if err = m.migrate(db, batch); err != nil {
+			batch.Reset()
+			return fmt.Errorf("failed to migrate database from schema version %d (%s): %w", version, m.name, err)
+		}
+
+		if err = batch.Put(common.SchemaVersionKey, encodeSchemaVersion(version+1)); err != nil {
+			batch.Reset()
+			return err
+		}
+
+		if err = batch.Flush(); err != nil {
+			return fmt.Errorf("failed to write migration from schema version %d: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// reindexBlockNumbers rebuilds the block number to hash index of the canonical chain by walking back from the best
+// block until the genesis block, or until a header is no longer in the database.
+func reindexBlockNumbers(db chaindb.Database, batch chaindb.Batch) error {
+	blockDB := chaindb.NewTable(db, blockPrefix)
+	blockBatch := &prefixedBatch{Batch: batch, prefix: []byte(blockPrefix)}
+
+	hash, err := NewBaseState(db).LoadBestBlockHash()
+	if err != nil {
+		return fmt.Errorf("failed to load best block hash: %w", err)
+	}
+
+	for {
+		data, err := blockDB.Get(headerKey(hash))
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		header := new(types.Header)
+		if _, err = header.Decode(bytes.NewBuffer(data)); err != nil {
+			return fmt.Errorf("failed to decode header %s: %w", hash, err)
+		}
+
+		if err = blockBatch.Put(headerHashKey(header.Number.Uint64()), hash.ToBytes()); err != nil {
+			return err
+		}
+
+		if header.Number.Sign() == 0 {
+			return nil
+		}
+
+		hash = header.ParentHash
+	}
+}
+
+// prefixedBatch writes to a batch of the un-prefixed database, prepending prefix to each key, so that a migration
+// can update several tables atomically
+type prefixedBatch struct {
+	chaindb.Batch
+	prefix []byte
+}
+
+func (b *prefixedBatch) Put(key, value []byte) error {
+	return b.Batch.Put(append(append([]byte{}, b.prefix...), key...), value)
+}
+
+func (b *prefixedBatch) Del(key []byte) error {
+	return b.Batch.Del(append(append([]byte{}, b.prefix...), key...))
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMigrationBlockState returns a BlockState with a canonical chain of the given depth, whose database is at
+// schema version 1 and is missing the block number to hash index for all blocks after genesis
+func newTestMigrationBlockState(t *testing.T, depth int) (*BlockState, []*types.Header) {
+	header := &types.Header{
+		Number:    big.NewInt(0),
+		StateRoot: trie.EmptyHash,
+	}
+
+	bs := newTestBlockState(t, header)
+	chain, _ := AddBlocksToState(t, bs, depth)
+
+	for _, h := range chain {
+		err := bs.db.Del(headerHashKey(h.Number.Uint64()))
+		require.NoError(t, err)
+	}
+
+	err := bs.baseState.StoreSchemaVersion(1)
+	require.NoError(t, err)
+	return bs, chain
+}
+
+func TestMigrate(t *testing.T) {
+	bs, chain := newTestMigrationBlockState(t, 10)
+
+	_, err := bs.GetHashByNumber(chain[len(chain)-1].Number)
+	require.Error(t, err)
+
+	err = Migrate(bs.baseState.db)
+	require.NoError(t, err)
+
+	version, err := bs.baseState.LoadSchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, version)
+
+	for _, h := range chain {
+		hash, err := bs.GetHashByNumber(h.Number)
+		require.NoError(t, err)
+		require.Equal(t, h.Hash(), hash)
+	}
+
+	// migrations are not re-run once recorded
+	err = Migrate(bs.baseState.db)
+	require.NoError(t, err)
+}
+
+func TestMigrate_NoSchemaVersion(t *testing.T) {
+	bs, chain := newTestMigrationBlockState(t, 5)
+	err := bs.baseState.db.Del(common.SchemaVersionKey)
+	require.NoError(t, err)
+
+	err = Migrate(bs.baseState.db)
+	require.NoError(t, err)
+
+	version, err := bs.baseState.LoadSchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, version)
+
+	hash, err := bs.GetHashByNumber(chain[len(chain)-1].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain[len(chain)-1].Hash(), hash)
+}
+
+func TestMigrate_Failure(t *testing.T) {
+	bs, chain := newTestMigrationBlockState(t, 5)
+
+	failing := []migration{
+		migrations[0],
+		{
+			version: 1,
+			name:    "failing",
+			migrate: func(db chaindb.Database, batch chaindb.Batch) error {
+				if err := reindexBlockNumbers(db, batch); err != nil {
+					return err
+				}
+				return errors.New("migration failed")
+			},
+		},
+	}
+
+	err := runMigrations(bs.baseState.db, failing, 2)
+	require.Error(t, err)
+
+	// the database is left at the version and state it had before the failed migration
+	version, err := bs.baseState.LoadSchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), version)
+
+	_, err = bs.GetHashByNumber(chain[len(chain)-1].Number)
+	require.Error(t, err)
+}
+
+func TestMigrate_NewerVersion(t *testing.T) {
+	bs, _ := newTestMigrationBlockState(t, 1)
+	err := bs.baseState.StoreSchemaVersion(SchemaVersion + 1)
+	require.NoError(t, err)
+
+	err = Migrate(bs.baseState.db)
+	require.Error(t, err)
+}
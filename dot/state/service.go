@@ -19,13 +19,16 @@ package state
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/ChainSafe/chaindb"
 	log "github.com/ChainSafe/log15"
@@ -38,7 +41,8 @@ type Service struct {
 	dbPath      string
 	logLvl      log.Lvl
 	db          chaindb.Database
-	isMemDB     bool // set to true if using an in-memory database; only used for testing.
+	isMemDB     bool   // set to true if using an in-memory database
+	tmpDir      string // data directory of an in-memory service, removed when the service is stopped
 	Base        *BaseState
 	Storage     *StorageState
 	Block       *BlockState
@@ -54,7 +58,7 @@ type Service struct {
 
 // NewService create a new instance of Service
 func NewService(path string, lvl log.Lvl) *Service {
-	handler := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	handler := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	handler = log.CallerFileHandler(handler)
 	logger.SetHandler(log.LvlFilterHandler(lvl, handler))
 
@@ -69,9 +73,23 @@ func NewService(path string, lvl log.Lvl) *Service {
 	}
 }
 
+// NewInMemoryService creates a new instance of Service that keeps its state in an in-memory database, which is
+// discarded when the service is stopped. The service must be initialised with Initialise before it's started.
+func NewInMemoryService(lvl log.Lvl) (*Service, error) {
+	// the database still needs a data directory, since chaindb may write to it
+	dir, err := ioutil.TempDir("", "gossamer-memdb-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary data directory: %w", err)
+	}
+
+	s := NewService(dir, lvl)
+	s.UseMemDB()
+	s.tmpDir = dir
+	return s, nil
+}
+
 // UseMemDB tells the service to use an in-memory key-value store instead of a persistent database.
 // This should be called after NewService, and before Initialise.
-// This should only be used for testing.
 func (s *Service) UseMemDB() {
 	s.isMemDB = true
 }
@@ -177,8 +195,9 @@ func (s *Service) Start() error {
 
 	num, _ := s.Block.BestBlockNumber()
 	logger.Info("created state service", "head", s.Block.BestBlockHash(), "highest number", num)
-	// Start background goroutine to GC pruned keys.
+	// Start background goroutines to GC pruned keys and to sweep expired transaction bans.
 	go s.Storage.pruneStorage(s.closeCh)
+	go s.Transaction.sweepExpired(s.closeCh)
 	return nil
 }
 
@@ -291,7 +310,14 @@ func (s *Service) Stop() error {
 		return err
 	}
 
-	return s.db.Close()
+	if err = s.db.Close(); err != nil {
+		return err
+	}
+
+	if s.tmpDir != "" {
+		return os.RemoveAll(s.tmpDir)
+	}
+	return nil
 }
 
 // Import imports the given state corresponding to the given header and sets the head of the chain
@@ -376,6 +402,10 @@ func (s *Service) Import(header *types.Header, t *trie.Trie, firstSlot uint64) e
 		return err
 	}
 
+	if err := block.setArrivalTime(header.Hash(), time.Now()); err != nil {
+		return err
+	}
+
 	logger.Debug("Import", "best block hash", header.Hash(), "latest state root", root)
 	if err := s.db.Flush(); err != nil {
 		return err
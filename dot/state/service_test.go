@@ -96,6 +96,40 @@ func TestService_Initialise(t *testing.T) {
 	require.Equal(t, genesisHeader, head)
 }
 
+func TestService_Initialise_ChildStorage(t *testing.T) {
+	state := newTestService(t)
+	defer utils.RemoveTestDir(t)
+
+	genData, err := genesis.NewGenesisFromJSONRaw("../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+	genData.Genesis.ChildrenDefault = map[string]map[string]string{
+		"0x6b6579746f6368696c64": {"0x6e6f6f74": "0x0304"},
+	}
+
+	genTrie, err := genesis.NewTrieFromGenesis(genData)
+	require.NoError(t, err)
+
+	genesisHeader, err := types.NewHeader(common.NewHash([]byte{0}), genTrie.MustHash(), trie.EmptyHash, big.NewInt(0), types.Digest{})
+	require.NoError(t, err)
+
+	err = state.Initialise(genData, genesisHeader, genTrie)
+	require.NoError(t, err)
+
+	err = state.Start()
+	require.NoError(t, err)
+	defer func() {
+		_ = state.Stop()
+	}()
+
+	root, err := state.Storage.StorageRoot()
+	require.NoError(t, err)
+	require.Equal(t, genesisHeader.StateRoot, root)
+
+	val, err := state.Storage.GetStorageFromChild(&root, []byte("keytochild"), []byte("noot"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, val)
+}
+
 func TestMemDB_Start(t *testing.T) {
 	state := newTestMemDBService()
 
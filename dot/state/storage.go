@@ -47,6 +47,9 @@ type StorageState struct {
 	db   chaindb.Database
 	lock sync.RWMutex
 
+	// cache of decoded trie nodes read from the database, shared by reads of tries that aren't held in memory
+	nodeCache *trie.NodeCache
+
 	// change notifiers
 	changedLock  sync.RWMutex
 	observerList []Observer
@@ -67,14 +70,33 @@ func NewStorageState(db chaindb.Database, blockState *BlockState, t *trie.Trie)
 	tries := make(map[common.Hash]*trie.Trie)
 	tries[t.MustHash()] = t
 
+	nodeCache, err := trie.NewNodeCache(trie.DefaultNodeCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &StorageState{
 		blockState:   blockState,
 		tries:        tries,
 		db:           chaindb.NewTable(db, storagePrefix),
+		nodeCache:    nodeCache,
 		observerList: []Observer{},
 	}, nil
 }
 
+// SetNodeCacheSize replaces the cache of decoded trie nodes with an empty one holding at most size nodes
+func (s *StorageState) SetNodeCacheSize(size int) error {
+	nodeCache, err := trie.NewNodeCache(size)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nodeCache = nodeCache
+	return nil
+}
+
 // SetSyncing sets whether the node is currently syncing or not
 func (s *StorageState) SetSyncing(syncing bool) {
 	s.syncing = syncing
@@ -104,11 +126,12 @@ func (s *StorageState) StoreTrie(ts *rtstorage.TrieState) error {
 		}
 	}
 	s.tries[root] = ts.Trie()
+	nodeCache := s.nodeCache
 	s.lock.Unlock()
 
 	logger.Trace("cached trie in storage state", "root", root)
 
-	if err := ts.Trie().WriteDirty(s.db); err != nil {
+	if err := ts.Trie().WriteDirtyWithCache(s.db, nodeCache); err != nil {
 		logger.Warn("failed to write trie to database", "root", root, "error", err)
 		return err
 	}
@@ -197,7 +220,7 @@ func (s *StorageState) GetStorage(root *common.Hash, key []byte) ([]byte, error)
 		return val, nil
 	}
 
-	return trie.GetFromDB(s.db, *root, key)
+	return trie.GetFromDBWithCache(s.db, s.nodeCache, *root, key)
 }
 
 // GetStorageByBlockHash returns the value at the given key at the given block hash
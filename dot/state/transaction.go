@@ -1,22 +1,150 @@
 package state
 
 import (
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// DefaultBanTime is the duration for which a banned extrinsic hash is rejected
+	DefaultBanTime = 30 * time.Minute
+	// DefaultFutureTransactionMaxAge is the duration after which a Future transaction that is still in the pool is dropped
+	DefaultFutureTransactionMaxAge = time.Hour
+
+	sweepInterval = time.Minute
 )
 
 // TransactionState represents the queue of transactions
 type TransactionState struct {
 	queue *transaction.PriorityQueue
 	pool  *transaction.Pool
+
+	lock         sync.RWMutex
+	banned       map[common.Hash]time.Time // extrinsic hash -> ban expiry
+	added        map[common.Hash]time.Time // extrinsic hash -> time it was added to the pool
+	banTime      time.Duration
+	futureMaxAge time.Duration
+	now          func() time.Time
 }
 
 // NewTransactionState returns a new TransactionState
 func NewTransactionState() *TransactionState {
 	return &TransactionState{
-		queue: transaction.NewPriorityQueue(),
-		pool:  transaction.NewPool(),
+		queue:        transaction.NewPriorityQueue(),
+		pool:         transaction.NewPool(),
+		banned:       make(map[common.Hash]time.Time),
+		added:        make(map[common.Hash]time.Time),
+		banTime:      DefaultBanTime,
+		futureMaxAge: DefaultFutureTransactionMaxAge,
+		now:          time.Now,
+	}
+}
+
+// SetFutureTransactionMaxAge sets the duration after which a Future transaction that is still in the pool is dropped
+func (s *TransactionState) SetFutureTransactionMaxAge(age time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.futureMaxAge = age
+}
+
+// Ban rejects the extrinsic with the given hash until the ban time has elapsed
+func (s *TransactionState) Ban(hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.banned[hash] = s.now().Add(s.banTime)
+}
+
+// IsBanned returns true if the extrinsic with the given hash is banned
+func (s *TransactionState) IsBanned(hash common.Hash) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	expiry, has := s.banned[hash]
+	return has && s.now().Before(expiry)
+}
+
+// BannedCount returns the number of banned extrinsic hashes, including expired bans that have not been swept yet
+func (s *TransactionState) BannedCount() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.banned)
+}
+
+// sweep removes expired bans and drops Future transactions that have been in the pool for longer than the maximum age
+func (s *TransactionState) sweep() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	for hash, expiry := range s.banned {
+		if !now.Before(expiry) {
+			delete(s.banned, hash)
+		}
+	}
+
+	provided := make(map[string]struct{})
+	for _, vt := range s.queue.Pending() {
+		for _, tag := range vt.Validity.Provides {
+			provided[string(tag)] = struct{}{}
+		}
+	}
+
+	pooled := s.pool.Transactions()
+	for _, vt := range pooled {
+		for _, tag := range vt.Validity.Provides {
+			provided[string(tag)] = struct{}{}
+		}
+	}
+
+	for _, vt := range pooled {
+		hash := vt.Extrinsic.Hash()
+		added, has := s.added[hash]
+		if !has || now.Sub(added) <= s.futureMaxAge || !isFuture(vt, provided) {
+			continue
+		}
+
+		s.pool.Remove(hash)
+		delete(s.added, hash)
+		logger.Debug("dropped stale future transaction from pool", "hash", hash, "added", added)
+	}
+
+	metrics.GetOrRegisterGauge("state/transaction/banned", metrics.DefaultRegistry).Update(int64(len(s.banned)))
+}
+
+// isFuture returns true if the transaction requires a tag that isn't provided by any pending transaction, and so
+// can't be included in a block yet
+func isFuture(vt *transaction.ValidTransaction, provided map[string]struct{}) bool {
+	for _, tag := range vt.Validity.Requires {
+		if len(tag) == 0 {
+			continue
+		}
+
+		if _, has := provided[string(tag)]; !has {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sweepExpired periodically sweeps expired bans and stale pool transactions until closeCh is closed
+func (s *TransactionState) sweepExpired(closeCh chan interface{}) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sweep()
+		case <-closeCh:
+			return
+		}
 	}
 }
 
@@ -40,6 +168,16 @@ func (s *TransactionState) Pending() []*transaction.ValidTransaction {
 	return append(s.queue.Pending(), s.pool.Transactions()...)
 }
 
+// PendingInOrder returns the current transactions in the queue and pool ordered by priority, without removing them.
+// Transactions with the same priority are returned with those in the queue first, in the order they were added.
+func (s *TransactionState) PendingInOrder() []*transaction.ValidTransaction {
+	txs := append(s.queue.PendingInOrder(), s.pool.Transactions()...)
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Validity.Priority > txs[j].Validity.Priority
+	})
+	return txs
+}
+
 // PendingInPool returns the current transactions in the pool
 func (s *TransactionState) PendingInPool() []*transaction.ValidTransaction {
 	return s.pool.Transactions()
@@ -47,16 +185,26 @@ func (s *TransactionState) PendingInPool() []*transaction.ValidTransaction {
 
 // RemoveExtrinsic removes an extrinsic from the queue and pool
 func (s *TransactionState) RemoveExtrinsic(ext types.Extrinsic) {
-	s.pool.Remove(ext.Hash())
+	s.RemoveExtrinsicFromPool(ext)
 	s.queue.RemoveExtrinsic(ext)
 }
 
 // RemoveExtrinsicFromPool removes an extrinsic from the pool
 func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
-	s.pool.Remove(ext.Hash())
+	hash := ext.Hash()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pool.Remove(hash)
+	delete(s.added, hash)
 }
 
 // AddToPool adds a transaction to the pool
 func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Hash {
-	return s.pool.Insert(vt)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	hash := s.pool.Insert(vt)
+	if _, has := s.added[hash]; !has {
+		s.added[hash] = s.now()
+	}
+	return hash
 }
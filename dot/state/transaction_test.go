@@ -3,7 +3,9 @@ package state
 import (
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 
@@ -59,3 +61,119 @@ func TestTransactionState_Pending(t *testing.T) {
 	head := ts.Peek()
 	require.Nil(t, head)
 }
+
+func TestTransactionState_PendingInOrder(t *testing.T) {
+	ts := NewTransactionState()
+
+	queued := []*transaction.ValidTransaction{
+		{
+			Extrinsic: []byte("a"),
+			Validity:  &transaction.Validity{Priority: 3},
+		},
+		{
+			Extrinsic: []byte("b"),
+			Validity:  &transaction.Validity{Priority: 10},
+		},
+		{
+			Extrinsic: []byte("c"),
+			Validity:  &transaction.Validity{Priority: 3},
+		},
+	}
+	pooled := []*transaction.ValidTransaction{
+		{
+			Extrinsic: []byte("d"),
+			Validity:  &transaction.Validity{Priority: 3},
+		},
+		{
+			Extrinsic: []byte("e"),
+			Validity:  &transaction.Validity{Priority: 12},
+		},
+	}
+
+	for _, tx := range queued {
+		_, err := ts.Push(tx)
+		require.NoError(t, err)
+	}
+	for _, tx := range pooled {
+		ts.AddToPool(tx)
+	}
+
+	expected := []*transaction.ValidTransaction{pooled[1], queued[1], queued[0], queued[2], pooled[0]}
+	require.Equal(t, expected, ts.PendingInOrder())
+
+	// nothing is removed, and Pop returns the highest priority queued transactions first
+	require.Equal(t, len(expected), len(ts.Pending()))
+	require.Equal(t, queued[1], ts.Pop())
+	require.Equal(t, queued[0], ts.Pop())
+	require.Equal(t, queued[2], ts.Pop())
+	require.Nil(t, ts.Pop())
+}
+
+func TestTransactionState_SweepBanned(t *testing.T) {
+	ts := NewTransactionState()
+	now := time.Now()
+	ts.now = func() time.Time { return now }
+
+	ext := types.Extrinsic("a")
+	ts.Ban(ext.Hash())
+	require.True(t, ts.IsBanned(ext.Hash()))
+	require.Equal(t, 1, ts.BannedCount())
+
+	// the ban is kept until it expires
+	ts.sweep()
+	require.Equal(t, 1, ts.BannedCount())
+
+	now = now.Add(DefaultBanTime)
+	require.False(t, ts.IsBanned(ext.Hash()))
+
+	ts.sweep()
+	require.Equal(t, 0, ts.BannedCount())
+
+	// the hash can be banned again once the ban has been swept
+	ts.Ban(ext.Hash())
+	require.True(t, ts.IsBanned(ext.Hash()))
+}
+
+func TestTransactionState_SweepFuture(t *testing.T) {
+	ts := NewTransactionState()
+	ts.SetFutureTransactionMaxAge(time.Minute)
+	now := time.Now()
+	ts.now = func() time.Time { return now }
+
+	// requires a tag that no pending transaction provides
+	future := &transaction.ValidTransaction{
+		Extrinsic: []byte("a"),
+		Validity:  &transaction.Validity{Priority: 1, Requires: [][]byte{{1}}},
+	}
+	ts.AddToPool(future)
+
+	// its requirement is provided by a pending transaction
+	ready := &transaction.ValidTransaction{
+		Extrinsic: []byte("b"),
+		Validity:  &transaction.Validity{Priority: 2, Requires: [][]byte{{2}}},
+	}
+	ts.AddToPool(ready)
+	_, err := ts.Push(&transaction.ValidTransaction{
+		Extrinsic: []byte("c"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{2}}},
+	})
+	require.NoError(t, err)
+
+	// requires nothing
+	old := &transaction.ValidTransaction{
+		Extrinsic: []byte("d"),
+		Validity:  &transaction.Validity{Priority: 3, Requires: [][]byte{{}}},
+	}
+	ts.AddToPool(old)
+
+	now = now.Add(30 * time.Second)
+	fresh := &transaction.ValidTransaction{
+		Extrinsic: []byte("e"),
+		Validity:  &transaction.Validity{Priority: 4, Requires: [][]byte{{3}}},
+	}
+	ts.AddToPool(fresh)
+
+	now = now.Add(31 * time.Second)
+	ts.sweep()
+	require.Equal(t, []*transaction.ValidTransaction{fresh, old, ready}, ts.PendingInPool())
+}
@@ -33,6 +33,7 @@ type BlockState interface {
 	AddBlock(*types.Block) error
 	CompareAndSetBlockData(bd *types.BlockData) error
 	GetBlockByNumber(*big.Int) (*types.Block, error)
+	GetHashByNumber(*big.Int) (common.Hash, error)
 	HasBlockBody(hash common.Hash) (bool, error)
 	GetBlockBody(common.Hash) (*types.Body, error)
 	SetHeader(*types.Header) error
@@ -79,5 +80,5 @@ type Verifier interface {
 
 // FinalityGadget implements justification verification functionality
 type FinalityGadget interface {
-	VerifyBlockJustification([]byte) error
+	VerifyBlockJustification(common.Hash, *big.Int, []byte) error
 }
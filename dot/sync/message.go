@@ -29,63 +29,47 @@ var maxResponseSize int64 = 128 // maximum number of block datas to reply with i
 
 // CreateBlockResponse creates a block response message from a block request message
 func (s *Service) CreateBlockResponse(blockRequest *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
-	var startHash common.Hash
-	var endHash common.Hash
-
 	if blockRequest.StartingBlock == nil {
 		return nil, ErrInvalidBlockRequest
 	}
 
-	switch startBlock := blockRequest.StartingBlock.Value().(type) {
-	case uint64:
-		if startBlock == 0 {
-			startBlock = 1
-		}
-		block, err := s.blockState.GetBlockByNumber(big.NewInt(0).SetUint64(startBlock))
-		if err != nil {
-			return nil, err
-		}
-
-		startHash = block.Header.Hash()
-	case common.Hash:
-		startHash = startBlock
-	}
-
-	if blockRequest.EndBlockHash != nil && blockRequest.EndBlockHash.Exists() {
-		endHash = blockRequest.EndBlockHash.Value()
-	} else {
-		endHash = s.blockState.BestBlockHash()
+	max := maxResponseSize
+	if blockRequest.Max != nil && blockRequest.Max.Exists() && int64(blockRequest.Max.Value()) < max {
+		max = int64(blockRequest.Max.Value())
 	}
 
-	startHeader, err := s.blockState.GetHeader(startHash)
-	if err != nil {
-		return nil, err
+	var (
+		subchain []common.Hash
+		err      error
+	)
+
+	switch blockRequest.Direction {
+	case network.Ascending:
+		subchain, err = s.getAscendingSubchain(blockRequest)
+	case network.Descending:
+		subchain, err = s.getDescendingSubchain(blockRequest, max)
+	default:
+		return nil, ErrInvalidBlockRequest
 	}
-
-	endHeader, err := s.blockState.GetHeader(endHash)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug("handling BlockRequestMessage", "start", startHeader.Number, "end", endHeader.Number, "startHash", startHash, "endHash", endHash)
-
-	// get sub-chain of block hashes
-	subchain, err := s.blockState.SubChain(startHash, endHash)
-	if err != nil {
-		return nil, err
+	if len(subchain) > int(max) {
+		subchain = subchain[:max]
 	}
 
-	if len(subchain) > int(maxResponseSize) {
-		subchain = subchain[:maxResponseSize]
+	if len(subchain) == 0 {
+		return &network.BlockResponseMessage{
+			BlockData: []*types.BlockData{},
+		}, nil
 	}
 
 	logger.Trace("subchain", "start", subchain[0], "end", subchain[len(subchain)-1])
 
 	responseData := []*types.BlockData{}
 
-	// TODO: check ascending vs descending direction
 	for _, hash := range subchain {
-
 		blockData := new(types.BlockData)
 		blockData.Hash = hash
 
@@ -139,8 +123,97 @@ func (s *Service) CreateBlockResponse(blockRequest *network.BlockRequestMessage)
 		responseData = append(responseData, blockData)
 	}
 
-	logger.Debug("sending BlockResponseMessage", "start", startHeader.Number, "end", endHeader.Number)
+	logger.Debug("sending BlockResponseMessage", "start", subchain[0], "end", subchain[len(subchain)-1])
 	return &network.BlockResponseMessage{
 		BlockData: responseData,
 	}, nil
 }
+
+// getAscendingSubchain returns the hashes of the blocks from the requested start block to the requested end block,
+// or to our best block if no end block is requested, in ascending order
+func (s *Service) getAscendingSubchain(blockRequest *network.BlockRequestMessage) ([]common.Hash, error) {
+	var startHash common.Hash
+	var endHash common.Hash
+
+	switch startBlock := blockRequest.StartingBlock.Value().(type) {
+	case uint64:
+		if startBlock == 0 {
+			startBlock = 1
+		}
+		block, err := s.blockState.GetBlockByNumber(big.NewInt(0).SetUint64(startBlock))
+		if err != nil {
+			return nil, err
+		}
+
+		startHash = block.Header.Hash()
+	case common.Hash:
+		startHash = startBlock
+	}
+
+	if blockRequest.EndBlockHash != nil && blockRequest.EndBlockHash.Exists() {
+		endHash = blockRequest.EndBlockHash.Value()
+	} else {
+		endHash = s.blockState.BestBlockHash()
+	}
+
+	startHeader, err := s.blockState.GetHeader(startHash)
+	if err != nil {
+		return nil, err
+	}
+
+	endHeader, err := s.blockState.GetHeader(endHash)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("handling BlockRequestMessage", "start", startHeader.Number, "end", endHeader.Number, "startHash", startHash, "endHash", endHash)
+
+	// get sub-chain of block hashes
+	return s.blockState.SubChain(startHash, endHash)
+}
+
+// getDescendingSubchain returns the hashes of at most max blocks walking back from the requested start block
+// towards the requested end block, in descending order. if no end block is requested, or the end block isn't
+// an ancestor of the start block, it stops at genesis.
+func (s *Service) getDescendingSubchain(blockRequest *network.BlockRequestMessage, max int64) ([]common.Hash, error) {
+	var (
+		hash common.Hash
+		err  error
+	)
+
+	switch startBlock := blockRequest.StartingBlock.Value().(type) {
+	case uint64:
+		hash, err = s.blockState.GetHashByNumber(big.NewInt(0).SetUint64(startBlock))
+		if err != nil {
+			return nil, err
+		}
+	case common.Hash:
+		hash = startBlock
+	}
+
+	var endHash *common.Hash
+	if blockRequest.EndBlockHash != nil && blockRequest.EndBlockHash.Exists() {
+		end := blockRequest.EndBlockHash.Value()
+		endHash = &end
+	}
+
+	logger.Debug("handling descending BlockRequestMessage", "startHash", hash, "endHash", endHash)
+
+	subchain := []common.Hash{}
+	for int64(len(subchain)) < max {
+		header, err := s.blockState.GetHeader(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		subchain = append(subchain, hash)
+
+		if (endHash != nil && hash == *endHash) || header.Number.Sign() == 0 {
+			break
+		}
+
+		hash = header.ParentHash
+	}
+
+	return subchain, nil
+}
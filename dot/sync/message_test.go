@@ -7,6 +7,7 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/common/optional"
 	"github.com/ChainSafe/gossamer/lib/common/variadic"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -98,7 +99,7 @@ func TestService_CreateBlockResponse(t *testing.T) {
 				RequestedData: 3,
 				StartingBlock: start,
 				EndBlockHash:  optional.NewHash(true, endHash),
-				Direction:     1,
+				Direction:     network.Ascending,
 				Max:           optional.NewUint32(false, 0),
 			},
 			expectedMsgValue: &network.BlockResponseMessage{
@@ -117,7 +118,7 @@ func TestService_CreateBlockResponse(t *testing.T) {
 				RequestedData: 1,
 				StartingBlock: start,
 				EndBlockHash:  optional.NewHash(true, endHash),
-				Direction:     1,
+				Direction:     network.Ascending,
 				Max:           optional.NewUint32(false, 0),
 			},
 			expectedMsgValue: &network.BlockResponseMessage{
@@ -136,7 +137,7 @@ func TestService_CreateBlockResponse(t *testing.T) {
 				RequestedData: 4,
 				StartingBlock: start,
 				EndBlockHash:  optional.NewHash(true, endHash),
-				Direction:     1,
+				Direction:     network.Ascending,
 				Max:           optional.NewUint32(false, 0),
 			},
 			expectedMsgValue: &network.BlockResponseMessage{
@@ -156,7 +157,7 @@ func TestService_CreateBlockResponse(t *testing.T) {
 				RequestedData: 8,
 				StartingBlock: start,
 				EndBlockHash:  optional.NewHash(true, endHash),
-				Direction:     1,
+				Direction:     network.Ascending,
 				Max:           optional.NewUint32(false, 0),
 			},
 			expectedMsgValue: &network.BlockResponseMessage{
@@ -195,3 +196,127 @@ func TestService_CreateBlockResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestService_CreateBlockResponse_Descending(t *testing.T) {
+	s := newTestSyncer(t)
+	addTestBlocksToState(t, 4, s.blockState)
+
+	hashes := make([]common.Hash, 5)
+	for i := range hashes {
+		var err error
+		hashes[i], err = s.blockState.GetHashByNumber(big.NewInt(int64(i)))
+		require.NoError(t, err)
+	}
+
+	startByNumber, err := variadic.NewUint64OrHash(uint64(4))
+	require.NoError(t, err)
+
+	startByHash, err := variadic.NewUint64OrHash(hashes[3])
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		value       *network.BlockRequestMessage
+		expected    []common.Hash
+	}{
+		{
+			description: "test descending to genesis",
+			value: &network.BlockRequestMessage{
+				RequestedData: 1,
+				StartingBlock: startByNumber,
+				EndBlockHash:  optional.NewHash(false, common.Hash{}),
+				Direction:     network.Descending,
+				Max:           optional.NewUint32(false, 0),
+			},
+			expected: []common.Hash{hashes[4], hashes[3], hashes[2], hashes[1], hashes[0]},
+		},
+		{
+			description: "test descending with max",
+			value: &network.BlockRequestMessage{
+				RequestedData: 1,
+				StartingBlock: startByNumber,
+				EndBlockHash:  optional.NewHash(false, common.Hash{}),
+				Direction:     network.Descending,
+				Max:           optional.NewUint32(true, 2),
+			},
+			expected: []common.Hash{hashes[4], hashes[3]},
+		},
+		{
+			description: "test descending to end block",
+			value: &network.BlockRequestMessage{
+				RequestedData: 1,
+				StartingBlock: startByHash,
+				EndBlockHash:  optional.NewHash(true, hashes[1]),
+				Direction:     network.Descending,
+				Max:           optional.NewUint32(false, 0),
+			},
+			expected: []common.Hash{hashes[3], hashes[2], hashes[1]},
+		},
+		{
+			description: "test descending from genesis",
+			value: &network.BlockRequestMessage{
+				RequestedData: 1,
+				StartingBlock: func() *variadic.Uint64OrHash {
+					v, err := variadic.NewUint64OrHash(uint64(0))
+					require.NoError(t, err)
+					return v
+				}(),
+				EndBlockHash: optional.NewHash(false, common.Hash{}),
+				Direction:    network.Descending,
+				Max:          optional.NewUint32(true, 10),
+			},
+			expected: []common.Hash{hashes[0]},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			resp, err := s.CreateBlockResponse(test.value)
+			require.NoError(t, err)
+			require.Len(t, resp.BlockData, len(test.expected))
+
+			for i, bd := range resp.BlockData {
+				require.Equal(t, test.expected[i], bd.Hash)
+				require.True(t, bd.Header.Exists())
+			}
+		})
+	}
+}
+
+func TestService_CreateBlockResponse_JustificationOnly(t *testing.T) {
+	s := newTestSyncer(t)
+	addTestBlocksToState(t, 2, s.blockState)
+
+	bestHash := s.blockState.BestBlockHash()
+	just := []byte("qwerty")
+	err := s.blockState.SetJustification(bestHash, just)
+	require.NoError(t, err)
+
+	firstHash, err := s.blockState.GetHashByNumber(big.NewInt(1))
+	require.NoError(t, err)
+
+	start, err := variadic.NewUint64OrHash(firstHash)
+	require.NoError(t, err)
+
+	resp, err := s.CreateBlockResponse(&network.BlockRequestMessage{
+		RequestedData: network.RequestedDataJustification,
+		StartingBlock: start,
+		EndBlockHash:  optional.NewHash(true, bestHash),
+		Direction:     network.Ascending,
+		Max:           optional.NewUint32(false, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.BlockData, 2)
+
+	for _, bd := range resp.BlockData {
+		require.False(t, bd.Header.Exists())
+		require.False(t, bd.Body.Exists())
+		require.False(t, bd.Receipt.Exists())
+		require.False(t, bd.MessageQueue.Exists())
+	}
+
+	require.Equal(t, firstHash, resp.BlockData[0].Hash)
+	require.False(t, resp.BlockData[0].Justification.Exists())
+	require.Equal(t, bestHash, resp.BlockData[1].Hash)
+	require.Equal(t, optional.NewBytes(true, just), resp.BlockData[1].Justification)
+}
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"os"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -30,6 +29,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 )
 
@@ -93,7 +93,7 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg.BlockProducer = newMockBlockProducer()
 	}
 
-	handler := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	handler := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	handler = log.CallerFileHandler(handler)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, handler))
 
@@ -152,7 +152,9 @@ func (s *Service) HandleBlockAnnounce(msg *network.BlockAnnounceMessage) error {
 	return nil
 }
 
-// ProcessJustification processes block data containing justifications
+// ProcessJustification processes block data containing justifications. each justification is verified by the
+// finality gadget before the block is marked as finalised. on failure, it returns the index of the block data
+// that errored.
 func (s *Service) ProcessJustification(data []*types.BlockData) (int, error) {
 	if len(data) == 0 {
 		return 0, ErrNilBlockData
@@ -166,7 +168,10 @@ func (s *Service) ProcessJustification(data []*types.BlockData) (int, error) {
 
 		if bd.Justification != nil && bd.Justification.Exists() {
 			logger.Debug("handling Justification...", "number", header.Number, "hash", bd.Hash)
-			s.handleJustification(header, bd.Justification.Value())
+			err = s.handleJustification(header, bd.Justification.Value())
+			if err != nil {
+				return i, err
+			}
 		}
 	}
 
@@ -214,7 +219,10 @@ func (s *Service) ProcessBlockData(data []*types.BlockData) (int, error) {
 
 			if bd.Justification != nil && bd.Justification.Exists() {
 				logger.Debug("handling Justification...", "number", header.Number, "hash", bd.Hash)
-				s.handleJustification(header, bd.Justification.Value())
+				err = s.handleJustification(header, bd.Justification.Value())
+				if err != nil {
+					logger.Warn("failed to handle block justification", "hash", bd.Hash, "error", err)
+				}
 			}
 
 			continue
@@ -283,7 +291,10 @@ func (s *Service) ProcessBlockData(data []*types.BlockData) (int, error) {
 
 		if bd.Justification != nil && bd.Justification.Exists() && header != nil {
 			logger.Debug("handling Justification...", "number", bd.Number(), "hash", bd.Hash)
-			s.handleJustification(header, bd.Justification.Value())
+			err := s.handleJustification(header, bd.Justification.Value())
+			if err != nil {
+				logger.Warn("failed to handle block justification", "hash", bd.Hash, "error", err)
+			}
 		}
 	}
 
@@ -375,30 +386,31 @@ func (s *Service) handleBlock(block *types.Block) error {
 	return s.handleRuntimeChanges(ts)
 }
 
-func (s *Service) handleJustification(header *types.Header, justification []byte) {
+// handleJustification verifies the justification for the block and, if it's valid, marks the block as finalised.
+// it returns an error if the justification is invalid.
+func (s *Service) handleJustification(header *types.Header, justification []byte) error {
 	if len(justification) == 0 || header == nil {
-		return
+		return nil
 	}
 
-	err := s.finalityGadget.VerifyBlockJustification(justification)
+	err := s.finalityGadget.VerifyBlockJustification(header.Hash(), header.Number, justification)
 	if err != nil {
-		logger.Warn("failed to verify block justification", "hash", header.Hash(), "number", header.Number, "error", err)
-		return
+		return fmt.Errorf("failed to verify justification for block %s: %w", header.Hash(), err)
 	}
 
-	err = s.blockState.SetFinalizedHash(header.Hash(), 0, 0)
+	// the justification is stored first, so that it can be read by those notified of the finalisation
+	err = s.blockState.SetJustification(header.Hash(), justification)
 	if err != nil {
-		logger.Error("failed to set finalised hash", "error", err)
-		return
+		return fmt.Errorf("failed to store justification: %w", err)
 	}
 
-	err = s.blockState.SetJustification(header.Hash(), justification)
+	err = s.blockState.SetFinalizedHash(header.Hash(), 0, 0)
 	if err != nil {
-		logger.Error("failed tostore justification", "error", err)
-		return
+		return fmt.Errorf("failed to set finalised hash: %w", err)
 	}
 
 	logger.Info("🔨 finalised block", "number", header.Number, "hash", header.Hash())
+	return nil
 }
 
 func (s *Service) handleRuntimeChanges(newState *rtstorage.TrieState) error {
@@ -44,10 +44,18 @@ import (
 
 type mockFinalityGadget struct{}
 
-func (m mockFinalityGadget) VerifyBlockJustification(_ []byte) error {
+func (m mockFinalityGadget) VerifyBlockJustification(_ common.Hash, _ *big.Int, _ []byte) error {
 	return nil
 }
 
+var errTestInvalidJustification = errors.New("invalid justification")
+
+type mockRejectingFinalityGadget struct{}
+
+func (m mockRejectingFinalityGadget) VerifyBlockJustification(_ common.Hash, _ *big.Int, _ []byte) error {
+	return errTestInvalidJustification
+}
+
 func newTestGenesisWithTrieAndHeader(t *testing.T) (*genesis.Genesis, *trie.Trie, *types.Header) {
 	gen, err := genesis.NewGenesisFromJSONRaw("../../chain/gssmr/genesis.json")
 	require.NoError(t, err)
@@ -364,6 +372,34 @@ func TestSyncer_HandleJustification(t *testing.T) {
 	require.Equal(t, just, res)
 }
 
+func TestSyncer_HandleJustification_StoredBeforeFinalised(t *testing.T) {
+	syncer := newTestSyncer(t)
+	addTestBlocksToState(t, 1, syncer.blockState)
+	bs := syncer.blockState.(*state.BlockState)
+
+	header, err := bs.BestBlockHeader()
+	require.NoError(t, err)
+
+	ch := make(chan *types.FinalisationInfo, 1)
+	id, err := bs.RegisterFinalizedChannel(ch)
+	require.NoError(t, err)
+	defer bs.UnregisterFinalizedChannel(id)
+
+	just := []byte("testjustification")
+	err = syncer.handleJustification(header, just)
+	require.NoError(t, err)
+
+	// the justification can be read as soon as the finalisation is notified
+	select {
+	case info := <-ch:
+		res, err := bs.GetJustification(info.Header.Hash())
+		require.NoError(t, err)
+		require.Equal(t, just, res)
+	case <-time.After(time.Second):
+		t.Fatal("did not receive finalisation notification")
+	}
+}
+
 func TestSyncer_ProcessJustification(t *testing.T) {
 	syncer := newTestSyncer(t)
 
@@ -389,3 +425,29 @@ func TestSyncer_ProcessJustification(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, just, res)
 }
+
+func TestSyncer_ProcessJustification_Invalid(t *testing.T) {
+	syncer := newTestSyncer(t)
+	syncer.finalityGadget = &mockRejectingFinalityGadget{}
+	addTestBlocksToState(t, 1, syncer.blockState)
+
+	bestHash := syncer.blockState.BestBlockHash()
+	data := []*types.BlockData{
+		{
+			Hash:          bestHash,
+			Justification: optional.NewBytes(true, []byte("testjustification")),
+		},
+	}
+
+	idx, err := syncer.ProcessJustification(data)
+	require.True(t, errors.Is(err, errTestInvalidJustification))
+	require.Equal(t, 0, idx)
+
+	// the block isn't finalised and the justification isn't stored
+	_, err = syncer.blockState.GetJustification(bestHash)
+	require.Error(t, err)
+
+	finalised, err := syncer.blockState.(*state.BlockState).GetFinalizedHash(0, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, bestHash, finalised)
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Properties holds the well known chain properties defined in the properties block of the genesis file
+type Properties struct {
+	SS58Format    uint8  `json:"ss58Format"`
+	TokenDecimals uint32 `json:"tokenDecimals"`
+	TokenSymbol   string `json:"tokenSymbol"`
+}
+
+// ParseProperties parses the genesis properties block into Properties
+func ParseProperties(props map[string]interface{}) (*Properties, error) {
+	if props == nil {
+		return &Properties{}, nil
+	}
+
+	bz, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Properties{}
+	if err = json.Unmarshal(bz, p); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis properties: %w", err)
+	}
+
+	return p, nil
+}
+
+// toMap returns the raw genesis properties with the well known ones that are present replaced by their
+// parsed types, so that custom properties are kept and no properties are added
+func (p *Properties) toMap(raw map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		res[k] = v
+	}
+
+	if _, ok := raw["ss58Format"]; ok {
+		res["ss58Format"] = p.SS58Format
+	}
+	if _, ok := raw["tokenDecimals"]; ok {
+		res["tokenDecimals"] = p.TokenDecimals
+	}
+	if _, ok := raw["tokenSymbol"]; ok {
+		res["tokenSymbol"] = p.TokenSymbol
+	}
+	return res
+}
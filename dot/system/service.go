@@ -25,14 +25,22 @@ import (
 type Service struct {
 	systemInfo  *types.SystemInfo
 	genesisData *genesis.Data
+	properties  *Properties
 }
 
 // NewService create a new instance of Service
 func NewService(si *types.SystemInfo, gd *genesis.Data) *Service {
-	return &Service{
+	s := &Service{
 		systemInfo:  si,
 		genesisData: gd,
 	}
+
+	if gd != nil {
+		// properties that can't be parsed, eg. a list of token symbols, are returned as defined in genesis
+		s.properties, _ = ParseProperties(gd.Properties)
+	}
+
+	return s
 }
 
 // SystemName returns the app name
@@ -50,6 +58,11 @@ func (s *Service) SystemVersion() string {
 	return s.systemInfo.SystemVersion
 }
 
+// ChainID returns the chain id defined in genesis.json
+func (s *Service) ChainID() string {
+	return s.genesisData.ID
+}
+
 // ChainName returns the chain name defined in genesis.json
 func (s *Service) ChainName() string {
 	return s.genesisData.Name
@@ -57,7 +70,11 @@ func (s *Service) ChainName() string {
 
 // Properties Get a custom set of properties as a JSON object, defined in the chain spec.
 func (s *Service) Properties() map[string]interface{} {
-	return s.genesisData.Properties
+	if s.genesisData.Properties == nil || s.properties == nil {
+		return s.genesisData.Properties
+	}
+
+	return s.properties.toMap(s.genesisData.Properties)
 }
 
 // Start implements Service interface
@@ -52,6 +52,29 @@ func TestService_Properties(t *testing.T) {
 	require.Equal(t, expected, props)
 }
 
+func TestService_Properties_OnlyGenesisKeys(t *testing.T) {
+	svc := NewService(&types.SystemInfo{}, &genesis.Data{
+		Properties: map[string]interface{}{"tokenDecimals": float64(10), "tokenSymbol": "DOT", "custom": "value"},
+	})
+
+	expected := map[string]interface{}{"tokenDecimals": uint32(10), "tokenSymbol": "DOT", "custom": "value"}
+	require.Equal(t, expected, svc.Properties())
+}
+
+func TestService_Properties_NonScalar(t *testing.T) {
+	raw := map[string]interface{}{"tokenDecimals": []interface{}{float64(10), float64(12)}, "tokenSymbol": []interface{}{"DOT", "KSM"}}
+	svc := NewService(&types.SystemInfo{}, &genesis.Data{
+		Properties: raw,
+	})
+
+	require.Equal(t, raw, svc.Properties())
+}
+
+func TestParseProperties_Invalid(t *testing.T) {
+	_, err := ParseProperties(map[string]interface{}{"tokenDecimals": "ten"})
+	require.Error(t, err)
+}
+
 func TestService_Start(t *testing.T) {
 	svc := newTestService()
 	err := svc.Start()
@@ -18,6 +18,7 @@ package telemetry
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -30,11 +31,30 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+var (
+	// reconnectBackoffMin is the initial time to wait before retrying to connect to a telemetry endpoint
+	reconnectBackoffMin = time.Second
+	// reconnectBackoffMax is the maximum time to wait before retrying to connect to a telemetry endpoint
+	reconnectBackoffMax = time.Minute
+)
+
 // Handler struct for holding telemetry related things
 type Handler struct {
-	buf    bytes.Buffer
-	wsConn []*websocket.Conn
+	buf bytes.Buffer
 	sync.RWMutex
+
+	connLock     sync.Mutex
+	wsConn       []*connection
+	connectedMsg []byte // the latest system.connected message, re-sent when reconnecting
+
+	// ctx is cancelled by Stop, which ends any reconnection attempts
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type connection struct {
+	endpoint string
+	conn     *websocket.Conn
 }
 
 // MyJSONFormatter struct for defining JSON Formatter
@@ -72,18 +92,103 @@ func GetInstance() *Handler {
 	return handlerInstance
 }
 
-// AddConnections adds connections to telemetry sever
+// AddConnections adds connections to telemetry sever. If an endpoint cannot be connected to, connecting is
+// retried in the background with an exponential backoff until it succeeds or the handler is stopped.
 func (h *Handler) AddConnections(conns []*genesis.TelemetryEndpoint) {
+	h.connLock.Lock()
+	if h.ctx == nil || h.ctx.Err() != nil {
+		h.ctx, h.cancel = context.WithCancel(context.Background())
+	}
+	ctx := h.ctx
+	h.connLock.Unlock()
+
 	for _, v := range conns {
 		c, _, err := websocket.DefaultDialer.Dial(v.Endpoint, nil)
 		if err != nil {
 			fmt.Printf("Error %v\n", err)
+			go h.reconnect(ctx, v.Endpoint)
 			continue
 		}
-		h.wsConn = append(h.wsConn, c)
+		h.addConnection(ctx, v.Endpoint, c)
+	}
+}
+
+// Start is a no-op, it is implemented so the handler can be registered as a node service
+func (h *Handler) Start() error {
+	return nil
+}
+
+// Stop stops any reconnection attempts and closes all connections to telemetry servers
+func (h *Handler) Stop() error {
+	h.connLock.Lock()
+	defer h.connLock.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	for _, c := range h.wsConn {
+		_ = c.conn.Close()
+	}
+	h.wsConn = nil
+	return nil
+}
+
+// reconnect attempts to connect to the endpoint until it succeeds or ctx is cancelled, doubling the time
+// between each attempt
+func (h *Handler) reconnect(ctx context.Context, endpoint string) {
+	backoff := reconnectBackoffMin
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		c, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+		if err == nil {
+			h.addConnection(ctx, endpoint, c)
+			return
+		}
+
+		backoff *= 2
+		if backoff > reconnectBackoffMax {
+			backoff = reconnectBackoffMax
+		}
+		timer.Reset(backoff)
 	}
 }
 
+func (h *Handler) addConnection(ctx context.Context, endpoint string, c *websocket.Conn) {
+	h.connLock.Lock()
+	defer h.connLock.Unlock()
+
+	// the handler was stopped while connecting
+	if ctx.Err() != nil {
+		_ = c.Close()
+		return
+	}
+
+	conn := &connection{
+		endpoint: endpoint,
+		conn:     c,
+	}
+
+	// the telemetry server needs the system.connected message before any other messages
+	if h.connectedMsg != nil {
+		if err := c.WriteMessage(websocket.TextMessage, h.connectedMsg); err != nil {
+			_ = c.Close()
+			go h.reconnect(ctx, endpoint)
+			return
+		}
+	}
+
+	h.wsConn = append(h.wsConn, conn)
+}
+
 // ConnectionData struct to hold connection data
 type ConnectionData struct {
 	Authority     bool
@@ -116,6 +221,15 @@ func (h *Handler) SendBlockImport(bestHash string, height *big.Int) {
 	telemetryLogger.Print()
 }
 
+// SendBlockFinalized sends block finalized message to telemetry connection
+func (h *Handler) SendBlockFinalized(hash common.Hash, height *big.Int) {
+	h.Lock()
+	defer h.Unlock()
+	payload := log.Fields{"best": hash.String(), "height": height.String(), "msg": "notify.finalized"}
+	telemetryLogger := log.WithFields(log.Fields{"id": 1, "payload": payload, "ts": time.Now()})
+	telemetryLogger.Print()
+}
+
 // NetworkData struct to hold network data telemetry information
 type NetworkData struct {
 	peers   int
@@ -171,12 +285,32 @@ func (h *Handler) sender() {
 			continue
 		}
 
-		for _, c := range h.wsConn {
-			err := c.WriteMessage(websocket.TextMessage, line)
-			if err != nil {
-				// TODO (ed) determine how to handle this error
-				fmt.Printf("ERROR connecting to telemetry %v\n", err)
-			}
+		h.send(line)
+	}
+}
+
+// send writes the message to all connections. if writing to a connection fails, the connection is closed and
+// reconnected in the background.
+func (h *Handler) send(msg []byte) {
+	h.connLock.Lock()
+	defer h.connLock.Unlock()
+
+	if bytes.Contains(msg, []byte(`"msg":"system.connected"`)) {
+		h.connectedMsg = msg
+	}
+
+	conns := h.wsConn[:0]
+	for _, c := range h.wsConn {
+		err := c.conn.WriteMessage(websocket.TextMessage, msg)
+		if err != nil {
+			fmt.Printf("ERROR connecting to telemetry %v\n", err)
+			_ = c.conn.Close()
+			go h.reconnect(h.ctx, c.endpoint)
+			continue
 		}
+
+		conns = append(conns, c)
 	}
+
+	h.wsConn = conns
 }
@@ -124,3 +124,101 @@ func listen(w http.ResponseWriter, r *http.Request) {
 		resultCh <- msg
 	}
 }
+
+func TestHandler_Reconnect(t *testing.T) {
+	reconnectBackoffMin = time.Millisecond * 10
+
+	// the endpoint isn't listening yet, so the first connection attempt fails
+	GetInstance().AddConnections([]*genesis.TelemetryEndpoint{
+		{
+			Endpoint: "ws://127.0.0.1:8002/",
+		},
+	})
+
+	GetInstance().SendConnection(&ConnectionData{
+		Chain:       "chain",
+		GenesisHash: "hash",
+		NodeName:    "nodeName",
+	})
+
+	received := make(chan []byte, 16)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- msg
+		}
+	})
+
+	srv := &http.Server{Addr: "127.0.0.1:8002", Handler: mux}
+	go srv.ListenAndServe() //nolint
+	defer srv.Close()       //nolint
+
+	// system.connected is sent once the connection is established
+	select {
+	case msg := <-received:
+		require.Contains(t, string(msg), `"msg":"system.connected"`)
+	case <-time.After(time.Second * 5):
+		t.Fatal("did not receive system.connected message")
+	}
+
+	GetInstance().SendBlockImport("hash", big.NewInt(2))
+	GetInstance().SendBlockFinalized(common.Hash{1}, big.NewInt(1))
+
+	expected := []string{
+		`{"id":1,"payload":{"best":"hash","height":2,"msg":"block.import","origin":"NetworkInitialSync"},"ts":`,
+		`{"id":1,"payload":{"best":"` + common.Hash{1}.String() + `","height":"1","msg":"notify.finalized"},"ts":`,
+	}
+
+	for _, exp := range expected {
+		select {
+		case msg := <-received:
+			require.Contains(t, string(msg), exp)
+		case <-time.After(time.Second * 5):
+			t.Fatal("did not receive message")
+		}
+	}
+}
+
+func TestHandler_StopEndsReconnect(t *testing.T) {
+	reconnectBackoffMin = time.Millisecond * 10
+
+	h := &Handler{}
+	h.AddConnections([]*genesis.TelemetryEndpoint{
+		{
+			Endpoint: "ws://127.0.0.1:8003/",
+		},
+	})
+
+	err := h.Stop()
+	require.NoError(t, err)
+
+	connected := make(chan struct{}, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		connected <- struct{}{}
+	})
+
+	srv := &http.Server{Addr: "127.0.0.1:8003", Handler: mux}
+	go srv.ListenAndServe() //nolint
+	defer srv.Close()       //nolint
+
+	select {
+	case <-connected:
+		t.Fatal("handler reconnected after being stopped")
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	h.connLock.Lock()
+	defer h.connLock.Unlock()
+	require.Empty(t, h.wsConn)
+}
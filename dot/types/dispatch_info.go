@@ -0,0 +1,77 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"io"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// DispatchClass is the class of a dispatchable extrinsic
+type DispatchClass byte
+
+// nolint
+const (
+	NormalDispatch DispatchClass = iota
+	OperationalDispatch
+	MandatoryDispatch
+)
+
+// String returns the name of the dispatch class
+func (c DispatchClass) String() string {
+	switch c {
+	case NormalDispatch:
+		return "normal"
+	case OperationalDispatch:
+		return "operational"
+	case MandatoryDispatch:
+		return "mandatory"
+	default:
+		return "unknown"
+	}
+}
+
+// RuntimeDispatchInfo is the fee information returned by runtime function TransactionPaymentApi_query_info
+type RuntimeDispatchInfo struct {
+	Weight     uint64
+	Class      DispatchClass
+	PartialFee *common.Uint128
+}
+
+// Decode sets the RuntimeDispatchInfo to the SCALE decoded input
+func (d *RuntimeDispatchInfo) Decode(r io.Reader) error {
+	weight, err := common.ReadUint64(r)
+	if err != nil {
+		return err
+	}
+
+	class, err := common.ReadByte(r)
+	if err != nil {
+		return err
+	}
+
+	fee, err := common.ReadBytes(r, 16)
+	if err != nil {
+		return err
+	}
+
+	d.Weight = weight
+	d.Class = DispatchClass(class)
+	d.PartialFee = common.Uint128FromLEBytes(fee)
+	return nil
+}
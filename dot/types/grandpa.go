@@ -172,3 +172,20 @@ type FinalisationInfo struct {
 	Round  uint64
 	SetID  uint64
 }
+
+// GrandpaVote represents a vote for a block in a GRANDPA round
+type GrandpaVote struct {
+	Hash   common.Hash
+	Number uint32
+}
+
+// GrandpaRoundState represents the state of the current GRANDPA round
+type GrandpaRoundState struct {
+	Round       uint64
+	SetID       uint64
+	Prevote     *GrandpaVote // our pre-vote, nil if we haven't pre-voted in the round
+	Precommit   *GrandpaVote // our pre-commit, nil if we haven't pre-committed in the round
+	Prevotes    int          // number of pre-votes received in the round
+	Precommits  int          // number of pre-commits received in the round
+	Completable bool
+}
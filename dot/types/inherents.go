@@ -99,6 +99,24 @@ func (d *InherentsData) SetBigIntInherent(key []byte, data *big.Int) error {
 	return nil
 }
 
+// SetInherent sets an inherent whose value is already scale-encoded, for example a custom runtime inherent
+func (d *InherentsData) SetInherent(key, value []byte) error {
+	if len(key) != 8 {
+		return errors.New("inherent key must be 8 bytes")
+	}
+
+	venc, err := scale.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	kb := [8]byte{}
+	copy(kb[:], key)
+
+	d.data[kb] = venc
+	return nil
+}
+
 // Encode will encode a given []byte using scale.Encode
 func (d *InherentsData) Encode() ([]byte, error) {
 	length := big.NewInt(int64(len(d.data)))
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -40,7 +41,7 @@ import (
 
 // setupLogger sets up the gossamer logger
 func setupLogger(cfg *Config) {
-	handler := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	handler := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	handler = log.CallerFileHandler(handler)
 	logger.SetHandler(log.LvlFilterHandler(cfg.Global.LogLvl, handler))
 }
@@ -198,14 +199,66 @@ func ExportConfig(cfg *Config, fp string) *os.File {
 	return WriteConfig(raw, fp)
 }
 
-// ExportTomlConfig exports a dot configuration to a toml configuration file
+// ExportTomlConfig exports a dot configuration to a toml configuration file, logging
+// any configuration field that does not read back with the same value
 func ExportTomlConfig(cfg *ctoml.Config, fp string) *os.File {
 	raw, err := toml.Marshal(*cfg)
 	if err != nil {
 		logger.Error("failed to marshal configuration", "error", err)
 		os.Exit(1)
 	}
-	return WriteConfig(raw, fp)
+
+	file := WriteConfig(raw, fp)
+
+	fields, err := TomlConfigDiff(cfg, fp)
+	if err != nil {
+		logger.Warn("failed to validate exported configuration file", "file", fp, "error", err)
+	}
+
+	for _, field := range fields {
+		logger.Warn("configuration field did not round-trip through configuration file", "file", fp, "field", field)
+	}
+
+	return file
+}
+
+// TomlConfigDiff reads the toml configuration file and returns the fields whose
+// values differ from the given configuration
+func TomlConfigDiff(cfg *ctoml.Config, fp string) ([]string, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ctoml.Config{}
+	if err = toml.Unmarshal(data, parsed); err != nil {
+		return nil, err
+	}
+
+	return diffStructFields("", reflect.ValueOf(*cfg), reflect.ValueOf(*parsed)), nil
+}
+
+// diffStructFields returns the names of the fields of the structs a and b whose values differ
+func diffStructFields(prefix string, a, b reflect.Value) []string {
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		name := a.Type().Field(i).Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case fa.Kind() == reflect.Struct:
+			fields = append(fields, diffStructFields(name, fa, fb)...)
+		case fa.Kind() == reflect.Slice && fa.Len() == 0 && fb.Len() == 0:
+			// empty slices are omitted from the file and read back as nil
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
 }
 
 // WriteConfig writes the config `data` in the file 'fp'.
@@ -19,10 +19,12 @@ package dot
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
 
+	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
 	"github.com/ChainSafe/gossamer/lib/trie"
@@ -205,3 +207,42 @@ func TestTrieSnapshot(t *testing.T) {
 	require.NotEqual(t, tHash, ssTrieHash)
 	require.Equal(t, dcTrieHash, ssTrieHash)
 }
+
+func TestExportTomlConfig_RoundTrip(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	cfg := &ctoml.Config{
+		Global:  ctoml.GlobalConfig{Name: "gossamer", ID: "gssmr", BasePath: testDir, LogLvl: "dbug", MetricsPort: 9877},
+		Log:     ctoml.LogConfig{CoreLvl: "dbug", SyncLvl: "trce", NetworkLvl: "warn", RPCLvl: "eror", StateLvl: "crit", RuntimeLvl: "info", BlockProducerLvl: "dbug", FinalityGadgetLvl: "trce"},
+		Init:    ctoml.InitConfig{Genesis: "genesis.json"},
+		Account: ctoml.AccountConfig{Key: "alice", Unlock: "0,1"},
+		Core: ctoml.CoreConfig{
+			Roles: 4, BabeAuthority: true, GrandpaAuthority: true, SlotDuration: 3000, EpochLength: 200,
+			WasmInterpreter: "wasmer", WasmFuelLimit: 1000000, WasmMaxMemoryPages: 64,
+		},
+		Network: ctoml.NetworkConfig{
+			Port: 7001, ListenAddresses: []string{"/ip4/0.0.0.0/tcp/7001"}, PublicAddresses: []string{"/ip4/1.2.3.4/tcp/7001"},
+			Bootnodes: []string{"/ip4/127.0.0.1/tcp/7002/p2p/12D3KooW"}, ProtocolID: "/gossamer/gssmr/0", NoBootstrap: true, NoMDNS: true,
+			MinPeers: 1, MaxPeers: 50, PersistentPeers: []string{"/ip4/127.0.0.1/tcp/7003/p2p/12D3KooX"}, BlockRequestSize: 64, CompressSync: true,
+			PingInterval: 10, PingFailureThreshold: 5,
+		},
+		RPC: ctoml.RPCConfig{
+			Enabled: true, External: true, Port: 8545, Host: "localhost", Modules: []string{"system", "chain"}, WSPort: 8546, WS: true, WSExternal: true,
+			MaxRequestSize: 1024, RateLimit: 2.5, RateBurst: 10, WSMessageRate: 1.5, WSMessageBurst: 5, Unsafe: true,
+		},
+	}
+
+	fp := filepath.Join(testDir, "config.toml")
+	ExportTomlConfig(cfg, fp)
+
+	fields, err := TomlConfigDiff(cfg, fp)
+	require.NoError(t, err)
+	require.Empty(t, fields)
+
+	// a field changed after export is reported
+	cfg.Network.Port = 7002
+	fields, err = TomlConfigDiff(cfg, fp)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Network.Port"}, fields)
+}
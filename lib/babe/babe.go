@@ -22,15 +22,17 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"os"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var logger log.Logger
@@ -55,19 +57,39 @@ type Service struct {
 
 	// Current runtime
 	rt runtime.Instance
+	// held while building a block, since building sets the runtime's storage for several runtime calls
+	rtLock sync.Mutex
 
 	// Epoch configuration data
 	slotDuration time.Duration
 	epochData    *epochData
-	slotToProof  map[uint64]*VrfOutputAndProof // for slots where we are a producer, store the vrf output (bytes 0-32) + proof (bytes 32-96)
 	isDisabled   bool
 
+	// for slots where we are a producer, store the vrf output (bytes 0-32) + proof (bytes 32-96). it's written both
+	// when an epoch is initiated and by SetSlot, so it's only accessed through slotProof and setSlotProof
+	slotToProofLock sync.RWMutex
+	slotToProof     map[uint64]*VrfOutputAndProof
+
+	// Additional inherents provided by the caller, merged with the built-in inherents when building a block
+	inherentsLock sync.RWMutex
+	inherents     map[[8]byte]InherentProvider
+
 	// Channels for inter-process communication
 	blockChan chan types.Block // send blocks to core service
 
 	// State variables
 	lock  sync.Mutex
 	pause chan struct{}
+
+	// the last slot handled, either by the slot timer or set manually on development chains
+	slotLock sync.Mutex
+	slot     uint64
+
+	// on development chains, the state root that blocks built on parentStateHash are built on, set by
+	// SetParentStateRoot
+	parentStateLock sync.Mutex
+	parentStateHash common.Hash
+	parentStateRoot *common.Hash
 }
 
 // ServiceConfig represents a BABE configuration
@@ -107,7 +129,7 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 	}
 
 	logger = log.New("pkg", "babe")
-	h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	h = log.CallerFileHandler(h)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 
@@ -124,6 +146,7 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 		rt:               cfg.Runtime,
 		transactionState: cfg.TransactionState,
 		slotToProof:      make(map[uint64]*VrfOutputAndProof),
+		inherents:        make(map[[8]byte]InherentProvider),
 		blockChan:        make(chan types.Block),
 		pause:            make(chan struct{}),
 		authority:        cfg.Authority,
@@ -225,6 +248,44 @@ func (b *Service) EpochLength() uint64 {
 	return b.epochLength
 }
 
+// SetSlot advances the service's slot to the given slot number and handles it, building a block if
+// the node can claim the slot. It lets tests drive block production one slot at a time, and should be
+// used while the service is paused. It is only available on development chains, for authority nodes.
+func (b *Service) SetSlot(slot uint64) error {
+	if !b.dev {
+		return ErrNotDevChain
+	}
+
+	if !b.authority {
+		return ErrNotAuthority
+	}
+
+	b.slotLock.Lock()
+	defer b.slotLock.Unlock()
+
+	if slot <= b.slot {
+		return fmt.Errorf("%w: slot %d is not after the current slot %d", ErrSlotNotAdvanced, slot, b.slot)
+	}
+
+	// the lottery is only run ahead of time for the slots of the current epoch
+	if b.slotProof(slot) == nil {
+		epoch, err := b.epochState.GetCurrentEpoch()
+		if err != nil {
+			return err
+		}
+
+		proof, err := b.runLottery(slot, epoch)
+		if err != nil {
+			return fmt.Errorf("error running slot lottery at slot %d: error %s", slot, err)
+		}
+
+		b.setSlotProof(slot, proof)
+	}
+
+	b.slot = slot
+	return b.handleSlot(slot)
+}
+
 // Pause pauses the service ie. halts block production
 func (b *Service) Pause() error {
 	if b.paused {
@@ -278,6 +339,12 @@ func (b *Service) SetRuntime(rt runtime.Instance) {
 	b.rt = rt
 }
 
+// RuntimeLock returns the lock held while the service builds a block. Other users of the runtime must hold it
+// while setting the runtime's storage and calling the runtime, so they don't change the storage of a block being built.
+func (b *Service) RuntimeLock() sync.Locker {
+	return &b.rtLock
+}
+
 // GetBlockChannel returns the channel where new blocks are passed
 func (b *Service) GetBlockChannel() <-chan types.Block {
 	return b.blockChan
@@ -378,7 +445,10 @@ func (b *Service) invokeBlockAuthoring(epoch uint64) {
 	}
 
 	logger.Info("initiating epoch", "number", epoch, "start slot", startSlot+b.epochLength)
+	// SetSlot runs the slot lottery with the epoch data, so it must not run while the epoch is initiated
+	b.slotLock.Lock()
 	err = b.initiateEpoch(epoch)
+	b.slotLock.Unlock()
 	if err != nil {
 		logger.Error("failed to initiate epoch", "epoch", epoch, "error", err)
 		return
@@ -423,7 +493,10 @@ func (b *Service) invokeBlockAuthoring(epoch uint64) {
 			}
 
 			slotNum := startSlot + uint64(i)
+			b.slotLock.Lock()
+			b.slot = slotNum
 			err = b.handleSlot(slotNum)
+			b.slotLock.Unlock()
 			if err == ErrNotAuthorized {
 				logger.Debug("not authorized to produce a block in this slot", "slot", slotNum)
 				continue
@@ -444,8 +517,52 @@ func (b *Service) invokeBlockAuthoring(epoch uint64) {
 	b.invokeBlockAuthoring(next)
 }
 
+// SetParentStateRoot sets the state root that blocks built on the given parent are built on, in place of the
+// parent's own state root, so that changes made to the state of the best block end up in the next block.
+// It is only available on development chains.
+func (b *Service) SetParentStateRoot(parent, root common.Hash) error {
+	if !b.dev {
+		return ErrNotDevChain
+	}
+
+	b.parentStateLock.Lock()
+	defer b.parentStateLock.Unlock()
+
+	b.parentStateHash = parent
+	b.parentStateRoot = &root
+	return nil
+}
+
+// ParentStateRoot returns the state root that blocks built on the given parent are built on
+func (b *Service) ParentStateRoot(parent *types.Header) common.Hash {
+	b.parentStateLock.Lock()
+	defer b.parentStateLock.Unlock()
+
+	if b.parentStateRoot != nil && b.parentStateHash == parent.Hash() {
+		return *b.parentStateRoot
+	}
+
+	return parent.StateRoot
+}
+
+// slotProof returns the VRF output and proof of the given slot, or nil if we aren't a producer in the slot
+func (b *Service) slotProof(slot uint64) *VrfOutputAndProof {
+	b.slotToProofLock.RLock()
+	defer b.slotToProofLock.RUnlock()
+	return b.slotToProof[slot]
+}
+
+// setSlotProof sets the VRF output and proof of the given slot
+func (b *Service) setSlotProof(slot uint64, proof *VrfOutputAndProof) {
+	b.slotToProofLock.Lock()
+	defer b.slotToProofLock.Unlock()
+	b.slotToProof[slot] = proof
+}
+
 func (b *Service) handleSlot(slotNum uint64) error {
-	if b.slotToProof[slotNum] == nil {
+	claimed := b.slotProof(slotNum) != nil
+	updateSlotClaimMetrics(claimed)
+	if !claimed {
 		return ErrNotAuthorized
 	}
 
@@ -474,15 +591,14 @@ func (b *Service) handleSlot(slotNum uint64) error {
 
 	// set runtime trie before building block
 	// if block building is successful, store the resulting trie in the storage state
-	ts, err := b.storageState.TrieState(&parent.StateRoot)
+	root := b.ParentStateRoot(parent)
+	ts, err := b.storageState.TrieState(&root)
 	if err != nil || ts == nil {
-		logger.Error("failed to get parent trie", "parent state root", parent.StateRoot, "error", err)
+		logger.Error("failed to get parent trie", "parent state root", root, "error", err)
 		return err
 	}
 
-	b.rt.SetContextStorage(ts)
-
-	block, err := b.buildBlock(parent, currentSlot)
+	block, err := b.buildBlock(parent, currentSlot, ts)
 	if err != nil {
 		logger.Error("block authoring", "error", err)
 		return nil
@@ -514,6 +630,22 @@ func (b *Service) handleSlot(slotNum uint64) error {
 	return nil
 }
 
+// updateSlotClaimMetrics updates the number of slots handled and claimed, and the slot claim success rate
+func updateSlotClaimMetrics(claimed bool) {
+	total := metrics.GetOrRegisterCounter("babe/slots/total", metrics.DefaultRegistry)
+	claims := metrics.GetOrRegisterCounter("babe/slots/claimed", metrics.DefaultRegistry)
+
+	total.Inc(1)
+	if claimed {
+		claims.Inc(1)
+	}
+
+	if total.Count() > 0 {
+		rate := float64(claims.Count()) / float64(total.Count())
+		metrics.GetOrRegisterGaugeFloat64("babe/slots/claimRate", metrics.DefaultRegistry).Update(rate)
+	}
+}
+
 func getCurrentSlot(slotDuration time.Duration) uint64 {
 	return uint64(time.Now().UnixNano()) / uint64(slotDuration.Nanoseconds())
 }
@@ -17,6 +17,7 @@
 package babe
 
 import (
+	"errors"
 	"io/ioutil"
 	"math/big"
 	"os"
@@ -184,6 +185,48 @@ func TestSlotDuration(t *testing.T) {
 	require.Equal(t, dur.Milliseconds(), int64(1000))
 }
 
+func TestService_SlotDurationOverride(t *testing.T) {
+	slotDuration := time.Millisecond * 500
+
+	babeService := createTestService(t, &ServiceConfig{
+		SlotDuration: uint64(slotDuration.Milliseconds()),
+		EpochLength:  20,
+	})
+	require.Equal(t, slotDuration, babeService.getSlotDuration())
+	require.Equal(t, uint64(20), babeService.EpochLength())
+
+	babeService.epochData.threshold = maxThreshold
+
+	err := babeService.Start()
+	require.NoError(t, err)
+	defer func() {
+		_ = babeService.Stop()
+	}()
+
+	newBlocks := babeService.GetBlockChannel()
+
+	var (
+		slots   []uint64
+		arrival []time.Time
+	)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case block := <-newBlocks:
+			slot, err := types.GetSlotFromHeader(block.Header)
+			require.NoError(t, err)
+			slots = append(slots, slot)
+			arrival = append(arrival, time.Now())
+		case <-time.After(testTimeout):
+			t.Fatal("did not receive block")
+		}
+	}
+
+	// blocks are built in consecutive slots, one slot duration apart
+	require.Equal(t, slots[0]+1, slots[1])
+	require.Less(t, int64(arrival[1].Sub(arrival[0])), int64(slotDuration*2))
+}
+
 func TestBabeAnnounceMessage(t *testing.T) {
 	babeService := createTestService(t, nil)
 
@@ -252,3 +295,82 @@ func TestStartAndStop(t *testing.T) {
 	err = bs.Stop()
 	require.NoError(t, err)
 }
+
+func TestService_SetSlot(t *testing.T) {
+	babeService := createTestService(t, &ServiceConfig{
+		Authority: true,
+		IsDev:     true,
+	})
+	babeService.epochData.threshold = maxThreshold
+
+	newBlocks := babeService.GetBlockChannel()
+	slot := uint64(1000)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- babeService.SetSlot(slot)
+	}()
+
+	select {
+	case block := <-newBlocks:
+		blockSlot, err := types.GetSlotFromHeader(block.Header)
+		require.NoError(t, err)
+		require.Equal(t, slot, blockSlot)
+		require.Equal(t, big.NewInt(1), block.Header.Number)
+	case <-time.After(testTimeout):
+		t.Fatal("did not receive block")
+	}
+	require.NoError(t, <-errCh)
+
+	// the slot can only be advanced
+	err := babeService.SetSlot(slot)
+	require.True(t, errors.Is(err, ErrSlotNotAdvanced))
+}
+
+func TestService_SetSlot_WhileInitiatingEpoch(t *testing.T) {
+	babeService := createTestService(t, &ServiceConfig{
+		Authority: true,
+		IsDev:     true,
+	})
+	babeService.epochData.threshold = maxThreshold
+	babeService.epochLength = 5
+
+	err := babeService.epochState.SetFirstSlot(1000)
+	require.NoError(t, err)
+
+	newBlocks := babeService.GetBlockChannel()
+	epochErrCh := make(chan error, 1)
+	go func() {
+		epochErrCh <- babeService.initiateEpoch(0)
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- babeService.SetSlot(2000)
+	}()
+
+	select {
+	case <-newBlocks:
+	case <-time.After(testTimeout):
+		t.Fatal("did not receive block")
+	}
+	require.NoError(t, <-errCh)
+	require.NoError(t, <-epochErrCh)
+
+	for i := uint64(1000); i < 1005; i++ {
+		require.NotNil(t, babeService.slotProof(i))
+	}
+	require.NotNil(t, babeService.slotProof(2000))
+}
+
+func TestService_SetSlot_NotDev(t *testing.T) {
+	babeService := createTestService(t, nil)
+	err := babeService.SetSlot(1)
+	require.Equal(t, ErrNotDevChain, err)
+
+	babeService = createTestService(t, &ServiceConfig{
+		IsDev: true,
+	})
+	err = babeService.SetSlot(1)
+	require.Equal(t, ErrNotAuthority, err)
+}
@@ -17,28 +17,117 @@
 package babe
 
 import (
-	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/scale"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 // BuildBlock builds a block for the slot with the given parent.
 // TODO: separate block builder logic into separate module. The only reason this is exported is so other packages
 // can build blocks for testing, but it would be preferred to have the builder functionality separated.
 func (b *Service) BuildBlock(parent *types.Header, slot Slot) (*types.Block, error) {
-	return b.buildBlock(parent, slot)
+	root := b.ParentStateRoot(parent)
+	ts, err := b.storageState.TrieState(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildBlock(parent, slot, ts)
+}
+
+// BuildBlockDiagnostics contains information about a block built by BuildBlockDryRun
+type BuildBlockDiagnostics struct {
+	Block     *types.Block
+	Inherents int     // number of inherent extrinsics applied
+	Included  int     // number of extrinsics from the transaction queue included in the block
+	Dropped   int     // number of extrinsics from the transaction queue not included in the block
+	Weight    *uint64 // total weight of the block, nil if it can't be read from the runtime's storage
+	Errors    []*ExtrinsicError
+
+	// every transaction popped from the queue while building, so it can be restored afterwards
+	popped []*transaction.ValidTransaction
 }
 
-// construct a block for this slot with the given parent
-func (b *Service) buildBlock(parent *types.Header, slot Slot) (*types.Block, error) {
+// ExtrinsicError is an error returned when validating or applying an extrinsic during block building.
+// Extrinsics that fail with a runtime.DispatchOutcomeError are still included in the block.
+type ExtrinsicError struct {
+	Extrinsic types.Extrinsic
+	Err       error
+}
+
+// BuildBlockDryRun runs the block building pipeline for the slot with the given parent and returns diagnostics
+// about the built block. The block is built on a copy of the parent state, and every transaction popped from the
+// transaction queue is restored afterwards. The block is not sent to the core service.
+func (b *Service) BuildBlockDryRun(parent *types.Header, slot Slot) (*BuildBlockDiagnostics, error) {
+	root := b.ParentStateRoot(parent)
+	parentState, err := b.storageState.TrieState(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parentState.Trie().DeepCopy()
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := rtstorage.NewTrieState(t)
+	if err != nil {
+		return nil, err
+	}
+
+	diag := &BuildBlockDiagnostics{}
+	defer func() {
+		b.addToQueue(diag.popped)
+		diag.popped = nil
+	}()
+
+	diag.Block, err = b.buildBlockWithDiagnostics(parent, slot, ts, diag)
+	if err != nil {
+		return nil, err
+	}
+
+	return diag, nil
+}
+
+// construct a block for this slot with the given parent, on top of the given parent trie state
+func (b *Service) buildBlock(parent *types.Header, slot Slot, ts *rtstorage.TrieState) (*types.Block, error) {
+	start := time.Now()
+	block, err := b.buildBlockWithDiagnostics(parent, slot, ts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.GetOrRegisterTimer("babe/block/build", metrics.DefaultRegistry).UpdateSince(start)
+	return block, nil
+}
+
+// buildBlockWithDiagnostics constructs a block for the slot. if diag is non-nil, the block is being built as a
+// dry-run; diagnostics are recorded in diag and popped transactions are left for the caller to restore.
+func (b *Service) buildBlockWithDiagnostics(parent *types.Header, slot Slot, ts *rtstorage.TrieState,
+	diag *BuildBlockDiagnostics) (*types.Block, error) {
 	logger.Trace("build block", "parent", parent, "slot", slot)
 
+	b.rtLock.Lock()
+	defer b.rtLock.Unlock()
+
+	// keep an unmodified copy of the parent state to re-validate transactions against
+	parentState, err := rtstorage.NewTrieState(ts.Snapshot())
+	if err != nil {
+		return nil, err
+	}
+
+	b.rt.SetContextStorage(ts)
+
 	// create pre-digest
 	preDigest, err := b.buildBlockPreDigest(slot)
 	if err != nil {
@@ -71,14 +160,23 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot) (*types.Block, err
 	logger.Trace("built block inherents", "encoded inherents", inherents)
 
 	// add block extrinsics
-	included := b.buildBlockExtrinsics(slot)
+	included := b.buildBlockExtrinsics(slot, ts, parentState, diag)
 
 	logger.Trace("built block extrinsics")
 
+	if diag != nil {
+		diag.Inherents = len(inherents)
+		diag.Included = len(included)
+		diag.Dropped = len(diag.popped) - len(included)
+		diag.Weight = blockWeight(ts)
+	}
+
 	// finalise block
 	header, err = b.rt.FinalizeBlock()
 	if err != nil {
-		b.addToQueue(included)
+		if diag == nil {
+			b.addToQueue(included)
+		}
 		return nil, fmt.Errorf("cannot finalise block: %s", err)
 	}
 
@@ -113,6 +211,22 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot) (*types.Block, err
 	return block, nil
 }
 
+// blockWeight returns the total weight of the block being built, which the runtime stores as the weight
+// consumed by each dispatch class. it returns nil if the weight isn't stored as expected.
+func blockWeight(ts *rtstorage.TrieState) *uint64 {
+	enc := ts.Get(runtime.SystemBlockWeightKey())
+	if len(enc) == 0 || len(enc)%8 != 0 {
+		return nil
+	}
+
+	var weight uint64
+	for i := 0; i < len(enc); i += 8 {
+		weight += binary.LittleEndian.Uint64(enc[i : i+8])
+	}
+
+	return &weight
+}
+
 // buildBlockSeal creates the seal for the block header.
 // the seal consists of the ConsensusEngineID and a signature of the encoded block header.
 func (b *Service) buildBlockSeal(header *types.Header) (*types.SealDigest, error) {
@@ -156,11 +270,11 @@ func (b *Service) buildBlockPreDigest(slot Slot) (*types.PreRuntimeDigest, error
 // buildBlockBABEPrimaryPreDigest creates the BABE header for the slot.
 // the BABE header includes the proof of authorship right for this slot.
 func (b *Service) buildBlockBABEPrimaryPreDigest(slot Slot) (*types.BabePrimaryPreDigest, error) {
-	if b.slotToProof[slot.number] == nil {
+	outAndProof := b.slotProof(slot.number)
+	if outAndProof == nil {
 		return nil, ErrNotAuthorized
 	}
 
-	outAndProof := b.slotToProof[slot.number]
 	return types.NewBabePrimaryPreDigest(
 		b.epochData.authorityIndex,
 		slot.number,
@@ -171,9 +285,22 @@ func (b *Service) buildBlockBABEPrimaryPreDigest(slot Slot) (*types.BabePrimaryP
 
 // buildBlockExtrinsics applies extrinsics to the block. it returns an array of included extrinsics.
 // for each extrinsic in queue, add it to the block, until the slot ends or the block is full.
-// if any extrinsic fails, it returns an empty array and an error.
-func (b *Service) buildBlockExtrinsics(slot Slot) []*transaction.ValidTransaction {
-	var included []*transaction.ValidTransaction
+// each extrinsic is re-validated against the parent state before being applied; invalid extrinsics
+// are dropped and extrinsics that may become valid in the future are returned to the queue.
+// if diag is non-nil, popped transactions and extrinsic errors are recorded in it instead, and nothing is
+// returned to the queue.
+func (b *Service) buildBlockExtrinsics(slot Slot, ts, parentState *rtstorage.TrieState,
+	diag *BuildBlockDiagnostics) []*transaction.ValidTransaction {
+	var included, future []*transaction.ValidTransaction
+	defer func() {
+		b.addToQueue(future)
+	}()
+
+	recordErr := func(ext types.Extrinsic, err error) {
+		if diag != nil {
+			diag.Errors = append(diag.Errors, &ExtrinsicError{Extrinsic: ext, Err: err})
+		}
+	}
 
 	for !hasSlotEnded(slot) {
 		txn := b.transactionState.Pop()
@@ -182,27 +309,56 @@ func (b *Service) buildBlockExtrinsics(slot Slot) []*transaction.ValidTransactio
 			return included
 		}
 
+		if diag != nil {
+			diag.popped = append(diag.popped, txn)
+		}
+
 		// Move to next extrinsic.
 		if txn.Extrinsic == nil {
 			continue
 		}
 
 		extrinsic := txn.Extrinsic
+
+		err := b.validateExtrinsic(ts, parentState, extrinsic)
+		if err == runtime.ErrFutureTransaction {
+			logger.Debug("extrinsic not yet valid, returning to queue", "extrinsic", extrinsic)
+			recordErr(extrinsic, err)
+			if diag == nil {
+				future = append(future, txn)
+			}
+			continue
+		}
+
+		if err != nil {
+			logger.Debug("dropping invalid extrinsic", "error", err, "extrinsic", extrinsic)
+			recordErr(extrinsic, err)
+			continue
+		}
+
 		logger.Trace("build block", "applying extrinsic", extrinsic)
 
 		ret, err := b.rt.ApplyExtrinsic(extrinsic)
 		if err != nil {
 			logger.Warn("failed to apply extrinsic", "error", err, "extrinsic", extrinsic)
+			recordErr(extrinsic, err)
 			continue
 		}
 
-		err = determineErr(ret)
+		res, err := runtime.DecodeApplyExtrinsicResult(ret)
 		if err != nil {
-			logger.Warn("failed to apply extrinsic", "error", err, "extrinsic", extrinsic)
+			logger.Warn("failed to decode apply extrinsic result", "error", err, "extrinsic", extrinsic)
+			recordErr(extrinsic, err)
+			continue
+		}
+
+		if res.Err != nil {
+			logger.Warn("failed to apply extrinsic", "error", res.Err, "extrinsic", extrinsic)
+			recordErr(extrinsic, res.Err)
 
 			// Failure of the module call dispatching doesn't invalidate the extrinsic.
 			// It is included in the block.
-			if _, ok := err.(*DispatchOutcomeError); !ok {
+			if !res.Included() {
 				continue
 			}
 		}
@@ -214,6 +370,72 @@ func (b *Service) buildBlockExtrinsics(slot Slot) []*transaction.ValidTransactio
 	return included
 }
 
+// validateExtrinsic checks the validity of the extrinsic against the parent state using
+// TaggedTransactionQueue_validate_transaction. Any changes made to the parent state are rolled back,
+// and the runtime storage is reset to the block being built afterwards.
+func (b *Service) validateExtrinsic(ts, parentState *rtstorage.TrieState, ext types.Extrinsic) error {
+	parentState.BeginStorageTransaction()
+	b.rt.SetContextStorage(parentState)
+
+	defer func() {
+		parentState.RollbackStorageTransaction()
+		b.rt.SetContextStorage(ts)
+	}()
+
+	_, err := b.rt.ValidateTransaction(append([]byte{byte(types.TxnInBlock)}, ext...))
+	return err
+}
+
+// InherentProvider returns the scale-encoded value of an inherent for the block being built
+type InherentProvider func() ([]byte, error)
+
+// RegisterInherent registers a provider for an additional inherent with the given 8-byte identifier, for runtimes
+// that require inherents other than the built-in timestamp, slot and finalized number inherents.
+// Registering a provider for an identifier that's already registered replaces the previous provider.
+func (b *Service) RegisterInherent(key []byte, provider InherentProvider) error {
+	if len(key) != 8 {
+		return ErrInvalidInherentKey
+	}
+
+	kb := [8]byte{}
+	copy(kb[:], key)
+
+	b.inherentsLock.Lock()
+	defer b.inherentsLock.Unlock()
+	b.inherents[kb] = provider
+	return nil
+}
+
+// UnregisterInherent removes the provider for the inherent with the given identifier
+func (b *Service) UnregisterInherent(key []byte) {
+	kb := [8]byte{}
+	copy(kb[:], key)
+
+	b.inherentsLock.Lock()
+	defer b.inherentsLock.Unlock()
+	delete(b.inherents, kb)
+}
+
+// setRegisteredInherents adds the values of all the registered inherents to the inherents data
+func (b *Service) setRegisteredInherents(idata *types.InherentsData) error {
+	b.inherentsLock.RLock()
+	defer b.inherentsLock.RUnlock()
+
+	for key, provider := range b.inherents {
+		value, err := provider()
+		if err != nil {
+			return fmt.Errorf("cannot get value for inherent %s: %w", key[:], err)
+		}
+
+		err = idata.SetInherent(key[:], value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // buildBlockInherents applies the inherents for a block
 func (b *Service) buildBlockInherents(slot Slot) ([][]byte, error) {
 	// Setup inherents: add timstap0
@@ -240,6 +462,12 @@ func (b *Service) buildBlockInherents(slot Slot) ([][]byte, error) {
 		return nil, err
 	}
 
+	// add registered inherents
+	err = b.setRegisteredInherents(idata)
+	if err != nil {
+		return nil, err
+	}
+
 	ienc, err := idata.Encode()
 	if err != nil {
 		return nil, err
@@ -269,9 +497,13 @@ func (b *Service) buildBlockInherents(slot Slot) ([][]byte, error) {
 			return nil, err
 		}
 
-		if !bytes.Equal(ret, []byte{0, 0}) {
-			errTxt := determineErr(ret)
-			return nil, fmt.Errorf("error applying inherent: %s", errTxt)
+		res, err := runtime.DecodeApplyExtrinsicResult(ret)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.Outcome != runtime.ApplySuccess {
+			return nil, fmt.Errorf("error applying inherent: %s", res.Err)
 		}
 	}
 
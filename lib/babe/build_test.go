@@ -18,7 +18,12 @@ package babe
 
 import (
 	"bytes"
+	"encoding/binary"
+	"io/ioutil"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,12 +31,17 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/scale"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	log "github.com/ChainSafe/log15"
 	cscale "github.com/centrifuge/go-substrate-rpc-client/v2/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v2/signature"
 	ctypes "github.com/centrifuge/go-substrate-rpc-client/v2/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -73,6 +83,19 @@ func addAuthorshipProof(t *testing.T, babeService *Service, slotNumber, epoch ui
 	babeService.slotToProof[slotNumber] = outAndProof
 }
 
+// newTestBuildState returns a copy of the best block's trie state to build a block on top of
+func newTestBuildState(t *testing.T, babeService *Service) *rtstorage.TrieState {
+	parentState, err := babeService.storageState.TrieState(nil)
+	require.NoError(t, err)
+
+	tr, err := parentState.Trie().DeepCopy()
+	require.NoError(t, err)
+
+	ts, err := rtstorage.NewTrieState(tr)
+	require.NoError(t, err)
+	return ts
+}
+
 func createTestBlock(t *testing.T, babeService *Service, parent *types.Header, exts [][]byte, slotNumber, epoch uint64) (*types.Block, Slot) { //nolint
 	// create proof that we can authorize this block
 	babeService.epochData.authorityIndex = 0
@@ -94,9 +117,11 @@ func createTestBlock(t *testing.T, babeService *Service, parent *types.Header, e
 	}
 
 	// build block
+	ts := newTestBuildState(t, babeService)
+
 	var block *types.Block
 	for i := 0; i < 1; i++ { // retry if error
-		block, err = babeService.buildBlock(parent, slot)
+		block, err = babeService.buildBlock(parent, slot, ts)
 		if err == nil {
 			return block, slot
 		}
@@ -146,6 +171,157 @@ func TestBuildBlock_ok(t *testing.T) {
 	require.Equal(t, 1, len(extsBytes))
 }
 
+func TestBuildBlock_DropsInvalidExtrinsic(t *testing.T) {
+	cfg := &ServiceConfig{
+		TransactionState: state.NewTransactionState(),
+		LogLvl:           log.LvlDebug,
+	}
+
+	babeService := createTestService(t, cfg)
+	babeService.epochData.threshold = maxThreshold
+	babeService.epochData.authorityIndex = 0
+	addAuthorshipProof(t, babeService, 1, testEpochIndex)
+
+	ts := newTestBuildState(t, babeService)
+	babeService.rt.SetContextStorage(ts)
+
+	// the test extrinsic is signed with this genesis hash, which the runtime takes from the parent of block 1
+	parentHash := common.MustHexToHash("0x35a28a7dbaf0ba07d1485b0f3da7757e3880509edc8c31d0850cb6dd6219361d")
+	header, err := types.NewHeader(parentHash, common.Hash{}, common.Hash{}, big.NewInt(1), types.NewEmptyDigest())
+	require.NoError(t, err)
+	err = babeService.rt.InitializeBlock(header)
+	require.NoError(t, err)
+
+	// the extrinsic is valid when it enters the queue
+	ext := types.Extrinsic(common.MustHexToBytes("0x410284ffd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d015a3e258da3ea20581b68fe1264a35d1f62d6a0debb1a44e836375eb9921ba33e3d0f265f2da33c9ca4e10490b03918300be902fcb229f806c9cf99af4cc10f8c0000000600ff8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480b00c465f14670"))
+	txVal, err := babeService.rt.ValidateTransaction(append([]byte{byte(types.TxnLocal)}, ext...))
+	require.NoError(t, err)
+
+	vtx := transaction.NewValidTransaction(ext, txVal)
+	_, err = babeService.transactionState.Push(vtx)
+	require.NoError(t, err)
+
+	// applying it to the parent state bumps the sender's nonce, making the queued extrinsic stale
+	res, err := babeService.rt.ApplyExtrinsic(ext)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0}, res)
+
+	slot := Slot{
+		start:    time.Now(),
+		duration: time.Second,
+		number:   1,
+	}
+
+	block, err := babeService.buildBlock(emptyHeader, slot, ts)
+	require.NoError(t, err)
+
+	exts, err := block.Body.AsExtrinsics()
+	require.NoError(t, err)
+	for _, included := range exts {
+		require.NotEqual(t, ext, included)
+	}
+
+	require.Nil(t, babeService.transactionState.Peek())
+}
+
+// newTestParentWithGenesisHash returns a child of the best block with a state in which the hash of block 0 is the
+// genesis hash the test extrinsic is signed with. The runtime overwrites the hash of block 0 when initialising block 1,
+// so the returned parent is block 1.
+func newTestParentWithGenesisHash(t *testing.T, babeService *Service) *types.Header {
+	best, err := babeService.blockState.BestBlockHeader()
+	require.NoError(t, err)
+
+	ts := newTestBuildState(t, babeService)
+
+	// System.BlockHash(0) storage key
+	system, err := common.Twox128Hash([]byte("System"))
+	require.NoError(t, err)
+	blockHash, err := common.Twox128Hash([]byte("BlockHash"))
+	require.NoError(t, err)
+
+	number := make([]byte, 4)
+	binary.LittleEndian.PutUint32(number, 0)
+	numberHash, err := common.Twox64(number)
+	require.NoError(t, err)
+	key := append(append(append(system, blockHash...), numberHash...), number...)
+
+	genesisHash := common.MustHexToHash("0x35a28a7dbaf0ba07d1485b0f3da7757e3880509edc8c31d0850cb6dd6219361d")
+	ts.Set(key, genesisHash[:])
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+	err = babeService.storageState.StoreTrie(ts)
+	require.NoError(t, err)
+
+	parent, err := types.NewHeader(best.Hash(), root, common.Hash{}, big.NewInt(1), types.NewEmptyDigest())
+	require.NoError(t, err)
+	return parent
+}
+
+func TestBuildBlockDryRun(t *testing.T) {
+	cfg := &ServiceConfig{
+		TransactionState: state.NewTransactionState(),
+		LogLvl:           log.LvlDebug,
+	}
+
+	babeService := createTestService(t, cfg)
+	babeService.epochData.threshold = maxThreshold
+	babeService.epochData.authorityIndex = 0
+	addAuthorshipProof(t, babeService, 1, testEpochIndex)
+
+	valid := types.Extrinsic(common.MustHexToBytes("0x410284ffd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d015a3e258da3ea20581b68fe1264a35d1f62d6a0debb1a44e836375eb9921ba33e3d0f265f2da33c9ca4e10490b03918300be902fcb229f806c9cf99af4cc10f8c0000000600ff8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480b00c465f14670"))
+	invalid := types.Extrinsic([]byte{1, 2, 3, 4})
+
+	_, err := babeService.transactionState.Push(transaction.NewValidTransaction(valid, &transaction.Validity{Priority: 2}))
+	require.NoError(t, err)
+	_, err = babeService.transactionState.Push(transaction.NewValidTransaction(invalid, &transaction.Validity{Priority: 1}))
+	require.NoError(t, err)
+
+	parent := newTestParentWithGenesisHash(t, babeService)
+
+	slot := Slot{
+		start:    time.Now(),
+		duration: time.Second,
+		number:   1,
+	}
+
+	diag, err := babeService.BuildBlockDryRun(parent, slot)
+	require.NoError(t, err)
+	require.NotNil(t, diag.Block)
+	require.NotZero(t, diag.Inherents)
+	require.Equal(t, 1, diag.Included)
+	require.Equal(t, 1, diag.Dropped)
+	require.NotNil(t, diag.Weight)
+	require.Equal(t, 1, len(diag.Errors))
+	require.Equal(t, invalid, diag.Errors[0].Extrinsic)
+	require.Error(t, diag.Errors[0].Err)
+
+	// the transaction queue and the parent state are left untouched
+	require.Equal(t, valid, babeService.transactionState.Pop().Extrinsic)
+	require.Equal(t, invalid, babeService.transactionState.Pop().Extrinsic)
+	require.Nil(t, babeService.transactionState.Pop())
+
+	_, err = babeService.storageState.TrieState(&parent.StateRoot)
+	require.NoError(t, err)
+}
+
+func TestBlockWeight(t *testing.T) {
+	ts, err := rtstorage.NewTrieState(nil)
+	require.NoError(t, err)
+	require.Nil(t, blockWeight(ts))
+
+	// weight consumed by the normal, operational and mandatory dispatch classes
+	enc := make([]byte, 24)
+	binary.LittleEndian.PutUint64(enc[0:8], 100)
+	binary.LittleEndian.PutUint64(enc[8:16], 20)
+	binary.LittleEndian.PutUint64(enc[16:24], 3)
+	ts.Set(runtime.SystemBlockWeightKey(), enc)
+	require.Equal(t, uint64(123), *blockWeight(ts))
+
+	ts.Set(runtime.SystemBlockWeightKey(), []byte{1, 2, 3})
+	require.Nil(t, blockWeight(ts))
+}
+
 func TestApplyExtrinsic(t *testing.T) {
 	cfg := &ServiceConfig{
 		TransactionState: state.NewTransactionState(),
@@ -305,7 +481,7 @@ func TestBuildBlock_failing(t *testing.T) {
 		number:   slotNumber,
 	}
 
-	_, err = babeService.buildBlock(parentHeader, slot)
+	_, err = babeService.buildBlock(parentHeader, slot, newTestBuildState(t, babeService))
 	if err == nil {
 		t.Fatal("should error when attempting to include invalid tx")
 	}
@@ -317,3 +493,152 @@ func TestBuildBlock_failing(t *testing.T) {
 		t.Fatal("did not readd valid transaction to queue")
 	}
 }
+
+// mockInherentsRuntime records the inherents data passed to BlockBuilder_inherent_extrinsics
+type mockInherentsRuntime struct {
+	runtime.Instance
+	inherentsData []byte
+}
+
+func (rt *mockInherentsRuntime) BabeConfiguration() (*types.BabeConfiguration, error) {
+	return genesisBABEConfig, nil
+}
+
+func (rt *mockInherentsRuntime) InherentExtrinsics(data []byte) ([]byte, error) {
+	rt.inherentsData = data
+	return scale.Encode([][]byte{})
+}
+
+func TestBuildBlockInherents_RegisteredInherent(t *testing.T) {
+	rt := &mockInherentsRuntime{}
+	babeService := createTestService(t, &ServiceConfig{
+		Runtime: rt,
+	})
+
+	err := babeService.RegisterInherent([]byte("short"), func() ([]byte, error) {
+		return nil, nil
+	})
+	require.Equal(t, ErrInvalidInherentKey, err)
+
+	value := []byte{1, 2, 3, 4}
+	err = babeService.RegisterInherent(types.Uncles00, func() ([]byte, error) {
+		return value, nil
+	})
+	require.NoError(t, err)
+
+	exts, err := babeService.buildBlockInherents(Slot{number: 1})
+	require.NoError(t, err)
+	require.Empty(t, exts)
+
+	expected := types.NewInherentsData()
+	err = expected.SetInherent(types.Uncles00, value)
+	require.NoError(t, err)
+	enc, err := expected.Encode()
+	require.NoError(t, err)
+
+	// the first byte of the encoding is the number of inherents
+	require.True(t, bytes.Contains(rt.inherentsData, enc[1:]))
+	require.True(t, bytes.Contains(rt.inherentsData, types.Timstap0))
+	require.True(t, bytes.Contains(rt.inherentsData, types.Babeslot))
+	require.True(t, bytes.Contains(rt.inherentsData, types.Finalnum))
+
+	babeService.UnregisterInherent(types.Uncles00)
+	_, err = babeService.buildBlockInherents(Slot{number: 1})
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(rt.inherentsData, types.Uncles00))
+}
+
+func TestBuildBlockExtrinsics_ExcludesInvalidExtrinsic(t *testing.T) {
+	rt := mocks.NewApplyExtrinsicRuntime(&mockInherentsRuntime{})
+	babeService := createTestService(t, &ServiceConfig{
+		Runtime: rt,
+	})
+
+	valid := types.Extrinsic{1}
+	dispatchErr := types.Extrinsic{2}
+	invalid := types.Extrinsic{3}
+	rt.SetResult(dispatchErr, mocks.DispatchErrorResult)
+	rt.SetResult(invalid, mocks.InvalidResult)
+
+	for _, ext := range []types.Extrinsic{valid, dispatchErr, invalid} {
+		_, err := babeService.transactionState.Push(transaction.NewValidTransaction(ext, &transaction.Validity{}))
+		require.NoError(t, err)
+	}
+
+	slot := Slot{
+		start:    time.Now(),
+		duration: time.Minute,
+		number:   1,
+	}
+
+	ts := newTestBuildState(t, babeService)
+	diag := &BuildBlockDiagnostics{}
+	included := babeService.buildBlockExtrinsics(slot, ts, newTestBuildState(t, babeService), diag)
+	require.Len(t, rt.Applied(), 3)
+
+	// an extrinsic whose call fails to dispatch is still included, an invalid one is dropped
+	exts := make([]types.Extrinsic, len(included))
+	for i, txn := range included {
+		exts[i] = txn.Extrinsic
+	}
+	require.ElementsMatch(t, []types.Extrinsic{valid, dispatchErr}, exts)
+
+	require.Len(t, diag.Errors, 2)
+	require.Nil(t, babeService.transactionState.Peek())
+}
+
+func TestBuildBlock_Metrics(t *testing.T) {
+	names := []string{
+		"babe/slots/total",
+		"babe/slots/claimed",
+		"babe/slots/claimRate",
+		"babe/block/build",
+		"state/block/best",
+		"state/block/finalized",
+		"state/block/finalization",
+	}
+
+	// metrics registered while metrics were disabled are no-ops, so re-register them
+	metrics.Enabled = true
+	defer func() {
+		metrics.Enabled = false
+	}()
+	for _, name := range names {
+		metrics.DefaultRegistry.Unregister(name)
+	}
+
+	babeService := createTestService(t, nil)
+	babeService.epochData.threshold = maxThreshold
+
+	parent, err := babeService.blockState.BestBlockHeader()
+	require.NoError(t, err)
+
+	block, _ := createTestBlock(t, babeService, parent, [][]byte{}, 1, testEpochIndex)
+	err = babeService.blockState.AddBlock(block)
+	require.NoError(t, err)
+
+	err = babeService.blockState.(*state.BlockState).SetFinalizedHash(block.Header.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	// slot 2 isn't claimed
+	err = babeService.handleSlot(2)
+	require.Equal(t, ErrNotAuthorized, err)
+
+	srv := httptest.NewServer(prometheus.Handler(metrics.DefaultRegistry))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL) //nolint
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, name := range names {
+		require.Contains(t, string(body), strings.Replace(name, "/", "_", -1))
+	}
+
+	require.Equal(t, int64(1), metrics.GetOrRegisterGauge("state/block/finalized", nil).Value())
+	require.Equal(t, int64(1), metrics.GetOrRegisterCounter("babe/slots/total", nil).Count())
+	require.Equal(t, int64(0), metrics.GetOrRegisterCounter("babe/slots/claimed", nil).Count())
+}
@@ -24,6 +24,15 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 )
 
+// the types of slots that blocks may be authored in, as specified by the SecondarySlots field of the BABE config.
+// see https://github.com/paritytech/substrate/blob/master/primitives/consensus/babe/src/lib.rs#L241
+const (
+	// primaryAndSecondaryPlainSlots means primary and secondary plain slot blocks are allowed
+	primaryAndSecondaryPlainSlots = byte(1)
+	// primaryAndSecondaryVRFSlots means primary and secondary VRF slot blocks are allowed
+	primaryAndSecondaryVRFSlots = byte(2)
+)
+
 func getSecondarySlotAuthor(slot uint64, numAuths int, randomness Randomness) (uint32, error) {
 	s := make([]byte, 8)
 	binary.LittleEndian.PutUint64(s, slot)
@@ -70,3 +70,17 @@ type epochData struct {
 	authorities    []*types.Authority
 	threshold      *common.Uint128
 }
+
+// EquivocationProof contains the two conflicting headers produced by the same authority in the same slot
+type EquivocationProof struct {
+	Slot           uint64
+	AuthorityIndex uint32
+	FirstHeader    *types.Header
+	SecondHeader   *types.Header
+}
+
+// slotAuthor identifies a block producer in a certain slot
+type slotAuthor struct {
+	slot           uint64
+	authorityIndex uint32
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -31,9 +32,10 @@ import (
 // verifierInfo contains the information needed to verify blocks
 // it remains the same for an epoch
 type verifierInfo struct {
-	authorities []*types.Authority
-	randomness  Randomness
-	threshold   *common.Uint128
+	authorities    []*types.Authority
+	randomness     Randomness
+	threshold      *common.Uint128
+	secondarySlots byte
 }
 
 // onDisabledInfo contains information about an authority that's been disabled at a certain
@@ -53,6 +55,12 @@ type VerificationManager struct {
 	epochInfo  map[uint64]*verifierInfo // map of epoch number -> info needed for verification
 	// there may be different OnDisabled digests on different branches of the chain, so we need to keep track of all of them.
 	onDisabled map[uint64]map[uint32][]*onDisabledInfo // map of epoch number -> block producer index -> block number and hash
+
+	// the first header seen for each (slot, authority) pair, used to detect equivocations
+	seenLock        sync.Mutex
+	seen            map[uint64]map[slotAuthor]*types.Header // map of epoch number -> slot and authority index -> header
+	equivocationsMu sync.RWMutex
+	equivocations   map[byte]chan<- *EquivocationProof
 }
 
 // NewVerificationManager returns a new NewVerificationManager
@@ -66,13 +74,118 @@ func NewVerificationManager(blockState BlockState, epochState EpochState) (*Veri
 	}
 
 	return &VerificationManager{
-		epochState: epochState,
-		blockState: blockState,
-		epochInfo:  make(map[uint64]*verifierInfo),
-		onDisabled: make(map[uint64]map[uint32][]*onDisabledInfo),
+		epochState:    epochState,
+		blockState:    blockState,
+		epochInfo:     make(map[uint64]*verifierInfo),
+		onDisabled:    make(map[uint64]map[uint32][]*onDisabledInfo),
+		seen:          make(map[uint64]map[slotAuthor]*types.Header),
+		equivocations: make(map[byte]chan<- *EquivocationProof),
 	}, nil
 }
 
+// RegisterEquivocationChannel registers a channel that is notified when an authority is found to have produced
+// two different blocks in the same slot. It returns the channel ID (used for unregistering the channel)
+func (v *VerificationManager) RegisterEquivocationChannel(ch chan<- *EquivocationProof) (byte, error) {
+	v.equivocationsMu.Lock()
+	defer v.equivocationsMu.Unlock()
+
+	if len(v.equivocations) == 256 {
+		return 0, errors.New("channel limit reached")
+	}
+
+	var id byte
+	for {
+		id = byte(rand.Intn(256))
+		if v.equivocations[id] == nil {
+			break
+		}
+	}
+
+	v.equivocations[id] = ch
+	return id, nil
+}
+
+// UnregisterEquivocationChannel removes the equivocation notification channel with the given ID.
+// A channel must be unregistered before closing it.
+func (v *VerificationManager) UnregisterEquivocationChannel(id byte) {
+	v.equivocationsMu.Lock()
+	defer v.equivocationsMu.Unlock()
+
+	delete(v.equivocations, id)
+}
+
+func (v *VerificationManager) notifyEquivocation(proof *EquivocationProof) {
+	v.equivocationsMu.RLock()
+	defer v.equivocationsMu.RUnlock()
+
+	for _, ch := range v.equivocations {
+		go func(ch chan<- *EquivocationProof) {
+			select {
+			case ch <- proof:
+			default:
+			}
+		}(ch)
+	}
+}
+
+// checkEquivocation records the authority and slot of the given header. If a different header has already been seen
+// for the same authority and slot, an EquivocationProof is sent to all registered equivocation channels.
+// Only the current and previous epochs are tracked.
+func (v *VerificationManager) checkEquivocation(epoch uint64, header *types.Header) error {
+	slot, err := types.GetSlotFromHeader(header)
+	if err != nil {
+		return err
+	}
+
+	idx, err := getAuthorityIndex(header)
+	if err != nil {
+		return err
+	}
+
+	key := slotAuthor{
+		slot:           slot,
+		authorityIndex: idx,
+	}
+
+	v.seenLock.Lock()
+	defer v.seenLock.Unlock()
+
+	if _, has := v.seen[epoch]; !has {
+		v.seen[epoch] = make(map[slotAuthor]*types.Header)
+
+		for e := range v.seen {
+			if e+1 < epoch {
+				delete(v.seen, e)
+			}
+		}
+	}
+
+	first, has := v.seen[epoch][key]
+	if !has {
+		v.seen[epoch][key] = header
+		return nil
+	}
+
+	if first.Hash() == header.Hash() {
+		return nil
+	}
+
+	logger.Warn("detected block producer equivocation",
+		"slot", key.slot,
+		"authority index", key.authorityIndex,
+		"first", first.Hash(),
+		"second", header.Hash(),
+	)
+
+	v.notifyEquivocation(&EquivocationProof{
+		Slot:           key.slot,
+		AuthorityIndex: key.authorityIndex,
+		FirstHeader:    first,
+		SecondHeader:   header,
+	})
+	return nil
+}
+
 // SetOnDisabled sets the BABE authority with the given index as disabled for the rest of the epoch
 func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header) error {
 	epoch, err := v.epochState.GetEpochForBlock(header)
@@ -215,7 +328,17 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 		return fmt.Errorf("failed to create new BABE verifier: %w", err)
 	}
 
-	return verifier.verifyAuthorshipRight(header)
+	err = verifier.verifyAuthorshipRight(header)
+	if err != nil && !errors.Is(err, ErrProducerEquivocated) {
+		return err
+	}
+
+	// the block seal is valid, so the block was definitely produced by the authority in the pre-digest
+	if eqErr := v.checkEquivocation(epoch, header); eqErr != nil {
+		logger.Debug("failed to check for block producer equivocation", "error", eqErr)
+	}
+
+	return err
 }
 
 func (v *VerificationManager) isDisabled(epoch uint64, header *types.Header) (bool, error) { //nolint
@@ -271,9 +394,10 @@ func (v *VerificationManager) getVerifierInfo(epoch uint64) (*verifierInfo, erro
 	}
 
 	return &verifierInfo{
-		authorities: epochData.Authorities,
-		randomness:  epochData.Randomness,
-		threshold:   threshold,
+		authorities:    epochData.Authorities,
+		randomness:     epochData.Randomness,
+		threshold:      threshold,
+		secondarySlots: configData.SecondarySlots,
 	}, nil
 }
 
@@ -294,11 +418,12 @@ func (v *VerificationManager) getConfigData(epoch uint64) (*types.ConfigData, er
 
 // verifier is a BABE verifier for a specific authority set, randomness, and threshold
 type verifier struct {
-	blockState  BlockState
-	epoch       uint64
-	authorities []*types.Authority
-	randomness  Randomness
-	threshold   *common.Uint128
+	blockState     BlockState
+	epoch          uint64
+	authorities    []*types.Authority
+	randomness     Randomness
+	threshold      *common.Uint128
+	secondarySlots byte
 }
 
 // newVerifier returns a Verifier for the epoch described by the given descriptor
@@ -308,11 +433,12 @@ func newVerifier(blockState BlockState, epoch uint64, info *verifierInfo) (*veri
 	}
 
 	return &verifier{
-		blockState:  blockState,
-		epoch:       epoch,
-		authorities: info.authorities,
-		randomness:  info.randomness,
-		threshold:   info.threshold,
+		blockState:     blockState,
+		epoch:          epoch,
+		authorities:    info.authorities,
+		randomness:     info.randomness,
+		threshold:      info.threshold,
+		secondarySlots: info.secondarySlots,
 	}, nil
 }
 
@@ -420,6 +546,10 @@ func (b *verifier) verifyPreRuntimeDigest(digest *types.PreRuntimeDigest) (types
 	case *types.BabePrimaryPreDigest:
 		ok, err = b.verifyPrimarySlotWinner(d.AuthorityIndex(), d.SlotNumber(), d.VrfOutput(), d.VrfProof())
 	case *types.BabeSecondaryVRFPreDigest:
+		if b.secondarySlots != primaryAndSecondaryVRFSlots {
+			return nil, ErrSecondarySlotsDisabled
+		}
+
 		pub := b.authorities[d.AuthorityIndex()].Key
 		var pk *sr25519.PublicKey
 		pk, err = sr25519.NewPublicKey(pub.Encode())
@@ -429,6 +559,10 @@ func (b *verifier) verifyPreRuntimeDigest(digest *types.PreRuntimeDigest) (types
 
 		ok, err = verifySecondarySlotVRF(d, pk, b.epoch, len(b.authorities), b.randomness)
 	case *types.BabeSecondaryPlainPreDigest:
+		if b.secondarySlots != primaryAndSecondaryPlainSlots {
+			return nil, ErrSecondarySlotsDisabled
+		}
+
 		ok = true
 		err = verifySecondarySlotPlain(d.AuthorityIndex(), d.SlotNumber(), len(b.authorities), b.randomness)
 	}
@@ -19,6 +19,7 @@ package babe
 import (
 	"errors"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
 	log "github.com/ChainSafe/log15"
@@ -396,3 +398,171 @@ func TestVerifyAuthorshipRight_Equivocation(t *testing.T) {
 	err = verifier.verifyAuthorshipRight(block2.Header)
 	require.Equal(t, ErrProducerEquivocated, err)
 }
+
+func newTestSecondarySlotHeader(t *testing.T, kp *sr25519.Keypair, parent common.Hash, preDigest types.BabePreRuntimeDigest) *types.Header {
+	header, err := types.NewHeader(parent, common.Hash{}, common.Hash{}, big.NewInt(1), types.Digest{
+		types.NewBABEPreRuntimeDigest(preDigest.Encode()),
+	})
+	require.NoError(t, err)
+
+	return sealTestHeader(t, kp, header)
+}
+
+func sealTestHeader(t *testing.T, kp *sr25519.Keypair, header *types.Header) *types.Header {
+	encHeader, err := header.Encode()
+	require.NoError(t, err)
+
+	hash, err := common.Blake2bHash(encHeader)
+	require.NoError(t, err)
+
+	sig, err := kp.Sign(hash[:])
+	require.NoError(t, err)
+
+	header.Digest = append(header.Digest, &types.SealDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              sig,
+	})
+	return header
+}
+
+func newTestSecondarySlotVerifier(t *testing.T, kp *sr25519.Keypair, secondarySlots byte) *verifier {
+	vm := newTestVerificationManager(t, nil)
+
+	verifier, err := newVerifier(vm.blockState, testEpochIndex, &verifierInfo{
+		authorities: []*types.Authority{
+			{Key: kp.Public().(*sr25519.PublicKey)},
+		},
+		threshold:      maxThreshold,
+		randomness:     Randomness{},
+		secondarySlots: secondarySlots,
+	})
+	require.NoError(t, err)
+	return verifier
+}
+
+func TestVerifyAuthorshipRight_SecondaryPlain(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	parent := newTestVerificationManager(t, nil).blockState.BestBlockHash()
+	header := newTestSecondarySlotHeader(t, kp, parent, types.NewBabeSecondaryPlainPreDigest(0, 77))
+
+	verifier := newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryPlainSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.NoError(t, err)
+
+	// only primary slot blocks are allowed
+	verifier = newTestSecondarySlotVerifier(t, kp, 0)
+	err = verifier.verifyAuthorshipRight(header)
+	require.True(t, errors.Is(err, ErrSecondarySlotsDisabled))
+
+	// authority 1 is not in the authority set
+	header = newTestSecondarySlotHeader(t, kp, parent, types.NewBabeSecondaryPlainPreDigest(1, 77))
+	verifier = newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryPlainSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.True(t, errors.Is(err, ErrInvalidBlockProducerIndex))
+}
+
+func TestVerifyAuthorshipRight_SecondaryVRF(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	parent := newTestVerificationManager(t, nil).blockState.BestBlockHash()
+	preDigest := createSecondaryVRFPreDigest(t, kp, 0, 77, testEpochIndex, Randomness{})
+	header := newTestSecondarySlotHeader(t, kp, parent, preDigest)
+
+	verifier := newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryVRFSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.NoError(t, err)
+
+	verifier = newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryPlainSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.True(t, errors.Is(err, ErrSecondarySlotsDisabled))
+
+	// VRF proof for a different slot should fail
+	badPreDigest := createSecondaryVRFPreDigest(t, kp, 0, 78, testEpochIndex, Randomness{})
+	header = newTestSecondarySlotHeader(t, kp, parent, types.NewBabeSecondaryVRFPreDigest(0, 77, badPreDigest.VrfOutput(), badPreDigest.VrfProof()))
+	verifier = newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryVRFSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.True(t, errors.Is(err, ErrBadSlotClaim))
+}
+
+func TestVerifyAuthorshipRight_SecondaryMalformed(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	parent := newTestVerificationManager(t, nil).blockState.BestBlockHash()
+	header := newTestSecondarySlotHeader(t, kp, parent, types.NewBabeSecondaryPlainPreDigest(0, 77))
+
+	// truncate the encoded secondary pre-digest so that it can't be decoded
+	preDigest := header.Digest[0].(*types.PreRuntimeDigest)
+	preDigest.Data = preDigest.Data[:3]
+
+	verifier := newTestSecondarySlotVerifier(t, kp, primaryAndSecondaryPlainSlots)
+	err = verifier.verifyAuthorshipRight(header)
+	require.Error(t, err)
+
+	// header signed by a different key should fail
+	kp2, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	header = newTestSecondarySlotHeader(t, kp2, parent, types.NewBabeSecondaryPlainPreDigest(0, 77))
+	err = verifier.verifyAuthorshipRight(header)
+	require.Equal(t, ErrBadSignature, err)
+}
+
+func TestVerificationManager_VerifyBlock_EquivocationReported(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	cfg := *genesisBABEConfig
+	cfg.GenesisAuthorities = []*types.AuthorityRaw{
+		{Key: kp.Public().(*sr25519.PublicKey).AsBytes(), Weight: 1},
+	}
+	cfg.SecondarySlots = primaryAndSecondaryPlainSlots
+
+	vm := newTestVerificationManager(t, &cfg)
+
+	ch := make(chan *EquivocationProof, 1)
+	id, err := vm.RegisterEquivocationChannel(ch)
+	require.NoError(t, err)
+	defer vm.UnregisterEquivocationChannel(id)
+
+	parent := vm.blockState.BestBlockHash()
+	preDigest := types.NewBabeSecondaryPlainPreDigest(0, 77)
+
+	header1 := newTestSecondarySlotHeader(t, kp, parent, preDigest)
+	err = vm.VerifyBlock(header1)
+	require.NoError(t, err)
+
+	// verifying the same block again is not an equivocation
+	err = vm.VerifyBlock(header1)
+	require.NoError(t, err)
+
+	select {
+	case <-ch:
+		t.Fatal("should not have reported equivocation")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// a different block by the same authority in the same slot
+	header2, err := types.NewHeader(parent, common.Hash{1}, common.Hash{}, big.NewInt(1), types.Digest{
+		types.NewBABEPreRuntimeDigest(preDigest.Encode()),
+	})
+	require.NoError(t, err)
+	header2 = sealTestHeader(t, kp, header2)
+	require.NotEqual(t, header1.Hash(), header2.Hash())
+
+	err = vm.VerifyBlock(header2)
+	require.NoError(t, err)
+
+	select {
+	case proof := <-ch:
+		require.Equal(t, uint64(77), proof.Slot)
+		require.Equal(t, uint32(0), proof.AuthorityIndex)
+		require.Equal(t, header1.Hash(), proof.FirstHeader.Hash())
+		require.Equal(t, header2.Hash(), proof.SecondHeader.Hash())
+	case <-time.After(time.Second):
+		t.Fatal("did not receive equivocation proof")
+	}
+}
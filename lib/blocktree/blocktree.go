@@ -19,6 +19,7 @@ package blocktree
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,12 +33,26 @@ import (
 // Hash common.Hash
 type Hash = common.Hash
 
+// maxDescendantCacheSize is the maximum number of IsDescendantOf results kept by the BlockTree.
+// once exceeded, the cache is cleared.
+const maxDescendantCacheSize = 4096
+
+// descendantKey is the key of a cached IsDescendantOf result
+type descendantKey struct {
+	parent, child Hash
+}
+
 // BlockTree represents the current state with all possible blocks
 type BlockTree struct {
 	head   *node // root node TODO: rename this!!
 	leaves *leafMap
+	depths map[uint64][]Hash // depth -> hashes of the blocks at that depth, in the order they were added
 	db     database.Database
 	sync.RWMutex
+
+	// cache of IsDescendantOf results, cleared whenever blocks are removed from the tree
+	descendantCacheLock sync.Mutex
+	descendantCache     map[descendantKey]bool
 }
 
 // NewEmptyBlockTree creates a BlockTree with a nil head
@@ -63,10 +78,28 @@ func NewBlockTreeFromRoot(root *types.Header, db database.Database) *BlockTree {
 	return &BlockTree{
 		head:   head,
 		leaves: newLeafMap(head),
+		depths: newDepthIndex(head),
 		db:     db,
 	}
 }
 
+// newDepthIndex returns the depth index of the tree with the given head
+func newDepthIndex(head *node) map[uint64][]Hash {
+	depths := make(map[uint64][]Hash)
+	var index func(n *node)
+	index = func(n *node) {
+		depths[n.depth.Uint64()] = append(depths[n.depth.Uint64()], n.hash)
+		for _, child := range n.children {
+			index(child)
+		}
+	}
+
+	if head != nil {
+		index(head)
+	}
+	return depths
+}
+
 // GenesisHash returns the hash of the genesis block
 func (bt *BlockTree) GenesisHash() Hash {
 	bt.RLock()
@@ -101,9 +134,15 @@ func (bt *BlockTree) AddBlock(header *types.Header, arrivalTime uint64) error {
 		depth:       depth,
 		arrivalTime: arrivalTime,
 	}
+	// adding a leaf doesn't change whether the blocks already in the tree descend from one another,
+	// so the descendant cache remains valid
 	parent.addChild(n)
 	bt.leaves.replace(parent, n)
 
+	if bt.depths == nil {
+		bt.depths = make(map[uint64][]Hash)
+	}
+	bt.depths[depth.Uint64()] = append(bt.depths[depth.Uint64()], n.hash)
 	return nil
 }
 
@@ -123,30 +162,58 @@ func (bt *BlockTree) Rewind(numBlocks int) {
 
 			bt.leaves.replace(leaf, leaf.parent)
 			leaf.parent.deleteChild(leaf)
+			bt.unindex(leaf)
 		}
 	}
+
+	bt.clearDescendantCache()
+}
+
+// unindex removes the given node from the depth index
+func (bt *BlockTree) unindex(n *node) {
+	d := n.depth.Uint64()
+	for i, hash := range bt.depths[d] {
+		if hash == n.hash {
+			bt.depths[d] = append(bt.depths[d][:i], bt.depths[d][i+1:]...)
+			break
+		}
+	}
+
+	if len(bt.depths[d]) == 0 {
+		delete(bt.depths, d)
+	}
 }
 
 // GetAllBlocksAtDepth will return all blocks hashes with the depth of the given hash plus one.
 // To find all blocks at a depth matching a certain block, pass in that block's parent hash
 func (bt *BlockTree) GetAllBlocksAtDepth(hash common.Hash) []common.Hash {
+	return bt.GetBlocksAtDepth(hash, 0, -1)
+}
+
+// GetBlocksAtDepth returns up to limit hashes of the blocks with the depth of the given hash plus one, skipping
+// the first offset of them in the order they were added. A negative limit returns all the remaining hashes.
+func (bt *BlockTree) GetBlocksAtDepth(hash common.Hash, offset, limit int) []common.Hash {
 	bt.RLock()
 	defer bt.RUnlock()
 
 	hashes := []common.Hash{}
 
-	if bt.getNode(hash) == nil {
+	n := bt.getNode(hash)
+	if n == nil {
 		return hashes
 	}
 
-	depth := big.NewInt(0).Add(bt.getNode(hash).depth, big.NewInt(1))
-
-	if bt.head.depth.Cmp(depth) == 0 {
-		hashes = append(hashes, bt.head.hash)
+	atDepth := bt.depths[n.depth.Uint64()+1]
+	if offset < 0 || offset >= len(atDepth) {
 		return hashes
 	}
 
-	return bt.head.getNodesWithDepth(depth, hashes)
+	atDepth = atDepth[offset:]
+	if limit >= 0 && limit < len(atDepth) {
+		atDepth = atDepth[:limit]
+	}
+
+	return append(hashes, atDepth...)
 }
 
 // getNode finds and returns a node based on its Hash. Returns nil if not found.
@@ -187,8 +254,30 @@ func (bt *BlockTree) Prune(finalised Hash) (pruned []Hash) {
 
 	pruned = bt.head.prune(n, nil)
 	bt.head = n
-	bt.leaves = newEmptyLeafMap()
-	bt.leaves.store(n.hash, n)
+	bt.leaves = newLeafMap(n)
+	bt.clearDescendantCache()
+
+	// keep the remaining blocks in the depth index in the order they were added
+	retained := make(map[Hash]struct{})
+	for _, hash := range n.getAllDescendants(nil) {
+		retained[hash] = struct{}{}
+	}
+
+	for d, hashes := range bt.depths {
+		kept := hashes[:0]
+		for _, hash := range hashes {
+			if _, ok := retained[hash]; ok {
+				kept = append(kept, hash)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(bt.depths, d)
+			continue
+		}
+		bt.depths[d] = kept
+	}
+
 	return pruned
 }
 
@@ -217,6 +306,52 @@ func (bt *BlockTree) String() string {
 	return fmt.Sprintf("%s\n%s\n", metadata, tree.Print())
 }
 
+// DOT returns the blocktree in Graphviz DOT format, with an edge from each block to each of its children.
+// Each block is labelled with label(hash), or its hash if label is nil. The given finalised block and the
+// deepest block are highlighted.
+func (bt *BlockTree) DOT(label func(Hash) string, finalised Hash) string {
+	bt.RLock()
+	defer bt.RUnlock()
+
+	if label == nil {
+		label = func(hash Hash) string {
+			return hash.String()
+		}
+	}
+
+	var best Hash
+	if dl := bt.deepestLeaf(); dl != nil {
+		best = dl.hash
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph blocktree {\n")
+
+	var write func(n *node)
+	write = func(n *node) {
+		attrs := fmt.Sprintf("label=%q", label(n.hash))
+		switch n.hash {
+		case finalised:
+			attrs += ", style=filled, fillcolor=lightblue"
+		case best:
+			attrs += ", style=filled, fillcolor=palegreen"
+		}
+		fmt.Fprintf(&sb, "\t\"%s\" [%s];\n", n.hash, attrs)
+
+		for _, child := range n.children {
+			fmt.Fprintf(&sb, "\t\"%s\" -> \"%s\";\n", n.hash, child.hash)
+			write(child)
+		}
+	}
+
+	if bt.head != nil {
+		write(bt.head)
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
 // longestPath returns the path from the root to leftmost deepest leaf in BlockTree BT
 func (bt *BlockTree) longestPath() []*node { //nolint
 	dl := bt.deepestLeaf()
@@ -284,10 +419,21 @@ func (bt *BlockTree) DeepestBlockHash() Hash {
 
 // IsDescendantOf returns true if the child is a descendant of parent, false otherwise.
 // it returns an error if either the child or parent are not in the blocktree.
+// The result is cached until blocks are removed from the tree, so repeated lookups don't need to walk the tree.
 func (bt *BlockTree) IsDescendantOf(parent, child Hash) (bool, error) {
 	bt.RLock()
 	defer bt.RUnlock()
 
+	key := descendantKey{parent: parent, child: child}
+
+	bt.descendantCacheLock.Lock()
+	defer bt.descendantCacheLock.Unlock()
+
+	// both blocks of a cached result are still in the tree, as the cache is cleared whenever blocks are removed
+	if is, has := bt.descendantCache[key]; has {
+		return is, nil
+	}
+
 	pn := bt.getNode(parent)
 	if pn == nil {
 		return false, ErrStartNodeNotFound
@@ -296,7 +442,37 @@ func (bt *BlockTree) IsDescendantOf(parent, child Hash) (bool, error) {
 	if cn == nil {
 		return false, ErrEndNodeNotFound
 	}
-	return cn.isDescendantOf(pn), nil
+
+	if bt.descendantCache == nil || len(bt.descendantCache) >= maxDescendantCacheSize {
+		bt.descendantCache = make(map[descendantKey]bool)
+	}
+
+	is := cn.isDescendantOf(pn)
+	bt.descendantCache[key] = is
+	return is, nil
+}
+
+// clearDescendantCache clears the cached IsDescendantOf results. It must be called whenever blocks are removed from
+// the tree.
+func (bt *BlockTree) clearDescendantCache() {
+	bt.descendantCacheLock.Lock()
+	defer bt.descendantCacheLock.Unlock()
+	bt.descendantCache = nil
+}
+
+// LeafCount returns the number of leaves of the blocktree. Leaves that don't descend from the root are removed
+// when the tree is pruned, so on a finalising chain the count is bounded by the number of forks since the last
+// finalised block.
+func (bt *BlockTree) LeafCount() int {
+	bt.RLock()
+	defer bt.RUnlock()
+
+	count := 0
+	bt.leaves.smap.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
 }
 
 // Leaves returns the leaves of the blocktree as an array
@@ -341,6 +517,20 @@ func (bt *BlockTree) GetAllBlocks() []Hash {
 	return bt.head.getAllDescendants(nil)
 }
 
+// GetAllDescendants returns the hashes of all the descendants of the block with the given hash, not including
+// the block itself
+func (bt *BlockTree) GetAllDescendants(hash Hash) ([]Hash, error) {
+	bt.RLock()
+	defer bt.RUnlock()
+
+	n := bt.getNode(hash)
+	if n == nil {
+		return nil, ErrNodeNotFound
+	}
+
+	return n.getAllDescendants(nil)[1:], nil
+}
+
 // DeepCopy returns a copy of the BlockTree
 func (bt *BlockTree) DeepCopy() *BlockTree {
 	bt.RLock()
@@ -356,6 +546,11 @@ func (bt *BlockTree) DeepCopy() *BlockTree {
 
 	btCopy.head = bt.head.deepCopy(nil)
 
+	btCopy.depths = make(map[uint64][]Hash, len(bt.depths))
+	for d, hashes := range bt.depths {
+		btCopy.depths[d] = append([]Hash{}, hashes...)
+	}
+
 	if bt.leaves != nil {
 		btCopy.leaves = newEmptyLeafMap()
 
@@ -18,8 +18,10 @@ package blocktree
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	database "github.com/ChainSafe/chaindb"
@@ -40,6 +42,7 @@ func newBlockTreeFromNode(head *node, db database.Database) *BlockTree {
 	return &BlockTree{
 		head:   head,
 		leaves: newLeafMap(head),
+		depths: newDepthIndex(head),
 		db:     db,
 	}
 }
@@ -378,6 +381,16 @@ func TestBlockTree_Prune(t *testing.T) {
 			t.Fatal("pruned an ancestor of the finalised node!!")
 		}
 	}
+
+	// the remaining leaves are those of the finalised node, so the best block is still chosen among its descendants
+	var leaves []Hash
+	for _, leaf := range finalised.getLeaves(nil) {
+		leaves = append(leaves, leaf.hash)
+	}
+	require.ElementsMatch(t, leaves, bt.Leaves())
+
+	deepest := bt.getNode(bt.DeepestBlockHash())
+	require.True(t, deepest.isDescendantOf(finalised))
 }
 
 func TestBlockTree_DeepCopy(t *testing.T) {
@@ -447,3 +460,180 @@ func TestBlockTree_Rewind(t *testing.T) {
 	deepest := bt.leaves.deepestLeaf()
 	require.Equal(t, start.depth.Int64()-int64(rewind), deepest.depth.Int64())
 }
+
+func TestBlockTree_GetBlocksAtDepth(t *testing.T) {
+	bt, hashes := createFlatTree(t, 2)
+
+	// add forks at depth 2
+	expected := []common.Hash{hashes[2]}
+	for i := 0; i < 3; i++ {
+		header := &types.Header{
+			ParentHash: hashes[1],
+			Number:     big.NewInt(2),
+			Digest:     types.Digest{utils.NewMockDigestItem(i)},
+		}
+
+		err := bt.AddBlock(header, 0)
+		require.NoError(t, err)
+		expected = append(expected, header.Hash())
+	}
+
+	require.Equal(t, expected, bt.GetAllBlocksAtDepth(hashes[1]))
+	require.Equal(t, expected[1:3], bt.GetBlocksAtDepth(hashes[1], 1, 2))
+	require.Equal(t, expected[3:], bt.GetBlocksAtDepth(hashes[1], 3, 2))
+	require.Equal(t, []common.Hash{}, bt.GetBlocksAtDepth(hashes[1], 4, 2))
+
+	// the index is rebuilt when the tree is decoded
+	enc, err := bt.Encode()
+	require.NoError(t, err)
+
+	decoded := NewEmptyBlockTree(nil)
+	err = decoded.Decode(enc)
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, decoded.GetAllBlocksAtDepth(hashes[1]))
+
+	// rewinding removes the blocks at the deepest depth
+	bt.Rewind(1)
+	require.Equal(t, []common.Hash{}, bt.GetAllBlocksAtDepth(hashes[1]))
+
+	// pruning removes the blocks that aren't descendants of the finalised block
+	for _, hash := range expected {
+		err = decoded.AddBlock(&types.Header{ParentHash: hash, Number: big.NewInt(3)}, 0)
+		require.NoError(t, err)
+	}
+
+	decoded.Prune(expected[1])
+	require.Equal(t, []common.Hash{
+		(&types.Header{ParentHash: expected[1], Number: big.NewInt(3)}).Hash(),
+	}, decoded.GetAllBlocksAtDepth(expected[1]))
+}
+
+func TestBlockTree_IsDescendantOf_Cached(t *testing.T) {
+	bt, _ := createTestBlockTree(testHeader, 16, nil)
+
+	requireMatchesUncached := func() {
+		hashes := bt.GetAllBlocks()
+		for _, parent := range hashes {
+			for _, child := range hashes {
+				expected := bt.getNode(child).isDescendantOf(bt.getNode(parent))
+
+				// the second lookup is served by the cache
+				for i := 0; i < 2; i++ {
+					is, err := bt.IsDescendantOf(parent, child)
+					require.NoError(t, err)
+					require.Equal(t, expected, is)
+				}
+			}
+		}
+	}
+
+	requireMatchesUncached()
+
+	// a re-org onto a new fork off the root
+	previous := bt.head.hash
+	for i := 1; i <= 20; i++ {
+		header := &types.Header{
+			ParentHash: previous,
+			Number:     big.NewInt(int64(i)),
+			Digest:     types.Digest{utils.NewMockDigestItem(0xff)},
+		}
+
+		err := bt.AddBlock(header, 0)
+		require.NoError(t, err)
+		previous = header.Hash()
+	}
+	require.Equal(t, previous, bt.DeepestBlockHash())
+	requireMatchesUncached()
+
+	bt.Rewind(5)
+	requireMatchesUncached()
+
+	// removed blocks are no longer found, even if a result for them was cached
+	removed := bt.head.children[0].hash
+	finalised := bt.head.children[len(bt.head.children)-1]
+	_, err := bt.IsDescendantOf(removed, removed)
+	require.NoError(t, err)
+
+	bt.Prune(finalised.hash)
+	_, err = bt.IsDescendantOf(removed, removed)
+	require.Equal(t, ErrStartNodeNotFound, err)
+	requireMatchesUncached()
+}
+
+func BenchmarkBlockTree_IsDescendantOf(b *testing.B) {
+	bt := NewBlockTreeFromRoot(testHeader, nil)
+
+	previous := bt.head.hash
+	for i := 1; i <= 1000; i++ {
+		header := &types.Header{
+			ParentHash: previous,
+			Number:     big.NewInt(int64(i)),
+		}
+
+		err := bt.AddBlock(header, 0)
+		require.NoError(b, err)
+		previous = header.Hash()
+	}
+
+	root, leaf := bt.getNode(bt.head.hash), bt.getNode(previous)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = leaf.isDescendantOf(root)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = bt.IsDescendantOf(root.hash, leaf.hash)
+		}
+	})
+}
+
+func TestBlockTree_DOT(t *testing.T) {
+	bt, hashes := createFlatTree(t, 4)
+
+	fork := &types.Header{
+		ParentHash: hashes[1],
+		Number:     big.NewInt(2),
+		Digest:     types.Digest{utils.NewMockDigestItem(1)},
+	}
+	err := bt.AddBlock(fork, 0)
+	require.NoError(t, err)
+
+	dot := bt.DOT(nil, hashes[0])
+	require.True(t, strings.HasPrefix(dot, "digraph blocktree {\n"))
+
+	for i := 1; i < len(hashes); i++ {
+		require.Contains(t, dot, fmt.Sprintf("\t\"%s\" -> \"%s\";\n", hashes[i-1], hashes[i]))
+	}
+	require.Contains(t, dot, fmt.Sprintf("\t\"%s\" -> \"%s\";\n", hashes[1], fork.Hash()))
+	require.Equal(t, len(hashes), strings.Count(dot, "->"))
+
+	require.Contains(t, dot, fmt.Sprintf("\t\"%s\" [label=\"%s\", style=filled, fillcolor=lightblue];\n",
+		hashes[0], hashes[0]))
+	require.Contains(t, dot, fmt.Sprintf("\t\"%s\" [label=\"%s\", style=filled, fillcolor=palegreen];\n",
+		hashes[4], hashes[4]))
+
+	dot = bt.DOT(func(hash Hash) string {
+		return "block"
+	}, hashes[0])
+	require.Equal(t, len(hashes)+1, strings.Count(dot, "[label=\"block\""))
+}
+
+func TestBlockTree_LeafCount(t *testing.T) {
+	bt, hashes := createFlatTree(t, 4)
+	require.Equal(t, 1, bt.LeafCount())
+
+	fork := &types.Header{
+		ParentHash: hashes[1],
+		Number:     big.NewInt(2),
+		Digest:     types.Digest{utils.NewMockDigestItem(1)},
+	}
+	err := bt.AddBlock(fork, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, bt.LeafCount())
+
+	bt.Prune(hashes[2])
+	require.Equal(t, 1, bt.LeafCount())
+}
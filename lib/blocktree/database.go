@@ -100,7 +100,14 @@ func (bt *BlockTree) Decode(in []byte) error {
 
 	bt.leaves = newLeafMap(bt.head)
 
-	return bt.decodeRecursive(r, bt.head)
+	err = bt.decodeRecursive(r, bt.head)
+	if err != nil {
+		return err
+	}
+
+	bt.depths = newDepthIndex(bt.head)
+	bt.clearDescendantCache()
+	return nil
 }
 
 // decode recursively decodes the blocktree
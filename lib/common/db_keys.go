@@ -33,4 +33,9 @@ var (
 	WorkingStorageHashKey = []byte("working_storage_hash")
 	//NodeNameKey is the storage key to store de current node name and avoid create a new name every initialization
 	NodeNameKey = []byte("node_name")
+	// SchemaVersionKey is the db location of the version of the database schema the node was initialised with
+	SchemaVersionKey = []byte("schema_version")
+	// PendingBlockDeletesKey is the db location of the pruned blocks whose deletion is deferred by the finalisation
+	// grace period
+	PendingBlockDeletesKey = []byte("pending_block_deletes")
 )
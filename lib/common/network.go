@@ -18,11 +18,25 @@ package common
 
 import ma "github.com/multiformats/go-multiaddr"
 
+// SyncStatus describes whether the node is synced, syncing, or unable to sync as it has no peers
+type SyncStatus string
+
+const (
+	// SyncStatusSynced is reported when the node has peers and is synced with them
+	SyncStatusSynced SyncStatus = "synced"
+	// SyncStatusSyncing is reported when the node has peers and is catching up to them
+	SyncStatusSyncing SyncStatus = "syncing"
+	// SyncStatusNoPeers is reported when the node has no peers to sync with
+	SyncStatusNoPeers SyncStatus = "no-peers"
+)
+
 // Health is network information about host needed for the rpc server
 type Health struct {
 	Peers           int
 	IsSyncing       bool
 	ShouldHavePeers bool
+	HasSyncTarget   bool       // true if a peer has announced a best block higher than ours
+	SyncStatus      SyncStatus // one of synced, syncing or no-peers
 }
 
 // NetworkState is network information about host needed for the rpc server and the runtime
@@ -0,0 +1,144 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package address
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DefaultPrefix is the generic substrate SS58 network prefix
+const DefaultPrefix uint16 = 42
+
+// maxPrefix is the largest network prefix that can be represented in an SS58 address
+const maxPrefix uint16 = 1<<14 - 1
+
+var ss58Prefix = []byte("SS58PRE")
+
+// ErrInvalidChecksum is returned when the checksum of an SS58 address does not match its contents
+var ErrInvalidChecksum = errors.New("invalid ss58 checksum")
+
+// SS58Encode returns the SS58 address of the given public key using the network prefix
+// see: https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
+func SS58Encode(pubkey []byte, prefix uint16) (string, error) {
+	if prefix > maxPrefix {
+		return "", fmt.Errorf("ss58 prefix %d is too large", prefix)
+	}
+
+	checksumLen, err := checksumLength(len(pubkey))
+	if err != nil {
+		return "", err
+	}
+
+	data := append(encodePrefix(prefix), pubkey...)
+	checksum, err := ss58Checksum(data)
+	if err != nil {
+		return "", err
+	}
+
+	return base58.Encode(append(data, checksum[:checksumLen]...)), nil
+}
+
+// SS58Decode returns the public key and network prefix of the given SS58 address
+func SS58Decode(addr string) ([]byte, uint16, error) {
+	data := base58.Decode(addr)
+	if len(data) < 2 {
+		return nil, 0, errors.New("ss58 address is too short")
+	}
+
+	prefix, prefixLen, err := decodePrefix(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the payload is either a 32/33 byte key followed by a 2 byte checksum or a 1, 2, 4 or 8 byte
+	// index followed by a 1 byte checksum
+	var checksumLen int
+	switch len(data) - prefixLen {
+	case 34, 35:
+		checksumLen = 2
+	case 2, 3, 5, 9:
+		checksumLen = 1
+	default:
+		return nil, 0, fmt.Errorf("invalid ss58 address length %d", len(data))
+	}
+
+	payloadLen := len(data) - prefixLen - checksumLen
+	checksum, err := ss58Checksum(data[:prefixLen+payloadLen])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !bytes.Equal(checksum[:checksumLen], data[prefixLen+payloadLen:]) {
+		return nil, 0, ErrInvalidChecksum
+	}
+
+	return data[prefixLen : prefixLen+payloadLen], prefix, nil
+}
+
+func encodePrefix(prefix uint16) []byte {
+	if prefix < 64 {
+		return []byte{byte(prefix)}
+	}
+
+	return []byte{
+		byte((prefix&0xfc)>>2) | 0x40,
+		byte(prefix>>8) | byte((prefix&0x03)<<6),
+	}
+}
+
+func decodePrefix(data []byte) (uint16, int, error) {
+	switch {
+	case data[0] < 64:
+		return uint16(data[0]), 1, nil
+	case data[0] < 128:
+		lower := (data[0] << 2) | (data[1] >> 6)
+		upper := data[1] & 0x3f
+		return uint16(lower) | uint16(upper)<<8, 2, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid ss58 prefix byte %d", data[0])
+	}
+}
+
+func checksumLength(payloadLen int) (int, error) {
+	switch payloadLen {
+	case 1, 2, 4, 8:
+		return 1, nil
+	case 32, 33:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("invalid ss58 payload length %d", payloadLen)
+	}
+}
+
+func ss58Checksum(data []byte) ([]byte, error) {
+	hasher, err := blake2b.New512(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hasher.Write(append(ss58Prefix[:len(ss58Prefix):len(ss58Prefix)], data...))
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
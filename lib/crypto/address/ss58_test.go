@@ -0,0 +1,75 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package address
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+var alicePublicKey = common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+func TestSS58Encode(t *testing.T) {
+	tests := []struct {
+		prefix uint16
+		addr   string
+	}{
+		{prefix: DefaultPrefix, addr: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+		{prefix: 0, addr: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"},
+		{prefix: 2, addr: "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"},
+	}
+
+	for _, test := range tests {
+		addr, err := SS58Encode(alicePublicKey, test.prefix)
+		require.NoError(t, err)
+		require.Equal(t, test.addr, addr)
+
+		pub, prefix, err := SS58Decode(test.addr)
+		require.NoError(t, err)
+		require.Equal(t, alicePublicKey, pub)
+		require.Equal(t, test.prefix, prefix)
+	}
+}
+
+func TestSS58Encode_TwoBytePrefix(t *testing.T) {
+	for _, prefix := range []uint16{64, 255, 1000, maxPrefix} {
+		addr, err := SS58Encode(alicePublicKey, prefix)
+		require.NoError(t, err)
+
+		pub, res, err := SS58Decode(addr)
+		require.NoError(t, err)
+		require.Equal(t, alicePublicKey, pub)
+		require.Equal(t, prefix, res)
+	}
+
+	_, err := SS58Encode(alicePublicKey, maxPrefix+1)
+	require.Error(t, err)
+}
+
+func TestSS58Decode_Invalid(t *testing.T) {
+	_, _, err := SS58Decode("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")
+	require.ErrorIs(t, err, ErrInvalidChecksum)
+
+	_, _, err = SS58Decode("5Grwva")
+	require.Error(t, err)
+
+	_, err = SS58Encode([]byte{1, 2, 3}, DefaultPrefix)
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ChainSafe/gossamer/lib/scale"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// JunctionIDLength is the length of the chain code of a derivation junction
+const JunctionIDLength = 32
+
+var (
+	derivationPathRegex = regexp.MustCompile(`^(//?[^/]+)*$`)
+	junctionRegex       = regexp.MustCompile(`(//?)([^/]+)`)
+)
+
+// ErrInvalidDerivationPath is returned when a derivation path cannot be parsed
+var ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+// DeriveJunction is a single step of a key derivation path. A hard junction (`//name`) can only be derived from
+// a private key, whereas a soft junction (`/name`) can also be derived from a public key.
+type DeriveJunction struct {
+	ChainCode [JunctionIDLength]byte
+	Hard      bool
+}
+
+// NewDeriveJunction creates a DeriveJunction from a junction name in the same way as substrate. If the name is an
+// integer, its SCALE encoding as a u64 is used as the chain code, otherwise the SCALE encoding of the string is
+// used. Encodings longer than JunctionIDLength are hashed with blake2b.
+func NewDeriveJunction(name string, hard bool) (DeriveJunction, error) {
+	var (
+		enc []byte
+		err error
+	)
+
+	if n, perr := strconv.ParseUint(name, 10, 64); perr == nil {
+		enc, err = scale.Encode(n)
+	} else {
+		enc, err = scale.Encode(name)
+	}
+	if err != nil {
+		return DeriveJunction{}, err
+	}
+
+	j := DeriveJunction{
+		Hard: hard,
+	}
+
+	if len(enc) > JunctionIDLength {
+		j.ChainCode = blake2b.Sum256(enc)
+	} else {
+		copy(j.ChainCode[:], enc)
+	}
+
+	return j, nil
+}
+
+// ParseDerivationPath parses a derivation path such as `//Alice/soft` into its junctions
+func ParseDerivationPath(path string) ([]DeriveJunction, error) {
+	if !derivationPathRegex.MatchString(path) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDerivationPath, path)
+	}
+
+	matches := junctionRegex.FindAllStringSubmatch(path, -1)
+	junctions := make([]DeriveJunction, len(matches))
+	for i, m := range matches {
+		j, err := NewDeriveJunction(m[2], m[1] == "//")
+		if err != nil {
+			return nil, err
+		}
+
+		junctions[i] = j
+	}
+
+	return junctions, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	junctions, err := ParseDerivationPath("//Alice/soft//1")
+	require.NoError(t, err)
+	require.Len(t, junctions, 3)
+
+	require.True(t, junctions[0].Hard)
+	require.Equal(t, append([]byte{5 << 2}, "Alice"...), junctions[0].ChainCode[:6])
+
+	require.False(t, junctions[1].Hard)
+	require.Equal(t, append([]byte{4 << 2}, "soft"...), junctions[1].ChainCode[:5])
+
+	// integer junctions are encoded as u64
+	require.True(t, junctions[2].Hard)
+	require.Equal(t, [JunctionIDLength]byte{1}, junctions[2].ChainCode)
+
+	junctions, err = ParseDerivationPath("")
+	require.NoError(t, err)
+	require.Empty(t, junctions)
+}
+
+func TestParseDerivationPath_Long(t *testing.T) {
+	junctions, err := ParseDerivationPath("//thisjunctionnameislongerthanthirtytwobytes")
+	require.NoError(t, err)
+	require.Len(t, junctions, 1)
+	require.NotEqual(t, [JunctionIDLength]byte{}, junctions[0].ChainCode)
+	require.NotEqual(t, byte(42<<2), junctions[0].ChainCode[0])
+}
+
+func TestParseDerivationPath_Invalid(t *testing.T) {
+	for _, path := range []string{"Alice", "//Alice//", "///password"} {
+		_, err := ParseDerivationPath(path)
+		require.ErrorIs(t, err, ErrInvalidDerivationPath, path)
+	}
+}
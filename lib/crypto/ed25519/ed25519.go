@@ -26,8 +26,10 @@ import (
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/scale"
 
 	"github.com/ChainSafe/go-schnorrkel"
+	"golang.org/x/crypto/blake2b"
 )
 
 // PublicKeyLength is the fixed Public Key Length
@@ -127,6 +129,27 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	return NewKeypairFromSeed(seed[:32])
 }
 
+// Derive returns the keypair derived from kp along the given junctions. Only hard junctions are supported.
+func (kp *Keypair) Derive(path []crypto.DeriveJunction) (*Keypair, error) {
+	prefix, err := scale.Encode("Ed25519HDKD")
+	if err != nil {
+		return nil, err
+	}
+
+	seed := ed25519.PrivateKey(*kp.private).Seed()
+	for _, j := range path {
+		if !j.Hard {
+			return nil, errors.New("soft derivation is not supported for ed25519")
+		}
+
+		data := append(append(prefix[:len(prefix):len(prefix)], seed...), j.ChainCode[:]...)
+		hash := blake2b.Sum256(data)
+		seed = hash[:]
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
 // GenerateKeypair returns a new ed25519 keypair
 func GenerateKeypair() (*Keypair, error) {
 	buf := make([]byte, SeedLength)
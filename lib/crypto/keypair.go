@@ -18,10 +18,10 @@ package crypto
 
 import (
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/address"
 
 	"github.com/btcsuite/btcutil/base58"
 	bip39 "github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
 // KeyType str
@@ -65,23 +65,15 @@ type PrivateKey interface {
 	Hex() string
 }
 
-var ss58Prefix = []byte("SS58PRE")
-
 // PublicKeyToAddress returns an ss58 address given a PublicKey
 // see: https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
 // also see: https://github.com/paritytech/substrate/blob/master/primitives/core/src/crypto.rs#L275
 func PublicKeyToAddress(pub PublicKey) common.Address {
-	enc := append([]byte{42}, pub.Encode()...)
-	hasher, err := blake2b.New(64, nil)
-	if err != nil {
-		return ""
-	}
-	_, err = hasher.Write(append(ss58Prefix, enc...))
+	addr, err := address.SS58Encode(pub.Encode(), address.DefaultPrefix)
 	if err != nil {
 		return ""
 	}
-	checksum := hasher.Sum(nil)
-	return common.Address(base58.Encode(append(enc, checksum[:2]...)))
+	return common.Address(addr)
 }
 
 // PublicAddressToByteArray returns []byte address for given PublicKey Address
@@ -144,6 +144,33 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// Derive returns the keypair derived from kp along the given junctions. Both hard and soft junctions are supported.
+func (kp *Keypair) Derive(path []crypto.DeriveJunction) (*Keypair, error) {
+	sk := kp.private.key
+	for _, j := range path {
+		var (
+			ek  *sr25519.ExtendedKey
+			err error
+		)
+
+		if j.Hard {
+			ek, err = sr25519.DeriveKeyHard(sk, nil, j.ChainCode)
+		} else {
+			ek, err = sr25519.DeriveKeySimple(sk, nil, j.ChainCode)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sk, err = ek.Secret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewKeypair(sk)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -261,6 +288,14 @@ func (k *PrivateKey) Decode(in []byte) error {
 	return k.key.Decode(b)
 }
 
+// Zero overwrites the private key in memory
+func (k *PrivateKey) Zero() {
+	if k.key == nil {
+		return
+	}
+	*k.key = sr25519.SecretKey{}
+}
+
 // Hex returns the private key as a '0x' prefixed hex string
 func (k *PrivateKey) Hex() string {
 	enc := k.Encode()
@@ -366,6 +401,29 @@ func (k *PublicKey) Decode(in []byte) error {
 	return k.key.Decode(b)
 }
 
+// Derive returns the public key derived from k along the given junctions. Only soft junctions can be derived from
+// a public key.
+func (k *PublicKey) Derive(path []crypto.DeriveJunction) (*PublicKey, error) {
+	pk := k.key
+	for _, j := range path {
+		if j.Hard {
+			return nil, errors.New("cannot derive hard junction from public key")
+		}
+
+		ek, err := sr25519.DeriveKeySimple(pk, nil, j.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+
+		pk, err = ek.Public()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &PublicKey{key: pk}, nil
+}
+
 // Address returns the ss58 address for this public key
 func (k *PublicKey) Address() common.Address {
 	return crypto.PublicKeyToAddress(k)
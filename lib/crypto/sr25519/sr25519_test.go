@@ -73,6 +73,14 @@ func TestEncodeAndDecodePrivateKey(t *testing.T) {
 	require.Equal(t, exp, res.key.Encode())
 }
 
+func TestPrivateKey_Zero(t *testing.T) {
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	kp.private.Zero()
+	require.Equal(t, make([]byte, PrivateKeyLength), kp.private.Encode())
+}
+
 func TestEncodeAndDecodePublicKey(t *testing.T) {
 	kp, err := GenerateKeypair()
 	require.NoError(t, err)
@@ -17,6 +17,10 @@
 package genesis
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -55,10 +59,93 @@ type TelemetryEndpoint struct {
 	Verbosity int
 }
 
+// childrenDefaultKey is the key of the raw section containing the default child storage
+const childrenDefaultKey = "childrenDefault"
+
 // Fields stores genesis raw data, and human readable runtime data
 type Fields struct {
 	Raw     map[string]map[string]string      `json:"raw,omitempty"`
 	Runtime map[string]map[string]interface{} `json:"runtime,omitempty"`
+	// ChildrenDefault stores the raw default child storage, found at raw.childrenDefault, by child storage key
+	ChildrenDefault map[string]map[string]string `json:"-"`
+}
+
+type fieldsJSON struct {
+	Raw     map[string]json.RawMessage        `json:"raw,omitempty"`
+	Runtime map[string]map[string]interface{} `json:"runtime,omitempty"`
+}
+
+// UnmarshalJSON decodes genesis fields, reading raw.childrenDefault into ChildrenDefault
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	var enc fieldsJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	f.Runtime = enc.Runtime
+	f.Raw = nil
+	f.ChildrenDefault = nil
+	if enc.Raw == nil {
+		return nil
+	}
+
+	f.Raw = make(map[string]map[string]string)
+	for section, msg := range enc.Raw {
+		if section == childrenDefaultKey {
+			if err := json.Unmarshal(msg, &f.ChildrenDefault); err != nil {
+				return rawSectionError(section, err)
+			}
+			continue
+		}
+
+		var storage map[string]string
+		if err := json.Unmarshal(msg, &storage); err != nil {
+			return rawSectionError(section, err)
+		}
+		f.Raw[section] = storage
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes genesis fields, writing ChildrenDefault to raw.childrenDefault
+func (f Fields) MarshalJSON() ([]byte, error) {
+	enc := struct {
+		Raw     map[string]interface{}            `json:"raw,omitempty"`
+		Runtime map[string]map[string]interface{} `json:"runtime,omitempty"`
+	}{
+		Runtime: f.Runtime,
+	}
+
+	if f.Raw != nil || f.ChildrenDefault != nil {
+		enc.Raw = make(map[string]interface{}, len(f.Raw)+1)
+		for section, storage := range f.Raw {
+			enc.Raw[section] = storage
+		}
+
+		if f.ChildrenDefault != nil {
+			enc.Raw[childrenDefaultKey] = f.ChildrenDefault
+		}
+	}
+
+	return json.Marshal(enc)
+}
+
+func rawSectionError(section string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	path := "genesis.raw." + section
+	if typeErr.Field != "" {
+		path += "." + typeErr.Field
+	}
+
+	return &ValidationError{
+		Path:   path,
+		Reason: fmt.Sprintf("has invalid type %s, expected %s", typeErr.Value, typeErr.Type),
+	}
 }
 
 // GenesisData formats genesis for trie storage
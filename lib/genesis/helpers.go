@@ -18,14 +18,13 @@ package genesis
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"math/big"
-	"path/filepath"
 	"reflect"
+	"strings"
 
+	"github.com/ChainSafe/gossamer/chain"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -38,18 +37,21 @@ import (
 
 // NewGenesisFromJSONRaw parses a JSON formatted genesis file
 func NewGenesisFromJSONRaw(file string) (*Genesis, error) {
-	fp, err := filepath.Abs(file)
+	data, err := chain.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
-	data, err := ioutil.ReadFile(filepath.Clean(fp))
+
+	g, err := decodeGenesis(data)
 	if err != nil {
 		return nil, err
 	}
 
-	g := new(Genesis)
-	err = json.Unmarshal(data, g)
-	return g, err
+	if err = g.Validate(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
 }
 
 // NewTrieFromGenesis creates a new trie from the raw genesis data
@@ -63,6 +65,26 @@ func NewTrieFromGenesis(g *Genesis) (*trie.Trie, error) {
 		return nil, fmt.Errorf("failed to create trie from genesis: %s", err)
 	}
 
+	for storageKey, storage := range g.GenesisFields().ChildrenDefault {
+		if len(storage) == 0 {
+			continue
+		}
+
+		keyToChild, err := common.HexToBytes(storageKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode child storage key %s: %s", storageKey, err)
+		}
+
+		child := trie.NewEmptyTrie()
+		if err = child.LoadFromMap(storage); err != nil {
+			return nil, fmt.Errorf("failed to create child trie %s from genesis: %s", storageKey, err)
+		}
+
+		if err = t.PutChild(keyToChild, child); err != nil {
+			return nil, fmt.Errorf("failed to insert child trie %s: %s", storageKey, err)
+		}
+	}
+
 	return t, nil
 }
 
@@ -87,18 +109,26 @@ func NewGenesisBlockFromTrie(t *trie.Trie) (*types.Header, error) {
 // trimGenesisAuthority iterates over authorities in genesis and keeps only `authCount` number of authorities.
 func trimGenesisAuthority(g *Genesis, authCount int) {
 	for k, authMap := range g.Genesis.Runtime {
-		if k != "babe" && k != "grandpa" {
+		// module and storage item names may be capitalised, eg. Babe.Authorities
+		if !strings.EqualFold(k, "babe") && !strings.EqualFold(k, "grandpa") {
 			continue
 		}
-		authorities, _ := authMap["authorities"].([]interface{})
-		var newAuthorities []interface{}
-		for _, authority := range authorities {
-			if len(newAuthorities) >= authCount {
-				break
+
+		for key, value := range authMap {
+			if !strings.EqualFold(key, "authorities") {
+				continue
+			}
+
+			authorities, _ := value.([]interface{})
+			var newAuthorities []interface{}
+			for _, authority := range authorities {
+				if len(newAuthorities) >= authCount {
+					break
+				}
+				newAuthorities = append(newAuthorities, authority)
 			}
-			newAuthorities = append(newAuthorities, authority)
+			authMap[key] = newAuthorities
 		}
-		authMap["authorities"] = newAuthorities
 	}
 }
 
@@ -128,20 +158,17 @@ func NewGenesisFromJSON(file string, authCount int) (*Genesis, error) {
 
 // NewGenesisSpecFromJSON returns a new Genesis (without raw fields) from a human-readable genesis file
 func NewGenesisSpecFromJSON(file string) (*Genesis, error) {
-	fp, err := filepath.Abs(file)
+	data, err := chain.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	g, err := decodeGenesis(data)
 	if err != nil {
 		return nil, err
 	}
 
-	g := new(Genesis)
-
-	err = json.Unmarshal(data, g)
-	if err != nil {
+	if err = g.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -82,7 +82,7 @@ func TestNewGenesisFromJSON(t *testing.T) {
 	defer os.Remove(file.Name())
 
 	// create human readable test genesis
-	testGenesis := &Genesis{}
+	testGenesis := &Genesis{Name: "gossamer", ID: "gossamer"}
 	hrData := make(map[string]map[string]interface{})
 	hrData["system"] = map[string]interface{}{"code": "0xfoo"} // system code entry
 	hrData["babe"] = make(map[string]interface{})
@@ -136,3 +136,50 @@ func TestNewTrieFromGenesis(t *testing.T) {
 
 	require.Equal(t, expTrie, trie)
 }
+
+func TestNewTrieFromGenesis_ChildStorage(t *testing.T) {
+	rawGenesis := &Genesis{
+		Genesis: Fields{
+			Raw: map[string]map[string]string{
+				"top": {"0x3a636f6465": "0x0102"},
+			},
+			ChildrenDefault: map[string]map[string]string{
+				"0x6b6579746f6368696c64": {"0x6e6f6f74": "0x0304"},
+				"0x656d707479":           {},
+			},
+		},
+	}
+
+	expChild := trie.NewEmptyTrie()
+	expChild.Put([]byte("noot"), []byte{3, 4})
+	expTrie := trie.NewEmptyTrie()
+	expTrie.Put([]byte(`:code`), []byte{1, 2})
+	err := expTrie.PutChild([]byte("keytochild"), expChild)
+	require.NoError(t, err)
+
+	tr, err := NewTrieFromGenesis(rawGenesis)
+	require.NoError(t, err)
+	require.Equal(t, expTrie.MustHash(), tr.MustHash())
+
+	val, err := tr.GetFromChild([]byte("keytochild"), []byte("noot"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, val)
+
+	// empty child tries are not included in the state
+	_, err = tr.GetChild([]byte("empty"))
+	require.Error(t, err)
+}
+
+func TestFields_JSONChildStorage(t *testing.T) {
+	data := `{"raw":{"top":{"0x3a636f6465":"0x0102"},"childrenDefault":{"0x6b6579746f6368696c64":{"0x6e6f6f74":"0x0304"}}}}`
+
+	fields := Fields{}
+	err := json.Unmarshal([]byte(data), &fields)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{"top": {"0x3a636f6465": "0x0102"}}, fields.Raw)
+	require.Equal(t, map[string]map[string]string{"0x6b6579746f6368696c64": {"0x6e6f6f74": "0x0304"}}, fields.ChildrenDefault)
+
+	enc, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.JSONEq(t, data, string(enc))
+}
@@ -75,7 +75,7 @@ func CreateTestGenesisJSONFile(asRaw bool) (string, error) {
 
 	tGen := &Genesis{
 		Name:       "test",
-		ID:         "",
+		ID:         "test",
 		Bootnodes:  nil,
 		ProtocolID: "",
 		Genesis:    Fields{},
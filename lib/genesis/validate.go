@@ -0,0 +1,152 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ValidationError describes a problem with a genesis file and where in the file it occurs
+type ValidationError struct {
+	Path   string
+	Reason string
+}
+
+// Error returns the location of the error followed by the reason for it
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Path, e.Reason)
+}
+
+// Validate checks that the required genesis fields are present and that raw storage entries are valid hex
+func (g *Genesis) Validate() error {
+	if g.Name == "" {
+		return &ValidationError{Path: "name", Reason: "is required"}
+	}
+
+	if g.ID == "" {
+		return &ValidationError{Path: "id", Reason: "is required"}
+	}
+
+	if g.Genesis.Raw == nil && g.Genesis.Runtime == nil {
+		return &ValidationError{Path: "genesis", Reason: "must contain either raw or runtime"}
+	}
+
+	if g.Genesis.Raw != nil {
+		if _, has := g.Genesis.Raw["top"]; !has && g.Genesis.Runtime == nil {
+			return &ValidationError{Path: "genesis.raw.top", Reason: "is required"}
+		}
+
+		for _, child := range sortedKeys(g.Genesis.Raw) {
+			if err := validateRawStorage("genesis.raw."+child, g.Genesis.Raw[child]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, storageKey := range sortedKeys(g.Genesis.ChildrenDefault) {
+		if _, err := common.HexToBytes(storageKey); err != nil {
+			return &ValidationError{
+				Path:   "genesis.raw." + childrenDefaultKey,
+				Reason: fmt.Sprintf("child storage key %s is not valid hex: %s", storageKey, err),
+			}
+		}
+
+		path := "genesis.raw." + childrenDefaultKey + "." + storageKey
+		if err := validateRawStorage(path, g.Genesis.ChildrenDefault[storageKey]); err != nil {
+			return err
+		}
+	}
+
+	for name, module := range g.Genesis.Runtime {
+		if module == nil {
+			return &ValidationError{Path: "genesis.runtime." + name, Reason: "must be an object"}
+		}
+	}
+
+	return nil
+}
+
+func validateRawStorage(path string, storage map[string]string) error {
+	keys := make([]string, 0, len(storage))
+	for k := range storage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := common.HexToBytes(k); err != nil {
+			return &ValidationError{Path: path, Reason: fmt.Sprintf("key %s is not valid hex: %s", k, err)}
+		}
+
+		if _, err := common.HexToBytes(storage[k]); err != nil {
+			return &ValidationError{Path: path, Reason: fmt.Sprintf("value of key %s is not valid hex: %s", k, err)}
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeGenesis unmarshals a genesis file, reporting where in the file any decoding error occurred
+func decodeGenesis(data []byte) (*Genesis, error) {
+	g := new(Genesis)
+	err := json.Unmarshal(data, g)
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := offsetToPosition(data, syntaxErr.Offset)
+		return nil, fmt.Errorf("invalid JSON at line %d, column %d: %w", line, col, err)
+	case errors.As(err, &typeErr):
+		return nil, &ValidationError{
+			Path:   typeErr.Field,
+			Reason: fmt.Sprintf("has invalid type %s, expected %s", typeErr.Value, typeErr.Type),
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// offsetToPosition converts a byte offset into a 1-indexed line and column
+func offsetToPosition(data []byte, offset int64) (line, col int) {
+	line, col = 1, 1
+	for i := int64(0); i < offset-1 && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return line, col
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package genesis
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestGenesisFile(t *testing.T, data string) string {
+	file, err := ioutil.TempFile("", "genesis-validate-test")
+	require.NoError(t, err)
+
+	_, err = file.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return file.Name()
+}
+
+func TestNewGenesisFromJSONRaw_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "missing name",
+			data:     `{"id":"dev","genesis":{"raw":{"top":{}}}}`,
+			expected: "name is required",
+		},
+		{
+			name:     "missing id",
+			data:     `{"name":"Gossamer","genesis":{"raw":{"top":{}}}}`,
+			expected: "id is required",
+		},
+		{
+			name:     "missing genesis",
+			data:     `{"name":"Gossamer","id":"dev"}`,
+			expected: "genesis must contain either raw or runtime",
+		},
+		{
+			name:     "missing top",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{}}}`,
+			expected: "genesis.raw.top is required",
+		},
+		{
+			name:     "invalid key",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{"0xzz":"0x01"}}}}`,
+			expected: "genesis.raw.top key 0xzz is not valid hex",
+		},
+		{
+			name:     "missing key prefix",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{"3a636f6465":"0x01"}}}}`,
+			expected: "genesis.raw.top key 3a636f6465 is not valid hex",
+		},
+		{
+			name:     "invalid value",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{"0x3a636f6465":"0x012"}}}}`,
+			expected: "genesis.raw.top value of key 0x3a636f6465 is not valid hex",
+		},
+		{
+			name:     "invalid child storage key",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{},"childrenDefault":{"foo":{}}}}}`,
+			expected: "genesis.raw.childrenDefault child storage key foo is not valid hex",
+		},
+		{
+			name:     "invalid child storage",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{},"childrenDefault":{"0x01":{"0x02":"foo"}}}}}`,
+			expected: "genesis.raw.childrenDefault.0x01 value of key 0x02 is not valid hex",
+		},
+		{
+			name:     "invalid child storage type",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{},"childrenDefault":{"0x01":"foo"}}}}`,
+			expected: "genesis.raw.childrenDefault.0x01 has invalid type string",
+		},
+		{
+			name:     "invalid type",
+			data:     `{"name":"Gossamer","id":"dev","genesis":{"raw":{"top":{"0x01":1}}}}`,
+			expected: "genesis.raw.top.0x01 has invalid type number, expected string",
+		},
+		{
+			name:     "invalid JSON",
+			data:     "{\n\t\"name\": \"Gossamer\",\n\t\"id\": \"dev\"\n\t\"genesis\": {}\n}",
+			expected: "invalid JSON at line 4, column 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := writeTestGenesisFile(t, tc.data)
+			defer os.Remove(fp)
+
+			gen, err := NewGenesisFromJSONRaw(fp)
+			require.Nil(t, gen)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestNewGenesisSpecFromJSON_Invalid(t *testing.T) {
+	fp := writeTestGenesisFile(t, `{"name":"Gossamer","id":"dev","genesis":{"runtime":{"system":null}}}`)
+	defer os.Remove(fp)
+
+	gen, err := NewGenesisSpecFromJSON(fp)
+	require.Nil(t, gen)
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "genesis.runtime.system", verr.Path)
+	require.Equal(t, "must be an object", verr.Reason)
+}
+
+func TestGenesis_Validate(t *testing.T) {
+	gen, err := NewGenesisFromJSONRaw("../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+	require.NoError(t, gen.Validate())
+
+	gen, err = NewGenesisSpecFromJSON("../../chain/gssmr/genesis-spec.json")
+	require.NoError(t, err)
+	require.NoError(t, gen.Validate())
+}
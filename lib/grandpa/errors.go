@@ -30,6 +30,9 @@ var (
 	ErrNilKeypair       = errors.New("cannot have nil keypair")
 	ErrNilNetwork       = errors.New("cannot have nil Network")
 
+	// ErrInvalidNeighbourMessageInterval is returned when the configured neighbour message interval isn't positive
+	ErrInvalidNeighbourMessageInterval = errors.New("neighbour message interval must be positive")
+
 	// ErrBlockDoesNotExist is returned when trying to validate a vote for a block that doesn't exist
 	ErrBlockDoesNotExist = errors.New("block does not exist")
 
@@ -95,9 +98,19 @@ var (
 	// ErrJustificationHashMismatch is returned when a precommit hash within a justification does not match the justification hash
 	ErrJustificationHashMismatch = errors.New("precommit hash does not match justification hash")
 
+	// ErrJustificationBlockMismatch is returned when a justification does not finalise the block it's verified for
+	ErrJustificationBlockMismatch = errors.New("justification does not finalise the given block")
+
 	// ErrJustificationNumberMismatch is returned when a precommit number within a justification does not match the justification number
 	ErrJustificationNumberMismatch = errors.New("precommit number does not match justification number")
 
 	// ErrAuthorityNotInSet is returned when a precommit within a justification is signed by a key not in the authority set
 	ErrAuthorityNotInSet = errors.New("authority is not in set")
+
+	// ErrNoAuthoritySetChange is returned when a block in a finality proof that should signal an authority set change
+	// does not contain a GRANDPA scheduled or forced change digest
+	ErrNoAuthoritySetChange = errors.New("block does not contain an authority set change")
+
+	// ErrInvalidFinalityProof is returned when a finality proof is malformed
+	ErrInvalidFinalityProof = errors.New("invalid finality proof")
 )
@@ -0,0 +1,277 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package grandpa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/scale"
+)
+
+// FinalityProofFragment proves the finality of the last header in Headers. Headers is a chain of headers,
+// where each header is the parent of the next one, and Justification is the justification for the last header.
+// For an authority set change, the first header is the block that signalled the change and the last header is
+// the block at which the change is enacted, finalised by the authority set that was active before the change.
+type FinalityProofFragment struct {
+	SetID         uint64 // ID of the authority set that signed the justification
+	Headers       []*types.Header
+	Justification []byte
+}
+
+// FinalityProof is a compact proof of finality from a begin block up to the latest finalised block. It contains a
+// fragment for each authority set change since the begin block, and a fragment for the latest finalised block.
+// It can be verified with VerifyFinalityProof starting from the authority set active at the begin block.
+type FinalityProof struct {
+	SetID   uint64 // ID of the authority set active at the begin block
+	Changes []*FinalityProofFragment
+	Head    *FinalityProofFragment
+}
+
+// GetFinalityProof returns a finality proof from the given begin block up to the latest finalised block.
+func (s *Service) GetFinalityProof(begin common.Hash) (*FinalityProof, error) {
+	beginHeader, err := s.blockState.GetHeader(begin)
+	if err != nil {
+		return nil, err
+	}
+
+	finalised, err := s.blockState.GetFinalizedHeader(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if beginHeader.Number.Cmp(finalised.Number) > 0 {
+		return nil, fmt.Errorf("begin block %s has not been finalised", begin)
+	}
+
+	setID, err := s.grandpaState.GetSetIDByBlockNumber(beginHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	currSetID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &FinalityProof{
+		SetID: setID,
+	}
+
+	prev := beginHeader.Number
+	for id := setID + 1; id <= currSetID; id++ {
+		enacted, err := s.grandpaState.GetSetIDChange(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if enacted.Cmp(finalised.Number) > 0 {
+			break
+		}
+
+		fragment, err := s.getAuthoritySetChangeFragment(prev, enacted)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create proof for authority set %d: %w", id, err)
+		}
+
+		// the change to set id is finalised by the set before it
+		fragment.SetID = id - 1
+
+		proof.Changes = append(proof.Changes, fragment)
+		prev = enacted
+	}
+
+	just, err := s.blockState.GetJustification(finalised.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get justification for latest finalised block: %w", err)
+	}
+
+	proof.Head = &FinalityProofFragment{
+		SetID:         setID + uint64(len(proof.Changes)),
+		Headers:       []*types.Header{finalised},
+		Justification: just,
+	}
+
+	return proof, nil
+}
+
+// getAuthoritySetChangeFragment returns the proof fragment for the authority set change enacted at the given
+// block number. the block signalling the change is searched for between the enacting block and the lower bound.
+func (s *Service) getAuthoritySetChangeFragment(lower, enacted *big.Int) (*FinalityProofFragment, error) {
+	headers := []*types.Header{}
+
+	for num := new(big.Int).Set(enacted); num.Cmp(lower) > 0; num.Sub(num, big.NewInt(1)) {
+		header, err := s.blockState.GetHeaderByNumber(num)
+		if err != nil {
+			return nil, err
+		}
+
+		headers = append([]*types.Header{header}, headers...)
+
+		_, delay, err := getGrandpaChange(header)
+		if err == ErrNoAuthoritySetChange {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if new(big.Int).Add(header.Number, big.NewInt(int64(delay))).Cmp(enacted) != 0 {
+			continue
+		}
+
+		enactedHeader := headers[len(headers)-1]
+		just, err := s.blockState.GetJustification(enactedHeader.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("cannot get justification for block %s: %w", enactedHeader.Hash(), err)
+		}
+
+		return &FinalityProofFragment{
+			Headers:       headers,
+			Justification: just,
+		}, nil
+	}
+
+	return nil, ErrNoAuthoritySetChange
+}
+
+// VerifyFinalityProof verifies the finality proof against the given authority set, which must be the set active at
+// the begin block of the proof. It returns the latest finalised header, and the ID and authorities of the authority
+// set active at that header.
+func VerifyFinalityProof(proof *FinalityProof, setID uint64,
+	auths []*types.GrandpaVoter) (*types.Header, uint64, []*types.GrandpaVoter, error) {
+	if proof == nil || proof.Head == nil || proof.SetID != setID {
+		return nil, 0, nil, ErrInvalidFinalityProof
+	}
+
+	// the target of the previous fragment, which each fragment must follow on from
+	var prev *types.Header
+
+	for _, fragment := range proof.Changes {
+		err := verifyFinalityProofFragment(fragment, prev, setID, auths)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		next, delay, err := getGrandpaChange(fragment.Headers[0])
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		last := fragment.Headers[len(fragment.Headers)-1]
+		if new(big.Int).Add(fragment.Headers[0].Number, big.NewInt(int64(delay))).Cmp(last.Number) != 0 {
+			return nil, 0, nil, fmt.Errorf("%w: change is not enacted by the justified block", ErrInvalidFinalityProof)
+		}
+
+		setID++
+		auths = next
+		prev = last
+	}
+
+	err := verifyFinalityProofFragment(proof.Head, prev, setID, auths)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return proof.Head.Headers[len(proof.Head.Headers)-1], setID, auths, nil
+}
+
+// verifyFinalityProofFragment checks that the fragment follows on from the target of the previous fragment, if any,
+// that its headers form a chain and that the justification for the last header was signed by the given authority set
+func verifyFinalityProofFragment(fragment *FinalityProofFragment, prev *types.Header, setID uint64,
+	auths []*types.GrandpaVoter) error {
+	if fragment == nil || len(fragment.Headers) == 0 {
+		return ErrInvalidFinalityProof
+	}
+
+	if fragment.SetID != setID {
+		return fmt.Errorf("%w: fragment is for authority set %d, expected set %d", ErrInvalidFinalityProof,
+			fragment.SetID, setID)
+	}
+
+	// the fragment may start at the previous target, eg. if the block enacting a change signals the next one
+	first := fragment.Headers[0]
+	if prev != nil && first.Hash() != prev.Hash() && first.Number.Cmp(prev.Number) <= 0 {
+		return fmt.Errorf("%w: fragment does not follow block %s", ErrInvalidFinalityProof, prev.Hash())
+	}
+
+	for i := 1; i < len(fragment.Headers); i++ {
+		if fragment.Headers[i].ParentHash != fragment.Headers[i-1].Hash() {
+			return fmt.Errorf("%w: headers do not form a chain", ErrInvalidFinalityProof)
+		}
+	}
+
+	fj := new(Justification)
+	err := fj.Decode(bytes.NewBuffer(fragment.Justification))
+	if err != nil {
+		return err
+	}
+
+	last := fragment.Headers[len(fragment.Headers)-1]
+	if fj.Commit.Hash != last.Hash() || big.NewInt(int64(fj.Commit.Number)).Cmp(last.Number) != 0 {
+		return fmt.Errorf("%w: justification is not for block %s", ErrInvalidFinalityProof, last.Hash())
+	}
+
+	return verifyJustificationForSet(fj, setID, auths)
+}
+
+// getGrandpaChange returns the authorities and delay of the GRANDPA scheduled or forced change in the header's
+// digest. it returns ErrNoAuthoritySetChange if the header doesn't contain a change.
+func getGrandpaChange(header *types.Header) ([]*types.GrandpaVoter, uint32, error) {
+	for _, d := range header.Digest {
+		cd, ok := d.(*types.ConsensusDigest)
+		if !ok || cd.ConsensusEngineID != types.GrandpaEngineID || len(cd.Data) == 0 {
+			continue
+		}
+
+		var (
+			raw   []*types.GrandpaAuthoritiesRaw
+			delay uint32
+		)
+
+		switch cd.Data[0] {
+		case types.GrandpaScheduledChangeType:
+			dec, err := scale.Decode(cd.Data[1:], &types.GrandpaScheduledChange{})
+			if err != nil {
+				return nil, 0, err
+			}
+			sc := dec.(*types.GrandpaScheduledChange)
+			raw, delay = sc.Auths, sc.Delay
+		case types.GrandpaForcedChangeType:
+			dec, err := scale.Decode(cd.Data[1:], &types.GrandpaForcedChange{})
+			if err != nil {
+				return nil, 0, err
+			}
+			fc := dec.(*types.GrandpaForcedChange)
+			raw, delay = fc.Auths, fc.Delay
+		default:
+			continue
+		}
+
+		auths, err := types.NewGrandpaVotersFromAuthoritiesRaw(raw)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return auths, delay, nil
+	}
+
+	return nil, 0, ErrNoAuthoritySetChange
+}
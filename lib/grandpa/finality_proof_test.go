@@ -0,0 +1,184 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package grandpa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/scale"
+	"github.com/ChainSafe/gossamer/lib/trie"
+
+	"github.com/stretchr/testify/require"
+)
+
+func addTestBlockWithDigest(t *testing.T, bs *state.BlockState, parent *types.Header,
+	digest types.Digest) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(0).Add(parent.Number, big.NewInt(1)),
+		StateRoot:  trie.EmptyHash,
+		Digest:     digest,
+	}
+
+	err := bs.AddBlock(&types.Block{
+		Header: header,
+		Body:   &types.Body{},
+	})
+	require.NoError(t, err)
+	return header
+}
+
+func setTestJustification(t *testing.T, bs *state.BlockState, header *types.Header, setID uint64,
+	keys []*ed25519.Keypair) {
+	vote := NewVote(header.Hash(), uint32(header.Number.Int64()))
+	precommits := []*SignedPrecommit{}
+
+	for _, k := range keys {
+		msg, err := scale.Encode(&FullVote{
+			Stage: precommit,
+			Vote:  vote,
+			Round: 1,
+			SetID: setID,
+		})
+		require.NoError(t, err)
+
+		sig, err := k.Sign(msg)
+		require.NoError(t, err)
+
+		pc := &SignedPrecommit{
+			Vote:        vote,
+			AuthorityID: k.Public().(*ed25519.PublicKey).AsBytes(),
+		}
+		copy(pc.Signature[:], sig)
+		precommits = append(precommits, pc)
+	}
+
+	just, err := newJustification(1, vote.hash, vote.number, precommits).Encode()
+	require.NoError(t, err)
+
+	err = bs.SetJustification(header.Hash(), just)
+	require.NoError(t, err)
+}
+
+func TestGetFinalityProof_AuthoritySetChange(t *testing.T) {
+	gs, st := newTestService(t)
+
+	nextKeys := kr.Keys[:3]
+	next := []*Voter{}
+	raw := []*types.GrandpaAuthoritiesRaw{}
+	for i, k := range nextKeys {
+		next = append(next, &Voter{Key: k.Public().(*ed25519.PublicKey), ID: uint64(i)})
+		raw = append(raw, &types.GrandpaAuthoritiesRaw{Key: k.Public().(*ed25519.PublicKey).AsBytes(), ID: uint64(i)})
+	}
+
+	data, err := (&types.GrandpaScheduledChange{Auths: raw, Delay: 1}).Encode()
+	require.NoError(t, err)
+
+	change := &types.ConsensusDigest{
+		ConsensusEngineID: types.GrandpaEngineID,
+		Data:              data,
+	}
+
+	// block 2 signals the change, which is enacted at block 3
+	header1 := addTestBlockWithDigest(t, st.Block, testGenesisHeader, types.Digest{})
+	header2 := addTestBlockWithDigest(t, st.Block, header1, types.Digest{change})
+	header3 := addTestBlockWithDigest(t, st.Block, header2, types.Digest{})
+	header4 := addTestBlockWithDigest(t, st.Block, header3, types.Digest{})
+
+	err = st.Grandpa.SetNextChange(next, header3.Number)
+	require.NoError(t, err)
+	err = st.Grandpa.IncrementSetID()
+	require.NoError(t, err)
+
+	setTestJustification(t, st.Block, header3, 0, kr.Keys)
+	setTestJustification(t, st.Block, header4, 1, nextKeys)
+
+	err = st.Block.SetFinalizedHash(header4.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	proof, err := gs.GetFinalityProof(testGenesisHeader.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), proof.SetID)
+	require.Equal(t, 1, len(proof.Changes))
+	require.Equal(t, []*types.Header{header2, header3}, proof.Changes[0].Headers)
+
+	head, setID, auths, err := VerifyFinalityProof(proof, 0, voters)
+	require.NoError(t, err)
+	require.Equal(t, header4.Hash(), head.Hash())
+	require.Equal(t, uint64(1), setID)
+	require.Equal(t, next, auths)
+
+	// the proof must be verified starting from the authority set active at the begin block
+	_, _, _, err = VerifyFinalityProof(proof, 0, next)
+	require.Error(t, err)
+
+	// each fragment must be for the set following the previous fragment's set
+	proof.Head.SetID = 0
+	_, _, _, err = VerifyFinalityProof(proof, 0, voters)
+	require.ErrorIs(t, err, ErrInvalidFinalityProof)
+	proof.Head.SetID = 1
+
+	// and must follow on from the previous fragment's target
+	repeated := *proof.Changes[0]
+	repeated.SetID = 1
+	proof.Changes = append(proof.Changes, &repeated)
+	_, _, _, err = VerifyFinalityProof(proof, 0, voters)
+	require.ErrorIs(t, err, ErrInvalidFinalityProof)
+}
+
+func TestVerifyFinalityProof_RepeatedSigner(t *testing.T) {
+	gs, st := newTestService(t)
+
+	header1 := addTestBlockWithDigest(t, st.Block, testGenesisHeader, types.Digest{})
+	keys := []*ed25519.Keypair{}
+	for range kr.Keys {
+		keys = append(keys, kr.Keys[0])
+	}
+	setTestJustification(t, st.Block, header1, 0, keys)
+	err := st.Block.SetFinalizedHash(header1.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	proof, err := gs.GetFinalityProof(testGenesisHeader.Hash())
+	require.NoError(t, err)
+
+	_, _, _, err = VerifyFinalityProof(proof, 0, voters)
+	require.ErrorIs(t, err, ErrMinVotesNotMet)
+}
+
+func TestVerifyFinalityProof_BrokenChain(t *testing.T) {
+	gs, st := newTestService(t)
+
+	header1 := addTestBlockWithDigest(t, st.Block, testGenesisHeader, types.Digest{})
+	setTestJustification(t, st.Block, header1, 0, kr.Keys)
+	err := st.Block.SetFinalizedHash(header1.Hash(), 0, 0)
+	require.NoError(t, err)
+
+	proof, err := gs.GetFinalityProof(testGenesisHeader.Hash())
+	require.NoError(t, err)
+	require.Equal(t, 0, len(proof.Changes))
+
+	_, _, _, err = VerifyFinalityProof(proof, 0, voters)
+	require.NoError(t, err)
+
+	proof.Head.Headers = []*types.Header{header1, testGenesisHeader}
+	_, _, _, err = VerifyFinalityProof(proof, 0, voters)
+	require.ErrorIs(t, err, ErrInvalidFinalityProof)
+}
@@ -21,17 +21,19 @@ import (
 	"context"
 	"errors"
 	"math/big"
-	"os"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	gssmrmetrics "github.com/ChainSafe/gossamer/dot/metrics"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/utils"
 
 	log "github.com/ChainSafe/log15"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var (
@@ -78,6 +80,8 @@ type Service struct {
 	finalisedCh      chan *types.FinalisationInfo
 	finalisedChID    byte
 	neighbourMessage *NeighbourMessage // cached neighbour message
+
+	neighbourMessageInterval time.Duration // how often the cached neighbour message is re-sent
 }
 
 // Config represents a GRANDPA service configuration
@@ -90,6 +94,9 @@ type Config struct {
 	Voters        []*Voter
 	Keypair       *ed25519.Keypair
 	Authority     bool
+
+	// NeighbourMessageInterval is how often the neighbour message is re-sent; defaults to 5 minutes if unset
+	NeighbourMessageInterval time.Duration
 }
 
 // NewService returns a new GRANDPA Service instance.
@@ -114,7 +121,15 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, ErrNilNetwork
 	}
 
-	h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+	if cfg.NeighbourMessageInterval < 0 {
+		return nil, ErrInvalidNeighbourMessageInterval
+	}
+
+	if cfg.NeighbourMessageInterval == 0 {
+		cfg.NeighbourMessageInterval = defaultNeighbourMessageInterval
+	}
+
+	h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 	h = log.CallerFileHandler(h)
 	logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 
@@ -167,6 +182,8 @@ func NewService(cfg *Config) (*Service, error) {
 		network:            cfg.Network,
 		finalisedCh:        finalisedCh,
 		finalisedChID:      fid,
+
+		neighbourMessageInterval: cfg.NeighbourMessageInterval,
 	}
 
 	s.messageHandler = NewMessageHandler(s, s.blockState)
@@ -197,6 +214,10 @@ func (s *Service) Start() error {
 	}()
 
 	go s.sendNeighbourMessage()
+
+	if metrics.Enabled {
+		go s.collectRoundMetrics()
+	}
 	return nil
 }
 
@@ -258,6 +279,59 @@ func (s *Service) publicKeyBytes() ed25519.PublicKeyBytes {
 	return s.keypair.Public().(*ed25519.PublicKey).AsBytes()
 }
 
+// RoundState returns the state of the current round
+func (s *Service) RoundState() *types.GrandpaRoundState {
+	// hold the round lock so that the round isn't incremented while the state is read
+	s.roundLock.Lock()
+	defer s.roundLock.Unlock()
+
+	rs := &types.GrandpaRoundState{
+		Round: s.state.round,
+		SetID: s.state.setID,
+	}
+
+	s.mapLock.Lock()
+	rs.Prevotes = len(s.prevotes)
+	rs.Precommits = len(s.precommits)
+	if s.authority {
+		rs.Prevote = s.prevotes[s.publicKeyBytes()].toGrandpaVote()
+		rs.Precommit = s.precommits[s.publicKeyBytes()].toGrandpaVote()
+	}
+	s.mapLock.Unlock()
+
+	// the round isn't completable if there aren't enough votes to determine the pre-voted block
+	rs.Completable, _ = s.isCompletable()
+	return rs
+}
+
+// collectRoundMetrics periodically publishes the state of the current round as metrics
+func (s *Service) collectRoundMetrics() {
+	round := metrics.GetOrRegisterGauge("grandpa/round", metrics.DefaultRegistry)
+	setID := metrics.GetOrRegisterGauge("grandpa/setID", metrics.DefaultRegistry)
+	prevotes := metrics.GetOrRegisterGauge("grandpa/votes/prevotes", metrics.DefaultRegistry)
+	precommits := metrics.GetOrRegisterGauge("grandpa/votes/precommits", metrics.DefaultRegistry)
+	completable := metrics.GetOrRegisterGauge("grandpa/round/completable", metrics.DefaultRegistry)
+
+	for {
+		rs := s.RoundState()
+		round.Update(int64(rs.Round))
+		setID.Update(int64(rs.SetID))
+		prevotes.Update(int64(rs.Prevotes))
+		precommits.Update(int64(rs.Precommits))
+		if rs.Completable {
+			completable.Update(1)
+		} else {
+			completable.Update(0)
+		}
+
+		select {
+		case <-time.After(gssmrmetrics.Refresh):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
 // initiate initates a GRANDPA round
 func (s *Service) initiate() error {
 	// if there is an authority change, execute it
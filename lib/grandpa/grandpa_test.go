@@ -1118,3 +1118,51 @@ func TestGrandpa_NonAuthority(t *testing.T) {
 	require.Equal(t, uint64(2), gs.state.round)
 	require.Equal(t, uint64(0), gs.state.setID)
 }
+
+func TestRoundState(t *testing.T) {
+	gs, st := newTestService(t)
+	state.AddBlocksToState(t, st.Block, 3)
+
+	h, err := st.Block.BestBlockHeader()
+	require.NoError(t, err)
+	vote := NewVoteFromHeader(h)
+
+	rs := gs.RoundState()
+	require.Equal(t, gs.state.round, rs.Round)
+	require.Equal(t, gs.state.setID, rs.SetID)
+	require.Nil(t, rs.Prevote)
+	require.Nil(t, rs.Precommit)
+	require.Equal(t, 0, rs.Prevotes)
+	require.Equal(t, 0, rs.Precommits)
+	require.False(t, rs.Completable)
+
+	// receive pre-votes from all the other voters, then pre-vote ourselves
+	for _, k := range kr.Keys[1:] {
+		msg, err := gs.createVoteMessage(vote, prevote, k) //nolint
+		require.NoError(t, err)
+		_, err = gs.validateMessage(msg)
+		require.NoError(t, err)
+	}
+	gs.prevotes[gs.publicKeyBytes()] = vote
+
+	rs = gs.RoundState()
+	require.Equal(t, vote.toGrandpaVote(), rs.Prevote)
+	require.Nil(t, rs.Precommit)
+	require.Equal(t, len(kr.Keys), rs.Prevotes)
+	require.Equal(t, 0, rs.Precommits)
+
+	// receive pre-commits from a super-majority of the voters
+	for _, k := range kr.Keys[1:7] {
+		msg, err := gs.createVoteMessage(vote, precommit, k) //nolint
+		require.NoError(t, err)
+		_, err = gs.validateMessage(msg)
+		require.NoError(t, err)
+	}
+	gs.precommits[gs.publicKeyBytes()] = vote
+
+	rs = gs.RoundState()
+	require.Equal(t, vote.toGrandpaVote(), rs.Precommit)
+	require.Equal(t, len(kr.Keys), rs.Prevotes)
+	require.Equal(t, 7, rs.Precommits)
+	require.True(t, rs.Completable)
+}
@@ -427,8 +427,8 @@ func (h *MessageHandler) verifyJustification(just *SignedPrecommit, round, setID
 	return nil
 }
 
-// VerifyBlockJustification verifies the finality justification for a block
-func (s *Service) VerifyBlockJustification(justification []byte) error {
+// VerifyBlockJustification verifies the finality justification for the block with the given hash and number
+func (s *Service) VerifyBlockJustification(hash common.Hash, number *big.Int, justification []byte) error {
 	r := &bytes.Buffer{}
 	_, _ = r.Write(justification)
 	fj := new(Justification)
@@ -437,6 +437,10 @@ func (s *Service) VerifyBlockJustification(justification []byte) error {
 		return err
 	}
 
+	if fj.Commit.Hash != hash || big.NewInt(int64(fj.Commit.Number)).Cmp(number) != 0 {
+		return ErrJustificationBlockMismatch
+	}
+
 	setID, err := s.grandpaState.GetSetIDByBlockNumber(big.NewInt(int64(fj.Commit.Number)))
 	if err != nil {
 		return fmt.Errorf("cannot get set ID from block number: %w", err)
@@ -447,6 +451,11 @@ func (s *Service) VerifyBlockJustification(justification []byte) error {
 		return fmt.Errorf("cannot get authorities for set ID: %w", err)
 	}
 
+	return verifyJustificationForSet(fj, setID, auths)
+}
+
+// verifyJustificationForSet verifies that the justification was signed by the given authority set
+func verifyJustificationForSet(fj *Justification, setID uint64, auths []*types.GrandpaVoter) error {
 	logger.Debug("verifying justification",
 		"setID", setID,
 		"round", fj.Round,
@@ -455,9 +464,8 @@ func (s *Service) VerifyBlockJustification(justification []byte) error {
 		"sig count", len(fj.Commit.Precommits),
 	)
 
-	if len(fj.Commit.Precommits) < (2 * len(auths) / 3) {
-		return ErrMinVotesNotMet
-	}
+	// an authority may repeat its precommit, so only distinct signers count towards the threshold
+	signers := make(map[ed25519.PublicKeyBytes]struct{})
 
 	for _, just := range fj.Commit.Precommits {
 		if just.Vote.hash != fj.Commit.Hash {
@@ -497,6 +505,13 @@ func (s *Service) VerifyBlockJustification(justification []byte) error {
 		if !ok {
 			return ErrInvalidSignature
 		}
+
+		signers[just.AuthorityID] = struct{}{}
+	}
+
+	// more than 2/3 of the authority set must have signed
+	if 3*len(signers) <= 2*len(auths) {
+		return ErrMinVotesNotMet
 	}
 
 	return nil
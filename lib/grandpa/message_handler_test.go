@@ -507,18 +507,24 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 
 	round := uint64(2)
 	number := uint32(2)
-	precommits := buildTestJustification(t, 2, round, setID, kr, precommit)
+	precommits := buildTestJustification(t, 3, round, setID, kr, precommit)
 	just := newJustification(round, testHash, number, precommits)
 	data, err := just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
 	require.NoError(t, err)
 
+	// a valid justification for another block shouldn't verify
+	err = gs.VerifyBlockJustification(common.Hash{1}, big.NewInt(int64(number)), data)
+	require.Equal(t, ErrJustificationBlockMismatch, err)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number+1)), data)
+	require.Equal(t, ErrJustificationBlockMismatch, err)
+
 	// use wrong hash, shouldn't verify
 	just = newJustification(round, common.Hash{}, number, precommits)
 	data, err = just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(common.Hash{}, big.NewInt(int64(number)), data)
 	require.NotNil(t, err)
 	require.Equal(t, ErrJustificationHashMismatch, err)
 
@@ -526,7 +532,7 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 	just = newJustification(round, testHash, number+1, precommits)
 	data, err = just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number+1)), data)
 	require.NotNil(t, err)
 	require.Equal(t, ErrJustificationNumberMismatch, err)
 
@@ -534,7 +540,7 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 	just = newJustification(round+1, testHash, number, precommits)
 	data, err = just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
 	require.NotNil(t, err)
 	require.Equal(t, ErrInvalidSignature, err)
 
@@ -543,7 +549,7 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 	just = newJustification(round, testHash, number, precommits)
 	data, err = just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
 	require.Equal(t, ErrAuthorityNotInSet, err)
 
 	// not enough signatures, shouldn't verify
@@ -551,6 +557,23 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 	just = newJustification(round, testHash, number, precommits)
 	data, err = just.Encode()
 	require.NoError(t, err)
-	err = gs.VerifyBlockJustification(data)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
+	require.Equal(t, ErrMinVotesNotMet, err)
+
+	// exactly 2/3 of the authorities isn't enough, more than 2/3 must sign
+	precommits = buildTestJustification(t, 2, round, setID, kr, precommit)
+	just = newJustification(round, testHash, number, precommits)
+	data, err = just.Encode()
+	require.NoError(t, err)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
+	require.Equal(t, ErrMinVotesNotMet, err)
+
+	// a signer repeating its precommit is only counted once
+	precommits = buildTestJustification(t, 1, round, setID, kr, precommit)
+	precommits = append(precommits, precommits[0], precommits[0])
+	just = newJustification(round, testHash, number, precommits)
+	data, err = just.Encode()
+	require.NoError(t, err)
+	err = gs.VerifyBlockJustification(testHash, big.NewInt(int64(number)), data)
 	require.Equal(t, ErrMinVotesNotMet, err)
 }
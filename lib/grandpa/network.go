@@ -29,11 +29,13 @@ import (
 )
 
 var (
-	grandpaID                protocol.ID = "/paritytech/grandpa/1"
-	messageID                            = network.ConsensusMsgType
-	neighbourMessageInterval             = time.Minute * 5
+	grandpaID protocol.ID = "/paritytech/grandpa/1"
+	messageID             = network.ConsensusMsgType
 )
 
+// defaultNeighbourMessageInterval is how often the neighbour message is re-sent if no block is finalised in between
+const defaultNeighbourMessageInterval = time.Minute * 5
+
 // Handshake is an alias for network.Handshake
 type Handshake = network.Handshake
 
@@ -168,7 +170,7 @@ func (s *Service) sendNeighbourMessage() {
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-time.After(neighbourMessageInterval):
+		case <-time.After(s.neighbourMessageInterval):
 			if s.neighbourMessage == nil {
 				continue
 			}
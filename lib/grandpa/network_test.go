@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/require"
@@ -86,10 +87,7 @@ func TestHandleNetworkMessage(t *testing.T) {
 
 func TestSendNeighbourMessage(t *testing.T) {
 	gs, st := newTestService(t)
-	neighbourMessageInterval = time.Second
-	defer func() {
-		neighbourMessageInterval = time.Minute * 5
-	}()
+	gs.neighbourMessageInterval = time.Second
 	go gs.sendNeighbourMessage()
 
 	block := &types.Block{
@@ -136,3 +134,81 @@ func TestSendNeighbourMessage(t *testing.T) {
 		require.Equal(t, expected, nm)
 	}
 }
+
+func TestSendNeighbourMessage_Interval(t *testing.T) {
+	st := newTestState(t)
+	net := newTestNetwork(t)
+	interval := time.Millisecond * 200
+
+	cfg := &Config{
+		BlockState:               st.Block,
+		GrandpaState:             st.Grandpa,
+		DigestHandler:            &mockDigestHandler{},
+		Voters:                   voters,
+		Keypair:                  kr.Alice().(*ed25519.Keypair),
+		Authority:                true,
+		Network:                  net,
+		NeighbourMessageInterval: interval,
+	}
+
+	gs, err := NewService(cfg)
+	require.NoError(t, err)
+	require.Equal(t, interval, gs.neighbourMessageInterval)
+	go gs.sendNeighbourMessage()
+	defer gs.cancel()
+
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: testGenesisHeader.Hash(),
+			Number:     big.NewInt(1),
+		},
+		Body: &types.Body{},
+	}
+
+	err = st.Block.AddBlock(block)
+	require.NoError(t, err)
+	err = st.Block.SetFinalizedHash(block.Header.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	// the first message is sent on finalisation, the following ones once per interval
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("did not send message")
+	case <-net.out:
+	}
+
+	last := time.Now()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-time.After(interval * 2):
+			t.Fatal("did not send message")
+		case msg := <-net.out:
+			require.IsType(t, &NeighbourMessage{}, msg)
+		}
+
+		require.GreaterOrEqual(t, int64(time.Since(last)), int64(interval*3/4))
+		last = time.Now()
+	}
+}
+
+func TestNewService_InvalidNeighbourMessageInterval(t *testing.T) {
+	st := newTestState(t)
+
+	cfg := &Config{
+		BlockState:               st.Block,
+		GrandpaState:             st.Grandpa,
+		DigestHandler:            &mockDigestHandler{},
+		Voters:                   voters,
+		Network:                  newTestNetwork(t),
+		NeighbourMessageInterval: -time.Second,
+	}
+
+	_, err := NewService(cfg)
+	require.Equal(t, ErrInvalidNeighbourMessageInterval, err)
+
+	// the interval defaults to 5 minutes if it isn't set
+	cfg.NeighbourMessageInterval = 0
+	gs, err := NewService(cfg)
+	require.NoError(t, err)
+	require.Equal(t, defaultNeighbourMessageInterval, gs.neighbourMessageInterval)
+}
@@ -58,6 +58,7 @@ type GrandpaState interface { //nolint
 	GetCurrentSetID() (uint64, error)
 	GetAuthorities(setID uint64) ([]*types.GrandpaVoter, error)
 	GetSetIDByBlockNumber(num *big.Int) (uint64, error)
+	GetSetIDChange(setID uint64) (*big.Int, error)
 }
 
 // DigestHandler is the interface required by GRANDPA for the digest handler
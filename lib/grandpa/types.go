@@ -183,6 +183,18 @@ func (v *Vote) Decode(r io.Reader) (*Vote, error) {
 	return v, nil
 }
 
+// toGrandpaVote returns the vote as a types.GrandpaVote, or nil if the vote is nil
+func (v *Vote) toGrandpaVote() *types.GrandpaVote {
+	if v == nil {
+		return nil
+	}
+
+	return &types.GrandpaVote{
+		Hash:   v.hash,
+		Number: v.number,
+	}
+}
+
 // String returns the Vote as a string
 func (v *Vote) String() string {
 	return fmt.Sprintf("hash=%s number=%d", v.hash, v.number)
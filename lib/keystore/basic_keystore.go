@@ -57,21 +57,36 @@ func (ks *BasicKeystore) Size() int {
 }
 
 // Insert adds a keypair to the keystore
-func (ks *BasicKeystore) Insert(kp crypto.Keypair) {
+func (ks *BasicKeystore) Insert(kp crypto.Keypair) error {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
 	if kp.Type() != ks.typ {
-		return
+		return nil
 	}
 
 	pub := kp.Public()
 	addr := crypto.PublicKeyToAddress(pub)
 	ks.keys[addr] = kp
+	return nil
+}
+
+// Clear removes all keys from the keystore, zeroing the private keys where their type allows it
+func (ks *BasicKeystore) Clear() {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	for addr, kp := range ks.keys {
+		zeroKeypair(kp)
+		delete(ks.keys, addr)
+	}
 }
 
 // GetKeypair returns a keypair corresponding to the given public key, or nil if it doesn't exist
 func (ks *BasicKeystore) GetKeypair(pub crypto.PublicKey) crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	for _, key := range ks.keys {
 		if bytes.Equal(key.Public().Encode(), pub.Encode()) {
 			return key
@@ -89,6 +104,9 @@ func (ks *BasicKeystore) GetKeypairFromAddress(pub common.Address) crypto.Keypai
 
 // PublicKeys returns all public keys in the keystore
 func (ks *BasicKeystore) PublicKeys() []crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.PublicKey{}
 	if ks.keys == nil {
 		return srkeys
@@ -101,8 +119,21 @@ func (ks *BasicKeystore) PublicKeys() []crypto.PublicKey {
 	return srkeys
 }
 
+// ListByType returns all public keys in the keystore of the given type. Since a BasicKeystore only holds keys of
+// its own type, it returns either all or none of its keys.
+func (ks *BasicKeystore) ListByType(keytype crypto.KeyType) []crypto.PublicKey {
+	if keytype != ks.typ {
+		return []crypto.PublicKey{}
+	}
+
+	return ks.PublicKeys()
+}
+
 // Keypairs returns all keypairs in the keystore
 func (ks *BasicKeystore) Keypairs() []crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.Keypair{}
 	if ks.keys == nil {
 		return srkeys
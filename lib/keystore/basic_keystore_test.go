@@ -17,9 +17,11 @@
 package keystore
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -72,3 +74,85 @@ func TestBasicKeystore_PublicKeys(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", pubkeys, expectedPubkeys)
 	}
 }
+
+func TestBasicKeystore_ListByType(t *testing.T) {
+	ks := NewBasicKeystore("test", crypto.Sr25519Type)
+
+	kp, err := sr25519.GenerateKeypair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks.Insert(kp)
+
+	pubs := ks.ListByType(crypto.Sr25519Type)
+	if len(pubs) != 1 || pubs[0].Hex() != kp.Public().Hex() {
+		t.Fatalf("Fail: got %v expected %v", pubs, kp.Public())
+	}
+
+	if pubs = ks.ListByType(crypto.Ed25519Type); len(pubs) != 0 {
+		t.Fatalf("Fail: expected no ed25519 keys, got %v", pubs)
+	}
+}
+
+func TestBasicKeystore_Concurrent(t *testing.T) {
+	ks := NewBasicKeystore("test", crypto.Sr25519Type)
+	testKeystoreConcurrentAccess(t, ks, func() (crypto.Keypair, error) {
+		return sr25519.GenerateKeypair()
+	})
+}
+
+// testKeystoreConcurrentAccess inserts and reads keys from many goroutines at once, it is intended to be run
+// with the race detector enabled
+func testKeystoreConcurrentAccess(t *testing.T, ks Keystore, generate func() (crypto.Keypair, error)) {
+	const (
+		numRoutines = 16
+		numKeys     = 8
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numRoutines)
+
+	for i := 0; i < numRoutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < numKeys; j++ {
+				kp, err := generate()
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				ks.Insert(kp)
+
+				if ks.GetKeypair(kp.Public()) == nil {
+					errs <- fmt.Errorf("inserted key %s not found", kp.Public().Hex())
+					return
+				}
+
+				if ks.GetKeypairFromAddress(kp.Public().Address()) == nil {
+					errs <- fmt.Errorf("inserted key %s not found by address", kp.Public().Address())
+					return
+				}
+
+				_ = ks.Size()
+				_ = ks.PublicKeys()
+				_ = ks.Keypairs()
+				_ = ks.ListByType(kp.Type())
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if ks.Size() != numRoutines*numKeys {
+		t.Fatalf("Fail: got %d keys expected %d", ks.Size(), numRoutines*numKeys)
+	}
+}
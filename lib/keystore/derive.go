@@ -0,0 +1,68 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+)
+
+// DevPhrase is the mnemonic that the substrate development accounts (//Alice, //Bob, ...) are derived from
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+// DeriveKeypair derives a keypair of the given type from a secret URI of the form `[mnemonic]//hard/soft`.
+// If the mnemonic is omitted, the path is derived from DevPhrase, so `//Alice` produces the development account key.
+// sr25519 supports hard and soft junctions, ed25519 supports hard junctions only.
+func DeriveKeypair(keytype, suri string) (crypto.Keypair, error) {
+	phrase, path := suri, ""
+	if i := strings.Index(suri, "/"); i >= 0 {
+		phrase, path = suri[:i], suri[i:]
+	}
+
+	phrase = strings.TrimSpace(phrase)
+	if phrase == "" {
+		phrase = DevPhrase
+	}
+
+	junctions, err := crypto.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch keytype {
+	case "", crypto.Sr25519Type:
+		kp, err := sr25519.NewKeypairFromMnenomic(phrase, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return kp.Derive(junctions)
+	case crypto.Ed25519Type:
+		kp, err := ed25519.NewKeypairFromMnenomic(phrase, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return kp.Derive(junctions)
+	default:
+		return nil, fmt.Errorf("key derivation is not supported for key type %s", keytype)
+	}
+}
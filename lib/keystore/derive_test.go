@@ -0,0 +1,75 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveKeypair_Sr25519(t *testing.T) {
+	kp, err := DeriveKeypair(crypto.Sr25519Type, "//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", kp.Public().Hex())
+
+	kp, err = DeriveKeypair(crypto.Sr25519Type, DevPhrase+"//Bob")
+	require.NoError(t, err)
+	require.Equal(t, "0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48", kp.Public().Hex())
+}
+
+func TestDeriveKeypair_Sr25519Soft(t *testing.T) {
+	alice, err := DeriveKeypair(crypto.Sr25519Type, "//Alice")
+	require.NoError(t, err)
+
+	kp, err := DeriveKeypair(crypto.Sr25519Type, "//Alice/soft/1")
+	require.NoError(t, err)
+
+	// soft junctions can also be derived from the public key alone
+	path, err := crypto.ParseDerivationPath("/soft/1")
+	require.NoError(t, err)
+	pub, err := alice.Public().(*sr25519.PublicKey).Derive(path)
+	require.NoError(t, err)
+	require.Equal(t, kp.Public().Hex(), pub.Hex())
+
+	msg := []byte("helloworld")
+	sig, err := kp.Sign(msg)
+	require.NoError(t, err)
+	ok, err := pub.Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestDeriveKeypair_Ed25519(t *testing.T) {
+	kp, err := DeriveKeypair(crypto.Ed25519Type, "//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", kp.Public().Hex())
+
+	_, err = DeriveKeypair(crypto.Ed25519Type, "//Alice/soft")
+	require.Error(t, err)
+}
+
+func TestDeriveKeypair_Invalid(t *testing.T) {
+	_, err := DeriveKeypair(crypto.Sr25519Type, "//Alice//")
+	require.Error(t, err)
+
+	_, err = DeriveKeypair(crypto.Secp256k1Type, "//Alice")
+	require.Error(t, err)
+}
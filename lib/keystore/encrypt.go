@@ -34,20 +34,118 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
 	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
 )
 
-// EncryptedKeystore holds Type PublicKey and Ciphertext
+const (
+	// DefaultScryptN is the default scrypt CPU/memory cost parameter
+	DefaultScryptN = 1 << 15
+	// DefaultScryptR is the default scrypt block size parameter
+	DefaultScryptR = 8
+	// DefaultScryptP is the default scrypt parallelization parameter
+	DefaultScryptP = 1
+	// MinScryptN is the smallest scrypt cost parameter accepted when encrypting a keystore file
+	MinScryptN = 1 << 14
+	// MaxScryptN is the largest scrypt cost parameter accepted when encrypting or decrypting a keystore file
+	MaxScryptN = 1 << 20
+	// MaxScryptR is the largest scrypt block size parameter accepted when encrypting or decrypting a keystore file
+	MaxScryptR = 16
+	// MaxScryptP is the largest scrypt parallelization parameter accepted when encrypting or decrypting a keystore file
+	MaxScryptP = 16
+	// maxScryptMemory is the most memory in bytes that deriving a key may use, which is 128 * N * r bytes
+	maxScryptMemory = 1 << 30
+
+	scryptKeyLen  = 32
+	scryptSaltLen = 32
+)
+
+// ScryptParams holds the scrypt parameters used to derive the encryption key of a keystore file
+type ScryptParams struct {
+	N    int
+	R    int
+	P    int
+	Salt []byte `json:",omitempty"`
+}
+
+// DefaultScryptParams returns the default scrypt parameters
+func DefaultScryptParams() *ScryptParams {
+	return &ScryptParams{
+		N: DefaultScryptN,
+		R: DefaultScryptR,
+		P: DefaultScryptP,
+	}
+}
+
+// Validate checks that the parameters are safe to use for encrypting a keystore file
+func (p *ScryptParams) Validate() error {
+	if p.N < MinScryptN {
+		return fmt.Errorf("scrypt N must be at least %d", MinScryptN)
+	}
+
+	if p.N&(p.N-1) != 0 {
+		return errors.New("scrypt N must be a power of two")
+	}
+
+	if p.R < 1 || p.P < 1 {
+		return errors.New("scrypt r and p must be greater than zero")
+	}
+
+	return p.checkLimits()
+}
+
+// checkLimits checks that deriving a key with the parameters doesn't use an excessive amount of memory or time.
+// It is checked before decrypting a keystore file, as its parameters may have been chosen by anyone.
+func (p *ScryptParams) checkLimits() error {
+	if p.N > MaxScryptN {
+		return fmt.Errorf("scrypt N must be at most %d", MaxScryptN)
+	}
+
+	if p.R > MaxScryptR {
+		return fmt.Errorf("scrypt r must be at most %d", MaxScryptR)
+	}
+
+	if p.P > MaxScryptP {
+		return fmt.Errorf("scrypt p must be at most %d", MaxScryptP)
+	}
+
+	if 128*uint64(p.N)*uint64(p.R) > maxScryptMemory {
+		return fmt.Errorf("scrypt N and r must use at most %d bytes of memory", maxScryptMemory)
+	}
+
+	return nil
+}
+
+// EncryptedKeystore holds Type PublicKey and Ciphertext. If Scrypt is set, the encryption key was derived from the
+// password using the stored scrypt parameters, otherwise it is the blake2b hash of the password.
 type EncryptedKeystore struct {
 	Type       string
 	PublicKey  string
 	Ciphertext []byte
+	Scrypt     *ScryptParams `json:",omitempty"`
 }
 
 // gcmFromPassphrase creates a symmetric AES key given a password
 func gcmFromPassphrase(password []byte) (cipher.AEAD, error) {
 	hash := blake2b.Sum256(password)
+	return gcmFromKey(hash[:])
+}
+
+// gcmFromScrypt creates a symmetric AES key given a password and scrypt parameters
+func gcmFromScrypt(password []byte, params *ScryptParams) (cipher.AEAD, error) {
+	if err := params.checkLimits(); err != nil {
+		return nil, err
+	}
 
-	block, err := aes.NewCipher(hash[:])
+	key, err := scrypt.Key(password, params.Salt, params.N, params.R, params.P, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return gcmFromKey(key)
+}
+
+func gcmFromKey(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +165,22 @@ func Encrypt(msg, password []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	return seal(gcm, msg)
+}
+
+// EncryptWithScrypt uses AES to encrypt `msg` with the symmetric key derived from `password` using scrypt
+func EncryptWithScrypt(msg, password []byte, params *ScryptParams) ([]byte, error) {
+	gcm, err := gcmFromScrypt(password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(gcm, msg)
+}
+
+func seal(gcm cipher.AEAD, msg []byte) ([]byte, error) {
 	nonce := make([]byte, gcm.NonceSize())
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return nil, err
 	}
 
@@ -83,7 +195,25 @@ func Decrypt(data, password []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	return open(gcm, data)
+}
+
+// DecryptWithScrypt uses AES to decrypt ciphertext with the symmetric key derived from `password` using scrypt
+func DecryptWithScrypt(data, password []byte, params *ScryptParams) ([]byte, error) {
+	gcm, err := gcmFromScrypt(password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(gcm, data)
+}
+
+func open(gcm cipher.AEAD, data []byte) ([]byte, error) {
 	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
@@ -112,7 +242,38 @@ func DecryptPrivateKey(data, password []byte, keytype string) (crypto.PrivateKey
 
 // EncryptAndWriteToFile encrypts the `crypto.PrivateKey` using the password and saves it to the specified file
 func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte) error {
-	ciphertext, err := EncryptPrivateKey(pk, password)
+	return EncryptAndWriteToFileWithParams(file, pk, password, nil)
+}
+
+// EncryptAndWriteToFileWithParams encrypts the `crypto.PrivateKey` using a key derived from the password with the
+// given scrypt parameters and saves it, along with the parameters, to the specified file. If params is nil, the
+// key is encrypted using the blake2b hash of the password.
+func EncryptAndWriteToFileWithParams(file *os.File, pk crypto.PrivateKey, password []byte, params *ScryptParams) error {
+	var (
+		ciphertext []byte
+		err        error
+	)
+
+	if params == nil {
+		ciphertext, err = EncryptPrivateKey(pk, password)
+	} else {
+		if err = params.Validate(); err != nil {
+			return err
+		}
+
+		params = &ScryptParams{
+			N:    params.N,
+			R:    params.R,
+			P:    params.P,
+			Salt: make([]byte, scryptSaltLen),
+		}
+
+		if _, err = io.ReadFull(rand.Reader, params.Salt); err != nil {
+			return err
+		}
+
+		ciphertext, err = EncryptWithScrypt(pk.Encode(), password, params)
+	}
 	if err != nil {
 		return err
 	}
@@ -143,6 +304,7 @@ func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte)
 		Type:       keytype,
 		PublicKey:  pub.Hex(),
 		Ciphertext: ciphertext,
+		Scrypt:     params,
 	}
 
 	data, err := json.MarshalIndent(keydata, "", "\t")
@@ -172,5 +334,14 @@ func ReadFromFileAndDecrypt(filename string, password []byte) (crypto.PrivateKey
 		return nil, err
 	}
 
-	return DecryptPrivateKey(keydata.Ciphertext, password, keydata.Type)
+	if keydata.Scrypt == nil {
+		return DecryptPrivateKey(keydata.Ciphertext, password, keydata.Type)
+	}
+
+	pk, err := DecryptWithScrypt(keydata.Ciphertext, password, keydata.Scrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePrivateKey(pk, keydata.Type)
 }
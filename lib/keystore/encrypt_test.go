@@ -19,6 +19,9 @@ package keystore
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -27,6 +30,8 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncryptAndDecrypt(t *testing.T) {
@@ -171,3 +176,115 @@ func TestEncryptAndDecryptFromFile_Secp256k1(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", res, priv)
 	}
 }
+
+func TestEncryptAndDecryptFromFile_ScryptParams(t *testing.T) {
+	password := []byte("noot")
+	file, fp := createTestFile(t)
+	defer os.Remove(fp)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	priv := kp.Private()
+
+	params := &ScryptParams{
+		N: MinScryptN,
+		R: 8,
+		P: 2,
+	}
+
+	err = EncryptAndWriteToFileWithParams(file, priv, password, params)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	require.NoError(t, err)
+
+	keydata := new(EncryptedKeystore)
+	err = json.Unmarshal(data, keydata)
+	require.NoError(t, err)
+	require.NotNil(t, keydata.Scrypt)
+	require.Equal(t, params.N, keydata.Scrypt.N)
+	require.Equal(t, params.R, keydata.Scrypt.R)
+	require.Equal(t, params.P, keydata.Scrypt.P)
+	require.Len(t, keydata.Scrypt.Salt, scryptSaltLen)
+
+	res, err := ReadFromFileAndDecrypt(fp, password)
+	require.NoError(t, err)
+	require.Equal(t, priv.Encode(), res.Encode())
+
+	_, err = ReadFromFileAndDecrypt(fp, []byte("wrong"))
+	require.Error(t, err)
+}
+
+func TestEncryptAndWriteToFileWithParams_Invalid(t *testing.T) {
+	file, fp := createTestFile(t)
+	defer os.Remove(fp)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: 1 << 10, R: 8, P: 1})
+	require.Error(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: 3 << 14, R: 8, P: 1})
+	require.Error(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: MaxScryptN << 1, R: 8, P: 1})
+	require.Error(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: MinScryptN, R: MaxScryptR + 1, P: 1})
+	require.Error(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: MinScryptN, R: 8, P: MaxScryptP + 1})
+	require.Error(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), []byte("noot"), &ScryptParams{N: MaxScryptN, R: MaxScryptR, P: 1})
+	require.Error(t, err)
+}
+
+func TestReadFromFileAndDecrypt_ScryptParamsTooLarge(t *testing.T) {
+	password := []byte("noot")
+	file, fp := createTestFile(t)
+	defer os.Remove(fp)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), password, &ScryptParams{N: MinScryptN, R: 8, P: 1})
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	require.NoError(t, err)
+
+	keydata := new(EncryptedKeystore)
+	err = json.Unmarshal(data, keydata)
+	require.NoError(t, err)
+
+	keydata.Scrypt.N = 1 << 30
+	data, err = json.Marshal(keydata)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(fp, data, 0600)
+	require.NoError(t, err)
+
+	_, err = ReadFromFileAndDecrypt(fp, password)
+	require.EqualError(t, err, fmt.Sprintf("scrypt N must be at most %d", MaxScryptN))
+}
+
+func TestReadFromFileAndDecrypt_Legacy(t *testing.T) {
+	password := []byte("noot")
+	file, fp := createTestFile(t)
+	defer os.Remove(fp)
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	err = EncryptAndWriteToFile(file, kp.Private(), password)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Clean(fp))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "Scrypt")
+
+	res, err := ReadFromFileAndDecrypt(fp, password)
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), res.Encode())
+}
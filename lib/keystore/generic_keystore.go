@@ -58,17 +58,32 @@ func (ks *GenericKeystore) Size() int {
 }
 
 // Insert adds a keypair to the keystore
-func (ks *GenericKeystore) Insert(kp crypto.Keypair) {
+func (ks *GenericKeystore) Insert(kp crypto.Keypair) error {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
 	pub := kp.Public()
 	addr := crypto.PublicKeyToAddress(pub)
 	ks.keys[addr] = kp
+	return nil
+}
+
+// Clear removes all keys from the keystore, zeroing the private keys where their type allows it
+func (ks *GenericKeystore) Clear() {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	for addr, kp := range ks.keys {
+		zeroKeypair(kp)
+		delete(ks.keys, addr)
+	}
 }
 
 // GetKeypair returns a keypair corresponding to the given public key, or nil if it doesn't exist
 func (ks *GenericKeystore) GetKeypair(pub crypto.PublicKey) crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	for _, key := range ks.keys {
 		if bytes.Equal(key.Public().Encode(), pub.Encode()) {
 			return key
@@ -86,6 +101,9 @@ func (ks *GenericKeystore) GetKeypairFromAddress(pub common.Address) crypto.Keyp
 
 // PublicKeys returns all public keys in the keystore
 func (ks *GenericKeystore) PublicKeys() []crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.PublicKey{}
 	if ks.keys == nil {
 		return srkeys
@@ -98,8 +116,25 @@ func (ks *GenericKeystore) PublicKeys() []crypto.PublicKey {
 	return srkeys
 }
 
+// ListByType returns all public keys in the keystore of the given type
+func (ks *GenericKeystore) ListByType(keytype crypto.KeyType) []crypto.PublicKey {
+	switch keytype {
+	case crypto.Sr25519Type:
+		return ks.Sr25519PublicKeys()
+	case crypto.Ed25519Type:
+		return ks.Ed25519PublicKeys()
+	case crypto.Secp256k1Type:
+		return ks.Secp256k1PublicKeys()
+	default:
+		return []crypto.PublicKey{}
+	}
+}
+
 // Keypairs returns all keypairs in the keystore
 func (ks *GenericKeystore) Keypairs() []crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.Keypair{}
 	if ks.keys == nil {
 		return srkeys
@@ -123,6 +158,9 @@ func (ks *GenericKeystore) NumEd25519Keys() int {
 
 // Ed25519PublicKeys keys
 func (ks *GenericKeystore) Ed25519PublicKeys() []crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	edkeys := []crypto.PublicKey{}
 	if ks.keys == nil {
 		return edkeys
@@ -138,6 +176,9 @@ func (ks *GenericKeystore) Ed25519PublicKeys() []crypto.PublicKey {
 
 // Ed25519Keypairs Keypair
 func (ks *GenericKeystore) Ed25519Keypairs() []crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	edkeys := []crypto.Keypair{}
 	if ks.keys == nil {
 		return edkeys
@@ -153,6 +194,9 @@ func (ks *GenericKeystore) Ed25519Keypairs() []crypto.Keypair {
 
 // Sr25519PublicKeys PublicKey
 func (ks *GenericKeystore) Sr25519PublicKeys() []crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.PublicKey{}
 	if ks.keys == nil {
 		return srkeys
@@ -168,6 +212,9 @@ func (ks *GenericKeystore) Sr25519PublicKeys() []crypto.PublicKey {
 
 // Sr25519Keypairs Keypair
 func (ks *GenericKeystore) Sr25519Keypairs() []crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	srkeys := []crypto.Keypair{}
 	if ks.keys == nil {
 		return srkeys
@@ -183,6 +230,9 @@ func (ks *GenericKeystore) Sr25519Keypairs() []crypto.Keypair {
 
 // Secp256k1PublicKeys PublicKey
 func (ks *GenericKeystore) Secp256k1PublicKeys() []crypto.PublicKey {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	sckeys := []crypto.PublicKey{}
 	if ks.keys == nil {
 		return sckeys
@@ -198,6 +248,9 @@ func (ks *GenericKeystore) Secp256k1PublicKeys() []crypto.PublicKey {
 
 // Secp256k1Keypairs Keypair
 func (ks *GenericKeystore) Secp256k1Keypairs() []crypto.Keypair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
 	sckeys := []crypto.Keypair{}
 	if ks.keys == nil {
 		return sckeys
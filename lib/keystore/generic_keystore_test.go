@@ -20,12 +20,15 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetSr25519PublicKeys(t *testing.T) {
@@ -135,3 +138,49 @@ func TestGetSecp256k1PublicKeys(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", pubkeys, expectedPubkeys)
 	}
 }
+
+func TestGenericKeystore_ListByType(t *testing.T) {
+	ks := NewGenericKeystore("test")
+
+	expected := make(map[crypto.KeyType][]string)
+	for i := 0; i < 3; i++ {
+		srkp, err := sr25519.GenerateKeypair()
+		require.NoError(t, err)
+		ks.Insert(srkp)
+		expected[crypto.Sr25519Type] = append(expected[crypto.Sr25519Type], srkp.Public().Hex())
+
+		edkp, err := ed25519.GenerateKeypair()
+		require.NoError(t, err)
+		ks.Insert(edkp)
+		expected[crypto.Ed25519Type] = append(expected[crypto.Ed25519Type], edkp.Public().Hex())
+	}
+
+	sckp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	ks.Insert(sckp)
+	expected[crypto.Secp256k1Type] = []string{sckp.Public().Hex()}
+
+	for keytype, exp := range expected {
+		res := []string{}
+		for _, pub := range ks.ListByType(keytype) {
+			res = append(res, pub.Hex())
+		}
+		require.ElementsMatch(t, exp, res, keytype)
+	}
+
+	require.Empty(t, ks.ListByType(crypto.UnknownType))
+}
+
+func TestGenericKeystore_Concurrent(t *testing.T) {
+	ks := NewGenericKeystore("test")
+
+	var count uint32
+	testKeystoreConcurrentAccess(t, ks, func() (crypto.Keypair, error) {
+		if atomic.AddUint32(&count, 1)%2 == 0 {
+			return ed25519.GenerateKeypair()
+		}
+		return sr25519.GenerateKeypair()
+	})
+
+	require.Equal(t, ks.Size(), ks.NumSr25519Keys()+ks.NumEd25519Keys())
+}
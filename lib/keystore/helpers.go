@@ -64,15 +64,48 @@ func DecodePrivateKey(in []byte, keytype crypto.KeyType) (priv crypto.PrivateKey
 	return priv, err
 }
 
+// DecodePublicKey turns input bytes into a public key based on the specified key type
+func DecodePublicKey(in []byte, keytype crypto.KeyType) (crypto.PublicKey, error) {
+	var pub crypto.PublicKey
+	if keytype == crypto.Ed25519Type {
+		pub = &ed25519.PublicKey{}
+	} else if keytype == crypto.Sr25519Type {
+		pub = &sr25519.PublicKey{}
+	} else if keytype == crypto.Secp256k1Type {
+		pub = &secp256k1.PublicKey{}
+	} else {
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+
+	if err := pub.Decode(in); err != nil {
+		return nil, err
+	}
+
+	return pub, nil
+}
+
 // GenerateKeypair create a new keypair with the corresponding type and saves
 // it to basepath/keystore/[public key].key in json format encrypted using the
 // specified password and returns the resulting filepath of the new key
 func GenerateKeypair(keytype string, kp crypto.Keypair, basepath string, password []byte) (string, error) {
+	return GenerateKeypairWithParams(keytype, kp, basepath, password, nil)
+}
+
+// GenerateKeypairWithParams is the same as GenerateKeypair, but derives the encryption key from the password
+// using the given scrypt parameters, which are recorded in the key file
+func GenerateKeypairWithParams(keytype string, kp crypto.Keypair, basepath string, password []byte,
+	params *ScryptParams) (string, error) {
 	if keytype == "" {
 		keytype = crypto.Sr25519Type
 	}
 	var err error
 
+	if params != nil {
+		if err = params.Validate(); err != nil {
+			return "", fmt.Errorf("invalid scrypt parameters: %w", err)
+		}
+	}
+
 	if kp == nil {
 		if keytype == crypto.Sr25519Type {
 			kp, err = sr25519.GenerateKeypair()
@@ -108,7 +141,7 @@ func GenerateKeypair(keytype string, kp crypto.Keypair, basepath string, passwor
 		return "", err
 	}
 
-	err = EncryptAndWriteToFile(file, kp.Private(), password)
+	err = EncryptAndWriteToFileWithParams(file, kp.Private(), password, params)
 	if err != nil {
 		return "", fmt.Errorf("failed to write key to file: %s", err)
 	}
@@ -143,26 +176,30 @@ func LoadKeystore(key string, ks Keystore) error {
 
 		switch strings.ToLower(key) {
 		case "alice":
-			ks.Insert(kr.Alice())
+			err = ks.Insert(kr.Alice())
 		case "bob":
-			ks.Insert(kr.Bob())
+			err = ks.Insert(kr.Bob())
 		case "charlie":
-			ks.Insert(kr.Charlie())
+			err = ks.Insert(kr.Charlie())
 		case "dave":
-			ks.Insert(kr.Dave())
+			err = ks.Insert(kr.Dave())
 		case "eve":
-			ks.Insert(kr.Eve())
+			err = ks.Insert(kr.Eve())
 		case "ferdie":
-			ks.Insert(kr.Ferdie())
+			err = ks.Insert(kr.Ferdie())
 		case "george":
-			ks.Insert(kr.George())
+			err = ks.Insert(kr.George())
 		case "heather":
-			ks.Insert(kr.Heather())
+			err = ks.Insert(kr.Heather())
 		case "ian":
-			ks.Insert(kr.Ian())
+			err = ks.Insert(kr.Ian())
 		default:
 			return fmt.Errorf("invalid test key provided")
 		}
+
+		if err != nil {
+			return fmt.Errorf("failed to insert test key %s: %w", key, err)
+		}
 	}
 
 	return nil
@@ -201,8 +238,72 @@ func ImportKeypair(fp, dir string) (string, error) {
 	return keyFilePath, nil
 }
 
+// KeyFile describes an encrypted key file in the keystore directory
+type KeyFile struct {
+	Filename  string
+	Type      crypto.KeyType
+	PublicKey crypto.PublicKey
+}
+
+// ListKeyFiles returns the key files in the basepath's keystore directory. If keytype is not empty, only the
+// key files of that type are returned. The key files are not decrypted.
+func ListKeyFiles(basepath string, keytype crypto.KeyType) ([]*KeyFile, error) {
+	keyDir, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := utils.KeystoreFiles(basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*KeyFile{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(filepath.Clean(filepath.Join(keyDir, file)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore file: %s", err)
+		}
+
+		keydata := new(EncryptedKeystore)
+		err = json.Unmarshal(data, keydata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore file %s: %s", file, err)
+		}
+
+		if keytype != "" && keydata.Type != keytype {
+			continue
+		}
+
+		enc, err := common.HexToBytes(keydata.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key of %s: %s", file, err)
+		}
+
+		pub, err := DecodePublicKey(enc, keydata.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key of %s: %s", file, err)
+		}
+
+		keys = append(keys, &KeyFile{
+			Filename:  file,
+			Type:      keydata.Type,
+			PublicKey: pub,
+		})
+	}
+
+	return keys, nil
+}
+
 // ImportRawPrivateKey imports a raw private key and saves it to the keystore directory
 func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string, error) {
+	return ImportRawPrivateKeyWithParams(key, keytype, basepath, password, nil)
+}
+
+// ImportRawPrivateKeyWithParams imports a raw private key and saves it to the keystore directory, encrypted with a
+// key derived from the password using the given scrypt parameters
+func ImportRawPrivateKeyWithParams(key, keytype, basepath string, password []byte,
+	params *ScryptParams) (string, error) {
 	var kp crypto.Keypair
 	var err error
 
@@ -227,7 +328,7 @@ func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string
 		}
 	}
 
-	return GenerateKeypair(keytype, kp, basepath, password)
+	return GenerateKeypairWithParams(keytype, kp, basepath, password, params)
 }
 
 // UnlockKeys unlocks keys specified by the --unlock flag with the passwords given by --password
@@ -286,7 +387,10 @@ func UnlockKeys(ks Keystore, dir, unlock, password string) error {
 			return fmt.Errorf("failed to create keypair from private key %d: %s", idx, err)
 		}
 
-		ks.Insert(kp)
+		err = ks.Insert(kp)
+		if err != nil {
+			return fmt.Errorf("failed to insert key %d: %w", idx, err)
+		}
 	}
 
 	return nil
@@ -369,3 +369,26 @@ func TestImportRawPrivateKey_Secp256k1(t *testing.T) {
 	require.Equal(t, "secp256k1", kscontents.Type)
 	require.Equal(t, "0x03409094a319b2961660c3ebcc7d206266182c1b3e60d341b5fb17e6851865825c", kscontents.PublicKey)
 }
+
+func TestListKeyFiles(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	srfile, err := GenerateKeypair(crypto.Sr25519Type, nil, testdir, testPassword)
+	require.NoError(t, err)
+	_, err = GenerateKeypair(crypto.Ed25519Type, nil, testdir, testPassword)
+	require.NoError(t, err)
+	_, err = GenerateKeypair(crypto.Secp256k1Type, nil, testdir, testPassword)
+	require.NoError(t, err)
+
+	keys, err := ListKeyFiles(testdir, "")
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	keys, err = ListKeyFiles(testdir, crypto.Sr25519Type)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, crypto.Sr25519Type, keys[0].Type)
+	require.Equal(t, filepath.Base(srfile), keys[0].Filename)
+	require.Equal(t, strings.TrimSuffix(keys[0].Filename, ".key"), keys[0].PublicKey.Hex()[2:])
+}
@@ -37,14 +37,18 @@ var (
 	DumyName Name = "dumy"
 )
 
+// ErrKeystoreLocked is returned when inserting a key into a locked keystore
+var ErrKeystoreLocked = errors.New("keystore is locked")
+
 // Keystore provides key management functionality
 type Keystore interface {
 	Name() Name
 	Type() crypto.KeyType
-	Insert(kp crypto.Keypair)
+	Insert(kp crypto.Keypair) error
 	GetKeypairFromAddress(pub common.Address) crypto.Keypair
 	GetKeypair(pub crypto.PublicKey) crypto.Keypair
 	PublicKeys() []crypto.PublicKey
+	ListByType(keytype crypto.KeyType) []crypto.PublicKey
 	Keypairs() []crypto.Keypair
 	Size() int
 }
@@ -73,6 +77,33 @@ func NewGlobalKeystore() *GlobalKeystore {
 	}
 }
 
+// Summary holds the name, key type and number of keys of a keystore
+type Summary struct {
+	Name Name           `json:"name"`
+	Type crypto.KeyType `json:"type"`
+	Size int            `json:"size"`
+}
+
+// Summary returns the name, key type and number of keys of each keystore
+func (k *GlobalKeystore) Summary() []Summary {
+	keystores := []Keystore{k.Babe, k.Gran, k.Acco, k.Aura, k.Imon, k.Audi, k.Dumy}
+
+	summary := []Summary{}
+	for _, ks := range keystores {
+		if ks == nil {
+			continue
+		}
+
+		summary = append(summary, Summary{
+			Name: ks.Name(),
+			Type: ks.Type(),
+			Size: ks.Size(),
+		})
+	}
+
+	return summary
+}
+
 // GetKeystore returns a keystore given its name
 func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 	nameStr := Name(name)
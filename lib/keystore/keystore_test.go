@@ -0,0 +1,54 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalKeystore_Summary(t *testing.T) {
+	srkr, err := NewSr25519Keyring()
+	require.NoError(t, err)
+	edkr, err := NewEd25519Keyring()
+	require.NoError(t, err)
+
+	ks := NewGlobalKeystore()
+	ks.Babe.Insert(srkr.Alice())
+	ks.Gran.Insert(edkr.Alice())
+	ks.Gran.Insert(edkr.Bob())
+	ks.Acco.Insert(srkr.Alice())
+	ks.Acco.Insert(edkr.Alice())
+	ks.Acco.Insert(srkr.Charlie())
+
+	// keys of the wrong type are not inserted into typed keystores
+	ks.Babe.Insert(edkr.Bob())
+
+	expected := []Summary{
+		{Name: BabeName, Type: crypto.Sr25519Type, Size: 1},
+		{Name: GranName, Type: crypto.Ed25519Type, Size: 2},
+		{Name: AccoName, Type: crypto.UnknownType, Size: 3},
+		{Name: AuraName, Type: crypto.Sr25519Type, Size: 0},
+		{Name: ImonName, Type: crypto.Sr25519Type, Size: 0},
+		{Name: AudiName, Type: crypto.Sr25519Type, Size: 0},
+		{Name: DumyName, Type: crypto.UnknownType, Size: 0},
+	}
+	require.Equal(t, expected, ks.Summary())
+}
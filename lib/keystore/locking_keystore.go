@@ -0,0 +1,159 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+)
+
+// ClearableKeystore is a Keystore whose keys can be removed
+type ClearableKeystore interface {
+	Keystore
+	Clear()
+}
+
+// LockingKeystore is a Keystore that locks itself once it hasn't been used for the idle timeout. Locking
+// clears the decrypted keys from the underlying keystore, so they must be decrypted again with Unlock before use.
+type LockingKeystore struct {
+	ClearableKeystore
+	timeout time.Duration
+	lock    sync.Mutex
+	timer   *time.Timer
+	locked  bool
+}
+
+// NewLockingKeystore returns a LockingKeystore wrapping the given keystore that locks after the idle timeout
+func NewLockingKeystore(ks ClearableKeystore, timeout time.Duration) *LockingKeystore {
+	lks := &LockingKeystore{
+		ClearableKeystore: ks,
+		timeout:           timeout,
+	}
+
+	lks.timer = time.AfterFunc(timeout, lks.Lock)
+	return lks
+}
+
+// Lock clears the keys of the keystore
+func (ks *LockingKeystore) Lock() {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	ks.timer.Stop()
+	ks.ClearableKeystore.Clear()
+	ks.locked = true
+}
+
+// IsLocked returns true if the keystore has been locked since the keys were last inserted
+func (ks *LockingKeystore) IsLocked() bool {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	return ks.locked
+}
+
+// Stop stops the idle timer, the keystore won't lock itself afterwards
+func (ks *LockingKeystore) Stop() {
+	ks.timer.Stop()
+}
+
+// touch resets the idle timer, unless the keystore is locked
+func (ks *LockingKeystore) touch() {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if !ks.locked {
+		ks.timer.Reset(ks.timeout)
+	}
+}
+
+// Unlock decrypts the keys at the given indices of the keystore directory with the given passwords, as done
+// for the --unlock and --password flags, and places them into the keystore, unlocking it
+func (ks *LockingKeystore) Unlock(basepath, unlock, password string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := UnlockKeys(ks.ClearableKeystore, basepath, unlock, password); err != nil {
+		return err
+	}
+
+	ks.locked = false
+	ks.timer.Reset(ks.timeout)
+	return nil
+}
+
+// Insert adds a keypair to the keystore. It returns ErrKeystoreLocked while the keystore is locked, it must be
+// unlocked first.
+func (ks *LockingKeystore) Insert(kp crypto.Keypair) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if ks.locked {
+		return ErrKeystoreLocked
+	}
+
+	err := ks.ClearableKeystore.Insert(kp)
+	if err != nil {
+		return err
+	}
+
+	ks.timer.Reset(ks.timeout)
+	return nil
+}
+
+// GetKeypairFromAddress returns a keypair corresponding to the given address, or nil if it doesn't exist
+func (ks *LockingKeystore) GetKeypairFromAddress(pub common.Address) crypto.Keypair {
+	ks.touch()
+	return ks.ClearableKeystore.GetKeypairFromAddress(pub)
+}
+
+// GetKeypair returns a keypair corresponding to the given public key, or nil if it doesn't exist
+func (ks *LockingKeystore) GetKeypair(pub crypto.PublicKey) crypto.Keypair {
+	ks.touch()
+	return ks.ClearableKeystore.GetKeypair(pub)
+}
+
+// Keypairs returns all keypairs in the keystore
+func (ks *LockingKeystore) Keypairs() []crypto.Keypair {
+	ks.touch()
+	return ks.ClearableKeystore.Keypairs()
+}
+
+// zeroKeypair overwrites the private key of the keypair in memory. Only ed25519 and sr25519 keys can be
+// zeroed, other keys are released for garbage collection when removed from the keystore.
+func zeroKeypair(kp crypto.Keypair) {
+	switch priv := kp.Private().(type) {
+	case *ed25519.PrivateKey:
+		if priv == nil {
+			return
+		}
+
+		for i := range *priv {
+			(*priv)[i] = 0
+		}
+	case *sr25519.PrivateKey:
+		if priv == nil {
+			return
+		}
+
+		priv.Zero()
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockingKeystore_AutoLock(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	ks := NewGlobalKeystore()
+
+	gran, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks.Gran.Insert(gran)
+
+	acco, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	accoEd, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	_, err = GenerateKeypair("sr25519", acco, testdir, testPassword)
+	require.NoError(t, err)
+
+	lks := NewLockingKeystore(ks.Acco.(ClearableKeystore), 100*time.Millisecond)
+	defer lks.Stop()
+	ks.Acco = lks
+	ks.Acco.Insert(acco)
+	ks.Acco.Insert(accoEd)
+
+	require.NotNil(t, ks.Acco.GetKeypair(acco.Public()))
+	require.False(t, lks.IsLocked())
+
+	time.Sleep(300 * time.Millisecond)
+
+	// account keys are inaccessible once locked
+	require.True(t, lks.IsLocked())
+	require.Nil(t, ks.Acco.GetKeypair(acco.Public()))
+	require.Equal(t, 0, ks.Acco.Size())
+
+	// the private keys are zeroed in memory
+	require.Equal(t, make([]byte, len(*accoEd.Private().(*ed25519.PrivateKey))), []byte(*accoEd.Private().(*ed25519.PrivateKey)))
+	require.Equal(t, make([]byte, sr25519.PrivateKeyLength), acco.Private().Encode())
+
+	// consensus keys remain accessible
+	require.Equal(t, gran, ks.Gran.GetKeypair(gran.Public()))
+
+	// keys can't be inserted while locked
+	err = ks.Acco.Insert(accoEd)
+	require.True(t, errors.Is(err, ErrKeystoreLocked))
+	require.True(t, lks.IsLocked())
+	require.Equal(t, 0, ks.Acco.Size())
+
+	// unlocking decrypts the keys again
+	err = lks.Unlock(testdir, "0", string(testPassword))
+	require.NoError(t, err)
+	require.False(t, lks.IsLocked())
+	require.NotNil(t, ks.Acco.GetKeypair(acco.Public()))
+}
+
+func TestLockingKeystore_UnlockInvalidPassword(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	_, err := GenerateKeypair("sr25519", nil, testdir, testPassword)
+	require.NoError(t, err)
+
+	lks := NewLockingKeystore(NewGenericKeystore(AccoName), time.Minute)
+	defer lks.Stop()
+	lks.Lock()
+
+	err = lks.Unlock(testdir, "0", "wrongpassword")
+	require.Error(t, err)
+	require.True(t, lks.IsLocked())
+	require.Equal(t, 0, lks.Size())
+}
+
+func TestLockingKeystore_IdleTimerReset(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	lks := NewLockingKeystore(NewGenericKeystore(AccoName), 200*time.Millisecond)
+	defer lks.Stop()
+	lks.Insert(kp)
+
+	// using the keystore keeps it unlocked
+	for i := 0; i < 5; i++ {
+		time.Sleep(100 * time.Millisecond)
+		require.NotNil(t, lks.GetKeypair(kp.Public()))
+	}
+	require.False(t, lks.IsLocked())
+}
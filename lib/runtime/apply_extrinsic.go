@@ -0,0 +1,197 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common/optional"
+	"github.com/ChainSafe/gossamer/lib/scale"
+)
+
+var errInvalidResult = errors.New("invalid apply extrinsic result")
+
+// ApplyExtrinsicOutcome is the kind of result returned by BlockBuilder_apply_extrinsic
+type ApplyExtrinsicOutcome byte
+
+const (
+	// ApplySuccess is the outcome when the extrinsic was applied and its call was dispatched successfully
+	ApplySuccess ApplyExtrinsicOutcome = iota
+	// ApplyDispatchError is the outcome when the extrinsic was applied, but dispatching its call failed.
+	// The extrinsic is still included in the block.
+	ApplyDispatchError
+	// ApplyInvalidTransaction is the outcome when the extrinsic is invalid
+	ApplyInvalidTransaction
+	// ApplyFutureTransaction is the outcome when the extrinsic isn't valid yet, but may become valid in the future
+	ApplyFutureTransaction
+	// ApplyUnknownTransaction is the outcome when the validity of the extrinsic couldn't be determined
+	ApplyUnknownTransaction
+)
+
+// ApplyExtrinsicResult is the decoded return value of BlockBuilder_apply_extrinsic
+type ApplyExtrinsicResult struct {
+	Outcome ApplyExtrinsicOutcome
+	Err     error // a *DispatchOutcomeError or *TransactionValidityError describing the failure, nil on success
+}
+
+// Included returns true if the extrinsic can be included in a block, ie. it was applied, even if dispatching
+// its call failed
+func (r ApplyExtrinsicResult) Included() bool {
+	return r.Outcome == ApplySuccess || r.Outcome == ApplyDispatchError
+}
+
+// A DispatchOutcomeError is outcome of dispatching the extrinsic
+type DispatchOutcomeError struct {
+	msg string // description of error
+}
+
+func (e DispatchOutcomeError) Error() string {
+	return fmt.Sprintf("dispatch outcome error: %s", e.msg)
+}
+
+// A TransactionValidityError is possible errors while checking the validity of a transaction
+type TransactionValidityError struct {
+	msg string // description of error
+}
+
+func (e TransactionValidityError) Error() string {
+	return fmt.Sprintf("transaction validity error: %s", e.msg)
+}
+
+// DecodeApplyExtrinsicResult decodes the return value of BlockBuilder_apply_extrinsic, which is a
+// Result<Result<(), DispatchError>, TransactionValidityError>
+func DecodeApplyExtrinsicResult(ret []byte) (ApplyExtrinsicResult, error) {
+	if len(ret) < 2 {
+		return ApplyExtrinsicResult{}, errInvalidResult
+	}
+
+	switch ret[0] {
+	case 0: // DispatchOutcome
+		switch ret[1] {
+		case 0:
+			return ApplyExtrinsicResult{Outcome: ApplySuccess}, nil
+		case 1:
+			err := decodeDispatchErr(ret[2:])
+			if err == errInvalidResult {
+				return ApplyExtrinsicResult{}, err
+			}
+			return ApplyExtrinsicResult{Outcome: ApplyDispatchError, Err: err}, nil
+		}
+	case 1: // TransactionValidityError
+		switch ret[1] {
+		case 0:
+			return decodeInvalidTxnErr(ret[2:])
+		case 1:
+			return decodeUnknownTxnErr(ret[2:])
+		}
+	}
+
+	return ApplyExtrinsicResult{}, errInvalidResult
+}
+
+func decodeCustomModuleErr(res []byte) error {
+	if len(res) < 3 {
+		return errInvalidResult
+	}
+	errMsg, err := optional.NewBytes(false, nil).DecodeBytes(res[2:])
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("index: %d code: %d message: %s", res[0], res[1], errMsg.String())
+}
+
+func decodeDispatchErr(res []byte) error {
+	if len(res) == 0 {
+		return errInvalidResult
+	}
+
+	switch res[0] {
+	case 0:
+		unknownErr, err := scale.Decode(res[1:], []byte{})
+		if err != nil {
+			return errInvalidResult
+		}
+		return &DispatchOutcomeError{fmt.Sprintf("unknown error: %s", string(unknownErr.([]byte)))}
+	case 1:
+		return &DispatchOutcomeError{"failed lookup"}
+	case 2:
+		return &DispatchOutcomeError{"bad origin"}
+	case 3:
+		return &DispatchOutcomeError{fmt.Sprintf("custom module error: %s", decodeCustomModuleErr(res[1:]))}
+	}
+	return errInvalidResult
+}
+
+func decodeInvalidTxnErr(res []byte) (ApplyExtrinsicResult, error) {
+	if len(res) == 0 {
+		return ApplyExtrinsicResult{}, errInvalidResult
+	}
+
+	result := ApplyExtrinsicResult{Outcome: ApplyInvalidTransaction}
+	switch res[0] {
+	case 0:
+		result.Err = &TransactionValidityError{"call of the transaction is not expected"}
+	case 1:
+		result.Err = &TransactionValidityError{"invalid payment"}
+	case invalidTransactionFuture:
+		result.Outcome = ApplyFutureTransaction
+		result.Err = &TransactionValidityError{"transaction will be valid in the future"}
+	case 3:
+		result.Err = &TransactionValidityError{"outdated transaction"}
+	case 4:
+		result.Err = &TransactionValidityError{"bad proof"}
+	case 5:
+		result.Err = &TransactionValidityError{"ancient birth block"}
+	case 6:
+		result.Err = &TransactionValidityError{"exhausts resources"}
+	case 7:
+		if len(res) < 2 {
+			return ApplyExtrinsicResult{}, errInvalidResult
+		}
+		result.Err = &TransactionValidityError{fmt.Sprintf("unknown error: %d", res[1])}
+	case 8:
+		result.Err = &TransactionValidityError{"mandatory dispatch error"}
+	case 9:
+		result.Err = &TransactionValidityError{"invalid mandatory dispatch"}
+	default:
+		return ApplyExtrinsicResult{}, errInvalidResult
+	}
+	return result, nil
+}
+
+func decodeUnknownTxnErr(res []byte) (ApplyExtrinsicResult, error) {
+	if len(res) == 0 {
+		return ApplyExtrinsicResult{}, errInvalidResult
+	}
+
+	result := ApplyExtrinsicResult{Outcome: ApplyUnknownTransaction}
+	switch res[0] {
+	case 0:
+		result.Err = &TransactionValidityError{"lookup failed"}
+	case 1:
+		result.Err = &TransactionValidityError{"validator not found"}
+	case 2:
+		if len(res) < 2 {
+			return ApplyExtrinsicResult{}, errInvalidResult
+		}
+		result.Err = &TransactionValidityError{fmt.Sprintf("unknown error: %d", res[1])}
+	default:
+		return ApplyExtrinsicResult{}, errInvalidResult
+	}
+	return result, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeApplyExtrinsicResult(t *testing.T) {
+	testCases := []struct {
+		name     string
+		test     []byte
+		outcome  ApplyExtrinsicOutcome
+		expected string
+	}{
+		{
+			name:    "Valid extrinsic",
+			test:    []byte{0, 0},
+			outcome: ApplySuccess,
+		},
+		{
+			name:     "Dispatch unknown error",
+			test:     []byte{0, 1, 0, 0x04, 65},
+			outcome:  ApplyDispatchError,
+			expected: "dispatch outcome error: unknown error: A",
+		},
+		{
+			name:     "Dispatch failed lookup error",
+			test:     []byte{0, 1, 1},
+			outcome:  ApplyDispatchError,
+			expected: "dispatch outcome error: failed lookup",
+		},
+		{
+			name:     "Dispatch bad origin error",
+			test:     []byte{0, 1, 2},
+			outcome:  ApplyDispatchError,
+			expected: "dispatch outcome error: bad origin",
+		},
+		{
+			name:     "Dispatch custom module error empty",
+			test:     []byte{0, 1, 3, 4, 5, 1, 0},
+			outcome:  ApplyDispatchError,
+			expected: "dispatch outcome error: custom module error: index: 4 code: 5 message: ",
+		},
+		{
+			name:     "Dispatch custom module error",
+			test:     []byte{0, 1, 3, 4, 5, 1, 0x04, 0x65},
+			outcome:  ApplyDispatchError,
+			expected: "dispatch outcome error: custom module error: index: 4 code: 5 message: 65",
+		},
+		{
+			name:     "Invalid txn call error",
+			test:     []byte{1, 0, 0},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: call of the transaction is not expected",
+		},
+		{
+			name:     "Invalid txn payment error",
+			test:     []byte{1, 0, 1},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: invalid payment",
+		},
+		{
+			name:     "Invalid txn future error",
+			test:     []byte{1, 0, 2},
+			outcome:  ApplyFutureTransaction,
+			expected: "transaction validity error: transaction will be valid in the future",
+		},
+		{
+			name:     "Invalid txn stale error",
+			test:     []byte{1, 0, 3},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: outdated transaction",
+		},
+		{
+			name:     "Invalid txn bad proof error",
+			test:     []byte{1, 0, 4},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: bad proof",
+		},
+		{
+			name:     "Invalid txn ancient birth block error",
+			test:     []byte{1, 0, 5},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: ancient birth block",
+		},
+		{
+			name:     "Invalid txn exhausts resources error",
+			test:     []byte{1, 0, 6},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: exhausts resources",
+		},
+		{
+			name:     "Invalid txn custom error",
+			test:     []byte{1, 0, 7, 65},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: unknown error: 65",
+		},
+		{
+			name:     "Invalid txn bad mandatory error",
+			test:     []byte{1, 0, 8},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: mandatory dispatch error",
+		},
+		{
+			name:     "Invalid txn mandatory dispatch error",
+			test:     []byte{1, 0, 9},
+			outcome:  ApplyInvalidTransaction,
+			expected: "transaction validity error: invalid mandatory dispatch",
+		},
+		{
+			name:     "Unknown txn lookup failed error",
+			test:     []byte{1, 1, 0},
+			outcome:  ApplyUnknownTransaction,
+			expected: "transaction validity error: lookup failed",
+		},
+		{
+			name:     "Unknown txn no unsigned validator error",
+			test:     []byte{1, 1, 1},
+			outcome:  ApplyUnknownTransaction,
+			expected: "transaction validity error: validator not found",
+		},
+		{
+			name:     "Unknown txn custom error",
+			test:     []byte{1, 1, 2, 75},
+			outcome:  ApplyUnknownTransaction,
+			expected: "transaction validity error: unknown error: 75",
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := DecodeApplyExtrinsicResult(c.test)
+			require.NoError(t, err)
+			require.Equal(t, c.outcome, res.Outcome)
+
+			if c.expected == "" {
+				require.NoError(t, res.Err)
+				require.True(t, res.Included())
+				return
+			}
+
+			if c.outcome == ApplyDispatchError {
+				_, ok := res.Err.(*DispatchOutcomeError)
+				require.True(t, ok)
+				require.True(t, res.Included())
+			} else {
+				_, ok := res.Err.(*TransactionValidityError)
+				require.True(t, ok)
+				require.False(t, res.Included())
+			}
+			require.Equal(t, c.expected, res.Err.Error())
+		})
+	}
+}
+
+func TestDecodeApplyExtrinsicResult_Invalid(t *testing.T) {
+	for _, test := range [][]byte{
+		nil,
+		{0},
+		{0, 2},
+		{0, 1},
+		{0, 1, 4},
+		{1, 0},
+		{1, 0, 7},
+		{1, 0, 10},
+		{1, 1, 2},
+		{1, 1, 3},
+		{1, 2, 0},
+		{2, 0},
+	} {
+		_, err := DecodeApplyExtrinsicResult(test)
+		require.Equal(t, errInvalidResult, err, test)
+	}
+}
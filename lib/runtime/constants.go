@@ -67,6 +67,8 @@ var (
 	BlockBuilderApplyExtrinsic = "BlockBuilder_apply_extrinsic"
 	// BlockBuilderFinalizeBlock is the runtime API call BlockBuilder_finalize_block
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
+	// TransactionPaymentAPIQueryInfo is the runtime API call TransactionPaymentApi_query_info
+	TransactionPaymentAPIQueryInfo = "TransactionPaymentApi_query_info"
 )
 
 // GrandpaAuthoritiesKey is the location of GRANDPA authority data in the storage trie for LEGACY_NODE_RUNTIME and NODE_RUNTIME
@@ -94,3 +96,10 @@ func SystemAccountPrefix() []byte {
 	part2, _ := common.Twox128Hash([]byte(`Account`))
 	return append(prefix, part2...)
 }
+
+// SystemBlockWeightKey is the location of the weight consumed by the block being built, by dispatch class
+func SystemBlockWeightKey() []byte {
+	prefix, _ := common.Twox128Hash([]byte(`System`))
+	part2, _ := common.Twox128Hash([]byte(`BlockWeight`))
+	return append(prefix, part2...)
+}
@@ -29,9 +29,28 @@ var ErrCannotValidateTx = errors.New("could not validate transaction")
 //  value of [1, 0, x]
 var ErrInvalidTransaction = &json2.Error{Code: 1010, Message: "Invalid Transaction"}
 
+// ErrFutureTransaction is returned if the call to runtime function TaggedTransactionQueueValidateTransaction fails with
+//  value of [1, 0, 2], ie. the transaction is invalid now but may become valid in the future
+var ErrFutureTransaction = &json2.Error{Code: 1010, Message: "Invalid Transaction", Data: "Future"}
+
 // ErrUnknownTransaction is returned if the call to runtime function TaggedTransactionQueueValidateTransaction fails with
 //  value of [1, 1, x]
 var ErrUnknownTransaction = &json2.Error{Code: 1011, Message: "Unknown Transaction Validity"}
 
 // ErrNilStorage is returned when the runtime context storage isn't set
 var ErrNilStorage = errors.New("runtime context storage is nil")
+
+// ErrExportFunctionNotFound is returned when the runtime does not export the called function
+var ErrExportFunctionNotFound = errors.New("could not find exported function")
+
+// ErrExecutionExhausted is returned when a runtime call runs out of fuel before completing
+var ErrExecutionExhausted = errors.New("runtime execution exhausted its fuel limit")
+
+// ErrMemoryLimitExceeded is returned when growing the runtime memory would exceed its maximum number of pages
+var ErrMemoryLimitExceeded = errors.New("runtime memory limit exceeded")
+
+// ErrMissingHostFunction is returned when a runtime imports a host function that isn't registered with the instance
+var ErrMissingHostFunction = errors.New("missing host function")
+
+// ErrUnknownInterpreter is returned when no wasm interpreter is registered with the given name
+var ErrUnknownInterpreter = errors.New("unknown wasm interpreter")
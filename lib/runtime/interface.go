@@ -42,6 +42,7 @@ type Instance interface {
 	ApplyExtrinsic(data types.Extrinsic) ([]byte, error)
 	FinalizeBlock() (*types.Header, error)
 	ExecuteBlock(block *types.Block) ([]byte, error)
+	PaymentQueryInfo(ext types.Extrinsic) (*types.RuntimeDispatchInfo, error)
 
 	// TODO: parameters and return values for these are undefined in the spec
 	CheckInherents()
@@ -0,0 +1,85 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// InstanceFactory creates a runtime instance from the given wasm bytecode using a specific wasm interpreter
+type InstanceFactory func(code []byte, cfg *InstanceConfig) (Instance, error)
+
+var (
+	interpretersMu sync.RWMutex
+	interpreters   = make(map[string]InstanceFactory)
+)
+
+// RegisterInterpreter makes a wasm interpreter available by the given name. It is intended to be called from the
+// init function of the interpreter package, and panics if the factory is nil or the name is already registered.
+func RegisterInterpreter(name string, factory InstanceFactory) {
+	interpretersMu.Lock()
+	defer interpretersMu.Unlock()
+
+	if factory == nil {
+		panic("runtime: RegisterInterpreter factory is nil")
+	}
+
+	if _, has := interpreters[name]; has {
+		panic("runtime: RegisterInterpreter called twice for interpreter " + name)
+	}
+
+	interpreters[name] = factory
+}
+
+// IsRegisteredInterpreter returns true if a wasm interpreter is registered with the given name
+func IsRegisteredInterpreter(name string) bool {
+	interpretersMu.RLock()
+	defer interpretersMu.RUnlock()
+
+	_, has := interpreters[name]
+	return has
+}
+
+// Interpreters returns the sorted names of the registered wasm interpreters
+func Interpreters() []string {
+	interpretersMu.RLock()
+	defer interpretersMu.RUnlock()
+
+	names := make([]string, 0, len(interpreters))
+	for name := range interpreters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// NewInstance creates a runtime instance from the given wasm bytecode using the wasm interpreter registered with
+// the given name
+func NewInstance(name string, code []byte, cfg *InstanceConfig) (Instance, error) {
+	interpretersMu.RLock()
+	factory, has := interpreters[name]
+	interpretersMu.RUnlock()
+
+	if !has {
+		return nil, fmt.Errorf("%w %q, registered interpreters are %v", ErrUnknownInterpreter, name, Interpreters())
+	}
+
+	return factory(code, cfg)
+}
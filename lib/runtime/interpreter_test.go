@@ -0,0 +1,69 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubInstance struct {
+	Instance
+	code []byte
+	cfg  *InstanceConfig
+}
+
+func newStubInstance(code []byte, cfg *InstanceConfig) (Instance, error) {
+	return &stubInstance{
+		code: code,
+		cfg:  cfg,
+	}, nil
+}
+
+func TestRegisterInterpreter(t *testing.T) {
+	RegisterInterpreter("stub", newStubInstance)
+	require.True(t, IsRegisteredInterpreter("stub"))
+	require.Contains(t, Interpreters(), "stub")
+
+	code := []byte{0, 97, 115, 109}
+	cfg := &InstanceConfig{Role: 4}
+
+	in, err := NewInstance("stub", code, cfg)
+	require.NoError(t, err)
+
+	stub, ok := in.(*stubInstance)
+	require.True(t, ok)
+	require.Equal(t, code, stub.code)
+	require.Equal(t, cfg, stub.cfg)
+
+	require.Panics(t, func() {
+		RegisterInterpreter("stub", newStubInstance)
+	})
+	require.Panics(t, func() {
+		RegisterInterpreter("nil", nil)
+	})
+}
+
+func TestNewInstance_UnknownInterpreter(t *testing.T) {
+	require.False(t, IsRegisteredInterpreter("unknown"))
+
+	_, err := NewInstance("unknown", nil, &InstanceConfig{})
+	require.True(t, errors.Is(err, ErrUnknownInterpreter))
+	require.Contains(t, err.Error(), `"unknown"`)
+}
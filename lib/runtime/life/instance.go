@@ -18,12 +18,12 @@ package life
 import (
 	"errors"
 	"fmt"
-	"os"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	log "github.com/ChainSafe/log15"
 	"github.com/perlin-network/life/exec"
 )
@@ -38,6 +38,20 @@ var (
 	ctx    *runtime.Context
 )
 
+func init() {
+	runtime.RegisterInterpreter(Name, newRegisteredInstance)
+}
+
+// newRegisteredInstance is the life factory registered with the runtime package
+func newRegisteredInstance(code []byte, cfg *runtime.InstanceConfig) (runtime.Instance, error) {
+	rtCfg := &Config{
+		InstanceConfig: *cfg,
+		Resolver:       new(Resolver),
+	}
+
+	return NewInstance(code, rtCfg)
+}
+
 // Config represents a life configuration
 type Config struct {
 	runtime.InstanceConfig
@@ -71,7 +85,7 @@ func NewInstance(code []byte, cfg *Config) (runtime.Instance, error) {
 
 	// if cfg.LogLvl set to < 0, then don't change package log level
 	if cfg.LogLvl >= 0 {
-		h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+		h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 		h = log.CallerFileHandler(h)
 		logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 	}
@@ -161,7 +175,7 @@ func (in *Instance) Exec(function string, data []byte) ([]byte, error) {
 
 	fnc, ok := in.vm.GetFunctionExport(function)
 	if !ok {
-		panic("entry function not found")
+		return nil, fmt.Errorf("%w %s", runtime.ErrExportFunctionNotFound, function)
 	}
 
 	ret, err := in.vm.Run(fnc, int64(ptr), int64(len(data)))
@@ -0,0 +1,91 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+)
+
+var (
+	// SuccessResult is the encoded result of an extrinsic that was applied and whose call was dispatched successfully
+	SuccessResult = []byte{0, 0}
+	// DispatchErrorResult is the encoded result of an extrinsic that was applied, but whose call failed with a
+	// bad origin error
+	DispatchErrorResult = []byte{0, 1, 2}
+	// InvalidResult is the encoded result of an extrinsic that is invalid as it can't pay its fees
+	InvalidResult = []byte{1, 0, 1}
+	// FutureResult is the encoded result of an extrinsic that isn't valid yet
+	FutureResult = []byte{1, 0, 2}
+)
+
+// ApplyExtrinsicRuntime is a runtime whose ApplyExtrinsic returns the encoded result set for the extrinsic with
+// SetResult, or SuccessResult if none was set. ValidateTransaction considers every extrinsic valid and
+// SetContextStorage does nothing; all other calls are passed to the embedded Instance.
+type ApplyExtrinsicRuntime struct {
+	runtime.Instance
+
+	lock    sync.Mutex
+	results map[string][]byte
+	applied []types.Extrinsic
+}
+
+// NewApplyExtrinsicRuntime returns an ApplyExtrinsicRuntime that passes the calls it doesn't mock to fallback,
+// which may be nil if those calls are never made
+func NewApplyExtrinsicRuntime(fallback runtime.Instance) *ApplyExtrinsicRuntime {
+	return &ApplyExtrinsicRuntime{
+		Instance: fallback,
+		results:  make(map[string][]byte),
+	}
+}
+
+// SetResult sets the encoded result ApplyExtrinsic returns for the given extrinsic
+func (rt *ApplyExtrinsicRuntime) SetResult(ext types.Extrinsic, result []byte) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.results[string(ext)] = result
+}
+
+// Applied returns the extrinsics passed to ApplyExtrinsic, in the order they were applied
+func (rt *ApplyExtrinsicRuntime) Applied() []types.Extrinsic {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return append([]types.Extrinsic{}, rt.applied...)
+}
+
+// ApplyExtrinsic returns the encoded result set for the extrinsic, or SuccessResult if none was set
+func (rt *ApplyExtrinsicRuntime) ApplyExtrinsic(ext types.Extrinsic) ([]byte, error) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	rt.applied = append(rt.applied, ext)
+	if res, has := rt.results[string(ext)]; has {
+		return res, nil
+	}
+	return SuccessResult, nil
+}
+
+// ValidateTransaction returns an empty validity for every extrinsic
+func (rt *ApplyExtrinsicRuntime) ValidateTransaction(_ types.Extrinsic) (*transaction.Validity, error) {
+	return &transaction.Validity{}, nil
+}
+
+// SetContextStorage does nothing, as the mocked calls don't use storage
+func (rt *ApplyExtrinsicRuntime) SetContextStorage(_ runtime.Storage) {}
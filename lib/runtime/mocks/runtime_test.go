@@ -0,0 +1,63 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package mocks
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyExtrinsicRuntime(t *testing.T) {
+	rt := NewApplyExtrinsicRuntime(nil)
+
+	extA := types.Extrinsic{1}
+	extB := types.Extrinsic{2}
+	rt.SetResult(extB, InvalidResult)
+
+	ret, err := rt.ApplyExtrinsic(extA)
+	require.NoError(t, err)
+	require.Equal(t, SuccessResult, ret)
+
+	ret, err = rt.ApplyExtrinsic(extB)
+	require.NoError(t, err)
+	require.Equal(t, InvalidResult, ret)
+
+	require.Equal(t, []types.Extrinsic{extA, extB}, rt.Applied())
+}
+
+func TestResults_Decode(t *testing.T) {
+	tests := []struct {
+		result   []byte
+		outcome  runtime.ApplyExtrinsicOutcome
+		included bool
+	}{
+		{SuccessResult, runtime.ApplySuccess, true},
+		{DispatchErrorResult, runtime.ApplyDispatchError, true},
+		{InvalidResult, runtime.ApplyInvalidTransaction, false},
+		{FutureResult, runtime.ApplyFutureTransaction, false},
+	}
+
+	for _, test := range tests {
+		res, err := runtime.DecodeApplyExtrinsicResult(test.result)
+		require.NoError(t, err)
+		require.Equal(t, test.outcome, res.Outcome)
+		require.Equal(t, test.included, res.Included())
+	}
+}
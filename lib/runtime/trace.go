@@ -0,0 +1,54 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime
+
+import "time"
+
+// HostCallStats holds the number of calls made to a host function and the total time spent in it
+type HostCallStats struct {
+	Count    uint64
+	Duration time.Duration
+}
+
+// CallTrace records the host function calls made during a single runtime call
+type CallTrace struct {
+	Function     string                    // the runtime function that was called
+	Duration     time.Duration             // total duration of the runtime call
+	HostDuration time.Duration             // total time spent in host functions
+	HostCalls    map[string]*HostCallStats // stats of each host function that was called
+}
+
+// NewCallTrace returns a new, empty CallTrace for the given runtime function
+func NewCallTrace(function string) *CallTrace {
+	return &CallTrace{
+		Function:  function,
+		HostCalls: make(map[string]*HostCallStats),
+	}
+}
+
+// Record adds a call to the given host function that took the given duration
+func (t *CallTrace) Record(hostFunction string, d time.Duration) {
+	stats, has := t.HostCalls[hostFunction]
+	if !has {
+		stats = new(HostCallStats)
+		t.HostCalls[hostFunction] = stats
+	}
+
+	stats.Count++
+	stats.Duration += d
+	t.HostDuration += d
+}
@@ -45,6 +45,12 @@ type InstanceConfig struct {
 	NodeStorage NodeStorage
 	Network     BasicNetwork
 	Transaction TransactionState
+	// FuelLimit the maximum fuel a single call into the runtime may consume (0 = unlimited). Only supported by
+	// the wasmer interpreter
+	FuelLimit uint64
+	// MaxMemoryPages the maximum number of pages the runtime memory may grow to (0 = unlimited). Only supported by
+	// the wasmer interpreter
+	MaxMemoryPages uint32
 }
 
 // Context is the context for the wasm interpreter's imported functions
@@ -57,8 +63,12 @@ type Context struct {
 	Network     BasicNetwork
 	Transaction TransactionState
 	SigVerifier *SignatureVerifier
+	Trace       *CallTrace // records the host function calls of the current runtime call, if tracing is enabled
 }
 
+// invalidTransactionFuture is the index of the Future variant of the runtime's InvalidTransaction enum
+const invalidTransactionFuture = 2
+
 // NewValidateTransactionError returns an error based on a return value from TaggedTransactionQueueValidateTransaction
 func NewValidateTransactionError(res []byte) error {
 	// confirm we have an error
@@ -68,6 +78,9 @@ func NewValidateTransactionError(res []byte) error {
 
 	if res[1] == 0 {
 		// transaction is invalid
+		if len(res) > 2 && res[2] == invalidTransactionFuture {
+			return ErrFutureTransaction
+		}
 		return ErrInvalidTransaction
 	}
 
@@ -130,3 +130,11 @@ func TestAllCryptoTypeSignature(t *testing.T) {
 
 	require.True(t, signVerify.Finish())
 }
+
+func TestNewValidateTransactionError(t *testing.T) {
+	require.NoError(t, NewValidateTransactionError([]byte{0, 0}))
+	require.Equal(t, ErrInvalidTransaction, NewValidateTransactionError([]byte{1, 0, 3}))
+	require.Equal(t, ErrFutureTransaction, NewValidateTransactionError([]byte{1, 0, 2}))
+	require.Equal(t, ErrUnknownTransaction, NewValidateTransactionError([]byte{1, 1, 0}))
+	require.Equal(t, ErrCannotValidateTx, NewValidateTransactionError([]byte{1, 2}))
+}
@@ -179,6 +179,28 @@ func (in *Instance) ExecuteBlock(block *types.Block) ([]byte, error) {
 	return in.exec(runtime.CoreExecuteBlock, bdEnc)
 }
 
+// PaymentQueryInfo returns the fee information for the given extrinsic using the runtime function
+// TransactionPaymentApi_query_info
+func (in *Instance) PaymentQueryInfo(ext types.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	encLen, err := scale.Encode(uint32(len(ext)))
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := in.exec(runtime.TransactionPaymentAPIQueryInfo, append(ext[:len(ext):len(ext)], encLen...))
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(types.RuntimeDispatchInfo)
+	err = info.Decode(bytes.NewReader(ret))
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
 func (in *Instance) CheckInherents()      {} //nolint
 func (in *Instance) RandomSeed()          {} //nolint
 func (in *Instance) OffchainWorker()      {} //nolint
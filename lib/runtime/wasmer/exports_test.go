@@ -3,6 +3,7 @@ package wasmer
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"math/big"
 	"testing"
@@ -523,6 +524,26 @@ func TestInstance_ApplyExtrinsic_GossamerRuntime(t *testing.T) {
 	require.Equal(t, []byte{0, 0}, res)
 }
 
+func TestInstance_PaymentQueryInfo_DevRuntime(t *testing.T) {
+	instance := NewTestInstance(t, runtime.DEV_RUNTIME)
+
+	// transfer signed by alice (created with polkadot.js/api test_transaction)
+	ext := types.Extrinsic(common.MustHexToBytes("0x410284ffd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d015a3e258da3ea20581b68fe1264a35d1f62d6a0debb1a44e836375eb9921ba33e3d0f265f2da33c9ca4e10490b03918300be902fcb229f806c9cf99af4cc10f8c0000000600ff8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480b00c465f14670"))
+
+	info, err := instance.PaymentQueryInfo(ext)
+	require.NoError(t, err)
+	require.NotZero(t, info.Weight)
+	require.Equal(t, types.NormalDispatch, info.Class)
+	require.Equal(t, 1, info.PartialFee.Cmp(&common.Uint128{}))
+}
+
+func TestInstance_PaymentQueryInfo_NotSupported(t *testing.T) {
+	instance := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
+
+	_, err := instance.PaymentQueryInfo(types.Extrinsic{})
+	require.True(t, errors.Is(err, runtime.ErrExportFunctionNotFound))
+}
+
 func TestInstance_ExecuteBlock_PolkadotRuntime(t *testing.T) {
 	DefaultTestLogLvl = 0
 
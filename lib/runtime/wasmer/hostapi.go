@@ -0,0 +1,180 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+
+	wasm "github.com/wasmerio/go-ext-wasm/wasmer"
+)
+
+const hostFunctionVersionSep = "_version_"
+
+// HostAPI is a set of host function versions a runtime instance can import. It maps the name of a host
+// function, without its version suffix, to the one version of it that's included; host functions that aren't
+// in the set are included at version 1. A nil HostAPI includes every version of every host function.
+type HostAPI map[string]uint32
+
+var (
+	// HostAPIv1 registers version 1 of every host function
+	HostAPIv1 = HostAPI{}
+
+	// HostAPIv2 registers version 2 of the host functions that have one, and version 1 of the others
+	HostAPIv2 = HostAPI{
+		"ext_crypto_sr25519_verify": 2,
+	}
+)
+
+// CoreAPIName is the hashed name of the Core runtime API, as declared in a runtime's version
+var CoreAPIName = [8]byte{0xdf, 0x6a, 0xcb, 0x68, 0x99, 0x07, 0x60, 0x9b}
+
+// HostAPISelector returns the host API set to register with a runtime of the given version. The version is nil
+// if the runtime doesn't declare one. Returning nil keeps every host function registered.
+type HostAPISelector func(v runtime.Version) HostAPI
+
+// SelectHostAPI returns a HostAPISelector that selects the given host API set for runtimes declaring at least the
+// given version of the runtime API with the given hashed name
+func SelectHostAPI(name [8]byte, minVersion uint32, api HostAPI) HostAPISelector {
+	return func(v runtime.Version) HostAPI {
+		if v == nil {
+			return nil
+		}
+
+		for _, item := range v.APIItems() {
+			if item.Name == name && item.Ver >= minVersion {
+				return api
+			}
+		}
+		return nil
+	}
+}
+
+// DefaultHostAPI is the HostAPISelector used by the node. It selects HostAPIv2 for runtimes declaring version 3
+// or later of the Core API, and HostAPIv1 for older runtimes.
+func DefaultHostAPI(v runtime.Version) HostAPI {
+	if v == nil {
+		return nil
+	}
+
+	if api := SelectHostAPI(CoreAPIName, 3, HostAPIv2)(v); api != nil {
+		return api
+	}
+	return HostAPIv1
+}
+
+// includes returns true if the host function with the given name is registered as part of the host API set
+func (api HostAPI) includes(name string) bool {
+	if api == nil {
+		return true
+	}
+
+	i := strings.LastIndex(name, hostFunctionVersionSep)
+	if i < 0 {
+		return true
+	}
+
+	version, err := strconv.ParseUint(name[i+len(hostFunctionVersionSep):], 10, 32)
+	if err != nil {
+		return true
+	}
+
+	selected, ok := api[name[:i]]
+	if !ok {
+		selected = 1
+	}
+	return uint32(version) == selected
+}
+
+// hostImports wraps the wasmer imports, only registering the host functions included in a host API set
+type hostImports struct {
+	*wasm.Imports
+	api   HostAPI
+	names map[string]struct{}
+}
+
+func newHostImports(api HostAPI) *hostImports {
+	return &hostImports{
+		Imports: wasm.NewImports(),
+		api:     api,
+		names:   make(map[string]struct{}),
+	}
+}
+
+// Append registers the given host function if it's included in the host API set
+func (imports *hostImports) Append(name string, implementation interface{},
+	cgoPointer unsafe.Pointer) (*wasm.Imports, error) {
+	if !imports.api.includes(name) {
+		return imports.Imports, nil
+	}
+
+	imports.names[name] = struct{}{}
+	return imports.Imports.Append(name, implementation, cgoPointer)
+}
+
+// checkHostImports returns an error naming the first host function imported by the given code that isn't
+// registered as part of the given host API set
+func checkHostImports(code []byte, api HostAPI) error {
+	required, err := functionImports(code)
+	if err != nil {
+		return err
+	}
+
+	imports, err := importsNodeRuntime(api)
+	if err != nil {
+		return err
+	}
+	defer imports.Close()
+
+	for _, name := range required {
+		if _, ok := imports.names[name]; !ok {
+			return fmt.Errorf("%w: %s", runtime.ErrMissingHostFunction, name)
+		}
+	}
+
+	return nil
+}
+
+// functionImports returns the names of the functions the given code imports from the host
+func functionImports(code []byte) ([]string, error) {
+	sections, err := readSections(code)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, s := range sections {
+		if s.id != sectionImport {
+			continue
+		}
+
+		err = readImports(s.payload, func(module, field string, kind byte) {
+			if module == "env" && kind == 0 {
+				names = append(names, field)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
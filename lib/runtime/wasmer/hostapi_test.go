@@ -0,0 +1,155 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVerifyV1 = "ext_crypto_sr25519_verify_version_1"
+	testVerifyV2 = "ext_crypto_sr25519_verify_version_2"
+)
+
+// newHostAPITestModule returns a module importing the given sr25519 verify host function or functions
+func newHostAPITestModule(hostFuncs ...string) []byte {
+	types := []byte{2, typeFunc, 2, 0x7f, 0x7f, 1, typeI64, typeFunc, 3, 0x7f, typeI64, 0x7f, 1, 0x7f}
+	hostTypes := make([]byte, len(hostFuncs))
+	for i := range hostTypes {
+		hostTypes[i] = 1
+	}
+
+	body := []byte{0, opI64Const, 0, opEnd}
+	return newHostFuncTestModule(types, hostFuncs, hostTypes, body)
+}
+
+func newHostAPITestConfig(t *testing.T, api HostAPI) *Config {
+	s, err := storage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Imports: ImportsNodeRuntime,
+		HostAPI: func(runtime.Version) HostAPI {
+			return api
+		},
+	}
+	cfg.Storage = s
+	cfg.LogLvl = -1
+	return cfg
+}
+
+func TestHostAPI_Imports(t *testing.T) {
+	testCases := []struct {
+		api    HostAPI
+		bound  string
+		absent string
+	}{
+		{api: HostAPIv1, bound: testVerifyV1, absent: testVerifyV2},
+		{api: HostAPIv2, bound: testVerifyV2, absent: testVerifyV1},
+	}
+
+	for _, test := range testCases {
+		imports, err := importsNodeRuntime(test.api)
+		require.NoError(t, err)
+
+		require.Contains(t, imports.names, test.bound)
+		require.Contains(t, imports.names, "ext_storage_get_version_1")
+		require.NotContains(t, imports.names, test.absent)
+		imports.Close()
+	}
+
+	imports, err := importsNodeRuntime(nil)
+	require.NoError(t, err)
+	defer imports.Close()
+	require.Contains(t, imports.names, testVerifyV1)
+	require.Contains(t, imports.names, testVerifyV2)
+}
+
+func TestNewInstance_HostAPI(t *testing.T) {
+	instance, err := NewInstance(newHostAPITestModule(testVerifyV1), newHostAPITestConfig(t, HostAPIv1))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec("run", []byte{})
+	require.NoError(t, err)
+}
+
+func TestNewInstance_HostAPI_MissingHostFunction(t *testing.T) {
+	testCases := []struct {
+		api     HostAPI
+		imports []string
+		missing string
+	}{
+		{api: HostAPIv1, imports: []string{testVerifyV2}, missing: testVerifyV2},
+		{api: HostAPIv2, imports: []string{testVerifyV1}, missing: testVerifyV1},
+		{api: nil, imports: []string{testVerifyV1, "ext_unknown_version_1"}, missing: "ext_unknown_version_1"},
+	}
+
+	for _, test := range testCases {
+		_, err := NewInstance(newHostAPITestModule(test.imports...), newHostAPITestConfig(t, test.api))
+		require.True(t, errors.Is(err, runtime.ErrMissingHostFunction), err)
+		require.Contains(t, err.Error(), test.missing)
+	}
+}
+
+func TestSelectHostAPI(t *testing.T) {
+	selector := SelectHostAPI(CoreAPIName, 3, HostAPIv2)
+
+	newVersion := func(core uint32) runtime.Version {
+		items := []*runtime.APIItem{{Name: CoreAPIName, Ver: core}}
+		return runtime.NewVersionData([]byte("node"), []byte("node"), 0, 1, 0, items, 1)
+	}
+
+	require.Equal(t, HostAPIv2, selector(newVersion(3)))
+	require.Equal(t, HostAPIv2, selector(newVersion(4)))
+	require.Nil(t, selector(newVersion(2)))
+	require.Nil(t, selector(nil))
+}
+
+func TestDefaultHostAPI(t *testing.T) {
+	newVersion := func(core uint32) runtime.Version {
+		items := []*runtime.APIItem{{Name: CoreAPIName, Ver: core}}
+		return runtime.NewVersionData([]byte("node"), []byte("node"), 0, 1, 0, items, 1)
+	}
+
+	require.Equal(t, HostAPIv2, DefaultHostAPI(newVersion(3)))
+	require.Equal(t, HostAPIv1, DefaultHostAPI(newVersion(2)))
+	require.Nil(t, DefaultHostAPI(nil))
+}
+
+func TestInstance_UpdateRuntimeCode_InvalidCode(t *testing.T) {
+	instance, err := NewInstance(newHostAPITestModule(testVerifyV1), newHostAPITestConfig(t, HostAPIv1))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	// code importing an unknown host function, and code without a runtime version, are rejected
+	err = instance.UpdateRuntimeCode(newHostAPITestModule(testVerifyV1, "ext_unknown_version_1"))
+	require.True(t, errors.Is(err, runtime.ErrMissingHostFunction), err)
+
+	err = instance.UpdateRuntimeCode(newHostAPITestModule(testVerifyV1))
+	require.Error(t, err)
+
+	// the instance keeps running the current code
+	_, err = instance.Exec("run", []byte{})
+	require.NoError(t, err)
+}
@@ -19,6 +19,7 @@ package wasmer
 // #include <stdlib.h>
 //
 // extern void ext_logging_log_version_1(void *context, int32_t level, int64_t target, int64_t msg);
+// extern int32_t ext_logging_max_level_version_1(void *context);
 //
 // extern void ext_sandbox_instance_teardown_version_1(void *context, int32_t a);
 // extern int32_t ext_sandbox_instantiate_version_1(void *context, int32_t a, int64_t b, int64_t c, int32_t d);
@@ -103,6 +104,8 @@ import (
 	"math/big"
 	"math/rand"
 	"reflect"
+	"sync/atomic"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -125,36 +128,51 @@ import (
 //export ext_logging_log_version_1
 func ext_logging_log_version_1(context unsafe.Pointer, level C.int32_t, targetData C.int64_t, msgData C.int64_t) {
 	logger.Trace("[ext_logging_log_version_1] executing...")
+	defer traceHostCall(context, "ext_logging_log_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	target := string(asMemorySlice(instanceContext, targetData))
 	msg := string(asMemorySlice(instanceContext, msgData))
+	rtLogger := logger.New("target", target)
 
+	// levels of the runtime's LogLevel enum
 	switch int(level) {
 	case 0:
-		logger.Crit("[ext_logging_log_version_1]", "target", target, "message", msg)
+		rtLogger.Error(msg)
 	case 1:
-		logger.Warn("[ext_logging_log_version_1]", "target", target, "message", msg)
+		rtLogger.Warn(msg)
 	case 2:
-		logger.Info("[ext_logging_log_version_1]", "target", target, "message", msg)
+		rtLogger.Info(msg)
 	case 3:
-		logger.Debug("[ext_logging_log_version_1]", "target", target, "message", msg)
+		rtLogger.Debug(msg)
 	case 4:
-		logger.Trace("[ext_logging_log_version_1]", "target", target, "message", msg)
+		rtLogger.Trace(msg)
 	default:
-		logger.Error("[ext_logging_log_version_1]", "level", int(level), "target", target, "message", msg)
+		rtLogger.Error(msg, "level", int(level))
 	}
 }
 
+//export ext_logging_max_level_version_1
+func ext_logging_max_level_version_1(context unsafe.Pointer) C.int32_t {
+	logger.Trace("[ext_logging_max_level_version_1] executing...")
+	defer traceHostCall(context, "ext_logging_max_level_version_1")()
+
+	// the runtime's LogLevelFilter enum is Off, Error, Warn, Info, Debug, Trace, which matches the order of the log15
+	// levels, with LvlCrit mapping to Off
+	return C.int32_t(atomic.LoadInt32(&maxLogLevel))
+}
+
 //export ext_sandbox_instance_teardown_version_1
 func ext_sandbox_instance_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
 	logger.Trace("[ext_sandbox_instance_teardown_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_instance_teardown_version_1")()
 	logger.Warn("[ext_sandbox_instance_teardown_version_1] unimplemented")
 }
 
 //export ext_sandbox_instantiate_version_1
 func ext_sandbox_instantiate_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z C.int32_t) C.int32_t {
 	logger.Trace("[ext_sandbox_instantiate_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_instantiate_version_1")()
 	logger.Warn("[ext_sandbox_instantiate_version_1] unimplemented")
 	return 0
 }
@@ -162,6 +180,7 @@ func ext_sandbox_instantiate_version_1(context unsafe.Pointer, a C.int32_t, x, y
 //export ext_sandbox_invoke_version_1
 func ext_sandbox_invoke_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z, d, e C.int32_t) C.int32_t {
 	logger.Trace("[ext_sandbox_invoke_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_invoke_version_1")()
 	logger.Warn("[ext_sandbox_invoke_version_1] unimplemented")
 	return 0
 }
@@ -169,6 +188,7 @@ func ext_sandbox_invoke_version_1(context unsafe.Pointer, a C.int32_t, x, y C.in
 //export ext_sandbox_memory_get_version_1
 func ext_sandbox_memory_get_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
 	logger.Trace("[ext_sandbox_memory_get_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_memory_get_version_1")()
 	logger.Warn("[ext_sandbox_memory_get_version_1] unimplemented")
 	return 0
 }
@@ -176,6 +196,7 @@ func ext_sandbox_memory_get_version_1(context unsafe.Pointer, a, z, d, e C.int32
 //export ext_sandbox_memory_new_version_1
 func ext_sandbox_memory_new_version_1(context unsafe.Pointer, a, z C.int32_t) C.int32_t {
 	logger.Trace("[ext_sandbox_memory_new_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_memory_new_version_1")()
 	logger.Warn("[ext_sandbox_memory_new_version_1] unimplemented")
 	return 0
 }
@@ -183,6 +204,7 @@ func ext_sandbox_memory_new_version_1(context unsafe.Pointer, a, z C.int32_t) C.
 //export ext_sandbox_memory_set_version_1
 func ext_sandbox_memory_set_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
 	logger.Trace("[ext_sandbox_memory_set_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_memory_set_version_1")()
 	logger.Warn("[ext_sandbox_memory_set_version_1] unimplemented")
 	return 0
 }
@@ -190,12 +212,14 @@ func ext_sandbox_memory_set_version_1(context unsafe.Pointer, a, z, d, e C.int32
 //export ext_sandbox_memory_teardown_version_1
 func ext_sandbox_memory_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
 	logger.Trace("[ext_sandbox_memory_teardown_version_1] executing...")
+	defer traceHostCall(context, "ext_sandbox_memory_teardown_version_1")()
 	logger.Warn("[ext_sandbox_memory_teardown_version_1] unimplemented")
 }
 
 //export ext_crypto_ed25519_generate_version_1
 func ext_crypto_ed25519_generate_version_1(context unsafe.Pointer, keyTypeID C.int32_t, seedSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_crypto_ed25519_generate_version_1] executing...")
+	defer traceHostCall(context, "ext_crypto_ed25519_generate_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
@@ -231,7 +255,11 @@ func ext_crypto_ed25519_generate_version_1(context unsafe.Pointer, keyTypeID C.i
 		return 0
 	}
 
-	ks.Insert(kp)
+	err = ks.Insert(kp)
+	if err != nil {
+		logger.Warn("[ext_crypto_ed25519_generate_version_1] failed to insert key", "name", id, "error", err)
+		return 0
+	}
 
 	ret, err := toWasmMemorySized(instanceContext, kp.Public().Encode(), 32)
 	if err != nil {
@@ -245,6 +273,7 @@ func ext_crypto_ed25519_generate_version_1(context unsafe.Pointer, keyTypeID C.i
 
 //export ext_crypto_ed25519_public_keys_version_1
 func ext_crypto_ed25519_public_keys_version_1(context unsafe.Pointer, keyTypeID C.int32_t) C.int64_t {
+	defer traceHostCall(context, "ext_crypto_ed25519_public_keys_version_1")()
 	logger.Debug("[ext_crypto_ed25519_public_keys_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -292,6 +321,7 @@ func ext_crypto_ed25519_public_keys_version_1(context unsafe.Pointer, keyTypeID
 
 //export ext_crypto_ed25519_sign_version_1
 func ext_crypto_ed25519_sign_version_1(context unsafe.Pointer, keyTypeID C.int32_t, key C.int32_t, msg C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_crypto_ed25519_sign_version_1")()
 	logger.Debug("[ext_crypto_ed25519_sign_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -342,6 +372,7 @@ func ext_crypto_ed25519_sign_version_1(context unsafe.Pointer, keyTypeID C.int32
 
 //export ext_crypto_ed25519_verify_version_1
 func ext_crypto_ed25519_verify_version_1(context unsafe.Pointer, sig C.int32_t, msg C.int64_t, key C.int32_t) C.int32_t {
+	defer traceHostCall(context, "ext_crypto_ed25519_verify_version_1")()
 	logger.Debug("[ext_crypto_ed25519_verify_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -381,6 +412,7 @@ func ext_crypto_ed25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 //export ext_crypto_secp256k1_ecdsa_recover_version_1
 func ext_crypto_secp256k1_ecdsa_recover_version_1(context unsafe.Pointer, sig, msg C.int32_t) C.int64_t {
 	logger.Trace("[ext_crypto_secp256k1_ecdsa_recover_version_1] executing...")
+	defer traceHostCall(context, "ext_crypto_secp256k1_ecdsa_recover_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	memory := instanceContext.Memory().Data()
 
@@ -424,6 +456,7 @@ func ext_crypto_secp256k1_ecdsa_recover_version_1(context unsafe.Pointer, sig, m
 //export ext_crypto_secp256k1_ecdsa_recover_compressed_version_1
 func ext_crypto_secp256k1_ecdsa_recover_compressed_version_1(context unsafe.Pointer, sig, msg C.int32_t) C.int64_t {
 	logger.Trace("[ext_crypto_secp256k1_ecdsa_recover_compressed_version_1] executing...")
+	defer traceHostCall(context, "ext_crypto_secp256k1_ecdsa_recover_compressed_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	memory := instanceContext.Memory().Data()
 
@@ -462,6 +495,7 @@ func ext_crypto_secp256k1_ecdsa_recover_compressed_version_1(context unsafe.Poin
 //export ext_crypto_sr25519_generate_version_1
 func ext_crypto_sr25519_generate_version_1(context unsafe.Pointer, keyTypeID C.int32_t, seedSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_crypto_sr25519_generate_version_1] executing...")
+	defer traceHostCall(context, "ext_crypto_sr25519_generate_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
@@ -497,7 +531,12 @@ func ext_crypto_sr25519_generate_version_1(context unsafe.Pointer, keyTypeID C.i
 		return 0
 	}
 
-	ks.Insert(kp)
+	err = ks.Insert(kp)
+	if err != nil {
+		logger.Warn("[ext_crypto_sr25519_generate_version_1] failed to insert key", "name", id, "error", err)
+		return 0
+	}
+
 	ret, err := toWasmMemorySized(instanceContext, kp.Public().Encode(), 32)
 	if err != nil {
 		logger.Error("[ext_crypto_sr25519_generate_version_1] failed to allocate memory", "error", err)
@@ -510,6 +549,7 @@ func ext_crypto_sr25519_generate_version_1(context unsafe.Pointer, keyTypeID C.i
 
 //export ext_crypto_sr25519_public_keys_version_1
 func ext_crypto_sr25519_public_keys_version_1(context unsafe.Pointer, keyTypeID C.int32_t) C.int64_t {
+	defer traceHostCall(context, "ext_crypto_sr25519_public_keys_version_1")()
 	logger.Debug("[ext_crypto_sr25519_public_keys_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -557,6 +597,7 @@ func ext_crypto_sr25519_public_keys_version_1(context unsafe.Pointer, keyTypeID
 
 //export ext_crypto_sr25519_sign_version_1
 func ext_crypto_sr25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.int32_t, msg C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_crypto_sr25519_sign_version_1")()
 	logger.Debug("[ext_crypto_sr25519_sign_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
@@ -603,6 +644,7 @@ func ext_crypto_sr25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.
 
 //export ext_crypto_sr25519_verify_version_1
 func ext_crypto_sr25519_verify_version_1(context unsafe.Pointer, sig C.int32_t, msg C.int64_t, key C.int32_t) C.int32_t {
+	defer traceHostCall(context, "ext_crypto_sr25519_verify_version_1")()
 	logger.Debug("[ext_crypto_sr25519_verify_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -647,6 +689,7 @@ func ext_crypto_sr25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 //export ext_crypto_sr25519_verify_version_2
 func ext_crypto_sr25519_verify_version_2(context unsafe.Pointer, sig C.int32_t, msg C.int64_t, key C.int32_t) C.int32_t {
 	logger.Trace("[ext_crypto_sr25519_verify_version_2] executing...")
+	defer traceHostCall(context, "ext_crypto_sr25519_verify_version_2")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	memory := instanceContext.Memory().Data()
@@ -688,6 +731,7 @@ func ext_crypto_sr25519_verify_version_2(context unsafe.Pointer, sig C.int32_t,
 
 //export ext_crypto_start_batch_verify_version_1
 func ext_crypto_start_batch_verify_version_1(context unsafe.Pointer) {
+	defer traceHostCall(context, "ext_crypto_start_batch_verify_version_1")()
 	logger.Debug("[ext_crypto_start_batch_verify_version_1] executing...")
 
 	// TODO: fix and re-enable signature verification
@@ -708,6 +752,7 @@ func beginBatchVerify(context unsafe.Pointer) { //nolint
 
 //export ext_crypto_finish_batch_verify_version_1
 func ext_crypto_finish_batch_verify_version_1(context unsafe.Pointer) C.int32_t {
+	defer traceHostCall(context, "ext_crypto_finish_batch_verify_version_1")()
 	logger.Debug("[ext_crypto_finish_batch_verify_version_1] executing...")
 
 	// TODO: fix and re-enable signature verification
@@ -733,6 +778,7 @@ func finishBatchVerify(context unsafe.Pointer) C.int32_t { //nolint
 
 //export ext_trie_blake2_256_root_version_1
 func ext_trie_blake2_256_root_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
+	defer traceHostCall(context, "ext_trie_blake2_256_root_version_1")()
 	logger.Debug("[ext_trie_blake2_256_root_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -782,6 +828,7 @@ func ext_trie_blake2_256_root_version_1(context unsafe.Pointer, dataSpan C.int64
 
 //export ext_trie_blake2_256_ordered_root_version_1
 func ext_trie_blake2_256_ordered_root_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
+	defer traceHostCall(context, "ext_trie_blake2_256_ordered_root_version_1")()
 	logger.Debug("[ext_trie_blake2_256_ordered_root_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -830,6 +877,7 @@ func ext_trie_blake2_256_ordered_root_version_1(context unsafe.Pointer, dataSpan
 //export ext_misc_print_hex_version_1
 func ext_misc_print_hex_version_1(context unsafe.Pointer, dataSpan C.int64_t) {
 	logger.Trace("[ext_misc_print_hex_version_1] executing...")
+	defer traceHostCall(context, "ext_misc_print_hex_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -839,22 +887,30 @@ func ext_misc_print_hex_version_1(context unsafe.Pointer, dataSpan C.int64_t) {
 //export ext_misc_print_num_version_1
 func ext_misc_print_num_version_1(context unsafe.Pointer, data C.int64_t) {
 	logger.Trace("[ext_misc_print_num_version_1] executing...")
+	defer traceHostCall(context, "ext_misc_print_num_version_1")()
 
-	logger.Debug("[ext_misc_print_num_version_1]", "num", fmt.Sprintf("%d", int64(data)))
+	logger.Debug("[ext_misc_print_num_version_1]", "num", fmt.Sprintf("%d", uint64(data)))
 }
 
 //export ext_misc_print_utf8_version_1
 func ext_misc_print_utf8_version_1(context unsafe.Pointer, dataSpan C.int64_t) {
 	logger.Trace("[ext_misc_print_utf8_version_1] executing...")
+	defer traceHostCall(context, "ext_misc_print_utf8_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	data := asMemorySlice(instanceContext, dataSpan)
+	if !utf8.Valid(data) {
+		logger.Debug("[ext_misc_print_utf8_version_1] invalid utf8", "hex", fmt.Sprintf("0x%x", data))
+		return
+	}
+
 	logger.Debug("[ext_misc_print_utf8_version_1]", "utf8", string(data))
 }
 
 //export ext_misc_runtime_version_version_1
 func ext_misc_runtime_version_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int64_t {
 	logger.Trace("[ext_misc_runtime_version_version_1] executing...")
+	defer traceHostCall(context, "ext_misc_runtime_version_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -898,6 +954,7 @@ func ext_misc_runtime_version_version_1(context unsafe.Pointer, dataSpan C.int64
 
 //export ext_default_child_storage_read_version_1
 func ext_default_child_storage_read_version_1(context unsafe.Pointer, childStorageKey C.int64_t, key C.int64_t, valueOut C.int64_t, offset C.int32_t) C.int64_t {
+	defer traceHostCall(context, "ext_default_child_storage_read_version_1")()
 	logger.Debug("[ext_default_child_storage_read_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -928,6 +985,7 @@ func ext_default_child_storage_read_version_1(context unsafe.Pointer, childStora
 
 //export ext_default_child_storage_clear_version_1
 func ext_default_child_storage_clear_version_1(context unsafe.Pointer, childStorageKey, keySpan C.int64_t) {
+	defer traceHostCall(context, "ext_default_child_storage_clear_version_1")()
 	logger.Debug("[ext_default_child_storage_clear_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -945,6 +1003,7 @@ func ext_default_child_storage_clear_version_1(context unsafe.Pointer, childStor
 
 //export ext_default_child_storage_clear_prefix_version_1
 func ext_default_child_storage_clear_prefix_version_1(context unsafe.Pointer, childStorageKey C.int64_t, prefixSpan C.int64_t) {
+	defer traceHostCall(context, "ext_default_child_storage_clear_prefix_version_1")()
 	logger.Debug("[ext_default_child_storage_clear_prefix_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -962,6 +1021,7 @@ func ext_default_child_storage_clear_prefix_version_1(context unsafe.Pointer, ch
 
 //export ext_default_child_storage_exists_version_1
 func ext_default_child_storage_exists_version_1(context unsafe.Pointer, childStorageKey C.int64_t, key C.int64_t) C.int32_t {
+	defer traceHostCall(context, "ext_default_child_storage_exists_version_1")()
 	logger.Debug("[ext_default_child_storage_exists_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -980,6 +1040,7 @@ func ext_default_child_storage_exists_version_1(context unsafe.Pointer, childSto
 
 //export ext_default_child_storage_get_version_1
 func ext_default_child_storage_get_version_1(context unsafe.Pointer, childStorageKey, key C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_default_child_storage_get_version_1")()
 	logger.Debug("[ext_default_child_storage_get_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1002,6 +1063,7 @@ func ext_default_child_storage_get_version_1(context unsafe.Pointer, childStorag
 
 //export ext_default_child_storage_next_key_version_1
 func ext_default_child_storage_next_key_version_1(context unsafe.Pointer, childStorageKey C.int64_t, key C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_default_child_storage_next_key_version_1")()
 	logger.Debug("[ext_default_child_storage_next_key_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1024,6 +1086,7 @@ func ext_default_child_storage_next_key_version_1(context unsafe.Pointer, childS
 
 //export ext_default_child_storage_root_version_1
 func ext_default_child_storage_root_version_1(context unsafe.Pointer, childStorageKey C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_default_child_storage_root_version_1")()
 	logger.Debug("[ext_default_child_storage_root_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1052,6 +1115,7 @@ func ext_default_child_storage_root_version_1(context unsafe.Pointer, childStora
 
 //export ext_default_child_storage_set_version_1
 func ext_default_child_storage_set_version_1(context unsafe.Pointer, childStorageKeySpan, keySpan, valueSpan C.int64_t) {
+	defer traceHostCall(context, "ext_default_child_storage_set_version_1")()
 	logger.Debug("[ext_default_child_storage_set_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1074,6 +1138,7 @@ func ext_default_child_storage_set_version_1(context unsafe.Pointer, childStorag
 
 //export ext_default_child_storage_storage_kill_version_1
 func ext_default_child_storage_storage_kill_version_1(context unsafe.Pointer, childStorageKeySpan C.int64_t) {
+	defer traceHostCall(context, "ext_default_child_storage_storage_kill_version_1")()
 	logger.Debug("[ext_default_child_storage_storage_kill_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1087,6 +1152,7 @@ func ext_default_child_storage_storage_kill_version_1(context unsafe.Pointer, ch
 //export ext_allocator_free_version_1
 func ext_allocator_free_version_1(context unsafe.Pointer, addr C.int32_t) {
 	logger.Trace("[ext_allocator_free_version_1] executing...")
+	defer traceHostCall(context, "ext_allocator_free_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
 
@@ -1100,13 +1166,18 @@ func ext_allocator_free_version_1(context unsafe.Pointer, addr C.int32_t) {
 //export ext_allocator_malloc_version_1
 func ext_allocator_malloc_version_1(context unsafe.Pointer, size C.int32_t) C.int32_t {
 	logger.Trace("[ext_allocator_malloc_version_1] executing...", "size", size)
+	defer traceHostCall(context, "ext_allocator_malloc_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	ctx := instanceContext.Data().(*runtime.Context)
 
 	// Allocate memory
 	res, err := ctx.Allocator.Allocate(uint32(size))
-	if err != nil {
+	if errors.Is(err, runtime.ErrMemoryLimitExceeded) {
+		// panicking would crash the node, the error is returned by the runtime call instead
+		logger.Error("[ext_allocator_malloc_version_1] failed to allocate memory", "error", err)
+		return 0
+	} else if err != nil {
 		logger.Crit("[ext_allocator_malloc_version_1] failed to allocate memory", "error", err)
 		panic(err)
 	}
@@ -1117,6 +1188,7 @@ func ext_allocator_malloc_version_1(context unsafe.Pointer, size C.int32_t) C.in
 //export ext_hashing_blake2_128_version_1
 func ext_hashing_blake2_128_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_blake2_128_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_blake2_128_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1141,6 +1213,7 @@ func ext_hashing_blake2_128_version_1(context unsafe.Pointer, dataSpan C.int64_t
 //export ext_hashing_blake2_256_version_1
 func ext_hashing_blake2_256_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_blake2_256_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_blake2_256_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1165,6 +1238,7 @@ func ext_hashing_blake2_256_version_1(context unsafe.Pointer, dataSpan C.int64_t
 //export ext_hashing_keccak_256_version_1
 func ext_hashing_keccak_256_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_keccak_256_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_keccak_256_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1189,6 +1263,7 @@ func ext_hashing_keccak_256_version_1(context unsafe.Pointer, dataSpan C.int64_t
 //export ext_hashing_sha2_256_version_1
 func ext_hashing_sha2_256_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_sha2_256_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_sha2_256_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1208,6 +1283,7 @@ func ext_hashing_sha2_256_version_1(context unsafe.Pointer, dataSpan C.int64_t)
 //export ext_hashing_twox_256_version_1
 func ext_hashing_twox_256_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_twox_256_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_twox_256_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1232,6 +1308,7 @@ func ext_hashing_twox_256_version_1(context unsafe.Pointer, dataSpan C.int64_t)
 //export ext_hashing_twox_128_version_1
 func ext_hashing_twox_128_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_twox_128_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_twox_128_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	data := asMemorySlice(instanceContext, dataSpan)
 
@@ -1255,6 +1332,7 @@ func ext_hashing_twox_128_version_1(context unsafe.Pointer, dataSpan C.int64_t)
 //export ext_hashing_twox_64_version_1
 func ext_hashing_twox_64_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_hashing_twox_64_version_1] executing...")
+	defer traceHostCall(context, "ext_hashing_twox_64_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 
 	data := asMemorySlice(instanceContext, dataSpan)
@@ -1279,11 +1357,13 @@ func ext_hashing_twox_64_version_1(context unsafe.Pointer, dataSpan C.int64_t) C
 //export ext_offchain_index_set_version_1
 func ext_offchain_index_set_version_1(context unsafe.Pointer, keySpan, valueSpan C.int64_t) {
 	logger.Trace("[ext_offchain_index_set_version_1] executing...")
+	defer traceHostCall(context, "ext_offchain_index_set_version_1")()
 	logger.Warn("[ext_offchain_index_set_version_1] unimplemented")
 }
 
 //export ext_offchain_is_validator_version_1
 func ext_offchain_is_validator_version_1(context unsafe.Pointer) C.int32_t {
+	defer traceHostCall(context, "ext_offchain_is_validator_version_1")()
 	logger.Debug("[ext_offchain_is_validator_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 
@@ -1296,6 +1376,7 @@ func ext_offchain_is_validator_version_1(context unsafe.Pointer) C.int32_t {
 
 //export ext_offchain_local_storage_compare_and_set_version_1
 func ext_offchain_local_storage_compare_and_set_version_1(context unsafe.Pointer, kind C.int32_t, key, oldValue, newValue C.int64_t) C.int32_t {
+	defer traceHostCall(context, "ext_offchain_local_storage_compare_and_set_version_1")()
 	logger.Debug("[ext_offchain_local_storage_compare_and_set_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1335,6 +1416,7 @@ func ext_offchain_local_storage_compare_and_set_version_1(context unsafe.Pointer
 
 //export ext_offchain_local_storage_get_version_1
 func ext_offchain_local_storage_get_version_1(context unsafe.Pointer, kind C.int32_t, key C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_offchain_local_storage_get_version_1")()
 	logger.Debug("[ext_offchain_local_storage_get_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1365,6 +1447,7 @@ func ext_offchain_local_storage_get_version_1(context unsafe.Pointer, kind C.int
 
 //export ext_offchain_local_storage_set_version_1
 func ext_offchain_local_storage_set_version_1(context unsafe.Pointer, kind C.int32_t, key, value C.int64_t) {
+	defer traceHostCall(context, "ext_offchain_local_storage_set_version_1")()
 	logger.Debug("[ext_offchain_local_storage_set_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1389,6 +1472,7 @@ func ext_offchain_local_storage_set_version_1(context unsafe.Pointer, kind C.int
 
 //export ext_offchain_network_state_version_1
 func ext_offchain_network_state_version_1(context unsafe.Pointer) C.int64_t {
+	defer traceHostCall(context, "ext_offchain_network_state_version_1")()
 	logger.Debug("[ext_offchain_network_state_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
@@ -1419,6 +1503,7 @@ func ext_offchain_network_state_version_1(context unsafe.Pointer) C.int64_t {
 
 //export ext_offchain_random_seed_version_1
 func ext_offchain_random_seed_version_1(context unsafe.Pointer) C.int32_t {
+	defer traceHostCall(context, "ext_offchain_random_seed_version_1")()
 	logger.Debug("[ext_offchain_random_seed_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 
@@ -1436,6 +1521,7 @@ func ext_offchain_random_seed_version_1(context unsafe.Pointer) C.int32_t {
 
 //export ext_offchain_submit_transaction_version_1
 func ext_offchain_submit_transaction_version_1(context unsafe.Pointer, data C.int64_t) C.int64_t {
+	defer traceHostCall(context, "ext_offchain_submit_transaction_version_1")()
 	logger.Debug("[ext_offchain_submit_transaction_version_1] executing...")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1507,6 +1593,7 @@ func storageAppend(storage runtime.Storage, key, valueToAppend []byte) error {
 //export ext_storage_append_version_1
 func ext_storage_append_version_1(context unsafe.Pointer, keySpan, valueSpan C.int64_t) {
 	logger.Trace("[ext_storage_append_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_append_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	ctx := instanceContext.Data().(*runtime.Context)
 	storage := ctx.Storage
@@ -1527,6 +1614,7 @@ func ext_storage_append_version_1(context unsafe.Pointer, keySpan, valueSpan C.i
 //export ext_storage_changes_root_version_1
 func ext_storage_changes_root_version_1(context unsafe.Pointer, parentHashSpan C.int64_t) C.int64_t {
 	logger.Trace("[ext_storage_changes_root_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_changes_root_version_1")()
 	logger.Debug("[ext_storage_changes_root_version_1] returning None")
 
 	instanceContext := wasm.IntoInstanceContext(context)
@@ -1543,6 +1631,7 @@ func ext_storage_changes_root_version_1(context unsafe.Pointer, parentHashSpan C
 //export ext_storage_clear_version_1
 func ext_storage_clear_version_1(context unsafe.Pointer, keySpan C.int64_t) {
 	logger.Trace("[ext_storage_clear_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_clear_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	ctx := instanceContext.Data().(*runtime.Context)
 	storage := ctx.Storage
@@ -1556,6 +1645,7 @@ func ext_storage_clear_version_1(context unsafe.Pointer, keySpan C.int64_t) {
 //export ext_storage_clear_prefix_version_1
 func ext_storage_clear_prefix_version_1(context unsafe.Pointer, prefixSpan C.int64_t) {
 	logger.Trace("[ext_storage_clear_prefix_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_clear_prefix_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	ctx := instanceContext.Data().(*runtime.Context)
 	storage := ctx.Storage
@@ -1572,6 +1662,7 @@ func ext_storage_clear_prefix_version_1(context unsafe.Pointer, prefixSpan C.int
 //export ext_storage_exists_version_1
 func ext_storage_exists_version_1(context unsafe.Pointer, keySpan C.int64_t) C.int32_t {
 	logger.Trace("[ext_storage_exists_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_exists_version_1")()
 	instanceContext := wasm.IntoInstanceContext(context)
 	storage := instanceContext.Data().(*runtime.Context).Storage
 
@@ -1589,6 +1680,7 @@ func ext_storage_exists_version_1(context unsafe.Pointer, keySpan C.int64_t) C.i
 //export ext_storage_get_version_1
 func ext_storage_get_version_1(context unsafe.Pointer, keySpan C.int64_t) C.int64_t {
 	logger.Trace("[ext_storage_get_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_get_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	storage := instanceContext.Data().(*runtime.Context).Storage
@@ -1612,6 +1704,7 @@ func ext_storage_get_version_1(context unsafe.Pointer, keySpan C.int64_t) C.int6
 //export ext_storage_next_key_version_1
 func ext_storage_next_key_version_1(context unsafe.Pointer, keySpan C.int64_t) C.int64_t {
 	logger.Trace("[ext_storage_next_key_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_next_key_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	storage := instanceContext.Data().(*runtime.Context).Storage
@@ -1633,6 +1726,7 @@ func ext_storage_next_key_version_1(context unsafe.Pointer, keySpan C.int64_t) C
 //export ext_storage_read_version_1
 func ext_storage_read_version_1(context unsafe.Pointer, keySpan, valueOut C.int64_t, offset C.int32_t) C.int64_t {
 	logger.Trace("[ext_storage_read_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_read_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	storage := instanceContext.Data().(*runtime.Context).Storage
@@ -1669,6 +1763,7 @@ func ext_storage_read_version_1(context unsafe.Pointer, keySpan, valueOut C.int6
 //export ext_storage_root_version_1
 func ext_storage_root_version_1(context unsafe.Pointer) C.int64_t {
 	logger.Trace("[ext_storage_root_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_root_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	storage := instanceContext.Data().(*runtime.Context).Storage
@@ -1693,6 +1788,7 @@ func ext_storage_root_version_1(context unsafe.Pointer) C.int64_t {
 //export ext_storage_set_version_1
 func ext_storage_set_version_1(context unsafe.Pointer, keySpan C.int64_t, valueSpan C.int64_t) {
 	logger.Trace("[ext_storage_set_version_1] executing...")
+	defer traceHostCall(context, "ext_storage_set_version_1")()
 
 	instanceContext := wasm.IntoInstanceContext(context)
 	ctx := instanceContext.Data().(*runtime.Context)
@@ -1710,6 +1806,7 @@ func ext_storage_set_version_1(context unsafe.Pointer, keySpan C.int64_t, valueS
 
 //export ext_storage_start_transaction_version_1
 func ext_storage_start_transaction_version_1(context unsafe.Pointer) {
+	defer traceHostCall(context, "ext_storage_start_transaction_version_1")()
 	logger.Debug("[ext_storage_start_transaction_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 	instanceContext.Data().(*runtime.Context).Storage.BeginStorageTransaction()
@@ -1717,6 +1814,7 @@ func ext_storage_start_transaction_version_1(context unsafe.Pointer) {
 
 //export ext_storage_rollback_transaction_version_1
 func ext_storage_rollback_transaction_version_1(context unsafe.Pointer) {
+	defer traceHostCall(context, "ext_storage_rollback_transaction_version_1")()
 	logger.Debug("[ext_storage_rollback_transaction_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 	instanceContext.Data().(*runtime.Context).Storage.RollbackStorageTransaction()
@@ -1724,6 +1822,7 @@ func ext_storage_rollback_transaction_version_1(context unsafe.Pointer) {
 
 //export ext_storage_commit_transaction_version_1
 func ext_storage_commit_transaction_version_1(context unsafe.Pointer) {
+	defer traceHostCall(context, "ext_storage_commit_transaction_version_1")()
 	logger.Debug("[ext_storage_commit_transaction_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
 	instanceContext.Data().(*runtime.Context).Storage.CommitStorageTransaction()
@@ -1840,10 +1939,18 @@ func toWasmMemoryFixedSizeOptional(context wasm.InstanceContext, data []byte) (i
 }
 
 // ImportsNodeRuntime returns the imports for the v0.8 runtime
-func ImportsNodeRuntime() (*wasm.Imports, error) { //nolint
+func ImportsNodeRuntime() (*wasm.Imports, error) {
+	imports, err := importsNodeRuntime(nil)
+	if err != nil {
+		return nil, err
+	}
+	return imports.Imports, nil
+}
+
+func importsNodeRuntime(api HostAPI) (*hostImports, error) { //nolint
 	var err error
 
-	imports := wasm.NewImports()
+	imports := newHostImports(api)
 
 	_, err = imports.Append("ext_allocator_free_version_1", ext_allocator_free_version_1, C.ext_allocator_free_version_1)
 	if err != nil {
@@ -1977,6 +2084,11 @@ func ImportsNodeRuntime() (*wasm.Imports, error) { //nolint
 		return nil, err
 	}
 
+	_, err = imports.Append("ext_logging_max_level_version_1", ext_logging_max_level_version_1, C.ext_logging_max_level_version_1)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = imports.Append("ext_misc_print_hex_version_1", ext_misc_print_hex_version_1, C.ext_misc_print_hex_version_1)
 	if err != nil {
 		return nil, err
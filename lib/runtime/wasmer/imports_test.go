@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -1183,3 +1184,170 @@ func Test_ext_trie_blake2_256_root_version_1(t *testing.T) {
 	expected := tt.MustHash()
 	require.Equal(t, expected[:], hash)
 }
+
+const (
+	testLogTarget  = "gossamer"
+	testLogMessage = "hello from the runtime"
+)
+
+// appendSleb appends the signed LEB128 encoding of v
+func appendSleb(out []byte, v int64) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// appendSpan appends an i64.const instruction pushing the pointer-size of the given data
+func appendSpan(out []byte, ptr int64, data []byte) []byte {
+	return appendSleb(append(out, opI64Const), ptr|int64(len(data))<<32)
+}
+
+type testDataSegment struct {
+	ptr   int64
+	value []byte
+}
+
+// encodeDataSection returns a data section initialising the imported memory with the given segments
+func encodeDataSection(segments ...testDataSegment) []byte {
+	data := appendUleb(nil, uint32(len(segments)))
+	for _, segment := range segments {
+		// active segment of memory 0 at an i32.const offset
+		data = append(data, 0, 0x41)
+		data = appendSleb(data, segment.ptr)
+		data = append(data, opEnd)
+		data = appendUleb(data, uint32(len(segment.value)))
+		data = append(data, segment.value...)
+	}
+	return encodeSection(11, data)
+}
+
+// newHostFuncTestModule returns a module importing its memory and the given host functions, exporting a single
+// function "run" with the given body. types holds the type section, where type 0 is the type of "run" and
+// the given host functions have the given type indices.
+func newHostFuncTestModule(types []byte, hostFuncs []string, hostTypes []byte, body []byte,
+	segments ...testDataSegment) []byte {
+	imports := appendUleb(nil, uint32(len(hostFuncs)+1))
+	imports = append(appendName(appendName(imports, "env"), "memory"), 0x02, 0x00, 1)
+	for i, name := range hostFuncs {
+		imports = append(appendName(appendName(imports, "env"), name), exportFunc, hostTypes[i])
+	}
+
+	var exports []byte
+	exports = appendUleb(append(appendName(append(exports, 1), "run"), exportFunc), uint32(len(hostFuncs)))
+
+	code := appendUleb([]byte{1}, uint32(len(body)))
+	code = append(code, body...)
+
+	module := append([]byte{}, wasmMagic...)
+	module = append(module, encodeSection(sectionType, types)...)
+	module = append(module, encodeSection(sectionImport, imports)...)
+	module = append(module, encodeSection(sectionFunction, []byte{1, 0})...)
+	module = append(module, encodeSection(sectionExport, exports)...)
+	module = append(module, encodeSection(sectionCode, code)...)
+	return append(module, encodeDataSection(segments...)...)
+}
+
+// runCapturingLogs instantiates the given module with the given log level and calls its "run" function,
+// returning the records logged by the call
+func runCapturingLogs(t *testing.T, module []byte, lvl log.Lvl) []*log.Record {
+	defer func(h log.Handler) {
+		logger.SetHandler(h)
+	}(logger.GetHandler())
+
+	s, err := storage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Imports: ImportsNodeRuntime,
+	}
+	cfg.Storage = s
+	cfg.LogLvl = lvl
+
+	instance, err := NewInstance(module, cfg)
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	var records []*log.Record
+	logger.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+
+	_, err = instance.Exec("run", []byte{})
+	require.NoError(t, err)
+	return records
+}
+
+func Test_ext_logging_log_version_1(t *testing.T) {
+	target := testDataSegment{ptr: 16, value: []byte(testLogTarget)}
+	msg := testDataSegment{ptr: 32, value: []byte(testLogMessage)}
+
+	// if max_level() >= 4 { log(3, target, message) }, i64.const 0
+	body := []byte{0, 0x10, 0, 0x41, 4, 0x4f, opIf, blockTypeEmpty, 0x41, 3}
+	body = appendSpan(body, target.ptr, target.value)
+	body = appendSpan(body, msg.ptr, msg.value)
+	body = append(body, 0x10, 1, opEnd, opI64Const, 0, opEnd)
+
+	types := []byte{3, typeFunc, 2, 0x7f, 0x7f, 1, typeI64, typeFunc, 0, 1, 0x7f, typeFunc, 3, 0x7f, typeI64, typeI64, 0}
+	hostFuncs := []string{"ext_logging_max_level_version_1", "ext_logging_log_version_1"}
+	module := newHostFuncTestModule(types, hostFuncs, []byte{1, 2}, body, target, msg)
+
+	testCases := []struct {
+		lvl    log.Lvl
+		logged bool
+	}{
+		{lvl: log.LvlDebug, logged: true},
+		{lvl: log.LvlInfo, logged: false},
+	}
+
+	for _, test := range testCases {
+		var found bool
+		for _, r := range runCapturingLogs(t, module, test.lvl) {
+			if r.Msg != testLogMessage {
+				continue
+			}
+
+			found = true
+			require.Equal(t, log.LvlDebug, r.Lvl)
+			require.Contains(t, r.Ctx, "target")
+			require.Contains(t, r.Ctx, testLogTarget)
+		}
+		require.Equal(t, test.logged, found, test.lvl)
+	}
+}
+
+func Test_ext_misc_print_version_1(t *testing.T) {
+	valid := testDataSegment{ptr: 16, value: []byte("hello")}
+	invalid := testDataSegment{ptr: 32, value: []byte{0xff, 0xfe}}
+
+	// print_utf8(valid), print_utf8(invalid), print_hex(valid), print_num(-1), i64.const 0
+	body := []byte{0}
+	body = append(appendSpan(body, valid.ptr, valid.value), 0x10, 0)
+	body = append(appendSpan(body, invalid.ptr, invalid.value), 0x10, 0)
+	body = append(appendSpan(body, valid.ptr, valid.value), 0x10, 1)
+	body = append(body, opI64Const, 0x7f, 0x10, 2, opI64Const, 0, opEnd)
+
+	types := []byte{2, typeFunc, 2, 0x7f, 0x7f, 1, typeI64, typeFunc, 1, typeI64, 0}
+	hostFuncs := []string{"ext_misc_print_utf8_version_1", "ext_misc_print_hex_version_1", "ext_misc_print_num_version_1"}
+	module := newHostFuncTestModule(types, hostFuncs, []byte{1, 1, 1}, body, valid, invalid)
+
+	var printed [][]interface{}
+	for _, r := range runCapturingLogs(t, module, log.LvlDebug) {
+		if r.Lvl == log.LvlDebug && strings.HasPrefix(r.Msg, "[ext_misc_print_") {
+			printed = append(printed, append([]interface{}{r.Msg}, r.Ctx[4:]...))
+		}
+	}
+
+	expected := [][]interface{}{
+		{"[ext_misc_print_utf8_version_1]", "utf8", "hello"},
+		{"[ext_misc_print_utf8_version_1] invalid utf8", "hex", "0xfffe"},
+		{"[ext_misc_print_hex_version_1]", "hex", "0x68656c6c6f"},
+		{"[ext_misc_print_num_version_1]", "num", "18446744073709551615"},
+	}
+	require.Equal(t, expected, printed)
+}
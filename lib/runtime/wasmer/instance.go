@@ -19,13 +19,16 @@ package wasmer
 import (
 	"errors"
 	"fmt"
-	"os"
+	"math"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/utils"
 
 	log "github.com/ChainSafe/log15"
 	wasm "github.com/wasmerio/go-ext-wasm/wasmer"
@@ -40,21 +43,52 @@ var (
 	_ runtime.Memory   = (*wasm.Memory)(nil)
 
 	logger = log.New("pkg", "runtime", "module", "go-wasmer")
+
+	// maxLogLevel is the level of the most verbose runtime logs that are output, it's returned to the runtime by
+	// ext_logging_max_level_version_1
+	maxLogLevel = int32(log.LvlInfo)
 )
 
+func init() {
+	runtime.RegisterInterpreter(Name, newRegisteredInstance)
+}
+
+// newRegisteredInstance is the wasmer factory registered with the runtime package
+func newRegisteredInstance(code []byte, cfg *runtime.InstanceConfig) (runtime.Instance, error) {
+	rtCfg := &Config{
+		InstanceConfig: *cfg,
+		Imports:        ImportsNodeRuntime,
+		HostAPI:        DefaultHostAPI,
+	}
+
+	in, err := NewInstance(code, rtCfg)
+	if err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
 // Config represents a wasmer configuration
 type Config struct {
 	runtime.InstanceConfig
 	Imports func() (*wasm.Imports, error)
+	// HostAPI selects the host functions the runtime may import from its version. The runtime is instantiated
+	// with every host function to read its version, and fails to instantiate if it imports one that isn't selected.
+	HostAPI HostAPISelector
 }
 
 // Instance represents a v0.8 runtime go-wasmer instance
 type Instance struct {
-	vm      wasm.Instance
-	ctx     *runtime.Context
-	mutex   sync.Mutex
-	version runtime.Version
-	imports func() (*wasm.Imports, error)
+	vm        wasm.Instance
+	ctx       *runtime.Context
+	mutex     sync.Mutex
+	version   runtime.Version
+	imports   func() (*wasm.Imports, error)
+	hostAPI   HostAPISelector
+	fuelLimit int64
+	tracing   bool
+	lastTrace *runtime.CallTrace
+	memory    *limitedMemory
 }
 
 // NewRuntimeFromGenesis creates a runtime instance from the genesis data
@@ -66,6 +100,9 @@ func NewRuntimeFromGenesis(g *genesis.Genesis, cfg *Config) (runtime.Instance, e
 
 	code := common.MustHexToBytes(codeStr)
 	cfg.Imports = ImportsNodeRuntime
+	if cfg.HostAPI == nil {
+		cfg.HostAPI = DefaultHostAPI
+	}
 	return NewInstance(code, cfg)
 }
 
@@ -104,9 +141,31 @@ func newInstance(code []byte, cfg *Config) (*Instance, error) {
 
 	// if cfg.LogLvl set to < 0, then don't change package log level
 	if cfg.LogLvl >= 0 {
-		h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+		h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 		h = log.CallerFileHandler(h)
 		logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
+		atomic.StoreInt32(&maxLogLevel, int32(cfg.LogLvl))
+	}
+
+	if cfg.HostAPI != nil {
+		return newInstanceWithHostAPI(code, cfg)
+	}
+
+	fuelLimit := int64(cfg.FuelLimit)
+	if cfg.FuelLimit > math.MaxInt64 {
+		fuelLimit = math.MaxInt64
+	}
+
+	if fuelLimit > 0 {
+		var err error
+		code, err = injectFuelMetering(code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inject fuel metering: %w", err)
+		}
+	}
+
+	if cfg.MaxMemoryPages > 0 && cfg.MaxMemoryPages < initialMemoryPages {
+		return nil, fmt.Errorf("maximum memory pages must be at least %d", initialMemoryPages)
 	}
 
 	imports, err := cfg.Imports()
@@ -117,7 +176,7 @@ func newInstance(code []byte, cfg *Config) (*Instance, error) {
 	// Provide importable memory for newer runtimes
 	// TODO: determine memory descriptor size that the runtime wants from the wasm.
 	// should be doable w/ wasmer 1.0.0.
-	memory, err := wasm.NewMemory(23, 0)
+	memory, err := wasm.NewMemory(initialMemoryPages, cfg.MaxMemoryPages)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +201,18 @@ func newInstance(code []byte, cfg *Config) (*Instance, error) {
 		instance.Memory = memory
 	}
 
-	allocator := runtime.NewAllocator(instance.Memory, heapBase)
+	err = checkMemoryLimit(instance.Memory, cfg.MaxMemoryPages)
+	if err != nil {
+		instance.Close()
+		return nil, err
+	}
+
+	mem := &limitedMemory{
+		Memory:   instance.Memory,
+		maxPages: cfg.MaxMemoryPages,
+	}
+	allocator := runtime.NewAllocator(mem, heapBase)
+	mem.updatePeak()
 
 	runtimeCtx := &runtime.Context{
 		Storage:     cfg.Storage,
@@ -159,40 +229,113 @@ func newInstance(code []byte, cfg *Config) (*Instance, error) {
 	instance.SetContextData(runtimeCtx)
 
 	inst := &Instance{
-		vm:      instance,
-		ctx:     runtimeCtx,
-		imports: cfg.Imports,
+		vm:        instance,
+		ctx:       runtimeCtx,
+		imports:   cfg.Imports,
+		fuelLimit: fuelLimit,
+		memory:    mem,
 	}
 
 	inst.version, _ = inst.Version()
 	return inst, nil
 }
 
-// UpdateRuntimeCode updates the runtime instance to run the given code
+// newInstanceWithHostAPI instantiates the code with every host function to read its version, then checks that
+// the host functions selected for that version, if any are, provide every import of the code. The code is bound
+// to the same host functions whether the other ones are registered or not, so it isn't instantiated again.
+func newInstanceWithHostAPI(code []byte, cfg *Config) (*Instance, error) {
+	err := checkHostImports(code, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := *cfg
+	selected.HostAPI = nil
+
+	inst, err := newInstance(code, &selected)
+	if err != nil {
+		return nil, err
+	}
+
+	if api := cfg.HostAPI(inst.version); api != nil {
+		err = checkHostImports(code, api)
+		if err != nil {
+			inst.Stop()
+			return nil, err
+		}
+	}
+
+	inst.hostAPI = cfg.HostAPI
+	return inst, nil
+}
+
+// UpdateRuntimeCode updates the runtime instance to run the given code. The new code is instantiated and its
+// version read before the current instance is replaced, so the instance keeps running the current code if the
+// new code is invalid.
 func (in *Instance) UpdateRuntimeCode(code []byte) error {
-	in.Stop()
+	if in.hostAPI != nil {
+		err := checkHostImports(code, nil)
+		if err != nil {
+			return err
+		}
+	}
 
-	imports, err := in.imports()
+	next, err := in.instantiate(code, in.imports)
 	if err != nil {
 		return err
 	}
 
+	if in.hostAPI != nil {
+		if api := in.hostAPI(next.version); api != nil {
+			err = checkHostImports(code, api)
+			if err != nil {
+				next.Stop()
+				return err
+			}
+		}
+	}
+
+	in.Stop()
+	in.vm = next.vm
+	in.memory = next.memory
+	in.version = next.version
+	in.ctx.Allocator = next.ctx.Allocator
+	in.vm.SetContextData(in.ctx)
+	return nil
+}
+
+// instantiate returns a new instance running the given code with the given imports, sharing the configuration
+// of this instance. This instance is left unchanged.
+func (in *Instance) instantiate(code []byte, importsFunc func() (*wasm.Imports, error)) (*Instance, error) {
+	if in.fuelLimit > 0 {
+		var err error
+		code, err = injectFuelMetering(code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inject fuel metering: %w", err)
+		}
+	}
+
+	imports, err := importsFunc()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: determine memory descriptor size that the runtime wants from the wasm.
 	// should be doable w/ wasmer 1.0.0.
-	memory, err := wasm.NewMemory(23, 0)
+	memory, err := wasm.NewMemory(initialMemoryPages, in.memory.maxPages)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = imports.AppendMemory("memory", memory)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Instantiates the WebAssembly module.
 	instance, err := wasm.NewInstanceWithImports(code, imports)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// TODO: get __heap_base exported value from runtime.
@@ -204,16 +347,38 @@ func (in *Instance) UpdateRuntimeCode(code []byte) error {
 		instance.Memory = memory
 	}
 
-	in.ctx.Allocator = runtime.NewAllocator(instance.Memory, heapBase)
-	instance.SetContextData(in.ctx)
+	err = checkMemoryLimit(instance.Memory, in.memory.maxPages)
+	if err != nil {
+		instance.Close()
+		return nil, err
+	}
 
-	in.vm = instance
-	in.version, err = in.Version()
+	mem := &limitedMemory{
+		Memory:   instance.Memory,
+		maxPages: in.memory.maxPages,
+		peak:     in.memory.peak,
+	}
+
+	ctx := *in.ctx
+	ctx.Allocator = runtime.NewAllocator(mem, heapBase)
+	mem.updatePeak()
+	instance.SetContextData(&ctx)
+
+	next := &Instance{
+		vm:        instance,
+		ctx:       &ctx,
+		imports:   importsFunc,
+		fuelLimit: in.fuelLimit,
+		memory:    mem,
+	}
+
+	next.version, err = next.Version()
 	if err != nil {
-		return err
+		next.Stop()
+		return nil, err
 	}
 
-	return nil
+	return next, nil
 }
 
 // SetContextStorage sets the runtime's storage. It should be set before calls to the below functions.
@@ -252,6 +417,18 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 
 	in.mutex.Lock()
 	defer in.mutex.Unlock()
+	defer in.memory.updatePeak()
+
+	if in.tracing {
+		trace := runtime.NewCallTrace(function)
+		in.ctx.Trace = trace
+		start := time.Now()
+		defer func() {
+			trace.Duration = time.Since(start)
+			in.ctx.Trace = nil
+			in.lastTrace = trace
+		}()
+	}
 
 	ptr, err := in.malloc(uint32(len(data)))
 	if err != nil {
@@ -266,11 +443,28 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 
 	runtimeFunc, ok := in.vm.Exports[function]
 	if !ok {
-		return nil, fmt.Errorf("could not find exported function %s", function)
+		return nil, fmt.Errorf("%w %s", runtime.ErrExportFunctionNotFound, function)
+	}
+
+	if in.fuelLimit > 0 {
+		_, err = in.vm.Exports[fuelSetExport](in.fuelLimit)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	in.memory.err = nil
 	res, err := runtimeFunc(int32(ptr), datalen)
+
+	// the runtime can't be interrupted when an allocation fails, so the failure is reported once it returns
+	if in.memory.err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", function, in.memory.err)
+	}
+
 	if err != nil {
+		if in.fuelExhausted() {
+			return nil, fmt.Errorf("%w: %s", runtime.ErrExecutionExhausted, function)
+		}
 		return nil, err
 	}
 
@@ -278,6 +472,20 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 	return in.load(offset, length), nil
 }
 
+// fuelExhausted returns true if fuel metering is enabled and the last call ran out of fuel
+func (in *Instance) fuelExhausted() bool {
+	if in.fuelLimit <= 0 {
+		return false
+	}
+
+	fuel, err := in.vm.Exports[fuelGetExport]()
+	if err != nil {
+		return false
+	}
+
+	return fuel.ToI64() < 0
+}
+
 func (in *Instance) malloc(size uint32) (uint32, error) {
 	return in.ctx.Allocator.Allocate(size)
 }
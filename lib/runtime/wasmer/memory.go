@@ -0,0 +1,90 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+// initialMemoryPages is the number of pages of the memory provided to runtimes that import their memory
+const initialMemoryPages = 23
+
+// MemoryStats holds the memory usage of an instance
+type MemoryStats struct {
+	Size     uint64 // current size of the memory in bytes
+	PeakSize uint64 // largest size of the memory in bytes since the instance was created
+	MaxPages uint32 // maximum number of pages the memory may grow to, 0 if unlimited
+}
+
+// limitedMemory wraps the instance memory used by the allocator. It refuses to grow the memory past a maximum number
+// of pages and keeps track of the largest size the memory had.
+type limitedMemory struct {
+	runtime.Memory
+	maxPages uint32
+	peak     uint64
+	err      error // error of the last growth refused during the current runtime call
+}
+
+// Grow grows the memory by the given number of pages, unless this would exceed the maximum number of pages
+func (m *limitedMemory) Grow(numPages uint32) error {
+	pages := uint64(m.Length()) / runtime.PageSize
+	if m.maxPages > 0 && pages+uint64(numPages) > uint64(m.maxPages) {
+		m.err = fmt.Errorf("%w: cannot grow memory of %d pages by %d pages, maximum is %d pages",
+			runtime.ErrMemoryLimitExceeded, pages, numPages, m.maxPages)
+		return m.err
+	}
+
+	err := m.Memory.Grow(numPages)
+	if err != nil {
+		return err
+	}
+
+	m.updatePeak()
+	return nil
+}
+
+// updatePeak records the current size of the memory if it is the largest so far. The runtime may also grow its
+// memory itself, so this is called after every runtime call.
+func (m *limitedMemory) updatePeak() {
+	if size := uint64(m.Length()); size > m.peak {
+		m.peak = size
+	}
+}
+
+// checkMemoryLimit returns an error if the given memory is already larger than the maximum number of pages
+func checkMemoryLimit(mem runtime.Memory, maxPages uint32) error {
+	if pages := mem.Length() / runtime.PageSize; maxPages > 0 && pages > maxPages {
+		return fmt.Errorf("%w: runtime memory has %d pages, maximum is %d pages",
+			runtime.ErrMemoryLimitExceeded, pages, maxPages)
+	}
+	return nil
+}
+
+// MemoryStats returns the current and peak memory usage of the instance
+func (in *Instance) MemoryStats() MemoryStats {
+	in.mutex.Lock()
+	defer in.mutex.Unlock()
+
+	in.memory.updatePeak()
+	return MemoryStats{
+		Size:     uint64(in.memory.Length()),
+		PeakSize: in.memory.peak,
+		MaxPages: in.memory.maxPages,
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxMemoryPages = 64
+
+// newMemoryTestModule returns a module importing its memory and the allocator, exporting a function that grows the
+// memory until it can't anymore and a function that allocates until it fails
+func newMemoryTestModule() []byte {
+	var imports []byte
+	imports = append(appendName(appendName(append(imports, 2), "env"), "memory"), 0x02, 0x00, 1)
+	imports = append(appendName(appendName(imports, "env"), "ext_allocator_malloc_version_1"), exportFunc, 1)
+
+	var exports []byte
+	exports = append(appendName(append(exports, 2), "grow_memory"), exportFunc, 1)
+	exports = append(appendName(exports, "allocate_memory"), exportFunc, 2)
+
+	// loop (i32.const 1, memory.grow, i32.const -1, i32.ne, br_if 0) end, i64.const 0
+	growMemory := []byte{0, opLoop, blockTypeEmpty, 0x41, 1, 0x40, 0, 0x41, 0x7f, 0x47, 0x0d, 0, opEnd,
+		opI64Const, 0, opEnd}
+	// loop (i32.const 1mb, call malloc, br_if 0) end, i64.const 0
+	allocateMemory := []byte{0, opLoop, blockTypeEmpty, 0x41, 0x80, 0x80, 0xc0, 0, 0x10, 0, 0x0d, 0, opEnd,
+		opI64Const, 0, opEnd}
+
+	code := []byte{2}
+	for _, body := range [][]byte{growMemory, allocateMemory} {
+		code = append(appendUleb(code, uint32(len(body))), body...)
+	}
+
+	types := []byte{2, typeFunc, 2, 0x7f, 0x7f, 1, typeI64, typeFunc, 1, 0x7f, 1, 0x7f}
+
+	module := append([]byte{}, wasmMagic...)
+	module = append(module, encodeSection(sectionType, types)...)
+	module = append(module, encodeSection(sectionImport, imports)...)
+	module = append(module, encodeSection(sectionFunction, []byte{2, 0, 0})...)
+	module = append(module, encodeSection(sectionExport, exports)...)
+	return append(module, encodeSection(sectionCode, code)...)
+}
+
+func newMemoryTestConfig(t *testing.T, maxPages uint32) *Config {
+	s, err := storage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Imports: ImportsNodeRuntime,
+	}
+	cfg.Storage = s
+	cfg.LogLvl = 0
+	cfg.MaxMemoryPages = maxPages
+	return cfg
+}
+
+func TestInstance_MaxMemoryPages_RuntimeGrowth(t *testing.T) {
+	instance, err := NewInstance(newMemoryTestModule(), newMemoryTestConfig(t, testMaxMemoryPages))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec("grow_memory", []byte{})
+	require.NoError(t, err)
+
+	stats := instance.MemoryStats()
+	require.Equal(t, uint64(testMaxMemoryPages*runtime.PageSize), stats.Size)
+	require.Equal(t, stats.Size, stats.PeakSize)
+	require.Equal(t, uint32(testMaxMemoryPages), stats.MaxPages)
+}
+
+func TestInstance_MaxMemoryPages_Allocation(t *testing.T) {
+	instance, err := NewInstance(newMemoryTestModule(), newMemoryTestConfig(t, testMaxMemoryPages))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec("allocate_memory", []byte{})
+	require.True(t, errors.Is(err, runtime.ErrMemoryLimitExceeded), err)
+
+	stats := instance.MemoryStats()
+	require.LessOrEqual(t, stats.PeakSize, uint64(testMaxMemoryPages*runtime.PageSize))
+	require.Greater(t, stats.PeakSize, uint64(initialMemoryPages*runtime.PageSize))
+
+	// the input data of a call is allocated by the host
+	_, err = instance.Exec("grow_memory", make([]byte, testMaxMemoryPages*runtime.PageSize))
+	require.True(t, errors.Is(err, runtime.ErrMemoryLimitExceeded), err)
+
+	// the instance can still be used after failed allocations
+	_, err = instance.Exec("grow_memory", []byte{})
+	require.NoError(t, err)
+}
+
+func TestInstance_MaxMemoryPages_Runtime(t *testing.T) {
+	gen, err := genesis.NewGenesisFromJSONRaw("../../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+
+	code := common.MustHexToBytes(gen.GenesisFields().Raw["top"][common.BytesToHex(common.CodeKey)])
+	require.NotEmpty(t, code)
+
+	instance, err := NewInstance(code, newMemoryTestConfig(t, testMaxMemoryPages))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	version, err := instance.Version()
+	require.NoError(t, err)
+	require.Equal(t, []byte("node"), version.SpecName())
+
+	stats := instance.MemoryStats()
+	require.NotZero(t, stats.Size)
+	require.GreaterOrEqual(t, stats.PeakSize, stats.Size)
+	require.LessOrEqual(t, stats.PeakSize, uint64(testMaxMemoryPages*runtime.PageSize))
+}
+
+func TestInstance_MaxMemoryPages_Invalid(t *testing.T) {
+	_, err := NewInstance(newMemoryTestModule(), newMemoryTestConfig(t, initialMemoryPages-1))
+	require.Error(t, err)
+}
@@ -0,0 +1,594 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Fuel metering is implemented by instrumenting the wasm code before it is instantiated, since wasmer 0.3.x does
+// not support metering or interrupting a running instance. A mutable i64 global holding the remaining fuel is added
+// to the module, and one unit of fuel is charged at the start of every function and every loop iteration. When the
+// fuel drops below zero the instance traps. Two functions are exported to set and read the remaining fuel.
+//
+// All additions are appended to the end of their index spaces, so the indices used by the original code do not change.
+const (
+	fuelSetExport = "gossamer_fuel_set"
+	fuelGetExport = "gossamer_fuel_get"
+)
+
+var errInvalidWasm = errors.New("invalid wasm code")
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// wasm section ids
+const (
+	sectionCustom   byte = 0
+	sectionType     byte = 1
+	sectionImport   byte = 2
+	sectionFunction byte = 3
+	sectionGlobal   byte = 6
+	sectionExport   byte = 7
+	sectionCode     byte = 10
+)
+
+// sectionOrder is the order in which non-custom sections must appear in a module
+var sectionOrder = map[byte]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 12: 10, 10: 11, 11: 12}
+
+// wasm opcodes and types used by the injected code
+const (
+	opUnreachable byte = 0x00
+	opLoop        byte = 0x03
+	opIf          byte = 0x04
+	opEnd         byte = 0x0b
+	opLocalGet    byte = 0x20
+	opGlobalGet   byte = 0x23
+	opGlobalSet   byte = 0x24
+	opI64Const    byte = 0x42
+	opI64LtS      byte = 0x53
+	opI64Sub      byte = 0x7d
+
+	blockTypeEmpty byte = 0x40
+	typeFunc       byte = 0x60
+	typeI64        byte = 0x7e
+	exportFunc     byte = 0x00
+)
+
+type section struct {
+	id      byte
+	payload []byte
+}
+
+// injectFuelMetering returns a copy of the given wasm code instrumented with fuel metering
+func injectFuelMetering(code []byte) ([]byte, error) {
+	sections, err := readSections(code)
+	if err != nil {
+		return nil, err
+	}
+
+	sections = ensureSections(sections, sectionType, sectionFunction, sectionGlobal, sectionExport, sectionCode)
+
+	var importedFuncs, importedGlobals, typeCount, funcCount, globalCount uint32
+	for _, s := range sections {
+		switch s.id {
+		case sectionImport:
+			importedFuncs, importedGlobals, err = countImports(s.payload)
+		case sectionType:
+			typeCount, err = vecLen(s.payload)
+		case sectionFunction:
+			funcCount, err = vecLen(s.payload)
+		case sectionGlobal:
+			globalCount, err = vecLen(s.payload)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		fuelGlobal = importedGlobals + globalCount
+		setFunc    = importedFuncs + funcCount
+		setType    = typeCount
+		global     = appendUleb(nil, fuelGlobal)
+	)
+
+	out := append([]byte{}, wasmMagic...)
+	for _, s := range sections {
+		payload := s.payload
+
+		switch s.id {
+		case sectionType:
+			// (i64) -> () and () -> (i64)
+			payload, err = appendToVec(payload, 2, []byte{typeFunc, 1, typeI64, 0, typeFunc, 0, 1, typeI64})
+		case sectionFunction:
+			payload, err = appendToVec(payload, 2, appendUleb(appendUleb(nil, setType), setType+1))
+		case sectionGlobal:
+			// mutable i64 initialised to 0
+			payload, err = appendToVec(payload, 1, []byte{typeI64, 1, opI64Const, 0, opEnd})
+		case sectionExport:
+			var exports []byte
+			exports = appendName(exports, fuelSetExport)
+			exports = append(exports, exportFunc)
+			exports = appendUleb(exports, setFunc)
+			exports = appendName(exports, fuelGetExport)
+			exports = append(exports, exportFunc)
+			exports = appendUleb(exports, setFunc+1)
+			payload, err = appendToVec(payload, 2, exports)
+		case sectionCode:
+			payload, err = meterCode(payload, global)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, s.id)
+		out = appendUleb(out, uint32(len(payload)))
+		out = append(out, payload...)
+	}
+
+	return out, nil
+}
+
+func readSections(code []byte) ([]section, error) {
+	if !bytes.HasPrefix(code, wasmMagic) {
+		return nil, fmt.Errorf("%w: bad magic number or version", errInvalidWasm)
+	}
+
+	r := &wasmReader{buf: code, pos: len(wasmMagic)}
+
+	var sections []section
+	for !r.done() {
+		id, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := r.readUleb()
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section{id: id, payload: payload})
+	}
+
+	return sections, nil
+}
+
+// ensureSections adds empty sections with the given ids to the module if they do not exist
+func ensureSections(sections []section, ids ...byte) []section {
+	for _, id := range ids {
+		var (
+			found bool
+			at    = len(sections)
+		)
+
+		for i := len(sections) - 1; i >= 0; i-- {
+			if sections[i].id == sectionCustom {
+				continue
+			}
+
+			if sections[i].id == id {
+				found = true
+				break
+			}
+
+			if sectionOrder[sections[i].id] > sectionOrder[id] {
+				at = i
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		// an empty vector
+		sections = append(sections[:at], append([]section{{id: id, payload: []byte{0}}}, sections[at:]...)...)
+	}
+
+	return sections
+}
+
+// countImports returns the number of imported functions and globals in the given import section
+func countImports(payload []byte) (funcs, globals uint32, err error) {
+	err = readImports(payload, func(_, _ string, kind byte) {
+		switch kind {
+		case 0:
+			funcs++
+		case 3:
+			globals++
+		}
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return funcs, globals, nil
+}
+
+// readImports calls fn with the module name, field name and kind of each entry in the given import section
+func readImports(payload []byte, fn func(module, field string, kind byte)) error {
+	r := &wasmReader{buf: payload}
+
+	count, err := r.readUleb()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		var module, field string
+		if module, err = r.readName(); err != nil {
+			return err
+		}
+		if field, err = r.readName(); err != nil {
+			return err
+		}
+
+		var kind byte
+		kind, err = r.readByte()
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case 0: // function type index
+			_, err = r.readUleb()
+		case 1: // table reference type and limits
+			if _, err = r.readByte(); err == nil {
+				err = r.skipLimits()
+			}
+		case 2: // memory limits
+			err = r.skipLimits()
+		case 3: // global value type and mutability
+			_, err = r.readBytes(2)
+		default:
+			err = fmt.Errorf("%w: unknown import kind %d", errInvalidWasm, kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		fn(module, field, kind)
+	}
+
+	return nil
+}
+
+// vecLen returns the length of the vector in the given section payload
+func vecLen(payload []byte) (uint32, error) {
+	r := &wasmReader{buf: payload}
+	return r.readUleb()
+}
+
+// appendToVec appends n encoded items to the vector in the given section payload
+func appendToVec(payload []byte, n uint32, items []byte) ([]byte, error) {
+	r := &wasmReader{buf: payload}
+	count, err := r.readUleb()
+	if err != nil {
+		return nil, err
+	}
+
+	out := appendUleb(nil, count+n)
+	out = append(out, payload[r.pos:]...)
+	return append(out, items...), nil
+}
+
+// fuelCharge returns the code that charges one unit of fuel and traps if the fuel is exhausted
+func fuelCharge(global []byte) []byte {
+	var out []byte
+	out = append(append(out, opGlobalGet), global...)
+	out = append(out, opI64Const, 1, opI64Sub)
+	out = append(append(out, opGlobalSet), global...)
+	out = append(append(out, opGlobalGet), global...)
+	out = append(out, opI64Const, 0, opI64LtS, opIf, blockTypeEmpty, opUnreachable, opEnd)
+	return out
+}
+
+// meterCode instruments every function body in the given code section, and appends the bodies of the fuel
+// setter and getter functions
+func meterCode(payload []byte, global []byte) ([]byte, error) {
+	r := &wasmReader{buf: payload}
+	count, err := r.readUleb()
+	if err != nil {
+		return nil, err
+	}
+
+	charge := fuelCharge(global)
+	out := appendUleb(nil, count+2)
+
+	for i := uint32(0); i < count; i++ {
+		size, err := r.readUleb()
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		metered, err := meterBody(body, charge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to meter function %d: %w", i, err)
+		}
+
+		out = appendUleb(out, uint32(len(metered)))
+		out = append(out, metered...)
+	}
+
+	// no locals, global.set $fuel (local.get 0)
+	set := append(append([]byte{0, opLocalGet, 0, opGlobalSet}, global...), opEnd)
+	// no locals, global.get $fuel
+	get := append(append([]byte{0, opGlobalGet}, global...), opEnd)
+
+	for _, body := range [][]byte{set, get} {
+		out = appendUleb(out, uint32(len(body)))
+		out = append(out, body...)
+	}
+
+	return out, nil
+}
+
+// meterBody inserts the fuel charge at the start of the function body and at the start of every loop
+func meterBody(body, charge []byte) ([]byte, error) {
+	r := &wasmReader{buf: body}
+
+	// skip the local declarations
+	count, err := r.readUleb()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		if _, err = r.readUleb(); err != nil {
+			return nil, err
+		}
+		if _, err = r.readByte(); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]byte, 0, len(body)+len(charge))
+	out = append(out, body[:r.pos]...)
+	out = append(out, charge...)
+
+	last := r.pos
+	for !r.done() {
+		op, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.skipImmediates(op)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == opLoop {
+			out = append(out, body[last:r.pos]...)
+			out = append(out, charge...)
+			last = r.pos
+		}
+	}
+
+	return append(out, body[last:]...), nil
+}
+
+type wasmReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *wasmReader) done() bool {
+	return r.pos >= len(r.buf)
+}
+
+func (r *wasmReader) readByte() (byte, error) {
+	if r.done() {
+		return 0, fmt.Errorf("%w: unexpected end", errInvalidWasm)
+	}
+
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *wasmReader) readBytes(n uint32) ([]byte, error) {
+	if uint64(r.pos)+uint64(n) > uint64(len(r.buf)) {
+		return nil, fmt.Errorf("%w: unexpected end", errInvalidWasm)
+	}
+
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// readUleb reads an unsigned LEB128 encoded uint32
+func (r *wasmReader) readUleb() (uint32, error) {
+	var out uint32
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		out |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return out, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: integer too large", errInvalidWasm)
+}
+
+// skipLeb skips a signed or unsigned LEB128 encoded integer of up to 64 bits
+func (r *wasmReader) skipLeb() error {
+	for i := 0; i < 10; i++ {
+		b, err := r.readByte()
+		if err != nil {
+			return err
+		}
+
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: integer too large", errInvalidWasm)
+}
+
+func (r *wasmReader) readName() (string, error) {
+	n, err := r.readUleb()
+	if err != nil {
+		return "", err
+	}
+
+	name, err := r.readBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return string(name), nil
+}
+
+func (r *wasmReader) skipLimits() error {
+	flags, err := r.readByte()
+	if err != nil {
+		return err
+	}
+
+	if _, err = r.readUleb(); err != nil {
+		return err
+	}
+
+	if flags&1 == 1 {
+		_, err = r.readUleb()
+	}
+	return err
+}
+
+// skipUlebs skips n unsigned LEB128 encoded integers
+func (r *wasmReader) skipUlebs(n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := r.readUleb(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipImmediates skips the immediate arguments of the given instruction
+func (r *wasmReader) skipImmediates(op byte) error {
+	var err error
+
+	switch {
+	case op == 0x02 || op == 0x03 || op == 0x04: // block, loop, if: block type
+		err = r.skipLeb()
+	case op == 0x0c || op == 0x0d: // br, br_if: label index
+		err = r.skipUlebs(1)
+	case op == 0x0e: // br_table: label vector and default label
+		var n uint32
+		if n, err = r.readUleb(); err == nil {
+			err = r.skipUlebs(int(n) + 1)
+		}
+	case op == 0x10: // call: function index
+		err = r.skipUlebs(1)
+	case op == 0x11: // call_indirect: type index and table index
+		err = r.skipUlebs(2)
+	case op == 0x1c: // select with value types
+		var n uint32
+		if n, err = r.readUleb(); err == nil {
+			_, err = r.readBytes(n)
+		}
+	case op >= 0x20 && op <= 0x26: // local, global and table get/set: index
+		err = r.skipUlebs(1)
+	case op >= 0x28 && op <= 0x3e: // loads and stores: alignment and offset
+		err = r.skipUlebs(2)
+	case op == 0x3f || op == 0x40: // memory.size, memory.grow: memory index
+		_, err = r.readByte()
+	case op == 0x41 || op == 0x42: // i32.const, i64.const
+		err = r.skipLeb()
+	case op == 0x43: // f32.const
+		_, err = r.readBytes(4)
+	case op == 0x44: // f64.const
+		_, err = r.readBytes(8)
+	case op == 0xd0: // ref.null: reference type
+		_, err = r.readByte()
+	case op == 0xd2: // ref.func: function index
+		err = r.skipUlebs(1)
+	case op == 0xfc: // prefixed instructions
+		err = r.skipPrefixedImmediates()
+	case op == 0x05 || op == 0x0b || op == 0x0f || op == 0x1a || op == 0x1b: // else, end, return, drop, select
+	case op <= 0x01 || (op >= 0x45 && op <= 0xc4) || op == 0xd1: // unreachable, nop, numeric, ref.is_null
+	default:
+		err = fmt.Errorf("%w: unsupported opcode 0x%x", errInvalidWasm, op)
+	}
+
+	return err
+}
+
+func (r *wasmReader) skipPrefixedImmediates() error {
+	sub, err := r.readUleb()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sub <= 7: // saturating truncation
+		return nil
+	case sub == 8: // memory.init: data index and memory index
+		if err = r.skipUlebs(1); err == nil {
+			_, err = r.readByte()
+		}
+	case sub == 9 || sub == 13 || (sub >= 15 && sub <= 17): // data.drop, elem.drop, table.grow/size/fill
+		err = r.skipUlebs(1)
+	case sub == 10: // memory.copy: memory indices
+		_, err = r.readBytes(2)
+	case sub == 11: // memory.fill: memory index
+		_, err = r.readByte()
+	case sub == 12 || sub == 14: // table.init, table.copy
+		err = r.skipUlebs(2)
+	default:
+		err = fmt.Errorf("%w: unsupported opcode 0xfc %d", errInvalidWasm, sub)
+	}
+
+	return err
+}
+
+func appendUleb(out []byte, v uint32) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if v == 0 {
+			return out
+		}
+	}
+}
+
+func appendName(out []byte, name string) []byte {
+	out = appendUleb(out, uint32(len(name)))
+	return append(out, name...)
+}
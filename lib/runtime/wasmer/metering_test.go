@@ -0,0 +1,130 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeSection(id byte, payload []byte) []byte {
+	return append(appendUleb([]byte{id}, uint32(len(payload))), payload...)
+}
+
+// newLoopTestModule returns a module exporting an infinite loop and a function that returns immediately, both with
+// the (i32, i32) -> i64 signature of runtime functions
+func newLoopTestModule() []byte {
+	var exports []byte
+	exports = append(appendName(append(exports, 2), "loop_forever"), exportFunc, 0)
+	exports = append(appendName(exports, "finite"), exportFunc, 1)
+
+	// loop (br 0) end, i64.const 0
+	loopForever := []byte{0, opLoop, blockTypeEmpty, 0x0c, 0, opEnd, opI64Const, 0, opEnd}
+	finite := []byte{0, opI64Const, 0, opEnd}
+
+	code := []byte{2}
+	for _, body := range [][]byte{loopForever, finite} {
+		code = append(appendUleb(code, uint32(len(body))), body...)
+	}
+
+	module := append([]byte{}, wasmMagic...)
+	module = append(module, encodeSection(sectionType, []byte{1, typeFunc, 2, 0x7f, 0x7f, 1, typeI64})...)
+	module = append(module, encodeSection(sectionFunction, []byte{2, 0, 0})...)
+	module = append(module, encodeSection(sectionExport, exports)...)
+	return append(module, encodeSection(sectionCode, code)...)
+}
+
+func newMeteringTestConfig(t *testing.T, limit uint64) *Config {
+	s, err := storage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Imports: ImportsNodeRuntime,
+	}
+	cfg.Storage = s
+	cfg.LogLvl = 0
+	cfg.FuelLimit = limit
+	return cfg
+}
+
+func TestFuelMetering_ExecutionExhausted(t *testing.T) {
+	instance, err := NewInstance(newLoopTestModule(), newMeteringTestConfig(t, 1000))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec("loop_forever", []byte{})
+	require.True(t, errors.Is(err, runtime.ErrExecutionExhausted))
+
+	// the fuel is reset for every call
+	for i := 0; i < 3; i++ {
+		_, err = instance.Exec("finite", []byte{})
+		require.NoError(t, err)
+	}
+}
+
+func TestFuelMetering_Disabled(t *testing.T) {
+	instance, err := NewInstance(newLoopTestModule(), newMeteringTestConfig(t, 0))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec("finite", []byte{})
+	require.NoError(t, err)
+
+	_, has := instance.vm.Exports[fuelSetExport]
+	require.False(t, has)
+}
+
+func TestFuelMetering_Runtime(t *testing.T) {
+	gen, err := genesis.NewGenesisFromJSONRaw("../../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+
+	code := common.MustHexToBytes(gen.GenesisFields().Raw["top"][common.BytesToHex(common.CodeKey)])
+	require.NotEmpty(t, code)
+
+	// metering a real runtime must keep it valid and its behaviour unchanged
+	instance, err := NewInstance(code, newMeteringTestConfig(t, 1<<40))
+	require.NoError(t, err)
+
+	version, err := instance.Version()
+	require.NoError(t, err)
+	require.Equal(t, []byte("node"), version.SpecName())
+	instance.Stop()
+
+	instance, err = NewInstance(code, newMeteringTestConfig(t, 10))
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	_, err = instance.Exec(runtime.CoreVersion, []byte{})
+	require.True(t, errors.Is(err, runtime.ErrExecutionExhausted))
+}
+
+func TestInjectFuelMetering_InvalidCode(t *testing.T) {
+	_, err := injectFuelMetering([]byte{1, 2, 3})
+	require.True(t, errors.Is(err, errInvalidWasm))
+
+	// truncated code section
+	module := newLoopTestModule()
+	_, err = injectFuelMetering(module[:len(module)-2])
+	require.True(t, errors.Is(err, errInvalidWasm))
+}
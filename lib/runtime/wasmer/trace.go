@@ -0,0 +1,81 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+
+	wasm "github.com/wasmerio/go-ext-wasm/wasmer"
+)
+
+// tracingInstances is the number of instances with tracing enabled. While it is zero, host functions don't need to
+// look up their instance context to find out whether the call is traced.
+var tracingInstances int32
+
+func noopTrace() {}
+
+// traceHostCall starts timing a call to the given host function if the calling instance has tracing enabled.
+// The returned func records the call, so it should be deferred by the host function.
+func traceHostCall(context unsafe.Pointer, name string) func() {
+	if atomic.LoadInt32(&tracingInstances) == 0 {
+		return noopTrace
+	}
+
+	instanceContext := wasm.IntoInstanceContext(context)
+	ctx, ok := instanceContext.Data().(*runtime.Context)
+	if !ok || ctx.Trace == nil {
+		return noopTrace
+	}
+
+	trace := ctx.Trace
+	start := time.Now()
+	return func() {
+		trace.Record(name, time.Since(start))
+	}
+}
+
+// SetTracing enables or disables recording the host function calls made during each runtime call.
+// Tracing is disabled by default.
+func (in *Instance) SetTracing(enabled bool) {
+	in.mutex.Lock()
+	defer in.mutex.Unlock()
+
+	if in.tracing == enabled {
+		return
+	}
+
+	in.tracing = enabled
+	if enabled {
+		atomic.AddInt32(&tracingInstances, 1)
+		return
+	}
+
+	atomic.AddInt32(&tracingInstances, -1)
+	in.lastTrace = nil
+}
+
+// LastCallTrace returns the trace of the last runtime call. It returns nil if tracing is disabled or if no call was
+// made since it was enabled.
+func (in *Instance) LastCallTrace() *runtime.CallTrace {
+	in.mutex.Lock()
+	defer in.mutex.Unlock()
+	return in.lastTrace
+}
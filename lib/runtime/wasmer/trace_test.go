@@ -0,0 +1,99 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasmer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTracingTestInstance(t *testing.T) *Instance {
+	gen, err := genesis.NewGenesisFromJSONRaw("../../../chain/gssmr/genesis.json")
+	require.NoError(t, err)
+
+	genTrie, err := genesis.NewTrieFromGenesis(gen)
+	require.NoError(t, err)
+
+	genState, err := storage.NewTrieState(genTrie)
+	require.NoError(t, err)
+
+	cfg := &Config{}
+	cfg.Storage = genState
+	cfg.LogLvl = 4
+
+	rt, err := NewRuntimeFromGenesis(gen, cfg)
+	require.NoError(t, err)
+	t.Cleanup(rt.Stop)
+	return rt.(*Instance)
+}
+
+func TestInstance_CallTrace(t *testing.T) {
+	instance := newTracingTestInstance(t)
+	instance.SetTracing(true)
+
+	header := &types.Header{
+		Number: big.NewInt(1),
+		Digest: types.Digest{},
+	}
+
+	err := instance.InitializeBlock(header)
+	require.NoError(t, err)
+
+	trace := instance.LastCallTrace()
+	require.NotNil(t, trace)
+	require.Equal(t, runtime.CoreInitializeBlock, trace.Function)
+	require.NotZero(t, trace.Duration)
+	require.NotZero(t, trace.HostDuration)
+	require.LessOrEqual(t, int64(trace.HostDuration), int64(trace.Duration))
+
+	for _, name := range []string{"ext_storage_get_version_1", "ext_storage_set_version_1"} {
+		stats, has := trace.HostCalls[name]
+		require.True(t, has, name)
+		require.NotZero(t, stats.Count, name)
+	}
+
+	// each call replaces the trace of the previous one
+	_, err = instance.Version()
+	require.NoError(t, err)
+	trace = instance.LastCallTrace()
+	require.Equal(t, runtime.CoreVersion, trace.Function)
+	require.NotContains(t, trace.HostCalls, "ext_storage_set_version_1")
+}
+
+func TestInstance_CallTrace_Disabled(t *testing.T) {
+	instance := newTracingTestInstance(t)
+
+	_, err := instance.Version()
+	require.NoError(t, err)
+	require.Nil(t, instance.LastCallTrace())
+
+	instance.SetTracing(true)
+	_, err = instance.Version()
+	require.NoError(t, err)
+	require.NotNil(t, instance.LastCallTrace())
+
+	instance.SetTracing(false)
+	require.Nil(t, instance.LastCallTrace())
+	require.Nil(t, instance.ctx.Trace)
+}
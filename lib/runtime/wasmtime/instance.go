@@ -18,11 +18,12 @@ package wasmtime
 
 import (
 	"errors"
-	"os"
+	"fmt"
 	"runtime"
 	"sync"
 
 	gssmrruntime "github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/utils"
 
 	log "github.com/ChainSafe/log15"
 	"github.com/bytecodealliance/wasmtime-go"
@@ -38,6 +39,24 @@ var (
 	logger = log.New("pkg", "runtime", "module", "go-wasmtime")
 )
 
+func init() {
+	gssmrruntime.RegisterInterpreter(Name, newRegisteredInstance)
+}
+
+// newRegisteredInstance is the wasmtime factory registered with the runtime package
+func newRegisteredInstance(code []byte, cfg *gssmrruntime.InstanceConfig) (gssmrruntime.Instance, error) {
+	rtCfg := &Config{
+		InstanceConfig: *cfg,
+		Imports:        ImportNodeRuntime,
+	}
+
+	in, err := NewInstance(code, rtCfg)
+	if err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
 // ImportsFunc returns a linker with the module imports
 type ImportsFunc func(*wasmtime.Store, *wasmtime.Memory) (*wasmtime.Linker, error)
 
@@ -79,7 +98,7 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 func newInstanceFromModule(module *wasmtime.Module, engine *wasmtime.Engine, cfg *Config) (*Instance, error) {
 	// if cfg.LogLvl set to < 0, then don't change package log level
 	if cfg.LogLvl >= 0 {
-		h := log.StreamHandler(os.Stdout, log.TerminalFormat())
+		h := log.StreamHandler(utils.LogOutput(), log.TerminalFormat())
 		h = log.CallerFileHandler(h)
 		logger.SetHandler(log.LvlFilterHandler(cfg.LogLvl, h))
 	}
@@ -160,7 +179,12 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 	memdata := in.mem.UnsafeData()
 	copy(memdata[ptr:ptr+uint32(len(data))], data)
 
-	run := in.vm.GetExport(function).Func()
+	export := in.vm.GetExport(function)
+	if export == nil {
+		return nil, fmt.Errorf("%w %s", gssmrruntime.ErrExportFunctionNotFound, function)
+	}
+
+	run := export.Func()
 	resi, err := run.Call(int32(ptr), int32(len(data)))
 	if err != nil {
 		return nil, err
@@ -39,17 +39,34 @@ type Decoder struct {
 	Reader io.Reader
 }
 
-// Decode a byte array into interface
-func Decode(in []byte, t interface{}) (interface{}, error) {
-	buf := &bytes.Buffer{}
-	sd := Decoder{
-		Reader: buf,
+// NewDecoder returns a new Decoder that reads SCALE encoded values from r as they are decoded.
+// Reads from r are retried until the requested number of bytes is available, so r may be a
+// stream (eg. a network connection or pipe) that returns short reads.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		Reader: &streamReader{r: r},
 	}
-	_, err := buf.Write(in)
-	if err != nil {
-		return nil, err
+}
+
+// streamReader is an io.Reader that keeps reading from the underlying reader until p is full.
+// It matches the behaviour of bytes.Buffer once the underlying reader is exhausted: a partial
+// read returns the bytes read and no error, and io.EOF is only returned if nothing was read.
+type streamReader struct {
+	r io.Reader
+}
+
+// Read reads up to len(p) bytes from the underlying reader
+func (sr *streamReader) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(sr.r, p)
+	if err == io.ErrUnexpectedEOF {
+		return n, nil
 	}
+	return n, err
+}
 
+// Decode a byte array into interface
+func Decode(in []byte, t interface{}) (interface{}, error) {
+	sd := NewDecoder(bytes.NewReader(in))
 	output, err := sd.Decode(t)
 	return output, err
 }
@@ -258,3 +258,77 @@ func TestUint128EncodeDecode(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedVal, dec)
 }
+
+func newLargeExtrinsics(count, size int) [][]byte {
+	exts := make([][]byte, count)
+	for i := range exts {
+		exts[i] = make([]byte, size)
+		for j := range exts[i] {
+			exts[i][j] = byte(i + j)
+		}
+	}
+	return exts
+}
+
+func TestEncoder_Stream(t *testing.T) {
+	exts := newLargeExtrinsics(1024, 1024)
+
+	expected, err := Encode(exts)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	se := NewEncoder(buf)
+	n, err := se.Encode(exts)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), n)
+	require.Equal(t, expected, buf.Bytes())
+}
+
+func TestDecoder_Stream(t *testing.T) {
+	exts := newLargeExtrinsics(1024, 1024)
+
+	expected, err := Decode(mustEncode(t, exts), [][]byte{})
+	require.NoError(t, err)
+
+	// io.Pipe only returns as many bytes as are written per call, so the decoder
+	// must be able to handle short reads
+	r, w := io.Pipe()
+	go func() {
+		se := NewEncoder(w)
+		_, err := se.Encode(exts)
+		_ = w.CloseWithError(err)
+	}()
+
+	sd := NewDecoder(r)
+	res, err := sd.Decode([][]byte{})
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+	require.Equal(t, exts, res)
+}
+
+func TestDecoder_Stream_Multiple(t *testing.T) {
+	buf := &bytes.Buffer{}
+	se := NewEncoder(buf)
+	_, err := se.Encode(uint32(7))
+	require.NoError(t, err)
+	_, err = se.Encode([]byte("noot"))
+	require.NoError(t, err)
+
+	sd := NewDecoder(buf)
+	res, err := sd.Decode(uint32(0))
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), res)
+
+	res, err = sd.Decode([]byte{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("noot"), res)
+
+	_, err = sd.Decode(uint32(0))
+	require.Error(t, err)
+}
+
+func mustEncode(t *testing.T, in interface{}) []byte {
+	enc, err := Encode(in)
+	require.NoError(t, err)
+	return enc
+}
@@ -32,12 +32,19 @@ type Encoder struct {
 	Writer io.Writer
 }
 
+// NewEncoder returns a new Encoder that writes SCALE encoded values to w as they are encoded.
+// This allows large values, such as block bodies, to be streamed without first building the
+// whole encoding in memory.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Writer: w,
+	}
+}
+
 // Encode returns the SCALE encoding of the given interface
 func Encode(in interface{}) ([]byte, error) {
 	buffer := &bytes.Buffer{}
-	se := Encoder{
-		Writer: buffer,
-	}
+	se := NewEncoder(buffer)
 	_, err := se.Encode(in)
 	output := buffer.Bytes()
 	return output, err
@@ -33,6 +33,7 @@ type Service interface {
 type ServiceRegistry struct {
 	services     map[reflect.Type]Service // map of types to service instances
 	serviceTypes []reflect.Type           // all known service types, used to iterate through services
+	stopOrder    []reflect.Type           // service types in the order they are stopped by StopAll
 }
 
 // NewServiceRegistry creates an empty registry
@@ -53,23 +54,86 @@ func (s *ServiceRegistry) RegisterService(service Service) {
 	s.serviceTypes = append(s.serviceTypes, kind)
 }
 
-// StartAll calls `Service.Start()` for all registered services
-func (s *ServiceRegistry) StartAll() {
+// StartAll calls `Service.Start()` for all registered services, in the order they were registered. It returns the
+// error of the first service that fails to start, in which case the services after it are not started and the
+// services that were already started are stopped again.
+func (s *ServiceRegistry) StartAll() error {
 	log.Info(fmt.Sprintf("Starting services: %v", s.serviceTypes))
-	for _, typ := range s.serviceTypes {
+	for i, typ := range s.serviceTypes {
 		log.Debug(fmt.Sprintf("Starting service %v", typ))
 		err := s.services[typ].Start()
 		if err != nil {
 			log.Error("Error starting service", "srvc", typ, "err", err)
+			s.stopStarted(s.serviceTypes[:i])
+			return fmt.Errorf("failed to start service %v: %w", typ, err)
 		}
 	}
 	log.Debug("All services started.")
+	return nil
 }
 
-// StopAll calls `Service.Stop()` for all registered services
-func (s *ServiceRegistry) StopAll() {
-	log.Info(fmt.Sprintf("Stopping services: %v", s.serviceTypes))
+// stopStarted stops the given started services, in the order they are stopped by StopAll
+func (s *ServiceRegistry) stopStarted(started []reflect.Type) {
+	isStarted := make(map[reflect.Type]bool)
+	for _, typ := range started {
+		isStarted[typ] = true
+	}
+
+	for _, typ := range s.stopTypes() {
+		if !isStarted[typ] {
+			continue
+		}
+
+		log.Debug(fmt.Sprintf("Stopping service %v", typ))
+		err := s.services[typ].Stop()
+		if err != nil {
+			log.Error("Error stopping service", "srvc", typ, "err", err)
+		}
+	}
+}
+
+// SetStopOrder sets the order in which `StopAll()` stops services, given a value of each service type,
+// eg. (*Service)(nil). Registered services of types not in the order are stopped first, in the order
+// they were registered.
+func (s *ServiceRegistry) SetStopOrder(order ...interface{}) {
+	s.stopOrder = make([]reflect.Type, len(order))
+	for i, srvc := range order {
+		s.stopOrder[i] = reflect.TypeOf(srvc)
+	}
+}
+
+// stopTypes returns the registered service types in the order they are stopped
+func (s *ServiceRegistry) stopTypes() []reflect.Type {
+	if len(s.stopOrder) == 0 {
+		return s.serviceTypes
+	}
+
+	ordered := make(map[reflect.Type]bool)
+	for _, typ := range s.stopOrder {
+		ordered[typ] = true
+	}
+
+	var types []reflect.Type
 	for _, typ := range s.serviceTypes {
+		if !ordered[typ] {
+			types = append(types, typ)
+		}
+	}
+
+	for _, typ := range s.stopOrder {
+		if _, ok := s.services[typ]; ok {
+			types = append(types, typ)
+		}
+	}
+
+	return types
+}
+
+// StopAll calls `Service.Stop()` for all registered services, in the order set by `SetStopOrder()`
+func (s *ServiceRegistry) StopAll() {
+	types := s.stopTypes()
+	log.Info(fmt.Sprintf("Stopping services: %v", types))
+	for _, typ := range types {
 		log.Debug(fmt.Sprintf("Stopping service %v", typ))
 		err := s.services[typ].Stop()
 		if err != nil {
@@ -17,6 +17,8 @@
 package services
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -47,6 +49,26 @@ func (s *MockSrvcB) Stop() error {
 	return nil
 }
 
+type mockOrderedSrvc struct {
+	name    string
+	stopped *[]string
+}
+
+func (s *mockOrderedSrvc) Start() error { return nil }
+func (s *mockOrderedSrvc) Stop() error {
+	*s.stopped = append(*s.stopped, s.name)
+	return nil
+}
+
+type MockSrvcC struct{ mockOrderedSrvc }
+type MockSrvcD struct{ mockOrderedSrvc }
+type MockSrvcE struct{ mockOrderedSrvc }
+
+type mockFailingSrvc struct{}
+
+func (s *mockFailingSrvc) Start() error { return errors.New("failed to start") }
+func (s *mockFailingSrvc) Stop() error  { return nil }
+
 type FakeService struct{}
 
 func (s *FakeService) Start() error { return nil }
@@ -77,7 +99,10 @@ func TestServiceRegistry_StartStopAll(t *testing.T) {
 	r.RegisterService(a)
 	r.RegisterService(b)
 
-	r.StartAll()
+	err := r.StartAll()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if a.running != true || b.running != true {
 		t.Fatal("failed to start service")
@@ -91,6 +116,48 @@ func TestServiceRegistry_StartStopAll(t *testing.T) {
 
 }
 
+func TestServiceRegistry_StartAll_Error(t *testing.T) {
+	r := NewServiceRegistry()
+
+	a := &MockSrvcA{}
+	b := &MockSrvcB{}
+
+	r.RegisterService(a)
+	r.RegisterService(&mockFailingSrvc{})
+	r.RegisterService(b)
+
+	err := r.StartAll()
+	if err == nil || err.Error() != "failed to start service *services.mockFailingSrvc: failed to start" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// services after the failing service are not started, and the services before it are stopped again
+	if a.running != false || b.running != false {
+		t.Fatal("unexpected services running")
+	}
+}
+
+func TestServiceRegistry_StopOrder(t *testing.T) {
+	r := NewServiceRegistry()
+
+	var stopped []string
+	a := &MockSrvcC{mockOrderedSrvc{name: "a", stopped: &stopped}}
+	b := &MockSrvcD{mockOrderedSrvc{name: "b", stopped: &stopped}}
+	c := &MockSrvcE{mockOrderedSrvc{name: "c", stopped: &stopped}}
+
+	r.RegisterService(a)
+	r.RegisterService(b)
+	r.RegisterService(c)
+
+	// services that are not in the order are stopped first
+	r.SetStopOrder((*MockSrvcE)(nil), (*MockSrvcC)(nil))
+	r.StopAll()
+
+	if !reflect.DeepEqual(stopped, []string{"b", "c", "a"}) {
+		t.Fatalf("services stopped in wrong order: %v", stopped)
+	}
+}
+
 func TestServiceRegistry_Get_Err(t *testing.T) {
 	r := NewServiceRegistry()
 
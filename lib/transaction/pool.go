@@ -1,51 +1,40 @@
 package transaction
 
 import (
-	"sync"
-
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
-// Pool represents the transaction pool
+// Pool represents the transaction pool, ordered by transaction priority
 type Pool struct {
-	transactions map[common.Hash]*ValidTransaction
-	mu           sync.RWMutex
+	queue *PriorityQueue
 }
 
 // NewPool returns a new empty Pool
 func NewPool() *Pool {
 	return &Pool{
-		transactions: make(map[common.Hash]*ValidTransaction),
+		queue: NewPriorityQueue(),
 	}
 }
 
-// Transactions returns all the transactions in the pool
+// Transactions returns all the transactions in the pool, ordered by priority
 func (p *Pool) Transactions() []*ValidTransaction {
-	txs := make([]*ValidTransaction, len(p.transactions))
-	i := 0
-
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-
-	for _, tx := range p.transactions {
-		txs[i] = tx
-		i++
-	}
-	return txs
+	return p.queue.PendingInOrder()
 }
 
-// Insert inserts a transaction into the pool
+// Insert inserts a transaction into the pool, replacing it if it is already present
 func (p *Pool) Insert(tx *ValidTransaction) common.Hash {
+	p.queue.Lock()
+	defer p.queue.Unlock()
+
 	hash := tx.Extrinsic.Hash()
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.transactions[hash] = tx
+	p.queue.remove(hash)
+	p.queue.push(hash, tx)
 	return hash
 }
 
 // Remove removes a transaction from the pool
 func (p *Pool) Remove(hash common.Hash) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	delete(p.transactions, hash)
+	p.queue.Lock()
+	defer p.queue.Unlock()
+	p.queue.remove(hash)
 }
@@ -50,3 +50,24 @@ func TestPool(t *testing.T) {
 	}
 	require.Equal(t, 0, len(p.Transactions()))
 }
+
+func TestPool_TransactionsInOrder(t *testing.T) {
+	a := &ValidTransaction{Extrinsic: []byte("a"), Validity: &Validity{Priority: 1}}
+	b := &ValidTransaction{Extrinsic: []byte("b"), Validity: &Validity{Priority: 4}}
+	c := &ValidTransaction{Extrinsic: []byte("c"), Validity: &Validity{Priority: 4}}
+	d := &ValidTransaction{Extrinsic: []byte("d"), Validity: &Validity{Priority: 17}}
+
+	p := NewPool()
+	for _, tx := range []*ValidTransaction{a, b, c, d} {
+		p.Insert(tx)
+	}
+	require.Equal(t, []*ValidTransaction{d, b, c, a}, p.Transactions())
+
+	// re-inserting a transaction with a new priority replaces it
+	a2 := &ValidTransaction{Extrinsic: []byte("a"), Validity: &Validity{Priority: 30}}
+	p.Insert(a2)
+	require.Equal(t, []*ValidTransaction{a2, d, b, c}, p.Transactions())
+
+	p.Remove(d.Extrinsic.Hash())
+	require.Equal(t, []*ValidTransaction{a2, b, c}, p.Transactions())
+}
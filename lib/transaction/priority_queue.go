@@ -19,6 +19,7 @@ package transaction
 import (
 	"container/heap"
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -103,7 +104,11 @@ func (spq *PriorityQueue) RemoveExtrinsic(ext types.Extrinsic) {
 	spq.Lock()
 	defer spq.Unlock()
 
-	hash := ext.Hash()
+	spq.remove(ext.Hash())
+}
+
+// remove removes the transaction with the given hash from the queue, the caller must hold the lock
+func (spq *PriorityQueue) remove(hash common.Hash) {
 	item, ok := spq.txs[hash]
 	if !ok {
 		return
@@ -123,6 +128,12 @@ func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 		return hash, ErrTransactionExists
 	}
 
+	spq.push(hash, txn)
+	return hash, nil
+}
+
+// push inserts a valid transaction into the heap, the caller must hold the lock
+func (spq *PriorityQueue) push(hash common.Hash, txn *ValidTransaction) {
 	item := &Item{
 		data:     txn,
 		hash:     hash,
@@ -132,8 +143,6 @@ func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 	spq.currOrder++
 	heap.Push(&spq.pq, item)
 	spq.txs[hash] = item
-
-	return hash, nil
 }
 
 // Pop removes the transaction with has the highest priority value from the queue and returns it.
@@ -171,3 +180,25 @@ func (spq *PriorityQueue) Pending() []*ValidTransaction {
 	}
 	return txns
 }
+
+// PendingInOrder returns all the transactions currently in the queue in the order they would be popped,
+// without removing them from the queue
+func (spq *PriorityQueue) PendingInOrder() []*ValidTransaction {
+	spq.Lock()
+	defer spq.Unlock()
+
+	items := make(priorityQueue, spq.pq.Len())
+	copy(items, spq.pq)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].priority == items[j].priority {
+			return items[i].order < items[j].order
+		}
+		return items[i].priority > items[j].priority
+	})
+
+	txns := make([]*ValidTransaction, len(items))
+	for i, item := range items {
+		txns[i] = item.data
+	}
+	return txns
+}
@@ -259,3 +259,55 @@ func TestRemoveExtrinsic(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", res, tests[1])
 	}
 }
+
+func TestPendingInOrder(t *testing.T) {
+	tests := []*ValidTransaction{
+		{
+			Extrinsic: []byte("a"),
+			Validity:  &Validity{Priority: 2},
+		},
+		{
+			Extrinsic: []byte("b"),
+			Validity:  &Validity{Priority: 7},
+		},
+		{
+			Extrinsic: []byte("c"),
+			Validity:  &Validity{Priority: 2},
+		},
+		{
+			Extrinsic: []byte("d"),
+			Validity:  &Validity{Priority: 9},
+		},
+		{
+			Extrinsic: []byte("e"),
+			Validity:  &Validity{Priority: 1},
+		},
+	}
+
+	expected := []*ValidTransaction{tests[3], tests[1], tests[0], tests[2], tests[4]}
+
+	pq := NewPriorityQueue()
+	for _, node := range tests {
+		pq.Push(node)
+	}
+
+	pending := pq.PendingInOrder()
+	if !reflect.DeepEqual(pending, expected) {
+		t.Fatalf("Fail: got %v expected %v", pending, expected)
+	}
+
+	// PendingInOrder must not remove anything from the queue
+	if len(pq.Pending()) != len(tests) {
+		t.Fatalf("Fail: got %d pending expected %d", len(pq.Pending()), len(tests))
+	}
+
+	for _, exp := range expected {
+		if tx := pq.Pop(); !reflect.DeepEqual(tx, exp) {
+			t.Fatalf("Fail: got %v expected %v", tx, exp)
+		}
+	}
+
+	if len(pq.PendingInOrder()) != 0 {
+		t.Fatalf("Fail: expected empty queue")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"errors"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// DefaultNodeCacheSize is the default number of decoded nodes held in a NodeCache
+const DefaultNodeCacheSize = 8192
+
+// ErrInvalidNodeCacheSize is returned when creating a NodeCache with a non-positive size
+var ErrInvalidNodeCacheSize = errors.New("node cache size must be positive")
+
+// NodeCache is an LRU cache of decoded trie nodes keyed by node hash, used to avoid re-reading and re-decoding nodes
+// from the database on repeated reads. It is safe for concurrent use. A nil *NodeCache caches nothing.
+type NodeCache struct {
+	nodes *lru.Cache
+}
+
+// NewNodeCache creates a NodeCache holding at most size nodes. Once full, the least recently used node is evicted.
+func NewNodeCache(size int) (*NodeCache, error) {
+	if size <= 0 {
+		return nil, ErrInvalidNodeCacheSize
+	}
+
+	nodes, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeCache{
+		nodes: nodes,
+	}, nil
+}
+
+// Len returns the number of nodes in the cache
+func (c *NodeCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.nodes.Len()
+}
+
+// Purge removes all nodes from the cache
+func (c *NodeCache) Purge() {
+	if c == nil {
+		return
+	}
+	c.nodes.Purge()
+}
+
+func (c *NodeCache) get(hash []byte) (node, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	n, has := c.nodes.Get(string(hash))
+	if !has {
+		return nil, false
+	}
+	return n.(node), true
+}
+
+func (c *NodeCache) add(hash []byte, n node) {
+	if c == nil {
+		return
+	}
+	c.nodes.Add(string(hash), n)
+}
+
+func (c *NodeCache) remove(hash []byte) {
+	if c == nil {
+		return
+	}
+	c.nodes.Remove(string(hash))
+}
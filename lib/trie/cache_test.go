@@ -0,0 +1,158 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDB counts the reads made from the underlying database
+type countingDB struct {
+	chaindb.Database
+	reads uint64
+}
+
+func (db *countingDB) Get(key []byte) ([]byte, error) {
+	atomic.AddUint64(&db.reads, 1)
+	return db.Database.Get(key)
+}
+
+func TestNewNodeCache_InvalidSize(t *testing.T) {
+	_, err := NewNodeCache(0)
+	require.ErrorIs(t, err, ErrInvalidNodeCacheSize)
+}
+
+func TestNodeCache_Eviction(t *testing.T) {
+	cache, err := NewNodeCache(2)
+	require.NoError(t, err)
+
+	cache.add([]byte("a"), &leaf{value: []byte("a")})
+	cache.add([]byte("b"), &leaf{value: []byte("b")})
+	_, has := cache.get([]byte("a"))
+	require.True(t, has)
+
+	// b is the least recently used node, so it's evicted
+	cache.add([]byte("c"), &leaf{value: []byte("c")})
+	require.Equal(t, 2, cache.Len())
+	_, has = cache.get([]byte("b"))
+	require.False(t, has)
+	_, has = cache.get([]byte("a"))
+	require.True(t, has)
+
+	cache.Purge()
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestGetFromDBWithCache(t *testing.T) {
+	db := &countingDB{Database: newTestDB(t)}
+	cache, err := NewNodeCache(DefaultNodeCacheSize)
+	require.NoError(t, err)
+
+	tests := GenerateRandomTests(t, 1000)
+	tt := NewEmptyTrie()
+	for _, test := range tests {
+		tt.Put(test.key, test.value)
+	}
+	err = tt.WriteDirtyWithCache(db, cache)
+	require.NoError(t, err)
+	root := tt.MustHash()
+
+	for _, test := range tests {
+		val, err := GetFromDBWithCache(db, cache, root, test.key)
+		require.NoError(t, err)
+		require.Equal(t, test.value, val)
+	}
+
+	reads := atomic.LoadUint64(&db.reads)
+	for _, test := range tests {
+		val, err := GetFromDBWithCache(db, cache, root, test.key)
+		require.NoError(t, err)
+		require.Equal(t, test.value, val)
+	}
+	require.Equal(t, reads, atomic.LoadUint64(&db.reads))
+
+	// after intervening writes, both the previous and the new state can be read
+	for _, test := range tests[:100] {
+		tt.Put(test.key, []byte("updated"))
+	}
+	tt.Delete(tests[100].key)
+	err = tt.WriteDirtyWithCache(db, cache)
+	require.NoError(t, err)
+	newRoot := tt.MustHash()
+
+	for i, test := range tests {
+		val, err := GetFromDBWithCache(db, cache, root, test.key)
+		require.NoError(t, err)
+		require.Equal(t, test.value, val)
+
+		val, err = GetFromDBWithCache(db, cache, newRoot, test.key)
+		require.NoError(t, err)
+		switch {
+		case i < 100:
+			require.Equal(t, []byte("updated"), val)
+		case i == 100:
+			require.Nil(t, val)
+		default:
+			require.Equal(t, test.value, val)
+		}
+
+		// the cache doesn't change the result of a lookup
+		expected, err := GetFromDB(db, newRoot, test.key)
+		require.NoError(t, err)
+		require.Equal(t, expected, val)
+	}
+}
+
+func BenchmarkGetFromDB(b *testing.B) {
+	tt := NewEmptyTrie()
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key_%04d", i))
+		tt.Put(keys[i], []byte(fmt.Sprintf("value_%04d", i)))
+	}
+
+	db := &countingDB{Database: newTestDB(b)}
+	err := tt.WriteDirty(db)
+	require.NoError(b, err)
+	root := tt.MustHash()
+
+	b.Run("uncached", func(b *testing.B) {
+		atomic.StoreUint64(&db.reads, 0)
+		for i := 0; i < b.N; i++ {
+			_, err := GetFromDB(db, root, keys[i%len(keys)])
+			require.NoError(b, err)
+		}
+		b.ReportMetric(float64(atomic.LoadUint64(&db.reads))/float64(b.N), "reads/op")
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cache, err := NewNodeCache(DefaultNodeCacheSize)
+		require.NoError(b, err)
+
+		atomic.StoreUint64(&db.reads, 0)
+		for i := 0; i < b.N; i++ {
+			_, err := GetFromDBWithCache(db, cache, root, keys[i%len(keys)])
+			require.NoError(b, err)
+		}
+		b.ReportMetric(float64(atomic.LoadUint64(&db.reads))/float64(b.N), "reads/op")
+	})
+}
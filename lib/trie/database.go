@@ -18,26 +18,71 @@ package trie
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 
 	"github.com/ChainSafe/chaindb"
+	log "github.com/ChainSafe/log15"
 )
 
+var logger = log.New("pkg", "trie")
+
 // Store stores each trie node in the database, where the key is the hash of the encoded node and the value is the encoded node.
 // Generally, this will only be used for the genesis trie.
 func (t *Trie) Store(db chaindb.Database) error {
 	batch := db.NewBatch()
-	err := t.store(batch, t.root)
+	err := t.storeTrie(batch)
 	if err != nil {
 		batch.Reset()
 		return err
 	}
 
+	for _, child := range t.childTries {
+		if child == nil {
+			continue
+		}
+
+		err = child.storeTrie(batch)
+		if err != nil {
+			batch.Reset()
+			return err
+		}
+	}
+
 	return batch.Flush()
 }
 
+// storeTrie stores each node of the trie. If the encoded root is shorter than 32 bytes it is also stored under the
+// trie hash, since that is the key used by Load.
+func (t *Trie) storeTrie(db chaindb.Batch) error {
+	if t.root == nil {
+		return nil
+	}
+
+	err := t.store(db, t.root)
+	if err != nil {
+		return err
+	}
+
+	enc, hash, err := t.root.encodeAndHash()
+	if err != nil {
+		return err
+	}
+
+	if len(hash) == 32 {
+		return nil
+	}
+
+	root, err := common.Blake2bHash(enc)
+	if err != nil {
+		return err
+	}
+
+	return db.Put(root[:], enc)
+}
+
 func (t *Trie) store(db chaindb.Batch, curr node) error {
 	if curr == nil {
 		return nil
@@ -93,7 +138,88 @@ func (t *Trie) Load(db chaindb.Database, root common.Hash) error {
 	t.root.setDirty(false)
 	t.root.setEncodingAndHash(enc, root[:])
 
-	return t.load(db, t.root)
+	if err = t.load(db, t.root); err != nil {
+		return err
+	}
+
+	return t.loadChildTries(db)
+}
+
+// loadChildTries loads the child tries referenced at keys :child_storage:default:[keyToChild]. Child tries whose
+// nodes were never written to the database (eg. state imported from key-value pairs) are skipped.
+func (t *Trie) loadChildTries(db chaindb.Database) error {
+	for _, key := range t.GetKeysWithPrefix(ChildStorageKeyPrefix) {
+		childHash := common.BytesToHash(t.Get(key))
+		child := NewEmptyTrie()
+		err := child.Load(db, childHash)
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			logger.Debug("child trie not found in database, skipping", "key", fmt.Sprintf("0x%x", key), "root", childHash)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load child trie at key 0x%x: %w", key, err)
+		}
+
+		t.childTries[childHash] = child
+	}
+
+	return nil
+}
+
+// Verify re-encodes each node of the trie and its child tries and checks that the hash of the encoding matches the
+// hash the node was loaded with. It is used to check that the nodes of a trie loaded from the database are not corrupt.
+func (t *Trie) Verify() error {
+	if err := t.verify(t.root); err != nil {
+		return err
+	}
+
+	for _, child := range t.childTries {
+		if child == nil {
+			continue
+		}
+
+		if err := child.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Trie) verify(curr node) error {
+	if curr == nil {
+		return nil
+	}
+
+	enc, err := encode(curr)
+	if err != nil {
+		return err
+	}
+
+	// the root is always stored under the hash of its encoding, other nodes under 32 bytes are stored under their encoding
+	hash := enc
+	if curr == t.root || len(enc) >= 32 {
+		h, err := common.Blake2bHash(enc)
+		if err != nil {
+			return err
+		}
+
+		hash = h[:]
+	}
+
+	if !bytes.Equal(hash, curr.getHash()) {
+		return fmt.Errorf("node key=0x%x is corrupt: its encoding hashes to 0x%x", curr.getHash(), hash)
+	}
+
+	if c, ok := curr.(*branch); ok {
+		for _, child := range c.children {
+			if err = t.verify(child); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func (t *Trie) load(db chaindb.Database, curr node) error {
@@ -148,26 +274,47 @@ func (t *Trie) ClearPrefixFromDB(db chaindb.Database, prefix []byte) error {
 
 // GetFromDB retrieves a value from the trie using the database. It recursively descends into the trie using the database starting from the root node until it reaches the node with the given key. It then reads the value from the database.
 func GetFromDB(db chaindb.Database, root common.Hash, key []byte) ([]byte, error) {
+	return GetFromDBWithCache(db, nil, root, key)
+}
+
+// GetFromDBWithCache retrieves a value from the trie using the database, like GetFromDB. Decoded nodes are looked up in
+// and added to the given cache, so repeated reads of the same trie don't need to read and decode them again.
+func GetFromDBWithCache(db chaindb.Database, cache *NodeCache, root common.Hash, key []byte) ([]byte, error) {
 	if root == EmptyHash {
 		return nil, nil
 	}
 
 	k := keyToNibbles(key)
 
-	enc, err := db.Get(root[:])
+	rootNode, err := loadNode(db, cache, root[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to find root key=%s: %w", root, err)
 	}
 
-	rootNode, err := decodeBytes(enc)
+	return getFromDB(db, cache, rootNode, k)
+}
+
+// loadNode returns the decoded node with the given hash from the cache, or reads and decodes it from the database
+func loadNode(db chaindb.Database, cache *NodeCache, hash []byte) (node, error) {
+	if n, has := cache.get(hash); has {
+		return n, nil
+	}
+
+	enc, err := db.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := decodeBytes(enc)
 	if err != nil {
 		return nil, err
 	}
 
-	return getFromDB(db, rootNode, k)
+	cache.add(hash, n)
+	return n, nil
 }
 
-func getFromDB(db chaindb.Database, parent node, key []byte) ([]byte, error) {
+func getFromDB(db chaindb.Database, cache *NodeCache, parent node, key []byte) ([]byte, error) {
 	var value []byte
 
 	switch p := parent.(type) {
@@ -189,17 +336,12 @@ func getFromDB(db chaindb.Database, parent node, key []byte) ([]byte, error) {
 		}
 
 		// load child with potential value
-		enc, err := db.Get(p.children[key[length]].(*leaf).hash)
+		child, err := loadNode(db, cache, p.children[key[length]].(*leaf).hash)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find node in database: %w", err)
 		}
 
-		child, err := decodeBytes(enc)
-		if err != nil {
-			return nil, err
-		}
-
-		value, err = getFromDB(db, child, key[length+1:])
+		value, err = getFromDB(db, cache, child, key[length+1:])
 		if err != nil {
 			return nil, err
 		}
@@ -214,19 +356,37 @@ func getFromDB(db chaindb.Database, parent node, key []byte) ([]byte, error) {
 	return value, nil
 }
 
-// WriteDirty writes all dirty nodes to the database and sets them to clean
+// WriteDirty writes all dirty nodes of the trie and its child tries to the database and sets them to clean
 func (t *Trie) WriteDirty(db chaindb.Database) error {
+	return t.WriteDirtyWithCache(db, nil)
+}
+
+// WriteDirtyWithCache writes all dirty nodes to the database and sets them to clean, like WriteDirty. The written
+// nodes are removed from the given cache, so they are read again from the database on the next lookup.
+func (t *Trie) WriteDirtyWithCache(db chaindb.Database, cache *NodeCache) error {
 	batch := db.NewBatch()
-	err := t.writeDirty(batch, t.root)
+	err := t.writeDirty(batch, cache, t.root)
 	if err != nil {
 		batch.Reset()
 		return err
 	}
 
+	for _, child := range t.childTries {
+		if child == nil {
+			continue
+		}
+
+		err = child.writeDirty(batch, cache, child.root)
+		if err != nil {
+			batch.Reset()
+			return err
+		}
+	}
+
 	return batch.Flush()
 }
 
-func (t *Trie) writeDirty(db chaindb.Batch, curr node) error {
+func (t *Trie) writeDirty(db chaindb.Batch, cache *NodeCache, curr node) error {
 	if curr == nil || !curr.isDirty() {
 		return nil
 	}
@@ -250,6 +410,7 @@ func (t *Trie) writeDirty(db chaindb.Batch, curr node) error {
 	if err != nil {
 		return err
 	}
+	cache.remove(hash)
 
 	if c, ok := curr.(*branch); ok {
 		for _, child := range c.children {
@@ -257,7 +418,7 @@ func (t *Trie) writeDirty(db chaindb.Batch, curr node) error {
 				continue
 			}
 
-			err = t.writeDirty(db, child)
+			err = t.writeDirty(db, cache, child)
 			if err != nil {
 				return err
 			}
@@ -22,11 +22,13 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
+
 	"github.com/ChainSafe/chaindb"
 	"github.com/stretchr/testify/require"
 )
 
-func newTestDB(t *testing.T) chaindb.Database {
+func newTestDB(t testing.TB) chaindb.Database {
 	// TODO: dynamically get os.TMPDIR
 	testDatadirPath, _ := ioutil.TempDir("/tmp", "test-datadir-*")
 
@@ -96,6 +98,111 @@ func TestTrie_DatabaseStoreAndLoad(t *testing.T) {
 	}
 }
 
+func TestTrie_DatabaseStoreAndLoad_ChildTrie(t *testing.T) {
+	trie := NewEmptyTrie()
+	trie.Put([]byte("noot"), []byte("washere"))
+
+	child := NewEmptyTrie()
+	child.Put([]byte("child"), []byte("value"))
+	err := trie.PutChild([]byte("keytochild"), child)
+	require.NoError(t, err)
+
+	db := newTestDB(t)
+	err = trie.Store(db)
+	require.NoError(t, err)
+
+	res := NewEmptyTrie()
+	err = res.Load(db, trie.MustHash())
+	require.NoError(t, err)
+	require.Equal(t, trie.MustHash(), res.MustHash())
+
+	val, err := res.GetFromChild([]byte("keytochild"), []byte("child"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+}
+
+func TestTrie_WriteDirty_ChildTrie(t *testing.T) {
+	trie := NewEmptyTrie()
+	trie.Put([]byte("noot"), []byte("washere"))
+
+	child := NewEmptyTrie()
+	child.Put([]byte("child"), []byte("value"))
+	err := trie.PutChild([]byte("keytochild"), child)
+	require.NoError(t, err)
+
+	db := newTestDB(t)
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	err = trie.PutIntoChild([]byte("keytochild"), []byte("other"), []byte("othervalue"))
+	require.NoError(t, err)
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	res := NewEmptyTrie()
+	err = res.Load(db, trie.MustHash())
+	require.NoError(t, err)
+
+	val, err := res.GetFromChild([]byte("keytochild"), []byte("other"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("othervalue"), val)
+}
+
+func TestTrie_Load_MissingChildTrie(t *testing.T) {
+	trie := NewEmptyTrie()
+	trie.Put([]byte("noot"), []byte("washere"))
+	trie.Put(append(ChildStorageKeyPrefix, []byte("keytochild")...), common.Hash{1}.ToBytes())
+
+	db := newTestDB(t)
+	err := trie.Store(db)
+	require.NoError(t, err)
+
+	// the child trie isn't in the database, eg. state imported from key-value pairs, so it's skipped
+	res := NewEmptyTrie()
+	err = res.Load(db, trie.MustHash())
+	require.NoError(t, err)
+	require.Equal(t, trie.MustHash(), res.MustHash())
+	require.Equal(t, []byte("washere"), res.Get([]byte("noot")))
+	require.Nil(t, res.childTries[common.Hash{1}])
+}
+
+func TestTrie_Verify(t *testing.T) {
+	trie := NewEmptyTrie()
+	trie.Put([]byte("noot"), bytes.Repeat([]byte{1}, 64))
+	trie.Put([]byte("other"), bytes.Repeat([]byte{2}, 64))
+	trie.Put([]byte("a"), []byte("b"))
+
+	db := newTestDB(t)
+	err := trie.Store(db)
+	require.NoError(t, err)
+
+	res := NewEmptyTrie()
+	err = res.Load(db, trie.MustHash())
+	require.NoError(t, err)
+	require.NoError(t, res.Verify())
+
+	// corrupt the value of a leaf, which still decodes but no longer matches its hash
+	key := trie.MustHash()
+	rootEnc, err := db.Get(key[:])
+	require.NoError(t, err)
+	childHash := rootEnc[len(rootEnc)-32:]
+	enc, err := db.Get(childHash)
+	require.NoError(t, err)
+	corrupt := append([]byte{}, enc...)
+	corrupt[len(corrupt)-1]++
+	err = db.Put(childHash, corrupt)
+	require.NoError(t, err)
+
+	res = NewEmptyTrie()
+	err = res.Load(db, trie.MustHash())
+	require.NoError(t, err)
+	require.Equal(t, trie.MustHash(), res.MustHash())
+
+	err = res.Verify()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("node key=0x%x is corrupt", childHash))
+}
+
 func TestTrie_WriteDirty_Put(t *testing.T) {
 	cases := [][]Test{
 		{
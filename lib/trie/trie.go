@@ -148,6 +148,38 @@ func (t *Trie) entries(current node, prefix []byte, kv map[string][]byte) map[st
 	return kv
 }
 
+// Iterate calls fn for each key-value pair in the trie in lexicographic order of the keys.
+// It stops and returns the error if fn returns one.
+func (t *Trie) Iterate(fn func(key, value []byte) error) error {
+	return t.iterate(t.root, nil, fn)
+}
+
+func (t *Trie) iterate(current node, prefix []byte, fn func(key, value []byte) error) error {
+	switch c := current.(type) {
+	case *branch:
+		fullKey := append(prefix[:len(prefix):len(prefix)], c.key...)
+		if c.value != nil {
+			if err := fn(nibblesToKeyLE(fullKey), c.value); err != nil {
+				return err
+			}
+		}
+
+		for i, child := range c.children {
+			if child == nil {
+				continue
+			}
+
+			if err := t.iterate(child, append(fullKey[:len(fullKey):len(fullKey)], byte(i)), fn); err != nil {
+				return err
+			}
+		}
+	case *leaf:
+		return fn(nibblesToKeyLE(append(prefix[:len(prefix):len(prefix)], c.key...)), c.value)
+	}
+
+	return nil
+}
+
 // NextKey returns the next key in the trie in lexicographic order. It returns nil if there is no next key
 func (t *Trie) NextKey(key []byte) []byte {
 	k := keyToNibbles(key)
@@ -375,6 +407,41 @@ func (t *Trie) updateBranch(p *branch, key []byte, value node) (n node) {
 	return br
 }
 
+// Entry is a key-value pair to insert into the trie
+type Entry struct {
+	Key, Value []byte
+}
+
+// PutBatch inserts the given entries into the trie in order, so the resulting trie is the same as when calling Put for
+// each entry. Inserting only marks the updated nodes as dirty; they are encoded and hashed once the next time the
+// root hash is computed, no matter how many entries are inserted.
+func (t *Trie) PutBatch(entries []Entry) {
+	for _, e := range entries {
+		t.tryPut(e.Key, e.Value)
+	}
+}
+
+// Change is an insertion of Value at Key into the trie, or the deletion of Key if Delete is set
+type Change struct {
+	Key, Value []byte
+	Delete     bool
+}
+
+// ApplyChanges applies the given inserts and deletes to the trie in order and returns the new root hash. The nodes are
+// only encoded and hashed once after all the changes are applied, rather than after each of them.
+func (t *Trie) ApplyChanges(changes []Change) (common.Hash, error) {
+	for _, c := range changes {
+		if c.Delete {
+			t.Delete(c.Key)
+			continue
+		}
+
+		t.tryPut(c.Key, c.Value)
+	}
+
+	return t.Hash()
+}
+
 // LoadFromMap loads the given data into trie
 func (t *Trie) LoadFromMap(data map[string]string) error {
 	for key, value := range data {
@@ -19,6 +19,7 @@ package trie
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -1026,6 +1027,43 @@ func RandStringBytes(n int) string {
 	return string(b)
 }
 
+func TestIterate(t *testing.T) {
+	trie := NewEmptyTrie()
+
+	testCaseMap := make(map[string]struct{})
+	for i := 0; i < 1000; i++ {
+		testCaseMap[RandStringBytes(1+rand.Intn(20))] = struct{}{}
+	}
+
+	expected := make([][]byte, 0, len(testCaseMap))
+	for k := range testCaseMap {
+		expected = append(expected, []byte(k))
+		trie.Put([]byte(k), append([]byte("value"), k...))
+	}
+
+	sort.Slice(expected, func(i, j int) bool {
+		return bytes.Compare(expected[i], expected[j]) < 0
+	})
+
+	keys := [][]byte{}
+	err := trie.Iterate(func(key, value []byte) error {
+		require.Equal(t, append([]byte("value"), key...), value)
+		keys = append(keys, key)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, keys)
+
+	errStop := errors.New("stop")
+	count := 0
+	err = trie.Iterate(func(key, value []byte) error {
+		count++
+		return errStop
+	})
+	require.Equal(t, errStop, err)
+	require.Equal(t, 1, count)
+}
+
 func TestNextKey_Random(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		trie := NewEmptyTrie()
@@ -1070,3 +1108,154 @@ func TestNextKey_Random(t *testing.T) {
 		}
 	}
 }
+
+func TestPutBatch(t *testing.T) {
+	var entries []Entry
+	for _, test := range GenerateRandomTests(t, 2000) {
+		entries = append(entries, Entry{Key: test.key, Value: test.value})
+	}
+
+	// keys that are prefixes of each other, an empty value and a key given more than once
+	entries = append(entries,
+		Entry{Key: []byte{0x01}, Value: []byte("a")},
+		Entry{Key: []byte{0x01, 0x23}, Value: []byte("b")},
+		Entry{Key: []byte{0x01, 0x23, 0x45}, Value: []byte{}},
+		Entry{Key: []byte{}, Value: []byte("root")},
+		Entry{Key: []byte{0x01}, Value: []byte("c")},
+	)
+
+	sequential := NewEmptyTrie()
+	for _, e := range entries {
+		sequential.Put(e.Key, e.Value)
+	}
+
+	batch := NewEmptyTrie()
+	batch.PutBatch(entries)
+
+	require.Equal(t, sequential.MustHash(), batch.MustHash())
+	require.Equal(t, sequential.Entries(), batch.Entries())
+	require.Equal(t, []byte("c"), batch.Get([]byte{0x01}))
+
+	// the tries stay the same after further updates
+	for _, e := range entries[:500] {
+		sequential.Delete(e.Key)
+		batch.Delete(e.Key)
+	}
+	sequential.Put([]byte("new"), []byte("value"))
+	batch.Put([]byte("new"), []byte("value"))
+	require.Equal(t, sequential.MustHash(), batch.MustHash())
+}
+
+func TestPutBatch_NonEmptyTrie(t *testing.T) {
+	sequential := buildSmallTrie()
+	batch := buildSmallTrie()
+
+	entries := []Entry{
+		{Key: []byte{0x01, 0x35}, Value: []byte("pencil")},
+		{Key: []byte{0x02}, Value: []byte("new")},
+		{Key: []byte{0x03}, Value: nil},
+	}
+	for _, e := range entries {
+		sequential.Put(e.Key, e.Value)
+	}
+	batch.PutBatch(entries)
+
+	require.Equal(t, sequential.MustHash(), batch.MustHash())
+}
+
+func BenchmarkPutBatch(b *testing.B) {
+	r := rand.New(rand.NewSource(0)) //nolint
+	// keys of storage maps share a prefix of the hashed module and storage item names, like in the genesis state
+	prefixes := make([][]byte, 10)
+	for i := range prefixes {
+		prefixes[i] = make([]byte, 32)
+		r.Read(prefixes[i])
+	}
+
+	entries := make([]Entry, 5000)
+	for i := range entries {
+		key := make([]byte, 64)
+		copy(key, prefixes[i%len(prefixes)])
+		r.Read(key[32:])
+		value := make([]byte, 64)
+		r.Read(value)
+		entries[i] = Entry{Key: key, Value: value}
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			trie := NewEmptyTrie()
+			for _, e := range entries {
+				trie.Put(e.Key, e.Value)
+			}
+			trie.MustHash()
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			trie := NewEmptyTrie()
+			trie.PutBatch(entries)
+			trie.MustHash()
+		}
+	})
+}
+
+func TestApplyChanges(t *testing.T) {
+	tests := GenerateRandomTests(t, 1000)
+
+	trie := NewEmptyTrie()
+	for _, test := range tests {
+		trie.Put(test.key, test.value)
+	}
+
+	// a branch with two children, which collapses into a leaf once one of them is deleted
+	trie.Put([]byte{0xaa, 0x01}, []byte("a"))
+	trie.Put([]byte{0xaa, 0x02}, []byte("b"))
+	// a branch with a value and a single child, which collapses into a leaf once the child is deleted
+	trie.Put([]byte{0xbb}, []byte("c"))
+	trie.Put([]byte{0xbb, 0x01}, []byte("d"))
+
+	var changes []Change
+	for _, test := range tests[:300] {
+		changes = append(changes, Change{Key: test.key, Delete: true})
+	}
+	for _, test := range tests[300:400] {
+		changes = append(changes, Change{Key: test.key, Value: []byte("updated")})
+	}
+	changes = append(changes,
+		Change{Key: []byte{0xaa, 0x01}, Delete: true},
+		Change{Key: []byte{0xbb, 0x01}, Delete: true},
+		Change{Key: []byte("new"), Value: []byte("value")},
+		Change{Key: []byte("deleted"), Value: []byte("value")},
+		Change{Key: []byte("deleted"), Delete: true},
+		Change{Key: []byte("not_exist"), Delete: true},
+	)
+
+	sequential, err := trie.DeepCopy()
+	require.NoError(t, err)
+	for _, c := range changes {
+		if c.Delete {
+			sequential.Delete(c.Key)
+		} else {
+			sequential.Put(c.Key, c.Value)
+		}
+	}
+
+	root, err := trie.ApplyChanges(changes)
+	require.NoError(t, err)
+	require.Equal(t, sequential.MustHash(), root)
+	require.Equal(t, root, trie.MustHash())
+
+	// the result is the same as a trie built from the remaining entries only
+	expected := NewEmptyTrie()
+	for k, v := range trie.Entries() {
+		expected.Put([]byte(k), v)
+	}
+	require.Equal(t, expected.MustHash(), root)
+
+	require.Nil(t, trie.Get([]byte{0xaa, 0x01}))
+	require.Equal(t, []byte("b"), trie.Get([]byte{0xaa, 0x02}))
+	require.Equal(t, []byte("c"), trie.Get([]byte{0xbb}))
+	require.Nil(t, trie.Get([]byte("deleted")))
+}
@@ -0,0 +1,188 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	logOutputLock sync.RWMutex
+	logOutput     io.Writer = os.Stdout
+	logFile       io.Closer
+)
+
+// LogOutput returns the writer that log handlers should write to. It defaults to os.Stdout.
+func LogOutput() io.Writer {
+	logOutputLock.RLock()
+	defer logOutputLock.RUnlock()
+	return logOutput
+}
+
+// SetLogOutput sets the writer that log handlers should write to. It must be called before any
+// log handlers are created.
+func SetLogOutput(w io.Writer) {
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+	logOutput = w
+}
+
+// SetLogFile sets the writer that log handlers should write to to os.Stdout and the given file. The file is closed
+// by CloseLogFile. It must be called before any log handlers are created.
+func SetLogFile(f io.WriteCloser) {
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+	logOutput = io.MultiWriter(os.Stdout, f)
+	logFile = f
+}
+
+// CloseLogFile closes the file set by SetLogFile, if there is one. Log handlers keep writing to os.Stdout.
+func CloseLogFile() error {
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+
+	if logFile == nil {
+		return nil
+	}
+
+	err := logFile.Close()
+	logFile = nil
+	return err
+}
+
+// RotatingFile is a file that is rotated once it reaches its maximum size. Once rotated, the file at path is
+// renamed to path.1, path.1 is renamed to path.2, and so on, keeping at most maxFiles rotated files.
+// It is safe for concurrent use.
+type RotatingFile struct {
+	lock     sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingFile opens the file at the given path for appending, creating it if it doesn't exist.
+// The file is rotated when a write would make it larger than maxSize bytes.
+func NewRotatingFile(path string, maxSize int64, maxFiles int) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("max log file size must be greater than 0")
+	}
+
+	if maxFiles < 0 {
+		return nil, errors.New("number of retained log files cannot be negative")
+	}
+
+	f := &RotatingFile{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Write writes p to the file, rotating the file first if it would exceed its maximum size
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (f *RotatingFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate closes the current file, shifts the rotated files and opens a new file. it must be called with
+// the lock held.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.maxFiles == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return f.open()
+	}
+
+	for i := f.maxFiles - 1; i > 0; i-- {
+		err := os.Rename(rotatedFileName(f.path, i), rotatedFileName(f.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(f.path, rotatedFileName(f.path, 1)); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+func rotatedFileName(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
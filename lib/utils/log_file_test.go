@@ -0,0 +1,114 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRotatingFile tests that the file is rotated once it reaches its maximum size
+func TestRotatingFile(t *testing.T) {
+	dir := NewTestDir(t)
+	defer RemoveTestDir(t)
+
+	path := filepath.Join(dir, "gossamer.log")
+	line := strings.Repeat("a", 99) + "\n"
+
+	f, err := NewRotatingFile(path, int64(len(line)*10), 2)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := f.Write([]byte(line)); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	// 40 lines are written to a file that holds 10 lines, so there should be 2 rotated files
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		data, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat(line, 10), string(data))
+	}
+
+	require.False(t, PathExists(path+".3"))
+}
+
+// TestRotatingFile_Append tests that an existing file is appended to
+func TestRotatingFile_Append(t *testing.T) {
+	dir := NewTestDir(t)
+	defer RemoveTestDir(t)
+
+	path := filepath.Join(dir, "gossamer.log")
+	err := ioutil.WriteFile(path, []byte("first\n"), 0644)
+	require.NoError(t, err)
+
+	f, err := NewRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+
+	_, err = f.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	data, err := ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	require.Equal(t, "first\n", string(data))
+
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "second\n", string(data))
+}
+
+func TestSetLogFile_Close(t *testing.T) {
+	dir := NewTestDir(t)
+	defer RemoveTestDir(t)
+
+	f, err := NewRotatingFile(filepath.Join(dir, "gossamer.log"), 1024, 1)
+	require.NoError(t, err)
+
+	SetLogFile(f)
+	defer SetLogOutput(os.Stdout)
+
+	_, err = LogOutput().Write([]byte("line\n"))
+	require.NoError(t, err)
+
+	err = CloseLogFile()
+	require.NoError(t, err)
+
+	// the file is closed, and closing it again does nothing
+	_, err = f.Write([]byte("line\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+	err = CloseLogFile()
+	require.NoError(t, err)
+}
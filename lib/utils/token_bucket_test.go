@@ -0,0 +1,56 @@
+// Copyright 2019 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(2, 3)
+	now := b.last
+
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allowAt(now, 1))
+	}
+	require.False(t, b.allowAt(now, 1))
+
+	// half a second refills one token at 2 tokens per second
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, b.allowAt(now, 1))
+	require.False(t, b.allowAt(now, 1))
+
+	// refilling is capped at the burst size
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allowAt(now, 1))
+	}
+	require.False(t, b.allowAt(now, 1))
+}
+
+func TestTokenBucket_AllowN(t *testing.T) {
+	b := NewTokenBucket(2, 3)
+	now := b.last
+
+	require.False(t, b.allowAt(now, 4))
+	require.True(t, b.allowAt(now, 2))
+	require.False(t, b.allowAt(now, 2))
+	require.True(t, b.allowAt(now, 1))
+}
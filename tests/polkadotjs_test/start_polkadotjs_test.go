@@ -41,9 +41,12 @@ func TestStartGossamerAndPolkadotAPI(t *testing.T) {
 	nodes, err := utils.InitializeAndStartNodesWebsocket(t, 1, utils.GenesisDev, utils.ConfigDefault)
 	require.NoError(t, err)
 
+	// the node's websocket port is chosen at random, so pass it to the tests
 	command := "npx mocha ./test"
 	parts := strings.Fields(command)
-	data, err := exec.Command(parts[0], parts[1:]...).Output()
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Env = append(os.Environ(), "WS_PORT="+nodes[0].WSPort)
+	data, err := cmd.Output()
 	require.NoError(t, err, data)
 
 	//uncomment this to see log results from javascript tests
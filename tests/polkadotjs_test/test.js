@@ -5,7 +5,7 @@ const {Keyring } = require('@polkadot/keyring');
 async function main() {
     // Construct
 
-    const wsProvider = new WsProvider('ws://127.0.0.1:8546');
+    const wsProvider = new WsProvider(`ws://127.0.0.1:${process.env.WS_PORT || 8546}`);
     const api = await ApiPromise.create({ provider: wsProvider });
 
     // chain defaults
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"os"
 	"reflect"
-	"strconv"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/tests/utils"
@@ -28,8 +27,7 @@ import (
 )
 
 var (
-	currentPort = strconv.Itoa(utils.BaseRPCPort)
-	rpcSuite    = "rpc"
+	rpcSuite = "rpc"
 )
 
 func TestMain(m *testing.M) {
@@ -51,13 +49,13 @@ type testCase struct {
 	skip        bool
 }
 
-func getResponse(t *testing.T, test *testCase) interface{} {
+func getResponse(t *testing.T, node *utils.Node, test *testCase) interface{} {
 	if test.skip {
 		t.Skip("RPC endpoint not yet implemented")
 		return nil
 	}
 
-	respBody, err := utils.PostRPC(test.method, utils.NewEndpoint(currentPort), test.params)
+	respBody, err := utils.PostRPC(test.method, utils.NewEndpoint(node.RPCPort), test.params)
 	require.Nil(t, err)
 
 	target := reflect.New(reflect.TypeOf(test.expected)).Interface()
@@ -113,7 +113,7 @@ func TestSystemRPC(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			target := getResponse(t, test)
+			target := getResponse(t, nodes[0], test)
 
 			switch v := target.(type) {
 			case *modules.SystemHealthResponse:
@@ -171,7 +171,7 @@ func TestAuthorRPC(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			_ = getResponse(t, test)
+			_ = getResponse(t, nodes[0], test)
 		})
 	}
 
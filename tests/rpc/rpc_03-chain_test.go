@@ -19,11 +19,13 @@ package rpc
 import (
 	"fmt"
 	"log"
+	"math/big"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/tests/utils"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
@@ -87,7 +89,7 @@ func TestChainRPC(t *testing.T) {
 				test.params = "[\"" + chainBlockHeaderHash + "\"]"
 			}
 
-			target := getResponse(t, test)
+			target := getResponse(t, nodes[0], test)
 
 			switch v := target.(type) {
 			case *modules.ChainBlockHeaderResponse:
@@ -194,7 +196,7 @@ func TestChainSubscriptionRPC(t *testing.T) {
 	for _, test := range testCases {
 
 		t.Run(test.description, func(t *testing.T) {
-			callWebsocket(t, test)
+			callWebsocket(t, nodes[0], test)
 		})
 	}
 
@@ -204,11 +206,48 @@ func TestChainSubscriptionRPC(t *testing.T) {
 	require.Len(t, errList, 0)
 }
 
-func callWebsocket(t *testing.T, test *testCase) {
+func TestChainSubscribeNewHeads(t *testing.T) {
+	if utils.MODE != rpcSuite {
+		_, _ = fmt.Fprintln(os.Stdout, "Going to skip RPC suite tests")
+		return
+	}
+
+	t.Log("starting gossamer...")
+	nodes, err := utils.InitializeAndStartNodesWebsocket(t, 1, utils.GenesisDev, utils.ConfigDefault)
+	require.NoError(t, err)
+
+	defer func() {
+		t.Log("going to tear down gossamer...")
+		errList := utils.TearDown(t, nodes)
+		require.Len(t, errList, 0)
+	}()
+
+	conn, err := utils.NewWSConn(t, nodes[0])
+	require.NoError(t, err)
+
+	sub, err := conn.Subscribe("chain_subscribeNewHeads", "[]", time.Second*5)
+	require.NoError(t, err)
+
+	// the dev chain builds a block every slot, so each new head has a higher number
+	var prev uint64
+	for i := 0; i < 3; i++ {
+		header := new(modules.ChainBlockHeaderResponse)
+		err = sub.Next(header, time.Minute)
+		require.NoError(t, err)
+
+		nb, err := common.HexToBytes(header.Number)
+		require.NoError(t, err)
+		num := new(big.Int).SetBytes(nb).Uint64()
+		require.Greater(t, num, prev)
+		prev = num
+	}
+}
+
+func callWebsocket(t *testing.T, node *utils.Node, test *testCase) {
 	if test.skip {
 		t.Skip("Websocket endpoint not yet implemented")
 	}
-	url := "ws://localhost:8546/" // todo don't hard code this
+	url := "ws://localhost:" + node.WSPort + "/"
 	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
 	require.NoError(t, err)
 	defer ws.Close()
@@ -58,7 +58,7 @@ func TestOffchainRPC(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			_ = getResponse(t, test)
+			_ = getResponse(t, nodes[0], test)
 		})
 	}
 
@@ -53,8 +53,8 @@ func TestStateRPCResponseValidation(t *testing.T) {
 		{
 			description: "Test state_call",
 			method:      "state_call",
-			params:      `["", "","0x580d77a9136035a0bc3c3cd86286172f7f81291164c5914266073a30466fba21"]`,
-			expected:    modules.StateCallResponse{},
+			params:      fmt.Sprintf(`["Core_version", "0x", "%s"]`, blockHash.String()),
+			expected:    modules.StateCallResponse(""),
 		},
 		{ //TODO disable skip when implemented
 			description: "Test state_getKeysPaged",
@@ -64,25 +64,25 @@ func TestStateRPCResponseValidation(t *testing.T) {
 		{
 			description: "Test state_getChildKeys",
 			method:      "state_getChildKeys",
-			params:      `["","","0x579deccea7183c2afedbdaea59ad23e970458186afc4d57d5577842d4a219925"]`,
+			params:      fmt.Sprintf(`["0x", "0x", "%s"]`, blockHash.String()),
 			expected:    modules.StateKeysResponse{},
 		},
 		{
 			description: "Test state_getChildStorage",
 			method:      "state_getChildStorage",
-			params:      `["","","0x579deccea7183c2afedbdaea59ad23e970458186afc4d57d5577842d4a219925"]`,
+			params:      fmt.Sprintf(`["0x", "0x", "%s"]`, blockHash.String()),
 			expected:    modules.StateStorageDataResponse(""),
 		},
 		{
 			description: "Test state_getChildStorageHash",
 			method:      "state_getChildStorageHash",
-			params:      `["","","0x579deccea7183c2afedbdaea59ad23e970458186afc4d57d5577842d4a219925"]`,
+			params:      fmt.Sprintf(`["0x", "0x", "%s"]`, blockHash.String()),
 			expected:    modules.StateChildStorageResponse(""),
 		},
 		{
 			description: "Test state_getChildStorageSize",
 			method:      "state_getChildStorageSize",
-			params:      `["","","0x579deccea7183c2afedbdaea59ad23e970458186afc4d57d5577842d4a219925"]`,
+			params:      fmt.Sprintf(`["0x", "0x", "%s"]`, blockHash.String()),
 			expected:    modules.StateChildStorageSizeResponse(0),
 		},
 		{
@@ -149,7 +149,7 @@ func TestStateRPCResponseValidation(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			_ = getResponse(t, test)
+			_ = getResponse(t, nodes[0], test)
 		})
 	}
 
@@ -372,7 +372,7 @@ func TestRPCStructParamUnmarshal(t *testing.T) {
 		require.Len(t, errList, 0)
 	}()
 
-	time.Sleep(2 * time.Second) // Wait for block production
+	utils.WaitForBlock(t, nodes[0], 1, time.Minute)
 
 	test := testCase{
 		description: "Test valid read request in local json2",
@@ -53,7 +53,7 @@ func TestEngineRPC(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			_ = getResponse(t, test)
+			_ = getResponse(t, nodes[0], test)
 		})
 	}
 
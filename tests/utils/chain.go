@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -66,6 +67,54 @@ func GetChainHeadWithError(t *testing.T, node *Node) (*types.Header, error) {
 	return HeaderResponseToHeader(t, header), nil
 }
 
+// blockPollInterval is how often WaitForBlock checks the best block of a node
+var blockPollInterval = time.Millisecond * 200
+
+// WaitForBlock calls the endpoint chain_getHeader until the best block of the node reaches the given
+// number, failing the test if it isn't reached within the timeout. It returns the best block header.
+func WaitForBlock(t *testing.T, node *Node, num uint64, timeout time.Duration) *types.Header {
+	head, err := WaitForBlockWithError(t, node, num, timeout)
+	require.NoError(t, err)
+	return head
+}
+
+// WaitForBlockWithError calls the endpoint chain_getHeader until the best block of the node reaches the
+// given number, returning an error if it isn't reached within the timeout
+func WaitForBlockWithError(t *testing.T, node *Node, num uint64, timeout time.Duration) (*types.Header, error) {
+	var (
+		head *types.Header
+		err  error
+	)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		var respBody []byte
+		respBody, err = PostRPC(ChainGetHeader, NewEndpoint(node.RPCPort), "[]")
+		if err == nil {
+			header := new(modules.ChainBlockHeaderResponse)
+			if err = DecodeRPC(t, respBody, header); err == nil {
+				head = HeaderResponseToHeader(t, header)
+			}
+		}
+
+		if err == nil && head.Number.Uint64() >= num {
+			return head, nil
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(blockPollInterval)
+	}
+
+	if head == nil {
+		return nil, fmt.Errorf("node %d did not reach block %d within %s: %w", node.Idx, num, timeout, err)
+	}
+
+	return nil, fmt.Errorf("node %d did not reach block %d within %s, best block is %s", node.Idx, num, timeout, head.Number)
+}
+
 // GetBlockHash calls the endpoint chain_getBlockHash to get the latest chain head
 func GetBlockHash(t *testing.T, node *Node, num string) (common.Hash, error) {
 	respBody, err := PostRPCWithRetry(ChainGetBlockHash, NewEndpoint(node.RPCPort), "["+num+"]", 5)
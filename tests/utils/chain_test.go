@@ -0,0 +1,96 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockChainNode starts an RPC server whose best block number increases by one with each request to
+// chain_getHeader, up to maxHeight, and returns a Node using it
+func newMockChainNode(t *testing.T, maxHeight int64) *Node {
+	var height int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		num := atomic.AddInt64(&height, 1)
+		if num > maxHeight {
+			num = maxHeight
+		}
+
+		result, err := json.Marshal(&modules.ChainBlockHeaderResponse{
+			ParentHash:     common.Hash{}.String(),
+			Number:         common.BytesToHex(big.NewInt(num).Bytes()),
+			StateRoot:      common.Hash{}.String(),
+			ExtrinsicsRoot: common.Hash{}.String(),
+			Digest:         modules.ChainBlockHeaderDigest{Logs: []string{}},
+		})
+		require.NoError(t, err)
+
+		err = json.NewEncoder(w).Encode(&ServerResponse{
+			Version: "2.0",
+			Result:  result,
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	hostname := HOSTNAME
+	HOSTNAME = u.Hostname()
+	t.Cleanup(func() {
+		HOSTNAME = hostname
+	})
+
+	return &Node{
+		RPCPort: u.Port(),
+	}
+}
+
+func TestWaitForBlock(t *testing.T) {
+	node := newMockChainNode(t, 10)
+
+	head := WaitForBlock(t, node, 3, time.Second*5)
+	require.Equal(t, big.NewInt(3), head.Number)
+}
+
+func TestWaitForBlock_Timeout(t *testing.T) {
+	node := newMockChainNode(t, 2)
+
+	_, err := WaitForBlockWithError(t, node, 3, time.Second)
+	require.EqualError(t, err, "node 0 did not reach block 3 within 1s, best block is 2")
+}
+
+func TestWaitForBlock_NoNode(t *testing.T) {
+	node := newMockChainNode(t, 1)
+	node.RPCPort = "0"
+
+	_, err := WaitForBlockWithError(t, node, 1, time.Millisecond*500)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "node 0 did not reach block 1 within 500ms")
+}
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -57,7 +58,7 @@ type ServerResponse struct {
 	// Resulting values
 	Result json.RawMessage `json:"result"`
 	// Any generated errors
-	Error *Error `json:"error"`
+	Error *RPCError `json:"error"`
 	// Request id
 	ID *json.RawMessage `json:"id"`
 }
@@ -73,7 +74,7 @@ type WebsocketResponse struct {
 	// Params values including results
 	Params json.RawMessage `json:"params"`
 	// Any generated errors
-	Error *Error `json:"error"`
+	Error *RPCError `json:"error"`
 	// Request id
 	Subscription *json.RawMessage `json:"subscription"`
 	// Request id
@@ -83,14 +84,14 @@ type WebsocketResponse struct {
 // ErrCode is a int type used for the rpc error codes
 type ErrCode int
 
-// Error is a struct that holds the error message and the error code for a error
-type Error struct {
-	Message   string                 `json:"message"`
-	ErrorCode ErrCode                `json:"code"`
-	Data      map[string]interface{} `json:"data"`
+// RPCError is a JSON-RPC error object returned by a node, holding the error code and message
+type RPCError struct {
+	Code    ErrCode         `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
 }
 
-// Error returns the error Message string
-func (e *Error) Error() string {
-	return e.Message
+// Error returns the error code and message
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
 }
@@ -32,6 +32,24 @@ func PauseBABE(t *testing.T, node *Node) error {
 	return err
 }
 
+// SetSlot calls the endpoint dev_setSlot to advance BABE to the given slot and build a block for it
+func SetSlot(t *testing.T, node *Node, slot uint64) error {
+	_, err := PostRPC(DevSetSlot, NewEndpoint(node.RPCPort), "["+strconv.FormatUint(slot, 10)+"]")
+	return err
+}
+
+// BlockTree calls the endpoint dev_blockTree and returns the node's block tree in Graphviz DOT format
+func BlockTree(t *testing.T, node *Node) (string, error) {
+	respBody, err := PostRPC(DevBlockTree, NewEndpoint(node.RPCPort), "[]")
+	if err != nil {
+		return "", err
+	}
+
+	var dot string
+	err = DecodeRPC(t, respBody, &dot)
+	return dot, err
+}
+
 // SlotDuration Calls dev endpoint for slot duration
 func SlotDuration(t *testing.T, node *Node) time.Duration {
 	slotDuration, err := PostRPC("dev_slotDuration", NewEndpoint(node.RPCPort), "[]")
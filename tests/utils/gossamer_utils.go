@@ -18,6 +18,7 @@ package utils
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -37,7 +38,20 @@ import (
 )
 
 var logger = log.New("pkg", "test/utils")
-var maxRetries = 24
+
+// readyFile is the name of the file a node writes to its base path once it has started
+const readyFile = "ready"
+
+var (
+	// startTimeout is how long StartGossamer waits for a node to start
+	startTimeout = time.Minute * 2
+
+	// startInitialInterval is how long StartGossamer first waits before checking again whether a node has started
+	startInitialInterval = time.Millisecond * 50
+
+	// startMaxInterval is the longest StartGossamer waits between checks whether a node has started
+	startMaxInterval = time.Second * 2
+)
 
 // SetLogLevel sets the logging level for this package
 func SetLogLevel(lvl log.Lvl) {
@@ -47,14 +61,7 @@ func SetLogLevel(lvl log.Lvl) {
 
 var (
 	// KeyList is the list of built-in keys
-	KeyList  = []string{"alice", "bob", "charlie", "dave", "eve", "ferdie", "george", "heather", "ian"}
-	basePort = 7000
-
-	// BaseRPCPort is the starting RPC port for test nodes
-	BaseRPCPort = 8540
-
-	// BaseWSPort is the starting Websocket port for test nodes
-	BaseWSPort = 8546
+	KeyList = []string{"alice", "bob", "charlie", "dave", "eve", "ferdie", "george", "heather", "ian"}
 
 	currentDir, _ = os.Getwd()
 	gossamerCMD   = filepath.Join(currentDir, "../..", "bin/gossamer")
@@ -86,6 +93,7 @@ var (
 type Node struct {
 	Process  *exec.Cmd
 	Key      string
+	Port     string
 	RPCPort  string
 	Idx      int
 	basePath string
@@ -114,10 +122,21 @@ func InitGossamer(idx int, basePath, genesis, config string) (*Node, error) {
 	// TODO: get init exit code to see if node was successfully initialised
 	logger.Info("initialised gossamer!", "node", idx)
 
+	return newNode(idx, basePath, config)
+}
+
+// newNode returns a node reference with free network, RPC and websocket ports reserved for it
+func newNode(idx int, basePath, config string) (*Node, error) {
+	ports, err := FreePorts(3)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Node{
 		Idx:      idx,
-		RPCPort:  strconv.Itoa(BaseRPCPort + idx),
-		WSPort:   strconv.Itoa(BaseWSPort + idx),
+		Port:     strconv.Itoa(ports[0]),
+		RPCPort:  strconv.Itoa(ports[1]),
+		WSPort:   strconv.Itoa(ports[2]),
 		basePath: basePath,
 		config:   config,
 	}, nil
@@ -126,14 +145,16 @@ func InitGossamer(idx int, basePath, genesis, config string) (*Node, error) {
 // StartGossamer starts given node
 func StartGossamer(t *testing.T, node *Node, websocket bool) error {
 	var key string
-	var params []string = []string{"--port", strconv.Itoa(basePort + node.Idx),
+	var params []string = []string{"--port", node.Port,
 		"--config", node.config,
 		"--basepath", node.basePath,
 		"--rpchost", HOSTNAME,
 		"--rpcport", node.RPCPort,
 		"--rpcmods", "system,author,chain,state,dev",
 		"--rpc",
-		"--log", "info"}
+		"--log", "info",
+		"--log-file", filepath.Join(node.basePath, "log.out"),
+		"--ready-file", filepath.Join(node.basePath, readyFile)}
 
 	if node.Idx >= len(KeyList) {
 		params = append(params, "--roles", "1")
@@ -152,13 +173,6 @@ func StartGossamer(t *testing.T, node *Node, websocket bool) error {
 
 	node.Key = key
 
-	// create log file
-	outfile, err := os.Create(filepath.Join(node.basePath, "log.out"))
-	if err != nil {
-		logger.Error("Error when trying to set a log file for gossamer output", "error", err)
-		return err
-	}
-
 	// create error log file
 	errfile, err := os.Create(filepath.Join(node.basePath, "error.out"))
 	if err != nil {
@@ -168,16 +182,9 @@ func StartGossamer(t *testing.T, node *Node, websocket bool) error {
 
 	t.Cleanup(func() {
 		time.Sleep(time.Second) // wait for goroutine to finish writing
-		outfile.Close()         //nolint
 		errfile.Close()         //nolint
 	})
 
-	stdoutPipe, err := node.Process.StdoutPipe()
-	if err != nil {
-		logger.Error("failed to get stdoutPipe from node %d: %s\n", node.Idx, err)
-		return err
-	}
-
 	stderrPipe, err := node.Process.StderrPipe()
 	if err != nil {
 		logger.Error("failed to get stderrPipe from node %d: %s\n", node.Idx, err)
@@ -191,21 +198,11 @@ func StartGossamer(t *testing.T, node *Node, websocket bool) error {
 		return err
 	}
 
-	writer := bufio.NewWriter(outfile)
-	go io.Copy(writer, stdoutPipe) //nolint
 	errWriter := bufio.NewWriter(errfile)
 	go io.Copy(errWriter, stderrPipe) //nolint
 
-	var started bool
-	for i := 0; i < maxRetries; i++ {
-		time.Sleep(time.Second * 5)
-		if err = CheckNodeStarted(t, "http://"+HOSTNAME+":"+node.RPCPort); err == nil {
-			started = true
-			break
-		}
-	}
-
-	if started {
+	err = waitForReadyFile(filepath.Join(node.basePath, readyFile), startTimeout)
+	if err == nil {
 		logger.Info("node started", "key", key, "cmd.Process.Pid", node.Process.Process.Pid)
 	} else {
 		logger.Crit("node didn't start!", "err", err)
@@ -234,7 +231,49 @@ func RunGossamer(t *testing.T, idx int, basepath, genesis, config string, websoc
 	return node, nil
 }
 
-// CheckNodeStarted check if gossamer node is started
+// retryWithBackoff calls fn until it returns nil or timeout has elapsed, doubling the interval between calls
+// from startInitialInterval up to startMaxInterval. It returns the last error from fn on timeout.
+func retryWithBackoff(timeout time.Duration, fn func() error) error {
+	deadline := time.Now().Add(timeout)
+	interval := startInitialInterval
+
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+
+		time.Sleep(interval)
+
+		interval *= 2
+		if interval > startMaxInterval {
+			interval = startMaxInterval
+		}
+	}
+}
+
+// waitForReadyFile waits until the ready file written by a started node exists
+func waitForReadyFile(fp string, timeout time.Duration) error {
+	return retryWithBackoff(timeout, func() error {
+		_, err := os.Stat(fp)
+		if os.IsNotExist(err) {
+			return errors.New("node has not written its ready file")
+		}
+		return err
+	})
+}
+
+// CheckNodeStarted check if gossamer node is started and healthy. A node that expects peers is only healthy once
+// it has at least one, whereas a node started without bootnodes, eg. an isolated dev node, is healthy without any.
 func CheckNodeStarted(t *testing.T, gossamerHost string) error {
 	method := "system_health"
 
@@ -249,13 +288,20 @@ func CheckNodeStarted(t *testing.T, gossamerHost string) error {
 		return err
 	}
 
-	if !target.ShouldHavePeers {
+	if target.ShouldHavePeers && target.Peers == 0 {
 		return fmt.Errorf("no peers")
 	}
 
 	return nil
 }
 
+// WaitForNodeStarted calls CheckNodeStarted with exponential backoff until the node is healthy or timeout has elapsed
+func WaitForNodeStarted(t *testing.T, gossamerHost string, timeout time.Duration) error {
+	return retryWithBackoff(timeout, func() error {
+		return CheckNodeStarted(t, gossamerHost)
+	})
+}
+
 // KillProcess kills a instance of gossamer
 func KillProcess(t *testing.T, cmd *exec.Cmd) error {
 	err := cmd.Process.Kill()
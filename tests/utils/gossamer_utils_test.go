@@ -0,0 +1,113 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockHealthServer starts an RPC server that responds to system_health with health and returns its URL
+// along with a counter of the requests it has served
+func newMockHealthServer(t *testing.T, health common.Health) (string, *int64) {
+	var calls int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+
+		result, err := json.Marshal(modules.SystemHealthResponse(health))
+		require.NoError(t, err)
+
+		err = json.NewEncoder(w).Encode(&ServerResponse{
+			Version: "2.0",
+			Result:  result,
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL, &calls
+}
+
+func TestWaitForNodeStarted_Healthy(t *testing.T) {
+	host, calls := newMockHealthServer(t, common.Health{
+		Peers:           1,
+		ShouldHavePeers: true,
+		SyncStatus:      common.SyncStatusSynced,
+	})
+
+	start := time.Now()
+	err := WaitForNodeStarted(t, host, time.Second*10)
+	require.NoError(t, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+	require.Equal(t, int64(1), atomic.LoadInt64(calls))
+}
+
+func TestWaitForNodeStarted_NoPeersExpected(t *testing.T) {
+	host, _ := newMockHealthServer(t, common.Health{
+		ShouldHavePeers: false,
+		SyncStatus:      common.SyncStatusNoPeers,
+	})
+
+	err := WaitForNodeStarted(t, host, time.Second*10)
+	require.NoError(t, err)
+}
+
+func TestWaitForNodeStarted_NoPeers(t *testing.T) {
+	host, calls := newMockHealthServer(t, common.Health{
+		ShouldHavePeers: true,
+		SyncStatus:      common.SyncStatusNoPeers,
+	})
+
+	err := WaitForNodeStarted(t, host, time.Millisecond*500)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no peers")
+
+	// backing off from 50ms, the node is checked at 0, 50, 150, 350 and 500ms
+	require.LessOrEqual(t, atomic.LoadInt64(calls), int64(6))
+}
+
+func TestWaitForReadyFile(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), readyFile)
+
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		_ = ioutil.WriteFile(fp, []byte("1"), os.ModePerm)
+	}()
+
+	start := time.Now()
+	err := waitForReadyFile(fp, time.Second*10)
+	require.NoError(t, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestWaitForReadyFile_Timeout(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), readyFile)
+
+	err := waitForReadyFile(fp, time.Millisecond*100)
+	require.Error(t, err)
+}
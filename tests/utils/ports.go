@@ -0,0 +1,62 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"net"
+	"sync"
+)
+
+var (
+	// allocatedPorts are the ports handed out to test nodes by this process
+	allocatedPorts     = make(map[int]bool)
+	allocatedPortsLock sync.Mutex
+)
+
+// FreePorts returns the given number of free TCP ports. It finds them by binding to ephemeral ports,
+// then releasing them, and never returns a port it has already returned.
+func FreePorts(num int) ([]int, error) {
+	allocatedPortsLock.Lock()
+	defer allocatedPortsLock.Unlock()
+
+	// keep the ports bound until all have been found, so the same port isn't found twice
+	var listeners []net.Listener
+	defer func() {
+		for _, ln := range listeners {
+			_ = ln.Close()
+		}
+	}()
+
+	ports := make([]int, 0, num)
+	for len(ports) < num {
+		ln, err := net.Listen("tcp", ":0")
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, ln)
+
+		port := ln.Addr().(*net.TCPAddr).Port
+		if allocatedPorts[port] {
+			continue
+		}
+
+		allocatedPorts[port] = true
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNode_UniquePorts(t *testing.T) {
+	const numNodes = 8
+
+	var wg sync.WaitGroup
+	nodes := make([]*Node, numNodes)
+	errs := make([]error, numNodes)
+
+	for i := 0; i < numNodes; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nodes[i], errs[i] = newNode(i, t.TempDir(), ConfigDefault)
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i, node := range nodes {
+		require.NoError(t, errs[i])
+
+		for _, port := range []string{node.Port, node.RPCPort, node.WSPort} {
+			require.False(t, seen[port], "port %s allocated twice", port)
+			seen[port] = true
+
+			// the port is released once it has been allocated
+			ln, err := net.Listen("tcp", ":"+port)
+			require.NoError(t, err)
+			require.NoError(t, ln.Close())
+		}
+	}
+
+	require.Len(t, seen, numNodes*3)
+}
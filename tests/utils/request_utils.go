@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -83,7 +82,8 @@ func PostRPCWithRetry(method, host, params string, retry int) ([]byte, error) {
 	}
 }
 
-// DecodeRPC will decode []body into target interface
+// DecodeRPC will decode []body into target interface. If the response holds an error object, it is
+// returned as a *RPCError.
 func DecodeRPC(t *testing.T, body []byte, target interface{}) error {
 	decoder := json.NewDecoder(bytes.NewReader(body))
 	decoder.DisallowUnknownFields()
@@ -94,7 +94,7 @@ func DecodeRPC(t *testing.T, body []byte, target interface{}) error {
 	require.Equal(t, response.Version, "2.0")
 
 	if response.Error != nil {
-		return errors.New(response.Error.Message)
+		return response.Error
 	}
 
 	decoder = json.NewDecoder(bytes.NewReader(response.Result))
@@ -116,7 +116,7 @@ func DecodeWebsocket(t *testing.T, body []byte, target interface{}) error {
 	require.Equal(t, response.Version, "2.0")
 
 	if response.Error != nil {
-		return errors.New(response.Error.Message)
+		return response.Error
 	}
 
 	if response.Result != nil {
@@ -144,7 +144,7 @@ func DecodeRPC_NT(body []byte, target interface{}) error {
 	}
 
 	if response.Error != nil {
-		return errors.New(response.Error.Message)
+		return response.Error
 	}
 
 	decoder = json.NewDecoder(bytes.NewReader(response.Result))
@@ -0,0 +1,53 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRPC_Error(t *testing.T) {
+	body := []byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"rpc error method unknown not found","data":null},"id":1}`)
+
+	var target string
+	err := DecodeRPC(t, body, &target)
+
+	var rpcErr *RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, ErrCode(-32000), rpcErr.Code)
+	require.Equal(t, "rpc error method unknown not found", rpcErr.Message)
+	require.EqualError(t, err, "rpc error -32000: rpc error method unknown not found")
+
+	// the error data may be any JSON value
+	body = []byte(`{"jsonrpc":"2.0","error":{"code":1010,"message":"Invalid Transaction","data":"Inability to pay some fees"},"id":1}`)
+	err = DecodeRPC_NT(body, &target)
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, ErrCode(1010), rpcErr.Code)
+	require.Equal(t, `"Inability to pay some fees"`, string(rpcErr.Data))
+}
+
+func TestDecodeRPC_Result(t *testing.T) {
+	body := []byte(`{"jsonrpc":"2.0","result":"gossamer","id":1}`)
+
+	var target string
+	err := DecodeRPC(t, body, &target)
+	require.NoError(t, err)
+	require.Equal(t, "gossamer", target)
+}
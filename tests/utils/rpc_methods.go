@@ -23,17 +23,21 @@ var (
 	ChainGetHeader               = "chain_getHeader"
 	ChainGetFinalizedHead        = "chain_getFinalizedHead"
 	ChainGetFinalizedHeadByRound = "chain_getFinalizedHeadByRound"
+	ChainGetFinalizedHeader      = "chain_getFinalizedHeader"
 	ChainGetBlockHash            = "chain_getBlockHash"
 
 	// AUTHOR METHODS
-	AuthorSubmitExtrinsic   = "author_submitExtrinsic"
-	AuthorPendingExtrinsics = "author_pendingExtrinsics"
+	AuthorSubmitExtrinsic           = "author_submitExtrinsic"
+	AuthorPendingExtrinsics         = "author_pendingExtrinsics"
+	AuthorPendingExtrinsicsDetailed = "author_pendingExtrinsicsDetailed"
 
 	// STATE METHODS
 	StateGetStorage = "state_getStorage"
 
 	// DEV METHODS
-	DevControl = "dev_control"
+	DevControl   = "dev_control"
+	DevSetSlot   = "dev_setSlot"
+	DevBlockTree = "dev_blockTree"
 
 	// GRANDPA
 	GrandpaProveFinality = "grandpa_proveFinality"
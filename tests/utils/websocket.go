@@ -0,0 +1,225 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WSConn is a websocket connection to a node, used to call subscription methods and receive their
+// notifications
+type WSConn struct {
+	conn *websocket.Conn
+
+	lock          sync.Mutex
+	nextID        int
+	responses     map[int]chan *subscribeResult
+	subscriptions map[uint]*Subscription
+	done          chan struct{}
+	err           error // the error that stopped the connection from reading messages
+}
+
+// Subscription receives the notifications of a subscription made with WSConn.Subscribe
+type Subscription struct {
+	ID            uint
+	notifications chan json.RawMessage
+	done          <-chan struct{}
+}
+
+// subscribeResult is the subscription created from the response to a subscription method, or the error
+// returned by the node
+type subscribeResult struct {
+	sub *Subscription
+	err error
+}
+
+// subscriptionParams are the params of a subscription notification
+type subscriptionParams struct {
+	Result       json.RawMessage `json:"result"`
+	Subscription uint            `json:"subscription"`
+}
+
+// notificationBuffer is the number of notifications buffered per subscription before the connection
+// stops reading messages
+const notificationBuffer = 64
+
+// NewWSConn dials the websocket server of the node
+func NewWSConn(t *testing.T, node *Node) (*WSConn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+HOSTNAME+":"+node.WSPort+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &WSConn{
+		conn:          conn,
+		nextID:        1,
+		responses:     make(map[int]chan *subscribeResult),
+		subscriptions: make(map[uint]*Subscription),
+		done:          make(chan struct{}),
+	}
+
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+
+	go c.readMessages()
+	return c, nil
+}
+
+// Close closes the connection
+func (c *WSConn) Close() error {
+	return c.conn.Close()
+}
+
+// Subscribe calls the subscription method with the given params, eg. `[]`, and returns the subscription
+// once the node has responded with its id
+func (c *WSConn) Subscribe(method, params string, timeout time.Duration) (*Subscription, error) {
+	c.lock.Lock()
+	id := c.nextID
+	c.nextID++
+	respCh := make(chan *subscribeResult, 1)
+	c.responses[id] = respCh
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.responses, id)
+		c.lock.Unlock()
+	}()
+
+	data := []byte(`{"jsonrpc":"2.0","method":"` + method + `","params":` + params + `,"id":` + strconv.Itoa(id) + `}`)
+
+	c.lock.Lock()
+	err := c.conn.WriteMessage(websocket.TextMessage, data)
+	c.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-respCh:
+		return res.sub, res.err
+	case <-c.done:
+		return nil, fmt.Errorf("connection closed: %w", c.err)
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no response to %s within %s", method, timeout)
+	}
+}
+
+// Next decodes the result of the next notification of the subscription into target, returning an error
+// if none is received within the timeout
+func (s *Subscription) Next(target interface{}, timeout time.Duration) error {
+	select {
+	case result := <-s.notifications:
+		return json.Unmarshal(result, target)
+	case <-s.done:
+		return errors.New("connection closed")
+	case <-time.After(timeout):
+		return fmt.Errorf("no notification for subscription %d within %s", s.ID, timeout)
+	}
+}
+
+// readMessages passes responses to the calls waiting for them and notifications to their subscriptions
+// until the connection is closed
+func (c *WSConn) readMessages() {
+	defer close(c.done)
+
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		resp := new(WebsocketResponse)
+		if err = json.Unmarshal(msg, resp); err != nil {
+			logger.Warn("failed to decode websocket message", "message", string(msg), "error", err)
+			continue
+		}
+
+		if resp.Method == "" {
+			c.handleSubscribeResponse(resp)
+			continue
+		}
+
+		params := new(subscriptionParams)
+		if err = json.Unmarshal(resp.Params, params); err != nil {
+			logger.Warn("failed to decode websocket notification", "message", string(msg), "error", err)
+			continue
+		}
+
+		c.lock.Lock()
+		sub := c.subscriptions[params.Subscription]
+		c.lock.Unlock()
+
+		if sub == nil {
+			logger.Warn("notification for unknown subscription", "subscription", params.Subscription)
+			continue
+		}
+
+		sub.notifications <- params.Result
+	}
+}
+
+// handleSubscribeResponse registers the subscription created by a subscription method, before any of
+// its notifications are read, and passes it to the call waiting for it
+func (c *WSConn) handleSubscribeResponse(resp *WebsocketResponse) {
+	if resp.ID == nil {
+		return
+	}
+
+	var id int
+	if err := json.Unmarshal(*resp.ID, &id); err != nil {
+		logger.Warn("failed to decode websocket response id", "error", err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	respCh := c.responses[id]
+	if respCh == nil {
+		return
+	}
+
+	if resp.Error != nil {
+		respCh <- &subscribeResult{err: resp.Error}
+		return
+	}
+
+	var subID uint
+	if err := json.Unmarshal(resp.Result, &subID); err != nil {
+		respCh <- &subscribeResult{err: fmt.Errorf("failed to decode subscription id: %w", err)}
+		return
+	}
+
+	sub := &Subscription{
+		ID:            subID,
+		notifications: make(chan json.RawMessage, notificationBuffer),
+		done:          c.done,
+	}
+	c.subscriptions[subID] = sub
+	respCh <- &subscribeResult{sub: sub}
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 ChainSafe Systems (ON) Corp.
+// This file is part of gossamer.
+//
+// The gossamer library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gossamer library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the gossamer library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockWSNode starts a websocket server implementing chain_subscribeNewHeads, which sends a
+// notification for each of the given block numbers as soon as the subscription is made
+func newMockWSNode(t *testing.T, numbers ...int64) *Node {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close() //nolint
+
+		for {
+			var req struct {
+				Method string `json:"method"`
+				ID     int    `json:"id"`
+			}
+			if err = conn.ReadJSON(&req); err != nil {
+				return
+			}
+
+			if req.Method != "chain_subscribeNewHeads" {
+				err = conn.WriteJSON(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"error":   map[string]interface{}{"code": -32000, "message": "rpc error method " + req.Method + " not found"},
+					"id":      req.ID,
+				})
+				require.NoError(t, err)
+				continue
+			}
+
+			err = conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "result": 7, "id": req.ID})
+			require.NoError(t, err)
+
+			for _, num := range numbers {
+				err = conn.WriteJSON(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"method":  "chain_newHead",
+					"params": map[string]interface{}{
+						"result": &modules.ChainBlockHeaderResponse{
+							ParentHash: common.Hash{}.String(),
+							Number:     common.BytesToHex(big.NewInt(num).Bytes()),
+						},
+						"subscription": 7,
+					},
+				})
+				require.NoError(t, err)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	hostname := HOSTNAME
+	HOSTNAME = u.Hostname()
+	t.Cleanup(func() {
+		HOSTNAME = hostname
+	})
+
+	return &Node{
+		WSPort: u.Port(),
+	}
+}
+
+func TestWSConn_Subscribe(t *testing.T) {
+	node := newMockWSNode(t, 1, 2, 3)
+
+	conn, err := NewWSConn(t, node)
+	require.NoError(t, err)
+
+	sub, err := conn.Subscribe("chain_subscribeNewHeads", "[]", time.Second*5)
+	require.NoError(t, err)
+	require.Equal(t, uint(7), sub.ID)
+
+	for _, expected := range []string{"0x01", "0x02", "0x03"} {
+		header := new(modules.ChainBlockHeaderResponse)
+		err = sub.Next(header, time.Second*5)
+		require.NoError(t, err)
+		require.Equal(t, expected, header.Number)
+	}
+
+	// no more notifications are sent
+	err = sub.Next(new(modules.ChainBlockHeaderResponse), time.Millisecond*100)
+	require.EqualError(t, err, "no notification for subscription 7 within 100ms")
+}
+
+func TestWSConn_Subscribe_Error(t *testing.T) {
+	node := newMockWSNode(t)
+
+	conn, err := NewWSConn(t, node)
+	require.NoError(t, err)
+
+	_, err = conn.Subscribe("chain_subscribeUnknown", "[]", time.Second*5)
+	require.EqualError(t, err, "rpc error -32000: rpc error method chain_subscribeUnknown not found")
+}
+
+func TestWSConn_Subscribe_Timeout(t *testing.T) {
+	// a server that never responds
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close() //nolint
+
+		for {
+			if _, _, err = conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	hostname := HOSTNAME
+	HOSTNAME = u.Hostname()
+	defer func() {
+		HOSTNAME = hostname
+	}()
+
+	conn, err := NewWSConn(t, &Node{WSPort: u.Port()})
+	require.NoError(t, err)
+
+	_, err = conn.Subscribe("chain_subscribeNewHeads", "[]", time.Millisecond*100)
+	require.EqualError(t, err, "no response to chain_subscribeNewHeads within 100ms")
+}
+